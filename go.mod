@@ -10,6 +10,8 @@ require (
 	github.com/cybergarage/go-sqlparser v1.5.2-0.20250529080918-b3e3d96f3175
 	github.com/cybergarage/go-sqltest v1.5.0
 	github.com/cybergarage/go-tracing v1.1.5
+	github.com/go-sql-driver/mysql v1.9.0
+	github.com/jackc/pgx/v5 v5.5.5
 	github.com/ncruces/go-sqlite3 v0.21.3
 	github.com/prometheus/client_golang v1.20.5
 	github.com/spf13/viper v1.19.0
@@ -25,12 +27,10 @@ require (
 	github.com/cybergarage/go-safecast v1.3.3 // indirect
 	github.com/cybergarage/go-sasl v1.2.5 // indirect
 	github.com/fsnotify/fsnotify v1.7.0 // indirect
-	github.com/go-sql-driver/mysql v1.9.0 // indirect
 	github.com/google/uuid v1.6.0 // indirect
 	github.com/hashicorp/hcl v1.0.0 // indirect
 	github.com/jackc/pgpassfile v1.0.0 // indirect
 	github.com/jackc/pgservicefile v0.0.0-20231201235250-de7065d80cb9 // indirect
-	github.com/jackc/pgx/v5 v5.5.5 // indirect
 	github.com/klauspost/compress v1.17.9 // indirect
 	github.com/lib/pq v1.10.9 // indirect
 	github.com/magiconair/properties v1.8.7 // indirect
@@ -0,0 +1,84 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cybergarage/go-sqlserver/sql/dialect"
+	"github.com/cybergarage/go-sqlserver/sql/function"
+)
+
+// ArrayValueError represents an error of a value which is not a valid array literal of the array column.
+type ArrayValueError struct {
+	// Column is the column name.
+	Column string
+	// Type is the internal array type name of the column such as _INT4.
+	Type string
+	// Value is the rejected string literal, or an empty string if the value is not a string literal.
+	Value string
+}
+
+// Error returns the error message.
+func (err *ArrayValueError) Error() string {
+	return fmt.Sprintf("malformed array literal for %s column (%s)", err.Type, err.Column)
+}
+
+// arrayValueErrorOf returns the array value error of the specified CHECK constraint failure of an array column in the specified query.
+func (db *Database) arrayValueErrorOf(query string, column string, typeName string) *ArrayValueError {
+	valueErr := &ArrayValueError{Column: column, Type: typeName, Value: ""}
+	isRejected := func(v string) bool { return !function.IsValidArrayLiteral(v, typeName) }
+	if assignment, ok := db.assignmentOf(query, column, isRejected); ok {
+		valueErr.Value = assignment.Value
+	}
+	return valueErr
+}
+
+// rewriteConcatExpressions rewrites the concatenation operators of the array and JSON operands in the specified SQLite statement
+// into the array and JSON functions. The column types are looked up from the tables which the statement refers to only if
+// the statement has any concatenation operator.
+func (db *Database) rewriteConcatExpressions(d dialect.Dialect, stmt string) string {
+	if d != dialect.PostgreSQL || !strings.Contains(stmt, "||") {
+		return stmt
+	}
+	var columns map[string]string
+	columnTypeOf := func(name string) string {
+		if columns == nil {
+			columns = db.columnTypesOf(dialect.ReferencedTableNames(stmt))
+		}
+		return columns[strings.ToLower(name)]
+	}
+	q, err := dialect.ConcatExpressions(stmt, columnTypeOf)
+	if err != nil {
+		return stmt
+	}
+	return q
+}
+
+// columnTypesOf returns the upper case declared types of the columns of the specified tables by the lower case column names.
+func (db *Database) columnTypesOf(tblNames []string) map[string]string {
+	columns := map[string]string{}
+	for _, tblName := range tblNames {
+		columnNames, declTypes, err := db.tableColumns(tblName)
+		if err != nil {
+			continue
+		}
+		for n, declType := range declTypes {
+			columns[strings.ToLower(columnNames[n])] = declType
+		}
+	}
+	return columns
+}
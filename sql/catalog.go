@@ -0,0 +1,121 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"strings"
+
+	"github.com/cybergarage/go-sqlserver/sql/dialect"
+)
+
+// catalogTableName is the hidden table which keeps the logical column types such as BOOLEAN, UUID and the enum types,
+// because SQLite keeps only the type affinities of the columns.
+var catalogTableName = dialect.QuoteIdentifier(dialect.HiddenColumnPrefix + "columns")
+
+// loadCatalog creates the side catalog table if not exists, and loads the logical column types.
+func (db *Database) loadCatalog() error {
+	_, err := db.exec("CREATE TABLE IF NOT EXISTS " + catalogTableName + " (table_name TEXT, column_name TEXT, type_name TEXT, PRIMARY KEY (table_name, column_name))")
+	if err != nil {
+		return err
+	}
+	rows, err := db.Query("SELECT table_name, column_name, type_name FROM " + catalogTableName)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	for rows.Next() {
+		var tblName, columnName, typeName string
+		if err := rows.Scan(&tblName, &columnName, &typeName); err != nil {
+			return err
+		}
+		db.setCatalogType(tblName, columnName, typeName)
+	}
+	return rows.Err()
+}
+
+// updateCatalog updates the side catalog with the specified executed CREATE TABLE or DROP TABLE statement
+// and the enum types of the created columns.
+func (db *Database) updateCatalog(query string, enums map[string]*dialect.Enum) error {
+	switch dialect.LeadingKeyword(query) {
+	case "CREATE":
+		tblName, types, ok := dialect.LogicalColumnTypes(query)
+		if !ok || tblName == "" {
+			return nil
+		}
+		for columnName, enum := range enums {
+			types[columnName] = enum.TypeName()
+		}
+		if err := db.deleteCatalogTable(tblName); err != nil {
+			return err
+		}
+		for columnName, typeName := range types {
+			_, err := db.exec("INSERT INTO "+catalogTableName+" (table_name, column_name, type_name) VALUES (?, ?, ?)", strings.ToLower(tblName), strings.ToLower(columnName), typeName)
+			if err != nil {
+				return err
+			}
+			db.mutex.Lock()
+			db.setCatalogType(tblName, columnName, typeName)
+			db.mutex.Unlock()
+		}
+	case "DROP":
+		for _, tblName := range dialect.DroppedTableNames(query) {
+			if err := db.deleteCatalogTable(tblName); err != nil {
+				return err
+			}
+			if err := db.deleteInlineEnumTypes(tblName); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deleteCatalogTable deletes the logical column types of the specified table from the side catalog.
+func (db *Database) deleteCatalogTable(tblName string) error {
+	tblName = strings.ToLower(tblName)
+	if _, err := db.exec("DELETE FROM "+catalogTableName+" WHERE table_name = ?", tblName); err != nil {
+		return err
+	}
+	db.mutex.Lock()
+	delete(db.catalog, tblName)
+	db.mutex.Unlock()
+	return nil
+}
+
+func (db *Database) setCatalogType(tblName string, columnName string, typeName string) {
+	tblName = strings.ToLower(tblName)
+	columns, ok := db.catalog[tblName]
+	if !ok {
+		columns = map[string]string{}
+		db.catalog[tblName] = columns
+	}
+	columns[strings.ToLower(columnName)] = typeName
+}
+
+// hasCatalogType returns true if any column of the specified type is in the side catalog.
+func (db *Database) hasCatalogType(typeName string) bool {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	for _, columns := range db.catalog {
+		for _, columnType := range columns {
+			if columnType == typeName {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// SQLite: Source Of Data In Query Result
+// https://www.sqlite.org/c3ref/column_database_name.html
+// SQLite: Extracting Metadata About A Column Of A Table
+// https://www.sqlite.org/c3ref/table_column_metadata.html
+// SQLite: The Schema Table
+// https://www.sqlite.org/schematab.html
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cybergarage/go-sqlserver/sql/dialect"
+	"github.com/ncruces/go-sqlite3"
+	"github.com/ncruces/go-sqlite3/driver"
+)
+
+// tableObjectIDBase is the base of the table OIDs which are the row IDs of the schema table from the base,
+// and the base is far from enumObjectIDBase not to conflict with the enum type OIDs.
+const tableObjectIDBase = 1 << 24
+
+// ColumnOrigin represents the base table column of a result column.
+type ColumnOrigin struct {
+	// Database is the database name.
+	Database string
+	// Table is the base table name.
+	Table string
+	// Column is the base column name.
+	Column string
+	// DeclType is the upper case declared type of the base column such as VARCHAR(255).
+	DeclType string
+	// LogicalType is the logical type of the base column in the side catalog, or an empty string.
+	LogicalType string
+	// TableID is the OID of the base table.
+	TableID int32
+	// Number is the column number in the base table which starts from 1.
+	Number int16
+	// NotNull is true if the base column has the NOT NULL constraint.
+	NotNull bool
+	// PrimaryKey is true if the base column is a part of the primary key.
+	PrimaryKey bool
+	// AutoIncrement is true if the base column is an auto-increment column.
+	AutoIncrement bool
+}
+
+// ColumnOrigins returns the base table columns of the result columns of the specified query,
+// and the origins are nil if the columns are not the base table columns such as the expressions.
+func (db *Database) ColumnOrigins(query string) []*ColumnOrigin {
+	origins := []*ColumnOrigin{}
+	err := db.conn.Raw(func(driverConn any) error {
+		conn, ok := driverConn.(driver.Conn)
+		if !ok {
+			return nil
+		}
+		stmt, _, err := conn.Raw().Prepare(query)
+		if err != nil || stmt == nil {
+			return err
+		}
+		defer stmt.Close()
+		for n := 0; n < stmt.ColumnCount(); n++ {
+			origin, err := db.columnOriginOf(conn.Raw(), stmt.ColumnDatabaseName(n), stmt.ColumnTableName(n), stmt.ColumnOriginName(n))
+			if err != nil {
+				return err
+			}
+			origins = append(origins, origin)
+		}
+		return nil
+	})
+	if err != nil {
+		// The query error is returned by the query execution.
+		return nil
+	}
+	return origins
+}
+
+// columnOriginOf returns the specified base table column, or nil if the column name is empty.
+func (db *Database) columnOriginOf(conn *sqlite3.Conn, schema string, tblName string, columnName string) (*ColumnOrigin, error) {
+	if columnName == "" {
+		return nil, nil
+	}
+	declType, collation, notNull, primaryKey, _, err := conn.TableColumnMetadata(schema, tblName, columnName)
+	if err != nil {
+		return nil, err
+	}
+	tableID, number, err := tableColumnNumberOf(conn, schema, tblName, columnName)
+	if err != nil {
+		return nil, err
+	}
+	// The columns of the strict tables are declared with the STRICT datatypes instead of the declared types.
+	if strictDeclType, ok := db.declTypeOf(tblName, columnName); ok {
+		declType = strictDeclType
+	}
+	db.mutex.Lock()
+	logicalType := db.catalog[strings.ToLower(tblName)][strings.ToLower(columnName)]
+	db.mutex.Unlock()
+	return &ColumnOrigin{
+		Database:    db.Name(),
+		Table:       tblName,
+		Column:      columnName,
+		DeclType:    strings.ToUpper(declType),
+		LogicalType: logicalType,
+		TableID:     tableID,
+		Number:      number,
+		NotNull:     notNull,
+		PrimaryKey:  primaryKey,
+		// The auto-increment columns are the row ID aliases which are declared with the collations of the integer types.
+		AutoIncrement: primaryKey && dialect.IsAutoIncrementCollation(collation),
+	}, nil
+}
+
+// tableColumnNumberOf returns the table OID and the column number of the specified base table column.
+func tableColumnNumberOf(conn *sqlite3.Conn, schema string, tblName string, columnName string) (int32, int16, error) {
+	query := "SELECT (SELECT rowid FROM " + dialect.QuoteIdentifier(schema) + ".sqlite_schema WHERE type = 'table' AND name = ?1)," +
+		" (SELECT cid FROM pragma_table_info(?1, ?2) WHERE name = ?3 COLLATE NOCASE)"
+	stmt, _, err := conn.Prepare(query)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer stmt.Close()
+	for n, arg := range []string{tblName, schema, columnName} {
+		if err := stmt.BindText(n+1, arg); err != nil {
+			return 0, 0, err
+		}
+	}
+	if !stmt.Step() {
+		return 0, 0, stmt.Err()
+	}
+	return int32(tableObjectIDBase + stmt.ColumnInt64(0)), int16(stmt.ColumnInt(1) + 1), nil
+}
+
+// characterLengthOf returns the length of the specified declared character type such as VARCHAR(255).
+func characterLengthOf(declType string) (int, bool) {
+	open := strings.Index(declType, "(")
+	if open < 0 || !strings.HasSuffix(declType, ")") {
+		return 0, false
+	}
+	switch strings.TrimSpace(declType[:open]) {
+	case "CHAR", "CHARACTER", "VARCHAR", "CHARACTER VARYING", "NCHAR", "NVARCHAR":
+	default:
+		return 0, false
+	}
+	length, err := strconv.Atoi(strings.TrimSpace(declType[open+1 : len(declType)-1]))
+	if err != nil {
+		return 0, false
+	}
+	return length, true
+}
@@ -21,22 +21,26 @@ import (
 )
 
 const (
-	ConfigLogger     = "logger"
-	ConfigTLS        = "tls"
-	ConfigAuth       = "auth"
-	ConfigQuery      = "query"
-	ConfigTracer     = "tracer"
-	ConfigMetrics    = "metrics"
-	ConfigMySQL      = "mysql"
-	ConfigPostgresql = "postgresql"
-	ConfigPort       = "port"
-	ConfigEnabled    = "enabled"
-	ConfigLevel      = "level"
-	ConfigPrometheus = "prometheus"
-	ConfigStore      = "store"
-	ConfigSQLite     = "sqlite"
-	ConfigMemory     = "memory"
-	ConfigPlain      = "plain"
+	ConfigLogger         = "logger"
+	ConfigTLS            = "tls"
+	ConfigAuth           = "auth"
+	ConfigQuery          = "query"
+	ConfigTracer         = "tracer"
+	ConfigMetrics        = "metrics"
+	ConfigMySQL          = "mysql"
+	ConfigPostgresql     = "postgresql"
+	ConfigPort           = "port"
+	ConfigEnabled        = "enabled"
+	ConfigLevel          = "level"
+	ConfigPrometheus     = "prometheus"
+	ConfigStore          = "store"
+	ConfigSQLite         = "sqlite"
+	ConfigMemory         = "memory"
+	ConfigPlain          = "plain"
+	ConfigStrict         = "strict"
+	ConfigDatabases      = "databases"
+	ConfigStatementCache = "statement_cache"
+	ConfigSize           = "size"
 )
 
 // Config represents a configuration interface for PuzzleDB.
@@ -59,6 +63,10 @@ type Config interface {
 	PrometheusPort() (int, error)
 	// IsMemoryStoreEnabled returns true if the store is memory.
 	IsMemoryStoreEnabled() (bool, error)
+	// IsStrictModeEnabled returns true if the strict mode is enabled globally or for the specified database.
+	IsStrictModeEnabled(dbName string) (bool, error)
+	// StatementCacheSize returns the number of the prepared statements which are cached for each session.
+	StatementCacheSize() (int, error)
 	// IsAuthEnabled returns true if the authentication is enabled.
 	IsAuthEnabled() (bool, error)
 	// PlainCredentials returns plain configurations.
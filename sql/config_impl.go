@@ -20,6 +20,7 @@ import (
 	"crypto/tls"
 	_ "embed"
 	"os"
+	"slices"
 
 	"github.com/cybergarage/go-sqlserver/sql/auth"
 	"github.com/cybergarage/go-sqlserver/sql/config"
@@ -154,6 +155,31 @@ func (config *configImpl) IsMemoryStoreEnabled() (bool, error) {
 	return config.LookupConfigBool(ConfigStore, ConfigSQLite, ConfigMemory)
 }
 
+// IsStrictModeEnabled returns true if the strict mode is enabled globally or for the specified database.
+// The strict mode is disabled if the configuration has no strict mode settings.
+func (config *configImpl) IsStrictModeEnabled(dbName string) (bool, error) {
+	dbNames := []string{}
+	if err := config.UnmarshallConfig([]string{ConfigStore, ConfigSQLite, ConfigStrict, ConfigDatabases}, &dbNames); err != nil {
+		return false, err
+	}
+	if slices.Contains(dbNames, dbName) {
+		return true, nil
+	}
+	if _, err := config.LookupConfigObject(ConfigStore, ConfigSQLite, ConfigStrict, ConfigEnabled); err != nil {
+		return false, nil // nolint: nilerr
+	}
+	return config.LookupConfigBool(ConfigStore, ConfigSQLite, ConfigStrict, ConfigEnabled)
+}
+
+// StatementCacheSize returns the number of the prepared statements which are cached for each session.
+// The default size is returned if the configuration has no statement cache settings, and zero disables the statement cache.
+func (config *configImpl) StatementCacheSize() (int, error) {
+	if _, err := config.LookupConfigObject(ConfigStore, ConfigSQLite, ConfigStatementCache, ConfigSize); err != nil {
+		return DefaultStatementCacheSize, nil // nolint: nilerr
+	}
+	return config.LookupConfigInt(ConfigStore, ConfigSQLite, ConfigStatementCache, ConfigSize)
+}
+
 // IsAuthEnabled returns true if the authentication is enabled.
 func (config *configImpl) IsAuthEnabled() (bool, error) {
 	return config.LookupConfigBool(ConfigAuth, ConfigEnabled)
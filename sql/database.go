@@ -15,10 +15,19 @@
 package sql
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
 
-	_ "github.com/ncruces/go-sqlite3/driver"
+	"github.com/cybergarage/go-sqlserver/sql/dialect"
+	"github.com/cybergarage/go-sqlserver/sql/function"
+	sqlite3driver "github.com/ncruces/go-sqlite3/driver"
 	_ "github.com/ncruces/go-sqlite3/embed"
+	_ "github.com/ncruces/go-sqlite3/vfs/memdb"
 )
 
 const (
@@ -26,12 +35,30 @@ const (
 	DatabaseFilenameExt     = "sqlite3"
 )
 
-// Database represents a destination or source database of query.
+// memoryDatabaseCount is the number of the opened in-memory databases which names the shared in-memory databases.
+var memoryDatabaseCount atomic.Int64
+
+// Database represents a destination or source database of query. The database of a session has the connection and the transaction
+// of the session, and it shares the schema such as the side catalog with the database of the server.
 type Database struct {
-	name     string
-	filename string
-	db       *sql.DB
-	tx       *sql.Tx
+	*database
+	conn       *sql.Conn
+	tx         *sql.Tx
+	rowChanges *rowChangeRecorder
+}
+
+// database represents the state of a database which is shared by the sessions.
+type database struct {
+	name           string
+	filename       string
+	db             *sql.DB
+	catalog        map[string]map[string]string
+	enums          map[string]*enumType
+	collations     map[string]*enumCollation
+	strict         bool
+	declTypes      map[string]map[string]string
+	schemaVersions map[string]uint64
+	mutex          sync.Mutex
 }
 
 // DatabaseOption is a function that configures a database.
@@ -57,18 +84,60 @@ func WithDatabaseFilename(filename string) DatabaseOption {
 func NewDatabaseWith(opt ...DatabaseOption) (*Database, error) {
 	var err error
 	db := &Database{
-		name:     "",
-		filename: DatabaseDefaultFilename,
-		db:       nil,
-		tx:       nil,
+		database: &database{
+			name:           "",
+			filename:       DatabaseDefaultFilename,
+			db:             nil,
+			catalog:        map[string]map[string]string{},
+			enums:          map[string]*enumType{},
+			collations:     map[string]*enumCollation{},
+			strict:         false,
+			declTypes:      map[string]map[string]string{},
+			schemaVersions: map[string]uint64{},
+			mutex:          sync.Mutex{},
+		},
+		conn:       nil,
+		tx:         nil,
+		rowChanges: newRowChangeRecorder(),
 	}
 	if err := db.SetOptions(opt...); err != nil {
 		return nil, err
 	}
-	db.db, err = sql.Open("sqlite3", db.filename)
+	// The in-memory database is opened with the memdb VFS because every connection has its own in-memory database,
+	// and the memdb VFS shares the database between the connections of the sessions.
+	filename := db.filename
+	if filename == DatabaseDefaultFilename {
+		filename = fmt.Sprintf("file:/go-sqlserver-%d?vfs=memdb", memoryDatabaseCount.Add(1))
+	}
+	// Register the MySQL and PostgreSQL built-in functions on every connection.
+	db.db, err = sqlite3driver.Open(filename, function.Register)
+	if err != nil {
+		return nil, err
+	}
+	// The connection of the database keeps the in-memory database while the server is running.
+	db.conn, err = db.db.Conn(context.Background())
 	if err != nil {
 		return nil, err
 	}
+	if err := db.setUpdateHook(); err != nil {
+		return nil, err
+	}
+	if err := db.setCollationNeeded(); err != nil {
+		return nil, err
+	}
+	if err := db.createLastInsertIDFunction(); err != nil {
+		return nil, err
+	}
+	// The enum types are loaded first because the enum columns are declared with the collations of the enum types.
+	if err := db.loadEnumTypes(); err != nil {
+		return nil, err
+	}
+	if err := db.loadCatalog(); err != nil {
+		return nil, err
+	}
+	if err := db.loadDeclTypes(); err != nil {
+		return nil, err
+	}
 	return db, nil
 }
 
@@ -92,7 +161,59 @@ func (db *Database) Name() string {
 	return db.name
 }
 
-// Begin starts a transaction.
+// Open opens a new connection of a session in the specified dialect to the database, and returns the database which has the connection
+// and the transaction of the session. The returned database shares the schema with the database.
+func (db *Database) Open(d dialect.Dialect) (*Database, error) {
+	conn, err := db.db.Conn(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	sessionDB := &Database{
+		database:   db.database,
+		conn:       conn,
+		tx:         nil,
+		rowChanges: newRowChangeRecorder(),
+	}
+	sets := []func() error{sessionDB.setUpdateHook, sessionDB.setCollationNeeded, sessionDB.createLastInsertIDFunction}
+	if d == dialect.PostgreSQL {
+		sets = append(sets, sessionDB.registerPostgreSQLFunctions)
+	}
+	for _, set := range sets {
+		if err := set(); err != nil {
+			sessionDB.Close()
+			return nil, err
+		}
+	}
+	return sessionDB, nil
+}
+
+// registerPostgreSQLFunctions registers the functions which override the SQLite built-in functions as PostgreSQL does,
+// such as like() of the case-sensitive LIKE operator, to the connection of a PostgreSQL session.
+func (db *Database) registerPostgreSQLFunctions() error {
+	return db.conn.Raw(func(driverConn any) error {
+		conn, ok := driverConn.(sqlite3driver.Conn)
+		if !ok {
+			return newErrNotSupported(driverConn)
+		}
+		return function.RegisterPostgreSQL(conn.Raw())
+	})
+}
+
+// Close rolls back the transaction, and closes the connection of the session. The connection is discarded instead of being
+// returned to the connection pool because it has the functions of the session which the other sessions must not use.
+func (db *Database) Close() error {
+	rollbackErr := db.Rollback()
+	err := db.conn.Raw(func(driverConn any) error {
+		return driver.ErrBadConn
+	})
+	if !errors.Is(err, driver.ErrBadConn) {
+		return err
+	}
+	return rollbackErr
+}
+
+// Begin starts a transaction. The transaction takes the write lock at the beginning as BEGIN IMMEDIATE does,
+// so that the transactions of the sessions wait for each other instead of failing with SQLITE_BUSY at the first write.
 func (db *Database) Begin() error {
 	if db.tx != nil {
 		err := db.tx.Rollback()
@@ -101,7 +222,7 @@ func (db *Database) Begin() error {
 		}
 	}
 	var err error
-	db.tx, err = db.db.Begin()
+	db.tx, err = db.conn.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelSerializable, ReadOnly: false})
 	if err != nil {
 		return err
 	}
@@ -134,18 +255,73 @@ func (db *Database) Rollback() error {
 	return nil
 }
 
-// Exec executes a query.
+// InTransaction returns true if the database is in a transaction.
+func (db *Database) InTransaction() bool {
+	return db.tx != nil
+}
+
+// Exec executes a query, and updates the side catalog if the query is CREATE TABLE, DROP TABLE, CREATE TYPE or DROP TYPE.
+// The enum columns of CREATE TABLE are rewritten into the text columns which are restricted to the labels,
+// the decimal columns have the triggers which round the values to the scales,
+// and the tables are created as the STRICT tables in the strict mode. The schema statements invalidate the cached statements
+// which refer to the changed tables.
 func (db *Database) Exec(query string, args ...any) (sql.Result, error) {
-	if db.tx != nil {
-		return db.tx.Exec(query, args...)
+	if dialect.IsEnumTypeStatement(query) {
+		return db.execEnumTypeStatement(query)
+	}
+	query, enums, err := db.rewriteEnumColumns(query)
+	if err != nil {
+		return nil, err
+	}
+	// The logical column types are looked up from the declared types before the STRICT datatypes.
+	strictQuery, declTypes, err := db.rewriteStrictColumns(query)
+	if err != nil {
+		return nil, err
+	}
+	result, err := db.execRecordingRowChanges(strictQuery, func() (sql.Result, error) {
+		return db.exec(strictQuery, args...)
+	})
+	if err != nil {
+		return nil, db.valueErrorOf(query, err)
 	}
-	return db.db.Exec(query, args...)
+	if err := db.updateCatalog(query, enums); err != nil {
+		return nil, err
+	}
+	if err := db.updateDeclTypes(query, declTypes); err != nil {
+		return nil, err
+	}
+	if err := db.createDecimalTriggers(query); err != nil {
+		return nil, err
+	}
+	if tblNames, ok := dialect.SchemaChangedTableNames(query); ok {
+		db.changeSchema(tblNames)
+	}
+	return result, nil
 }
 
 // Query executes a query.
 func (db *Database) Query(query string, args ...any) (*sql.Rows, error) {
+	var rows *sql.Rows
+	var err error
 	if db.tx != nil {
-		return db.tx.Query(query, args...)
+		rows, err = db.tx.Query(query, args...)
+	} else {
+		rows, err = db.conn.QueryContext(context.Background(), query, args...)
+	}
+	return rows, db.valueErrorOf(query, err)
+}
+
+// QueryWithHold executes a query outside of the transaction, and the rows can be read after the transaction is committed.
+// The rows read the uncommitted changes of the transaction because the transaction shares the connection.
+func (db *Database) QueryWithHold(query string, args ...any) (*sql.Rows, error) {
+	rows, err := db.conn.QueryContext(context.Background(), query, args...)
+	return rows, db.valueErrorOf(query, err)
+}
+
+// exec executes a query without updating the side catalog.
+func (db *Database) exec(query string, args ...any) (sql.Result, error) {
+	if db.tx != nil {
+		return db.tx.Exec(query, args...)
 	}
-	return db.db.Query(query, args...)
+	return db.conn.ExecContext(context.Background(), query, args...)
 }
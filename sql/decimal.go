@@ -0,0 +1,128 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/cybergarage/go-sqlserver/sql/dialect"
+)
+
+// DecimalValueError represents an error of a decimal value which does not fit the precision of the column.
+type DecimalValueError struct {
+	// Column is the column name.
+	Column string
+	// Precision is the declared precision of the column.
+	Precision int
+	// Scale is the declared scale of the column.
+	Scale int
+}
+
+// Error returns the error message.
+func (err *DecimalValueError) Error() string {
+	return fmt.Sprintf("numeric field overflow for DECIMAL(%d, %d) column (%s)", err.Precision, err.Scale, err.Column)
+}
+
+// isDecimalTypeName returns true if the specified declared type name is a decimal type.
+func isDecimalTypeName(typeName string) bool {
+	_, _, ok := dialect.DecimalTypeOf(typeName)
+	return ok || strings.HasPrefix(typeName, "DECIMAL") || strings.HasPrefix(typeName, "NUMERIC")
+}
+
+// decimalTypeOf returns the precision and scale of the specified declared type name, and they are -1 if not specified.
+func decimalTypeOf(typeName string) (int, int) {
+	precision, scale, ok := dialect.DecimalTypeOf(typeName)
+	if !ok {
+		return -1, -1
+	}
+	return precision, scale
+}
+
+// formatDecimal returns the canonical text of the specified decimal text which is rounded to the specified scale.
+// The decimal text is returned as it is if the scale is not specified, but the exponent notation is expanded.
+func formatDecimal(s string, scale int) string {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, "/") {
+		return s
+	}
+	if scale < 0 {
+		if !strings.ContainsAny(s, "eE") {
+			return s
+		}
+		// The exponent notation comes from the REAL values.
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return s
+		}
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return s
+	}
+	return r.FloatString(scale)
+}
+
+// createDecimalTriggers creates the triggers which round the decimal columns of the specified executed CREATE TABLE statement
+// to the declared scales, because SQLite stores the decimal texts as they are.
+func (db *Database) createDecimalTriggers(query string) error {
+	for _, trigger := range dialect.DecimalTriggers(query) {
+		if _, err := db.exec(trigger); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rewriteDecimalExpressions rewrites the arithmetic operators and SUM of the decimal columns in the specified SQLite statement
+// into the decimal functions which compute them exactly. The decimal columns are looked up from the tables which the statement
+// refers to only if the statement has any operand to be rewritten.
+func (db *Database) rewriteDecimalExpressions(d dialect.Dialect, stmt string) string {
+	if !strings.ContainsAny(stmt, "+-*/") && !strings.Contains(strings.ToUpper(stmt), "SUM") {
+		return stmt
+	}
+	var columns map[string]bool
+	isDecimalColumn := func(name string) bool {
+		if columns == nil {
+			columns = db.decimalColumnsOf(dialect.ReferencedTableNames(stmt))
+		}
+		return columns[strings.ToLower(name)]
+	}
+	q, err := dialect.DecimalExpressions(d, stmt, isDecimalColumn)
+	if err != nil {
+		return stmt
+	}
+	return q
+}
+
+// decimalColumnsOf returns the lower case names of the decimal columns of the specified tables.
+func (db *Database) decimalColumnsOf(tblNames []string) map[string]bool {
+	columns := map[string]bool{}
+	for _, tblName := range tblNames {
+		columnNames, declTypes, err := db.tableColumns(tblName)
+		if err != nil {
+			continue
+		}
+		for n, declType := range declTypes {
+			if _, _, ok := dialect.DecimalTypeOf(declType); ok {
+				columns[strings.ToLower(columnNames[n])] = true
+			}
+		}
+	}
+	return columns
+}
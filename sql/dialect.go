@@ -0,0 +1,122 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	dbsql "database/sql"
+
+	"github.com/cybergarage/go-logger/log"
+	"github.com/cybergarage/go-postgresql/postgresql"
+	"github.com/cybergarage/go-sqlparser/sql/net"
+	sql "github.com/cybergarage/go-sqlparser/sql/query/response/resultset"
+	"github.com/cybergarage/go-sqlserver/sql/dialect"
+)
+
+// dialectOf returns the SQL dialect of the specified connection.
+func dialectOf(conn net.Conn) dialect.Dialect {
+	if _, ok := conn.(postgresql.Conn); ok {
+		return dialect.PostgreSQL
+	}
+	return dialect.MySQL
+}
+
+// rewriteQuery rewrites the specified query in the connection dialect into the SQLite statements.
+func rewriteQuery(conn net.Conn, q string) ([]string, error) {
+	return dialect.NewRewriterFor(dialectOf(conn)).RewriteStatements(q)
+}
+
+// rewriteStatement rewrites the specified statement in the connection dialect into the SQLite statement.
+func rewriteStatement(conn net.Conn, q string) (string, error) {
+	stmts, err := rewriteQuery(conn, q)
+	if err != nil {
+		return "", err
+	}
+	if len(stmts) != 1 {
+		return "", newErrInvalid(q)
+	}
+	return stmts[0], nil
+}
+
+// executeStatement executes the specified SQLite statement with the bind arguments, and returns the result set.
+func (server *server) executeStatement(conn net.Conn, stmt string, args ...any) (sql.ResultSet, error) {
+	log.Debugf("%v", stmt)
+	db, err := server.databaseOf(conn)
+	if err != nil {
+		return nil, err
+	}
+	if dialect.IsQuery(stmt) {
+		return server.query(conn, db, stmt, args...)
+	}
+	return server.exec(conn, db, stmt, args...)
+}
+
+// executeStatements executes the specified SQLite statements which are rewritten from a statement in sequence,
+// and returns the result set of the first statement. The following statements complete the first statement
+// such as CREATE INDEX of the inline index definitions of CREATE TABLE, and they have no bind arguments.
+func (server *server) executeStatements(conn net.Conn, stmts []string, args ...any) (sql.ResultSet, error) {
+	rs, err := server.executeStatement(conn, stmts[0], args...)
+	if err != nil {
+		return nil, err
+	}
+	for _, stmt := range stmts[1:] {
+		if _, err := server.executeStatement(conn, stmt); err != nil {
+			rs.Close()
+			return nil, err
+		}
+	}
+	return rs, nil
+}
+
+// newExecResultSetWith returns a new result set of the specified executed statement result,
+// and the result set has the specified warnings of the statement if any rows are affected.
+func newExecResultSetWith(result dbsql.Result, warnings []*Warning) (sql.ResultSet, error) {
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		warnings = nil
+	}
+	return NewResultSet(
+		WithResultSetResult(result),
+		WithResultSetWarnings(warnings),
+	)
+}
+
+// executeHoldableQuery executes the specified SQLite query with the bind arguments outside of the transaction, and returns the result set
+// which can be read after the transaction is committed.
+func (server *server) executeHoldableQuery(conn net.Conn, stmt string, args ...any) (sql.ResultSet, error) {
+	log.Debugf("%v", stmt)
+	db, err := server.databaseOf(conn)
+	if err != nil {
+		return nil, err
+	}
+	return server.queryWithHold(conn, db, stmt, args...)
+}
+
+// newQueryResultSetWith returns a new result set of the specified query rows. The first rows of the statements with RETURNING
+// are peeked because SQLite modifies the rows at the first step, and the errors are returned as the execution errors.
+func newQueryResultSetWith(db *Database, stmt string, rows *dbsql.Rows, opts ...ResultSetOption) (sql.ResultSet, error) {
+	opts = append(opts,
+		WithResultSetColumnOrigins(db.ColumnOrigins(stmt)),
+		WithResultSetExpressionTypes(dialect.ExpressionTypes(stmt)),
+		WithResultSetRows(rows),
+	)
+	if dialect.HasReturningClause(stmt) {
+		opts = append(opts, WithResultSetPeekedRow())
+	}
+	rs, err := NewResultSet(opts...)
+	if err != nil {
+		rows.Close()
+		return nil, db.valueErrorOf(stmt, err)
+	}
+	return rs, nil
+}
@@ -0,0 +1,675 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+// PostgreSQL: Documentation: 16: 8.15. Arrays
+// https://www.postgresql.org/docs/16/arrays.html
+// PostgreSQL: Documentation: 16: 9.24. Row and Array Comparisons
+// https://www.postgresql.org/docs/16/functions-comparisons.html
+
+import (
+	"strings"
+)
+
+// arrayConstraintPrefix is the name prefix of the CHECK constraints which restrict the array columns to the valid array literals.
+const arrayConstraintPrefix = HiddenColumnPrefix + "array:"
+
+// arrayValidFunction is the function which validates the array literals of the element types, and it is registered by the function package.
+const arrayValidFunction = "array_valid"
+
+// arrayFunctionNames represents the lower case names of the functions which return the arrays.
+var arrayFunctionNames = map[string]bool{
+	"array_construct": true, "array_in": true, "array_slice": true, "array_positions": true, "array_append": true,
+	"array_prepend": true, "array_cat": true, "array_remove": true, "array_replace": true, "string_to_array": true, "array_agg": true,
+}
+
+// arrayTypeNames maps the PostgreSQL element type names into the internal array type names such as _TEXT.
+// The array columns are created with the internal array type names, and the arrays are stored as the array literals such as {a,b}.
+var arrayTypeNames = map[string]string{
+	"TEXT":                        "_TEXT",
+	"VARCHAR":                     "_VARCHAR",
+	"CHARACTER VARYING":           "_VARCHAR",
+	"CHAR":                        "_BPCHAR",
+	"CHARACTER":                   "_BPCHAR",
+	"BPCHAR":                      "_BPCHAR",
+	"SMALLINT":                    "_INT2",
+	"INT2":                        "_INT2",
+	"INT":                         "_INT4",
+	"INTEGER":                     "_INT4",
+	"INT4":                        "_INT4",
+	"BIGINT":                      "_INT8",
+	"INT8":                        "_INT8",
+	"REAL":                        "_FLOAT4",
+	"FLOAT4":                      "_FLOAT4",
+	"FLOAT":                       "_FLOAT8",
+	"FLOAT8":                      "_FLOAT8",
+	"DOUBLE PRECISION":            "_FLOAT8",
+	"NUMERIC":                     "_NUMERIC",
+	"DECIMAL":                     "_NUMERIC",
+	"BOOL":                        "_BOOL",
+	"BOOLEAN":                     "_BOOL",
+	"DATE":                        "_DATE",
+	"TIME":                        "_TIME",
+	"TIMESTAMP":                   "_TIMESTAMP",
+	"TIMESTAMP WITHOUT TIME ZONE": "_TIMESTAMP",
+	"TIMESTAMPTZ":                 "_TIMESTAMPTZ",
+	"TIMESTAMP WITH TIME ZONE":    "_TIMESTAMPTZ",
+	"UUID":                        "_UUID",
+	"JSON":                        "_JSON",
+	"JSONB":                       "_JSONB",
+	"BYTEA":                       "_BYTEA",
+}
+
+// comparisonOperators represents the operators which are allowed before ANY, SOME and ALL.
+var comparisonOperators = map[string]bool{
+	"=": true, "<>": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true,
+}
+
+// IsArrayTypeName returns true if the specified upper case column type name is an internal array type name such as _TEXT.
+func IsArrayTypeName(typeName string) bool {
+	return strings.HasPrefix(typeName, "_")
+}
+
+// arrayTypeNameOf returns the internal array type name of the specified array type name such as TEXT [].
+func arrayTypeNameOf(typeName string) (string, bool) {
+	elemName, ok := strings.CutSuffix(typeName, " []")
+	if !ok {
+		return "", false
+	}
+	// The multi-dimensional arrays are stored as the one-dimensional arrays.
+	for strings.HasSuffix(elemName, " []") {
+		elemName = strings.TrimSuffix(elemName, " []")
+	}
+	arrayName, ok := arrayTypeNames[elemName]
+	return arrayName, ok
+}
+
+// arrayConstraintTokens returns the CHECK constraint tokens which restrict the specified array column to the valid array literals of the internal array type name.
+func arrayConstraintTokens(column string, typeName string) Tokens {
+	return Tokens{
+		NewSpaceToken(), NewWordToken("CONSTRAINT"),
+		NewSpaceToken(), NewIdentifierToken(arrayConstraintPrefix + typeName + ":" + column),
+		NewSpaceToken(), NewWordToken("CHECK"), NewSpaceToken(), NewPunctuationToken("("),
+		NewWordToken(arrayValidFunction), NewPunctuationToken("("), NewIdentifierToken(column), NewPunctuationToken(","),
+		NewSpaceToken(), NewStringToken(typeName), NewPunctuationToken(")"),
+		NewPunctuationToken(")"),
+	}
+}
+
+// ArrayConstraintOf returns the column name and the internal array type name of the specified CHECK constraint failure message
+// of an array column whose value is not a valid array literal.
+func ArrayConstraintOf(msg string) (string, string, bool) {
+	_, constraint, ok := strings.Cut(msg, arrayConstraintPrefix)
+	if !ok {
+		return "", "", false
+	}
+	typeName, column, ok := strings.Cut(constraint, ":")
+	if !ok {
+		return "", "", false
+	}
+	// The failure message may be followed by the other messages.
+	if idx := strings.IndexAny(column, " \n"); 0 <= idx {
+		column = column[:idx]
+	}
+	return column, typeName, true
+}
+
+// matchingBracket returns the index of the closing bracket which matches the open bracket at the specified index.
+func (tokens Tokens) matchingBracket(idx int) int {
+	depth := 0
+	for n := idx; n < len(tokens); n++ {
+		switch {
+		case tokens[n].IsPunctuation("["):
+			depth++
+		case tokens[n].IsPunctuation("]"):
+			depth--
+			if depth == 0 {
+				return n
+			}
+		}
+	}
+	return -1
+}
+
+// postgresqlArrayRule rewrites the array constructors, the subscripts and the array comparisons into the function calls.
+// ARRAY[a, b] is rewritten into array_construct(a, b), arr[n] and arr[m:n] are rewritten into array_element(arr, n) and array_slice(arr, m, n),
+// and x = ANY(arr) and x <> ALL(arr) are rewritten into array_any(x, '=', arr) and array_all(x, '<>', arr).
+func postgresqlArrayRule(tokens Tokens) (Tokens, error) {
+	if first := tokens.Next(-1); tokens.IsKeywordAt(first, "CREATE") || tokens.IsKeywordAt(first, "ALTER") {
+		// The array type names such as INT[3] are rewritten by createTableRule.
+		return tokens, nil
+	}
+	for n := 0; n < len(tokens); n++ {
+		var err error
+		switch {
+		case tokens[n].IsKeyword("ARRAY"):
+			tokens, err = tokens.rewriteArrayConstructor(n)
+		case tokens[n].IsPunctuation("["):
+			tokens, n, err = tokens.rewriteArraySubscript(n)
+		case tokens[n].IsKeyword("ANY"), tokens[n].IsKeyword("SOME"), tokens[n].IsKeyword("ALL"):
+			tokens, n, err = tokens.rewriteArrayComparison(n)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return tokens, nil
+}
+
+// rewriteArrayConstructor rewrites ARRAY[...] at the specified index into array_construct(...).
+func (tokens Tokens) rewriteArrayConstructor(n int) (Tokens, error) {
+	open := tokens.Next(n)
+	if open < 0 || !tokens[open].IsPunctuation("[") {
+		// ARRAY(subquery) is not supported yet.
+		if 0 <= open && tokens[open].IsPunctuation("(") {
+			return nil, newErrNotSupported(tokens[n:].String())
+		}
+		return tokens, nil
+	}
+	closing := tokens.matchingBracket(open)
+	if closing < 0 {
+		return nil, newErrInvalid(tokens[n:].String())
+	}
+	// The arrays are stored as the one-dimensional arrays, and the nested array constructors are not supported.
+	if first := tokens.Next(open); 0 <= first && (tokens[first].IsPunctuation("[") || tokens[first].IsKeyword("ARRAY")) {
+		return nil, newErrNotSupported("multidimensional array " + tokens[n:closing+1].String())
+	}
+	call := Tokens{NewWordToken("array_construct"), NewPunctuationToken("(")}
+	call = append(call, tokens[open+1:closing]...)
+	call = append(call, NewPunctuationToken(")"))
+	return tokens.Splice(n, closing, call...), nil
+}
+
+// rewriteArraySubscript rewrites arr[n] and arr[m:n] at the specified open bracket index into the function calls.
+func (tokens Tokens) rewriteArraySubscript(n int) (Tokens, int, error) {
+	closing := tokens.matchingBracket(n)
+	if closing < 0 {
+		return nil, n, newErrInvalid(tokens[n:].String())
+	}
+	operandEnd := tokens.Prev(n)
+	if next := tokens.Next(n); next == closing || operandEnd < 0 {
+		// The empty brackets such as text[] belong to the array type names.
+		return tokens, closing, nil
+	}
+	if !tokens[operandEnd].IsName() && !tokens[operandEnd].IsPunctuation(")") && tokens[operandEnd].Type != ParameterToken {
+		return tokens, n, nil
+	}
+	begin := tokens.operandBegin(operandEnd)
+	if prev := tokens.Prev(begin); 0 <= prev && tokens[prev].IsOperator("::") {
+		// The bounded array type names such as int[3] are the array type names.
+		return tokens, closing, nil
+	}
+	args := tokens[n+1 : closing]
+	name := "array_element"
+	for idx, tok := range args {
+		if tok.IsOperator(":") {
+			name = "array_slice"
+			lower := append(Tokens{}, args[:idx]...).trimSpace()
+			upper := append(Tokens{}, args[idx+1:]...).trimSpace()
+			if len(lower) == 0 {
+				lower = Tokens{NewWordToken("NULL")}
+			}
+			if len(upper) == 0 {
+				upper = Tokens{NewWordToken("NULL")}
+			}
+			args = append(append(lower, NewPunctuationToken(","), NewSpaceToken()), upper...)
+			break
+		}
+	}
+	call := Tokens{NewWordToken(name), NewPunctuationToken("(")}
+	call = append(call, tokens[begin:operandEnd+1]...)
+	call = append(call, NewPunctuationToken(","), NewSpaceToken())
+	call = append(call, args...)
+	call = append(call, NewPunctuationToken(")"))
+	return tokens.Splice(begin, closing, call...), begin, nil
+}
+
+// rewriteArrayComparison rewrites x op ANY(arr) and x op ALL(arr) at the specified ANY, SOME or ALL keyword index.
+// The subqueries are rewritten into x IN (subquery) and x NOT IN (subquery) because SQLite has no ANY and ALL.
+func (tokens Tokens) rewriteArrayComparison(n int) (Tokens, int, error) {
+	ope := tokens.Prev(n)
+	open := tokens.Next(n)
+	if ope < 0 || tokens[ope].Type != OperatorToken || !comparisonOperators[tokens[ope].Text] {
+		return tokens, n, nil
+	}
+	if open < 0 || !tokens[open].IsPunctuation("(") {
+		return nil, n, newErrInvalid(tokens[ope:].String())
+	}
+	closing := tokens.MatchingParen(open)
+	leftEnd := tokens.Prev(ope)
+	if closing < 0 || leftEnd < 0 {
+		return nil, n, newErrInvalid(tokens.String())
+	}
+	begin := tokens.operandBegin(leftEnd)
+	left := append(Tokens{}, tokens[begin:leftEnd+1]...)
+	isAll := tokens[n].IsKeyword("ALL")
+	if first := tokens.Next(open); tokens.IsKeywordAt(first, "SELECT") || tokens.IsKeywordAt(first, "WITH") {
+		var in Tokens
+		switch {
+		case !isAll && tokens[ope].IsOperator("="):
+			in = keywordTokens("IN")
+		case isAll && (tokens[ope].IsOperator("<>") || tokens[ope].IsOperator("!=")):
+			in = keywordTokens("NOT", "IN")
+		default:
+			return nil, n, newErrNotSupported(tokens[begin : closing+1].String())
+		}
+		repl := append(left, NewSpaceToken())
+		repl = append(repl, in...)
+		repl = append(repl, NewSpaceToken())
+		repl = append(repl, tokens[open:closing+1]...)
+		return tokens.Splice(begin, closing, repl...), begin, nil
+	}
+	name := "array_any"
+	if isAll {
+		name = "array_all"
+	}
+	call := Tokens{NewWordToken(name), NewPunctuationToken("(")}
+	call = append(call, left...)
+	call = append(call, NewPunctuationToken(","), NewSpaceToken(), NewStringToken(tokens[ope].Text), NewPunctuationToken(","), NewSpaceToken())
+	call = append(call, tokens[open+1:closing]...)
+	call = append(call, NewPunctuationToken(")"))
+	return tokens.Splice(begin, closing, call...), begin, nil
+}
+
+// unnestAlias is the table alias of the json_each() table which expands unnest() in a select list.
+const unnestAlias = HiddenColumnPrefix + "unnest"
+
+// postgresqlUnnestRule rewrites unnest(arr) in the FROM clauses into the SQLite json_each() table-valued function.
+// The column is named after the column alias, the table alias or unnest as PostgreSQL does.
+// The leading unnest() is rewritten into a subquery which has the named columns, and the following unnest()
+// such as FROM t, unnest(t.tags) AS u(tag) refers to the preceding tables, so that the column references are rewritten instead
+// because SQLite does not allow the correlated subqueries in the FROM clauses.
+// unnest() in the select lists is rewritten by rewriteSelectListUnnest.
+func postgresqlUnnestRule(tokens Tokens) (Tokens, error) {
+	for n := 0; n < len(tokens); n++ {
+		if !tokens[n].IsKeyword("UNNEST") {
+			continue
+		}
+		if !tokens.isTableReferenceAt(n) {
+			var err error
+			tokens, err = tokens.rewriteSelectListUnnest(n)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		open := tokens.Next(n)
+		if open < 0 || !tokens[open].IsPunctuation("(") {
+			continue
+		}
+		closing := tokens.MatchingParen(open)
+		if closing < 0 {
+			return nil, newErrInvalid(tokens[n:].String())
+		}
+		arg := append(Tokens{}, tokens[open+1:closing]...)
+		if 1 < len(arg.splitTopLevel(",")) {
+			return nil, newErrNotSupported(tokens[n : closing+1].String())
+		}
+		end := closing
+		isOrdinality := false
+		if next := tokens.Next(end); tokens.IsKeywordsAt(next, "WITH", "ORDINALITY") {
+			end = tokens.Next(next)
+			isOrdinality = true
+		}
+		alias, columns := "unnest", []string{"unnest", "ordinality"}
+		if idx := tokens.Next(end); tokens.IsKeywordAt(idx, "AS") || (0 <= idx && tokens[idx].IsName() && !reservedKeywords[strings.ToUpper(tokens[idx].Text)] && !tokens.isClauseKeywordAt(idx)) {
+			if tokens.IsKeywordAt(idx, "AS") {
+				idx = tokens.Next(idx)
+			}
+			if idx < 0 || !tokens[idx].IsName() {
+				return nil, newErrInvalid(tokens[n:].String())
+			}
+			alias, columns[0] = tokens[idx].Value, tokens[idx].Value
+			end = idx
+			if colOpen := tokens.Next(idx); 0 <= colOpen && tokens[colOpen].IsPunctuation("(") {
+				end = tokens.MatchingParen(colOpen)
+				if end < 0 {
+					return nil, newErrInvalid(tokens[n:].String())
+				}
+				for idx, col := range tokens[colOpen+1 : end].splitTopLevel(",") {
+					col = col.trimSpace()
+					if len(col) != 1 || len(columns) <= idx {
+						return nil, newErrInvalid(tokens[n : end+1].String())
+					}
+					columns[idx] = col[0].Value
+				}
+			}
+		}
+		call := Tokens{NewWordToken("json_each"), NewPunctuationToken("("), NewWordToken("array_to_json"), NewPunctuationToken("(")}
+		call = append(call, arg...)
+		call = append(call, NewPunctuationToken(")"), NewPunctuationToken(")"))
+		if prev := tokens.Prev(n); !tokens[prev].IsKeyword("FROM") {
+			repl := append(call, NewSpaceToken(), NewWordToken("AS"), NewSpaceToken(), NewIdentifierToken(alias))
+			tokens = tokens.Splice(n, end, repl...)
+			tokens = tokens.withUnnestColumnReferences(n, n+len(repl)-1, alias, columns, isOrdinality)
+			continue
+		}
+		subquery := keywordTokens("SELECT")
+		subquery = append(subquery, NewSpaceToken(), NewWordToken("value"), NewSpaceToken(), NewWordToken("AS"), NewSpaceToken(), NewIdentifierToken(columns[0]))
+		if isOrdinality {
+			subquery = append(subquery, NewPunctuationToken(","), NewSpaceToken())
+			subquery = append(subquery, ordinalityTokens("")...)
+			subquery = append(subquery, NewSpaceToken(), NewWordToken("AS"), NewSpaceToken(), NewIdentifierToken(columns[1]))
+		}
+		subquery = append(subquery, NewSpaceToken())
+		subquery = append(subquery, keywordTokens("FROM")...)
+		subquery = append(subquery, NewSpaceToken())
+		subquery = append(subquery, call...)
+		repl := append(Tokens{NewPunctuationToken("(")}, subquery...)
+		repl = append(repl, NewPunctuationToken(")"), NewSpaceToken(), NewWordToken("AS"), NewSpaceToken(), NewIdentifierToken(alias))
+		tokens = tokens.Splice(n, end, repl...)
+	}
+	return tokens, nil
+}
+
+// rewriteSelectListUnnest rewrites unnest(arr) at the specified index in a select list into the value column of the json_each() table
+// which is joined to the FROM clause, because SQLite has no set-returning functions. The select item is named unnest as PostgreSQL does,
+// and the columns of the single table in the FROM clause whose names are the json_each() column names such as id are qualified
+// not to be ambiguous. Only one unnest() is supported in a statement because PostgreSQL expands the multiple unnest() in parallel.
+func (tokens Tokens) rewriteSelectListUnnest(n int) (Tokens, error) {
+	open := tokens.Next(n)
+	if open < 0 || !tokens[open].IsPunctuation("(") {
+		return tokens, nil
+	}
+	closing := tokens.MatchingParen(open)
+	if closing < 0 {
+		return nil, newErrInvalid(tokens[n:].String())
+	}
+	arg := append(Tokens{}, tokens[open+1:closing]...)
+	selectIdx := tokens.selectListOf(n)
+	if selectIdx < 0 || 1 < len(arg.splitTopLevel(",")) {
+		return nil, newErrNotSupported(tokens[n : closing+1].String())
+	}
+	for _, tok := range tokens {
+		if tok.Type == IdentifierToken && tok.Value == unnestAlias {
+			return nil, newErrNotSupported(tokens[n : closing+1].String())
+		}
+	}
+	column := Tokens{NewIdentifierToken(unnestAlias), NewPunctuationToken("."), NewWordToken("value")}
+	if next := tokens.Next(closing); tokens.isSelectItemBeginAt(n) && (next < 0 || tokens[next].IsPunctuation(",") || tokens[next].IsKeyword("FROM") || tokens.isSelectClauseEndAt(next)) {
+		column = append(column, NewSpaceToken(), NewWordToken("AS"), NewSpaceToken(), NewWordToken("unnest"))
+	}
+	tokens = tokens.Splice(n, closing, column...)
+	from := tokens.nextSelectClause(n + len(column))
+	isFrom := from < len(tokens) && tokens[from].IsKeyword("FROM")
+	if isFrom {
+		if table, ok := tokens[from+1 : tokens.nextSelectClause(from+1)].tableReferenceName(); ok {
+			tokens = tokens.qualifyColumnReferences(selectIdx, table, jsonEachColumnNames)
+			from = tokens.nextSelectClause(n + len(column))
+		}
+	}
+	table := Tokens{NewWordToken("json_each"), NewPunctuationToken("("), NewWordToken("array_to_json"), NewPunctuationToken("(")}
+	table = append(table, arg...)
+	table = append(table, NewPunctuationToken(")"), NewPunctuationToken(")"), NewSpaceToken(), NewWordToken("AS"), NewSpaceToken(), NewIdentifierToken(unnestAlias))
+	end := from
+	if isFrom {
+		// The json_each() table follows the table references, and the arguments may refer to them.
+		end = tokens.nextSelectClause(from + 1)
+		table = append(Tokens{NewPunctuationToken(","), NewSpaceToken()}, table...)
+	} else {
+		table = append(append(Tokens{NewSpaceToken()}, keywordTokens("FROM")...), append(Tokens{NewSpaceToken()}, table...)...)
+	}
+	last := tokens.Prev(end)
+	return tokens.Splice(last+1, last, table...), nil
+}
+
+// jsonEachColumnNames represents the column names of the SQLite json_each() table-valued function.
+var jsonEachColumnNames = map[string]bool{
+	"key": true, "value": true, "type": true, "atom": true, "id": true, "parent": true, "fullkey": true, "path": true,
+}
+
+// selectListOf returns the index of the SELECT keyword of the select list which has the specified index, or -1 if the index is not in a select list.
+func (tokens Tokens) selectListOf(idx int) int {
+	depth := 0
+	for n := tokens.Prev(idx); 0 <= n; n = tokens.Prev(n) {
+		switch {
+		case tokens[n].IsPunctuation(")"):
+			depth++
+		case tokens[n].IsPunctuation("("):
+			// The expressions of the select items may be parenthesized.
+			depth = max(depth-1, 0)
+		case depth == 0 && tokens[n].IsKeyword("SELECT"):
+			return n
+		case depth == 0 && (tokens[n].IsKeyword("FROM") || tokens.isSelectClauseEndAt(n)):
+			return -1
+		}
+	}
+	return -1
+}
+
+// isSelectItemBeginAt returns true if the specified index begins a select item.
+func (tokens Tokens) isSelectItemBeginAt(idx int) bool {
+	prev := tokens.Prev(idx)
+	return 0 <= prev && (tokens[prev].IsKeyword("SELECT") || tokens[prev].IsKeyword("DISTINCT") || tokens[prev].IsKeyword("ALL") || tokens[prev].IsPunctuation(","))
+}
+
+// tableReferenceName returns the alias or the name of the specified FROM clause if the clause has only one table such as t AS a.
+func (tokens Tokens) tableReferenceName() (string, bool) {
+	tokens = tokens.trimSpace()
+	name := tokens.Next(-1)
+	if name < 0 || !tokens[name].IsName() || reservedKeywords[strings.ToUpper(tokens[name].Text)] {
+		return "", false
+	}
+	if dot := tokens.Next(name); 0 <= dot && tokens[dot].IsPunctuation(".") {
+		name = tokens.Next(dot)
+		if name < 0 || !tokens[name].IsName() {
+			return "", false
+		}
+	}
+	alias := tokens.Next(name)
+	if tokens.IsKeywordAt(alias, "AS") {
+		alias = tokens.Next(alias)
+	}
+	switch {
+	case alias < 0:
+		return tokens[name].Value, true
+	case tokens[alias].IsName() && !reservedKeywords[strings.ToUpper(tokens[alias].Text)] && tokens.Next(alias) < 0:
+		return tokens[alias].Value, true
+	}
+	return "", false
+}
+
+// qualifyColumnReferences qualifies the unqualified references to the specified lower case column names
+// and the wildcard in the select statement which begins at the specified index with the specified table name.
+// The references in the subqueries are not qualified.
+func (tokens Tokens) qualifyColumnReferences(begin int, table string, columns map[string]bool) Tokens {
+	subqueries := []bool{}
+	for n := begin + 1; n < len(tokens); n++ {
+		tok := tokens[n]
+		isSubquery := 0 < len(subqueries) && subqueries[len(subqueries)-1]
+		switch {
+		case tok.IsPunctuation("("):
+			next := tokens.Next(n)
+			subqueries = append(subqueries, isSubquery || tokens.IsKeywordAt(next, "SELECT") || tokens.IsKeywordAt(next, "WITH"))
+		case tok.IsPunctuation(")"):
+			if len(subqueries) == 0 {
+				return tokens
+			}
+			subqueries = subqueries[:len(subqueries)-1]
+		case len(subqueries) == 0 && (tok.IsPunctuation(";") || tok.IsKeyword("UNION") || tok.IsKeyword("INTERSECT") || tok.IsKeyword("EXCEPT")):
+			return tokens
+		case len(subqueries) == 0 && tok.IsOperator("*") && tokens.isSelectItemBeginAt(n):
+			// The wildcard does not expand the json_each() columns.
+			tokens = tokens.Splice(n, n, NewIdentifierToken(table), NewPunctuationToken("."), tok)
+			n += 2
+		case !isSubquery && tok.IsName() && columns[strings.ToLower(tok.Value)]:
+			prev, next := tokens.Prev(n), tokens.Next(n)
+			if 0 <= prev && (tokens[prev].IsPunctuation(".") || tokens[prev].IsKeyword("AS")) {
+				continue
+			}
+			if 0 <= next && (tokens[next].IsPunctuation(".") || tokens[next].IsPunctuation("(")) {
+				continue
+			}
+			tokens = tokens.Splice(n, n, NewIdentifierToken(table), NewPunctuationToken("."), tok)
+			n += 2
+		}
+	}
+	return tokens
+}
+
+// nextSelectClause returns the index of the FROM keyword or the clause which follows the select list or the FROM clause after the specified index,
+// and the index is the closing parenthesis of the subquery or the length of the tokens if the select statement ends.
+func (tokens Tokens) nextSelectClause(from int) int {
+	depth := 0
+	for n := from; n < len(tokens); n++ {
+		switch {
+		case tokens[n].IsPunctuation("("):
+			depth++
+		case tokens[n].IsPunctuation(")"):
+			if depth == 0 {
+				return n
+			}
+			depth--
+		case depth == 0 && (tokens[n].IsKeyword("FROM") || tokens.isSelectClauseEndAt(n)):
+			return n
+		}
+	}
+	return len(tokens)
+}
+
+// isSelectClauseEndAt returns true if the specified index is a keyword which ends the select list or the FROM clause.
+func (tokens Tokens) isSelectClauseEndAt(idx int) bool {
+	if tokens[idx].IsPunctuation(";") {
+		return true
+	}
+	for _, keyword := range []string{"WHERE", "GROUP", "HAVING", "WINDOW", "ORDER", "LIMIT", "OFFSET", "UNION", "INTERSECT", "EXCEPT", "FETCH", "FOR", "RETURNING"} {
+		if tokens[idx].IsKeyword(keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// ordinalityTokens returns the expression of the one-based ordinality of the json_each() rows.
+func ordinalityTokens(alias string) Tokens {
+	key := Tokens{NewWordToken("key")}
+	if alias != "" {
+		key = Tokens{NewIdentifierToken(alias), NewPunctuationToken("."), NewWordToken("key")}
+	}
+	tokens := append(Tokens{NewPunctuationToken("(")}, key...)
+	return append(tokens, NewSpaceToken(), NewOperatorToken("+"), NewSpaceToken(), &Token{Type: NumberToken, Text: "1", Value: "1"}, NewPunctuationToken(")"))
+}
+
+// withUnnestColumnReferences rewrites the references to the unnest() columns outside the specified range into the json_each() columns.
+func (tokens Tokens) withUnnestColumnReferences(begin int, end int, alias string, columns []string, isOrdinality bool) Tokens {
+	for n := 0; n < len(tokens); n++ {
+		if begin <= n && n <= end {
+			continue
+		}
+		if !tokens[n].IsName() {
+			continue
+		}
+		col := -1
+		for idx, name := range columns {
+			if strings.EqualFold(tokens[n].Value, name) && (idx == 0 || isOrdinality) {
+				col = idx
+			}
+		}
+		if col < 0 {
+			continue
+		}
+		refBegin := n
+		prev := tokens.Prev(n)
+		if next := tokens.Next(n); 0 <= next && (tokens[next].IsPunctuation("(") || tokens[next].IsPunctuation(".")) {
+			continue
+		}
+		if 0 <= prev && tokens[prev].IsKeyword("AS") {
+			continue
+		}
+		if 0 <= prev && tokens[prev].IsPunctuation(".") {
+			qualifier := tokens.Prev(prev)
+			if qualifier < 0 || !strings.EqualFold(tokens[qualifier].Value, alias) {
+				continue
+			}
+			refBegin = qualifier
+		}
+		repl := Tokens{NewIdentifierToken(alias), NewPunctuationToken("."), NewWordToken("value")}
+		if col == 1 {
+			repl = ordinalityTokens(alias)
+		}
+		if tokens.isSelectItemAt(refBegin, n) {
+			// The select items are named after the unnest() columns.
+			repl = append(repl, NewSpaceToken(), NewWordToken("AS"), NewSpaceToken(), NewIdentifierToken(columns[col]))
+		}
+		tokens = tokens.Splice(refBegin, n, repl...)
+		if refBegin < begin {
+			begin += len(repl) - (n - refBegin + 1)
+			end += len(repl) - (n - refBegin + 1)
+		}
+		n = refBegin + len(repl) - 1
+	}
+	return tokens
+}
+
+// isSelectItemAt returns true if the specified range is a whole select item which is not aliased.
+func (tokens Tokens) isSelectItemAt(begin int, end int) bool {
+	prev, next := tokens.Prev(begin), tokens.Next(end)
+	if prev < 0 || next < 0 {
+		return false
+	}
+	if !tokens[prev].IsKeyword("SELECT") && !tokens[prev].IsKeyword("DISTINCT") && !tokens[prev].IsPunctuation(",") {
+		return false
+	}
+	if !tokens[next].IsKeyword("FROM") && !tokens[next].IsPunctuation(",") {
+		return false
+	}
+	return 0 <= tokens.indexTopLevelKeyword(end, "FROM")
+}
+
+// isTableReferenceAt returns true if the specified index begins a table reference of the FROM clause.
+func (tokens Tokens) isTableReferenceAt(idx int) bool {
+	prev := tokens.Prev(idx)
+	switch {
+	case prev < 0:
+		return false
+	case tokens[prev].IsKeyword("FROM"), tokens[prev].IsKeyword("JOIN"), tokens[prev].IsKeyword("LATERAL"):
+		return true
+	case !tokens[prev].IsPunctuation(","):
+		return false
+	}
+	// The comma separated table references follow FROM at the same depth.
+	depth := 0
+	for n := prev; 0 <= n; n-- {
+		switch {
+		case tokens[n].IsPunctuation(")"):
+			depth++
+		case tokens[n].IsPunctuation("("):
+			if depth == 0 {
+				return false
+			}
+			depth--
+		case depth == 0 && tokens[n].IsKeyword("FROM"):
+			return true
+		case depth == 0 && tokens[n].IsKeyword("SELECT"):
+			return false
+		}
+	}
+	return false
+}
+
+// isClauseKeywordAt returns true if the specified index is a keyword which follows the table references.
+func (tokens Tokens) isClauseKeywordAt(idx int) bool {
+	for _, keyword := range []string{"CROSS", "INNER", "LEFT", "RIGHT", "FULL", "NATURAL", "GROUP", "ORDER", "WINDOW", "UNION", "INTERSECT", "EXCEPT", "FETCH", "FOR"} {
+		if tokens[idx].IsKeyword(keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// isArrayOperand returns true if the specified operand is an array column, an array function call or a parenthesized array operand.
+func (tokens Tokens) isArrayOperand(columnTypeOf func(string) string) bool {
+	return tokens.isTypedOperand(arrayFunctionNames, IsArrayTypeName, columnTypeOf)
+}
@@ -0,0 +1,214 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+// SQLite: INSERT
+// https://www.sqlite.org/lang_insert.html
+// SQLite: UPDATE
+// https://www.sqlite.org/lang_update.html
+
+import (
+	"strings"
+)
+
+// Assignment represents a string literal or a bind parameter which is assigned to a column by INSERT or UPDATE.
+type Assignment struct {
+	// Column is the column name, or an empty string if INSERT has no column list.
+	Column string
+	// Index is the index of the column in the column list of INSERT, in the table columns or in the SET clause of UPDATE.
+	Index int
+	// Row is the row number of INSERT which starts from 1, and it is 1 for UPDATE.
+	Row int
+	// Value is the assigned string, or an empty string if the bind parameter is assigned.
+	Value string
+	// Param is the number of the assigned bind parameter, or zero if the string literal is assigned.
+	Param int
+	// token is the assigned token.
+	token *Token
+}
+
+// updateClauseKeywords represents the keywords which terminate the SET clause of UPDATE.
+var updateClauseKeywords = []string{"FROM", "WHERE", "RETURNING", "ORDER", "LIMIT"}
+
+// StringAssignments returns the table name and the string literals and the bind parameters which are assigned to the columns
+// by the specified SQLite INSERT ... VALUES or UPDATE statement, because the bind parameters may be bound to the strings.
+// The values of the other expressions such as functions and casts are not included.
+func StringAssignments(stmt string) (string, []*Assignment, bool) {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return "", nil, false
+	}
+	return tokens.stringAssignments()
+}
+
+// ReplaceStringAssignments returns the specified SQLite statement whose string literals of the assignments are replaced with
+// the specified numbers. The numbers are keyed by the indexes of the assignments which StringAssignments returns.
+func ReplaceStringAssignments(stmt string, numbers map[int]string) (string, error) {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return "", err
+	}
+	_, assignments, ok := tokens.stringAssignments()
+	if !ok {
+		return "", newErrInvalid(stmt)
+	}
+	for idx, number := range numbers {
+		if idx < 0 || len(assignments) <= idx || assignments[idx].Param != 0 {
+			return "", newErrInvalid(stmt)
+		}
+		*assignments[idx].token = Token{Type: NumberToken, Text: number, Value: number}
+	}
+	return tokens.String(), nil
+}
+
+// stringAssignments returns the table name and the assignments of the string literals and the bind parameters of the statement.
+func (tokens Tokens) stringAssignments() (string, []*Assignment, bool) {
+	n := tokens.statementKeywordIndex()
+	if n < 0 {
+		return "", nil, false
+	}
+	switch strings.ToUpper(tokens[n].Text) {
+	case "INSERT", "REPLACE":
+		return tokens.insertStringAssignments(n, tokens.parameterNumbers())
+	case "UPDATE":
+		return tokens.updateStringAssignments(n, tokens.parameterNumbers())
+	}
+	return "", nil, false
+}
+
+// insertStringAssignments returns the table name and the string literals and the bind parameters of the VALUES rows of INSERT
+// at the specified index.
+func (tokens Tokens) insertStringAssignments(begin int, params map[*Token]int) (string, []*Assignment, bool) {
+	end, columns, n, ok := tokens.insertColumnsAt(begin)
+	if !ok || !tokens.IsKeywordAt(n, "VALUES") {
+		return "", nil, false
+	}
+	tblName := tokens[end].Value
+	assignments := []*Assignment{}
+	for row := 1; ; row++ {
+		n = tokens.Next(n)
+		if n < 0 || !tokens[n].IsPunctuation("(") {
+			break
+		}
+		closeIdx := tokens.MatchingParen(n)
+		if closeIdx < 0 {
+			return "", nil, false
+		}
+		for idx, value := range tokens[n+1 : closeIdx].splitTopLevel(",") {
+			value = value.trimSpace()
+			if len(value) != 1 || (value[0].Type != StringToken && params[value[0]] == 0) {
+				continue
+			}
+			column := ""
+			if columns != nil {
+				if len(columns) <= idx {
+					continue
+				}
+				column = columns[idx]
+			}
+			assignments = append(assignments, newAssignment(column, idx, row, value[0], params))
+		}
+		n = tokens.Next(closeIdx)
+		if n < 0 || !tokens[n].IsPunctuation(",") {
+			break
+		}
+	}
+	return tblName, assignments, true
+}
+
+// insertColumnsAt returns the index of the table name, the column list and the index of the token after the column list
+// of INSERT at the specified index. The column list is nil if INSERT has no column list.
+func (tokens Tokens) insertColumnsAt(begin int) (int, []string, int, bool) {
+	into := tokens.indexTopLevelKeyword(begin, "INTO")
+	if into < 0 {
+		return -1, nil, -1, false
+	}
+	end := tokens.tableNameIndexAfter(into)
+	if end < 0 {
+		return -1, nil, -1, false
+	}
+	n := tokens.Next(end)
+	if tokens.IsKeywordAt(n, "AS") {
+		n = tokens.Next(tokens.Next(n))
+	}
+	var columns []string
+	if 0 <= n && tokens[n].IsPunctuation("(") {
+		closeIdx := tokens.MatchingParen(n)
+		if closeIdx < 0 {
+			return -1, nil, -1, false
+		}
+		for _, column := range tokens[n+1 : closeIdx].splitTopLevel(",") {
+			column = column.trimSpace()
+			if len(column) != 1 || !column[0].IsName() {
+				return -1, nil, -1, false
+			}
+			columns = append(columns, column[0].Value)
+		}
+		n = tokens.Next(closeIdx)
+	}
+	return end, columns, n, true
+}
+
+// updateStringAssignments returns the table name and the string literals and the bind parameters of the SET clause of UPDATE
+// at the specified index.
+func (tokens Tokens) updateStringAssignments(begin int, params map[*Token]int) (string, []*Assignment, bool) {
+	// The conflict resolution such as UPDATE OR IGNORE precedes the table name.
+	if tokens.IsKeywordAt(tokens.Next(begin), "OR") {
+		begin = tokens.Next(tokens.Next(begin))
+	}
+	end := tokens.tableNameIndexAfter(begin)
+	if end < 0 {
+		return "", nil, false
+	}
+	set := tokens.indexTopLevelKeyword(end, "SET")
+	if set < 0 {
+		return "", nil, false
+	}
+	setEnd := len(tokens)
+	for _, keyword := range updateClauseKeywords {
+		if n := tokens.indexTopLevelKeyword(set, keyword); 0 <= n && n < setEnd {
+			setEnd = n
+		}
+	}
+	assignments := []*Assignment{}
+	for idx, assignment := range tokens[set+1 : setEnd].splitTopLevel(",") {
+		assignment = assignment.trimSpace()
+		eq := -1
+		for n, tok := range assignment {
+			if tok.IsOperator("=") {
+				eq = n
+				break
+			}
+		}
+		column := assignment.Prev(eq)
+		if eq < 0 || column < 0 || !assignment[column].IsName() {
+			continue
+		}
+		value := assignment[eq+1:].trimSpace()
+		if len(value) != 1 || (value[0].Type != StringToken && params[value[0]] == 0) {
+			continue
+		}
+		assignments = append(assignments, newAssignment(assignment[column].Value, idx, 1, value[0], params))
+	}
+	return tokens[end].Value, assignments, true
+}
+
+// newAssignment returns the assignment of the specified string literal or bind parameter token.
+func newAssignment(column string, idx int, row int, tok *Token, params map[*Token]int) *Assignment {
+	if param := params[tok]; 0 < param {
+		return &Assignment{Column: column, Index: idx, Row: row, Value: "", Param: param, token: tok}
+	}
+	return &Assignment{Column: column, Index: idx, Row: row, Value: tok.Value, Param: 0, token: tok}
+}
@@ -0,0 +1,159 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+// SQLite: DROP TABLE
+// https://www.sqlite.org/lang_droptable.html
+// PostgreSQL: Documentation: 16: 8.5. Date/Time Types
+// https://www.postgresql.org/docs/16/datatype-datetime.html
+// MySQL :: MySQL 8.0 Reference Manual :: 13.1.1 Numeric Data Type Syntax
+// https://dev.mysql.com/doc/refman/8.0/en/numeric-type-syntax.html
+
+// The logical column types which SQLite stores as the other types, and the side catalog keeps for the columns.
+const (
+	BooleanType     = "BOOLEAN"
+	DateType        = "DATE"
+	TimeType        = "TIME"
+	TimestampTZType = "TIMESTAMPTZ"
+	UUIDType        = "UUID"
+)
+
+// logicalTypes maps the declared type names into the logical column types.
+var logicalTypes = map[string]string{
+	"BOOL":                     BooleanType,
+	"BOOLEAN":                  BooleanType,
+	"DATE":                     DateType,
+	"TIME":                     TimeType,
+	"TIMETZ":                   TimeType,
+	"TIME WITH TIME ZONE":      TimeType,
+	"TIME WITHOUT TIME ZONE":   TimeType,
+	"TIMESTAMPTZ":              TimestampTZType,
+	"TIMESTAMP WITH TIME ZONE": TimestampTZType,
+	"UUID":                     UUIDType,
+}
+
+// IsLogicalTypeName returns true if the specified type name is a logical column type such as BOOLEAN and UUID.
+func IsLogicalTypeName(typeName string) bool {
+	switch typeName {
+	case BooleanType, DateType, TimeType, TimestampTZType, UUIDType:
+		return true
+	}
+	return false
+}
+
+// LogicalTypeOf returns the logical column type of the specified upper case declared type name such as BOOL and TIMETZ.
+func LogicalTypeOf(typeName string) (string, bool) {
+	logicalType, ok := logicalTypes[typeName]
+	return logicalType, ok
+}
+
+// LogicalColumnTypes returns the table name and the logical types of the columns in the specified CREATE TABLE statement,
+// and the declared integer types of the auto-increment columns. The columns of the other types are not included.
+func LogicalColumnTypes(stmt string) (string, map[string]string, bool) {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return "", nil, false
+	}
+	defs, ok := tokens.columnDefs()
+	if !ok {
+		return "", nil, false
+	}
+	types := map[string]string{}
+	for _, def := range defs {
+		if def.typeEnd < 0 {
+			continue
+		}
+		if logicalType, ok := tokens[def.typeBegin : def.typeEnd+1].logicalTypeOf(def.typeName); ok {
+			types[def.name] = logicalType
+		} else if integerType, ok := tokens[def.typeEnd+1 : def.end].integerTypeCollationOf(); ok {
+			types[def.name] = integerType
+		}
+	}
+	return tokens.tableNameAfter(tokens.indexTopLevelKeyword(0, "TABLE")), types, true
+}
+
+// DroppedTableNames returns the table names of the specified DROP TABLE statement, or nil if the statement is not DROP TABLE.
+func DroppedTableNames(stmt string) []string {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return nil
+	}
+	drop := tokens.Next(-1)
+	table := tokens.Next(drop)
+	if !tokens.IsKeywordAt(drop, "DROP") || !tokens.IsKeywordAt(table, "TABLE") {
+		return nil
+	}
+	names := []string{}
+	for _, elem := range tokens[table+1:].splitTopLevel(",") {
+		if name := elem.tableNameAfter(-1); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// logicalTypeOf returns the logical column type of the specified type tokens such as BOOLEAN and TINYINT(1).
+func (tokens Tokens) logicalTypeOf(typeName string) (string, bool) {
+	if logicalType, ok := logicalTypes[typeName]; ok {
+		return logicalType, true
+	}
+	// TINYINT(1) is the MySQL boolean type.
+	if typeName != "TINYINT" {
+		return "", false
+	}
+	params := []string{}
+	for _, tok := range tokens {
+		if tok.Type == NumberToken {
+			params = append(params, tok.Text)
+		}
+	}
+	if len(params) != 1 || params[0] != "1" {
+		return "", false
+	}
+	return BooleanType, true
+}
+
+// tableNameAfter returns the unqualified table name which follows the specified index and the optional IF [NOT] EXISTS.
+func (tokens Tokens) tableNameAfter(begin int) string {
+	n := tokens.tableNameIndexAfter(begin)
+	if n < 0 {
+		return ""
+	}
+	return tokens[n].Value
+}
+
+// tableNameIndexAfter returns the index of the unqualified table name which follows the specified index
+// and the optional IF [NOT] EXISTS, or -1 if not found.
+func (tokens Tokens) tableNameIndexAfter(begin int) int {
+	n := tokens.Next(begin)
+	switch {
+	case tokens.IsKeywordsAt(n, "IF", "NOT", "EXISTS"):
+		n = tokens.Next(tokens.Next(tokens.Next(n)))
+	case tokens.IsKeywordsAt(n, "IF", "EXISTS"):
+		n = tokens.Next(tokens.Next(n))
+	}
+	if n < 0 || !tokens[n].IsName() {
+		return -1
+	}
+	// The schema name such as main.table is dropped.
+	for dot := tokens.Next(n); 0 <= dot && tokens[dot].IsPunctuation("."); dot = tokens.Next(n) {
+		next := tokens.Next(dot)
+		if next < 0 || !tokens[next].IsName() {
+			break
+		}
+		n = next
+	}
+	return n
+}
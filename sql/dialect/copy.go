@@ -0,0 +1,294 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+// PostgreSQL: Documentation: 16: COPY
+// https://www.postgresql.org/docs/16/sql-copy.html
+
+import (
+	"strings"
+)
+
+// The data formats of COPY.
+const (
+	CopyTextFormat   = "TEXT"
+	CopyCSVFormat    = "CSV"
+	CopyBinaryFormat = "BINARY"
+)
+
+// The directions of COPY.
+const (
+	CopyFrom = "FROM"
+	CopyTo   = "TO"
+)
+
+// Copy represents a COPY FROM STDIN or COPY TO STDOUT statement.
+type Copy struct {
+	// Direction is FROM for COPY FROM STDIN, or TO for COPY TO STDOUT.
+	Direction string
+	// Table is the table name, or empty if the query is copied.
+	Table string
+	// Query is the query of COPY (query) TO STDOUT.
+	Query string
+	// Columns is the column names, or empty if all columns are copied.
+	Columns []string
+	// Format is the data format of TEXT, CSV or BINARY.
+	Format string
+	// Delimiter is the character which separates the columns.
+	Delimiter string
+	// Null is the string which represents NULL.
+	Null string
+	// Header is true if the first line is the header line of the column names.
+	Header bool
+	// Quote is the quoting character of CSV.
+	Quote string
+	// Escape is the character which escapes the quoting character in the quoted values of CSV.
+	Escape string
+}
+
+// CopyStatement returns the COPY of the specified PostgreSQL COPY table [(column, ...)] FROM STDIN, COPY table [(column, ...)] TO STDOUT
+// or COPY (query) TO STDOUT statement with the [[WITH] (option, ...)] options. The options of PostgreSQL 9.0 and later,
+// and the old options such as CSV HEADER are supported.
+func CopyStatement(stmt string) (*Copy, error) {
+	tokens, err := NewLexerWith(PostgreSQL).Tokenize(stmt)
+	if err != nil {
+		return nil, err
+	}
+	stmts := tokens.Statements()
+	if len(stmts) != 1 {
+		return nil, newErrInvalid(stmt)
+	}
+	tokens = stmts[0]
+	n := tokens.Next(-1)
+	if !tokens.IsKeywordAt(n, "COPY") {
+		return nil, newErrInvalid(stmt)
+	}
+	cp := &Copy{
+		Direction: CopyFrom,
+		Table:     "",
+		Query:     "",
+		Columns:   []string{},
+		Format:    CopyTextFormat,
+		Delimiter: "",
+		Null:      "",
+		Header:    false,
+		Quote:     "",
+		Escape:    "",
+	}
+	if paren := tokens.Next(n); 0 <= paren && tokens[paren].IsPunctuation("(") {
+		end := tokens.MatchingParen(paren)
+		if end < 0 {
+			return nil, newErrInvalid(stmt)
+		}
+		cp.Query = tokens[paren+1 : end].trimSpace().String()
+		n = tokens.Next(end)
+		if !tokens.IsKeywordAt(n, CopyTo) {
+			return nil, newErrInvalid(stmt)
+		}
+	} else {
+		tbl := tokens.tableNameIndexAfter(n)
+		if tbl < 0 {
+			return nil, newErrInvalid(stmt)
+		}
+		cp.Table = tokens[tbl].Value
+		n = tokens.Next(tbl)
+		if 0 <= n && tokens[n].IsPunctuation("(") {
+			end := tokens.MatchingParen(n)
+			if end < 0 {
+				return nil, newErrInvalid(stmt)
+			}
+			for _, elem := range tokens[n+1 : end].splitTopLevel(",") {
+				column := elem.Next(-1)
+				if column < 0 || !elem[column].IsName() || 0 <= elem.Next(column) {
+					return nil, newErrInvalid(stmt)
+				}
+				cp.Columns = append(cp.Columns, elem[column].Value)
+			}
+			n = tokens.Next(end)
+		}
+	}
+	// The server files and the programs are not supported.
+	switch {
+	case tokens.IsKeywordAt(n, CopyFrom) && tokens.IsKeywordAt(tokens.Next(n), "STDIN"):
+		cp.Direction = CopyFrom
+	case tokens.IsKeywordAt(n, CopyTo) && tokens.IsKeywordAt(tokens.Next(n), "STDOUT"):
+		cp.Direction = CopyTo
+	default:
+		return nil, newErrNotSupported(stmt)
+	}
+	n = tokens.Next(n)
+	options, err := tokens[n+1:].copyOptions()
+	if err != nil {
+		return nil, err
+	}
+	if err := cp.setOptions(options); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
+// copyOptions returns the upper case option names and the option values of the specified tokens which follow STDIN or STDOUT.
+// The values of the options without values are empty.
+func (tokens Tokens) copyOptions() ([][2]string, error) {
+	options := [][2]string{}
+	n := tokens.Next(-1)
+	if tokens.IsKeywordAt(n, "WITH") {
+		n = tokens.Next(n)
+	}
+	if n < 0 {
+		return options, nil
+	}
+	if tokens[n].IsPunctuation("(") {
+		end := tokens.MatchingParen(n)
+		if end < 0 || 0 <= tokens.Next(end) {
+			return nil, newErrInvalid(tokens.String())
+		}
+		for _, elem := range tokens[n+1 : end].splitTopLevel(",") {
+			name := elem.Next(-1)
+			if name < 0 || elem[name].Type != WordToken {
+				return nil, newErrInvalid(tokens.String())
+			}
+			value := ""
+			if v := elem.Next(name); 0 <= v {
+				if 0 <= elem.Next(v) {
+					return nil, newErrNotSupported(elem.trimSpace().String())
+				}
+				value = elem[v].Value
+			}
+			options = append(options, [2]string{strings.ToUpper(elem[name].Text), value})
+		}
+		return options, nil
+	}
+	// The old options such as DELIMITER AS ',' and CSV HEADER.
+	for ; 0 <= n; n = tokens.Next(n) {
+		name := strings.ToUpper(tokens[n].Text)
+		switch {
+		case tokens[n].Type != WordToken:
+			return nil, newErrInvalid(tokens.String())
+		case name == "BINARY", name == "CSV":
+			options = append(options, [2]string{"FORMAT", name})
+		case name == "HEADER":
+			options = append(options, [2]string{name, ""})
+		case name == "DELIMITER", name == "NULL", name == "QUOTE", name == "ESCAPE":
+			n = tokens.Next(n)
+			if tokens.IsKeywordAt(n, "AS") {
+				n = tokens.Next(n)
+			}
+			if n < 0 || tokens[n].Type != StringToken {
+				return nil, newErrInvalid(tokens.String())
+			}
+			options = append(options, [2]string{name, tokens[n].Value})
+		default:
+			return nil, newErrNotSupported(name)
+		}
+	}
+	return options, nil
+}
+
+// setOptions sets the specified options, and the unspecified options are set to the defaults of the format.
+func (cp *Copy) setOptions(options [][2]string) error {
+	values := map[string]string{}
+	for _, option := range options {
+		name, value := option[0], option[1]
+		if _, ok := values[name]; ok {
+			return newErrInvalid("COPY option " + name)
+		}
+		values[name] = value
+	}
+	if format, ok := values["FORMAT"]; ok {
+		cp.Format = strings.ToUpper(format)
+		delete(values, "FORMAT")
+	}
+	switch cp.Format {
+	case CopyTextFormat:
+		cp.Delimiter, cp.Null = "\t", `\N`
+	case CopyCSVFormat:
+		cp.Delimiter, cp.Null, cp.Quote = ",", "", `"`
+	case CopyBinaryFormat:
+		// The binary format has no delimiters, NULL strings, header lines and quotes.
+		for name := range values {
+			switch name {
+			case "DELIMITER", "NULL", "HEADER", "QUOTE", "ESCAPE":
+				return newErrInvalid("COPY " + name + " in BINARY mode")
+			}
+		}
+	default:
+		return newErrNotSupported("COPY format " + cp.Format)
+	}
+	for name, value := range values {
+		switch name {
+		case "DELIMITER":
+			cp.Delimiter = value
+		case "NULL":
+			cp.Null = value
+		case "HEADER":
+			header, ok := copyBoolOf(value)
+			if !ok {
+				return newErrNotSupported("COPY HEADER " + value)
+			}
+			cp.Header = header
+		case "QUOTE", "ESCAPE":
+			if cp.Format != CopyCSVFormat {
+				return newErrInvalid("COPY " + name + " outside of CSV mode")
+			}
+			if name == "QUOTE" {
+				cp.Quote = value
+			} else {
+				cp.Escape = value
+			}
+		case "ENCODING":
+			if !strings.EqualFold(strings.ReplaceAll(value, "-", ""), "UTF8") {
+				return newErrNotSupported("COPY ENCODING " + value)
+			}
+		case "FREEZE":
+			// The rows are not frozen because SQLite has no visibility information.
+		default:
+			return newErrNotSupported("COPY option " + name)
+		}
+	}
+	if cp.Format == CopyBinaryFormat {
+		return nil
+	}
+	if cp.Escape == "" {
+		cp.Escape = cp.Quote
+	}
+	for _, c := range []string{cp.Delimiter, cp.Quote, cp.Escape} {
+		if c != "" && len(c) != 1 {
+			return newErrInvalid("COPY character " + c)
+		}
+	}
+	switch {
+	case cp.Delimiter == "", strings.ContainsAny(cp.Delimiter, "\r\n"):
+		return newErrInvalid("COPY delimiter " + cp.Delimiter)
+	case cp.Format == CopyTextFormat && cp.Delimiter == `\`:
+		return newErrInvalid("COPY delimiter " + cp.Delimiter)
+	case cp.Format == CopyCSVFormat && (cp.Quote == "" || cp.Delimiter == cp.Quote):
+		return newErrInvalid("COPY quote " + cp.Quote)
+	case strings.Contains(cp.Null, cp.Delimiter):
+		return newErrInvalid("COPY null " + cp.Null)
+	}
+	return nil
+}
+
+// copyBoolOf returns the boolean value of the specified COPY option value, and the options without values are true.
+func copyBoolOf(value string) (bool, bool) {
+	switch strings.ToUpper(value) {
+	case "", "TRUE", "ON", "1":
+		return true, true
+	case "FALSE", "OFF", "0":
+		return false, true
+	}
+	return false, false
+}
@@ -0,0 +1,157 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+// PostgreSQL: Documentation: 16: DECLARE
+// https://www.postgresql.org/docs/16/sql-declare.html
+// PostgreSQL: Documentation: 16: FETCH
+// https://www.postgresql.org/docs/16/sql-fetch.html
+// PostgreSQL: Documentation: 16: MOVE
+// https://www.postgresql.org/docs/16/sql-move.html
+// PostgreSQL: Documentation: 16: CLOSE
+// https://www.postgresql.org/docs/16/sql-close.html
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Cursor represents a cursor of DECLARE CURSOR.
+type Cursor struct {
+	Name  string
+	Query string
+	Hold  bool
+}
+
+// DeclaredCursor returns the cursor of the specified DECLARE name CURSOR [WITH HOLD] FOR query statement.
+// The other cursor options such as BINARY and SCROLL are ignored because the cursors are fetched forward only.
+func DeclaredCursor(stmt string) (*Cursor, error) {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return nil, err
+	}
+	declare := tokens.Next(-1)
+	name := tokens.Next(declare)
+	if !tokens.IsKeywordAt(declare, "DECLARE") || name < 0 || !tokens[name].IsName() {
+		return nil, newErrInvalid(stmt)
+	}
+	cursor := tokens.indexTopLevelKeyword(name, "CURSOR")
+	if cursor < 0 {
+		return nil, newErrInvalid(stmt)
+	}
+	forIdx := tokens.indexTopLevelKeyword(cursor, "FOR")
+	if forIdx < 0 {
+		return nil, newErrInvalid(stmt)
+	}
+	query := tokens[forIdx+1:].trimSpace()
+	if end := query.Prev(len(query)); 0 <= end && query[end].IsPunctuation(";") {
+		query = query[:end].trimSpace()
+	}
+	if !IsQuery(query.String()) {
+		return nil, newErrInvalid(stmt)
+	}
+	return &Cursor{
+		Name:  cursorNameOf(tokens[name]),
+		Query: query.String(),
+		Hold:  tokens.IsKeywordsAt(tokens.Next(cursor), "WITH", "HOLD"),
+	}, nil
+}
+
+// FetchedCursor returns the cursor name and the row count of the specified FETCH or MOVE [direction] [FROM | IN] name statement,
+// and the row count is zero for ALL as the row limit of Execute. Only the forward directions are supported.
+func FetchedCursor(stmt string) (string, int, error) {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return "", 0, err
+	}
+	fetch := tokens.Next(-1)
+	if !tokens.IsKeywordAt(fetch, "FETCH") && !tokens.IsKeywordAt(fetch, "MOVE") {
+		return "", 0, newErrInvalid(stmt)
+	}
+	count := 1
+	n := tokens.Next(fetch)
+	switch {
+	case tokens.IsKeywordAt(n, "NEXT"):
+		n = tokens.Next(n)
+	case tokens.IsKeywordAt(n, "FORWARD"):
+		n = tokens.Next(n)
+		if next, ok := tokens.fetchCountAt(n); ok {
+			count = next
+			n = tokens.Next(n)
+		}
+	case tokens.IsKeywordAt(n, "PRIOR"), tokens.IsKeywordAt(n, "FIRST"), tokens.IsKeywordAt(n, "LAST"),
+		tokens.IsKeywordAt(n, "ABSOLUTE"), tokens.IsKeywordAt(n, "RELATIVE"), tokens.IsKeywordAt(n, "BACKWARD"):
+		return "", 0, newErrNotSupported(stmt)
+	default:
+		if next, ok := tokens.fetchCountAt(n); ok {
+			count = next
+			n = tokens.Next(n)
+		}
+	}
+	if tokens.IsKeywordAt(n, "FROM") || tokens.IsKeywordAt(n, "IN") {
+		n = tokens.Next(n)
+	}
+	if n < 0 || !tokens[n].IsName() {
+		return "", 0, newErrInvalid(stmt)
+	}
+	if count < 0 {
+		// FETCH 0 refetches the current row and the negative counts fetch backward.
+		return "", 0, newErrNotSupported(stmt)
+	}
+	return cursorNameOf(tokens[n]), count, nil
+}
+
+// ClosedCursor returns the cursor name of the specified CLOSE name statement, or an empty string for CLOSE ALL.
+func ClosedCursor(stmt string) (string, error) {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return "", err
+	}
+	closing := tokens.Next(-1)
+	name := tokens.Next(closing)
+	switch {
+	case !tokens.IsKeywordAt(closing, "CLOSE") || name < 0 || !tokens[name].IsName():
+		return "", newErrInvalid(stmt)
+	case tokens.IsKeywordAt(name, "ALL"):
+		return "", nil
+	}
+	return cursorNameOf(tokens[name]), nil
+}
+
+// fetchCountAt returns the row count of FETCH at the specified index such as 10 and ALL,
+// and the count is -1 if the count is zero or negative.
+func (tokens Tokens) fetchCountAt(idx int) (int, bool) {
+	switch {
+	case idx < 0:
+		return 0, false
+	case tokens.IsKeywordAt(idx, "ALL"):
+		return 0, true
+	case tokens[idx].Type != NumberToken:
+		return 0, false
+	}
+	count, err := strconv.Atoi(tokens[idx].Text)
+	if err != nil || count == 0 {
+		return -1, true
+	}
+	return count, true
+}
+
+// cursorNameOf returns the cursor name of the specified token, and the unquoted names are folded into lower case.
+func cursorNameOf(tok *Token) string {
+	if tok.Type == WordToken {
+		return strings.ToLower(tok.Value)
+	}
+	return tok.Value
+}
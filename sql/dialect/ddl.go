@@ -0,0 +1,362 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+// SQLite: CREATE TABLE
+// https://www.sqlite.org/lang_createtable.html
+// MySQL :: MySQL 8.0 Reference Manual :: 15.1.20 CREATE TABLE Statement
+// https://dev.mysql.com/doc/refman/8.0/en/create-table.html
+
+import (
+	"strings"
+)
+
+// extendedColumnTypes represents the column types which go-sqlparser does not support.
+// The tables which have the extended column types are created with the rewritten statements.
+var extendedColumnTypes = map[string]bool{
+	"JSON":  true,
+	"JSONB": true,
+	"ENUM":  true,
+	"SET":   true,
+}
+
+// tableConstraintKeywords represents the leading keywords of the table constraints in CREATE TABLE.
+var tableConstraintKeywords = map[string]bool{
+	"CONSTRAINT": true, "PRIMARY": true, "UNIQUE": true, "FOREIGN": true, "CHECK": true,
+	"KEY": true, "INDEX": true, "FULLTEXT": true, "SPATIAL": true, "EXCLUDE": true,
+}
+
+// columnDef represents the token indexes of a column definition in CREATE TABLE.
+type columnDef struct {
+	name      string
+	typeName  string
+	typeBegin int
+	typeEnd   int
+	end       int
+}
+
+// HasExtendedColumnType returns true if the specified CREATE TABLE statement has any column of the extended types
+// such as JSON, enums, decimals, unsigned integers, arrays and the logical types. The rewritten statements which have
+// the internal type names such as DECIMAL_TEXT_12_2 and the integer type collations are also extended.
+func HasExtendedColumnType(stmt string) bool {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return false
+	}
+	defs, ok := tokens.columnDefs()
+	if !ok {
+		return false
+	}
+	for _, def := range defs {
+		typeName := def.typeName
+		if extendedColumnTypes[typeName] || decimalTypeNames[typeName] || IsArrayTypeName(typeName) || IsUnsignedTypeName(typeName) {
+			return true
+		}
+		if _, _, ok := DecimalTypeOf(typeName); ok || typeName == unsignedBigIntTextType {
+			return true
+		}
+		if def.typeEnd < 0 {
+			continue
+		}
+		if tokens.IsKeywordAt(tokens.Next(def.typeEnd), "UNSIGNED") {
+			return true
+		}
+		if _, ok := tokens[def.typeEnd+1 : def.end].integerTypeCollationOf(); ok {
+			return true
+		}
+	}
+	_, types, _ := LogicalColumnTypes(stmt)
+	return 0 < len(types)
+}
+
+// ColumnTypes returns the upper case type names of the columns in the specified CREATE TABLE statement,
+// or nil if the statement is not CREATE TABLE.
+func ColumnTypes(stmt string) map[string]string {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return nil
+	}
+	defs, ok := tokens.columnDefs()
+	if !ok {
+		return nil
+	}
+	types := map[string]string{}
+	for _, def := range defs {
+		types[def.name] = def.typeName
+	}
+	return types
+}
+
+// tableElements returns the open and close parenthesis indexes of the table elements in CREATE TABLE.
+func (tokens Tokens) tableElements() (int, int, bool) {
+	create := tokens.Next(-1)
+	if !tokens.IsKeywordAt(create, "CREATE") {
+		return 0, 0, false
+	}
+	table := tokens.Next(create)
+	if tokens.IsKeywordAt(table, "TEMP") || tokens.IsKeywordAt(table, "TEMPORARY") {
+		table = tokens.Next(table)
+	}
+	if !tokens.IsKeywordAt(table, "TABLE") {
+		return 0, 0, false
+	}
+	for n := table; 0 <= n; n = tokens.Next(n) {
+		if tokens[n].IsKeyword("AS") {
+			return 0, 0, false
+		}
+		if tokens[n].IsPunctuation("(") {
+			closing := tokens.MatchingParen(n)
+			return n, closing, 0 <= closing
+		}
+	}
+	return 0, 0, false
+}
+
+// columnDefs returns the column definitions of the CREATE TABLE statement.
+func (tokens Tokens) columnDefs() ([]columnDef, bool) {
+	open, closing, ok := tokens.tableElements()
+	if !ok {
+		return nil, false
+	}
+	defs := []columnDef{}
+	begin := open
+	for begin < closing {
+		end := begin + 1
+		for depth := 0; end < closing; end++ {
+			switch {
+			case tokens[end].IsPunctuation("("):
+				depth++
+			case tokens[end].IsPunctuation(")"):
+				depth--
+			}
+			if depth == 0 && tokens[end].IsPunctuation(",") {
+				break
+			}
+		}
+		name := tokens.Next(begin)
+		if 0 <= name && name < end && tokens[name].IsName() && !(tokens[name].Type == WordToken && tableConstraintKeywords[strings.ToUpper(tokens[name].Text)]) {
+			typeBegin := tokens.Next(name)
+			typeName, typeEnd := "", -1
+			if 0 <= typeBegin && typeBegin < end {
+				typeName, typeEnd = tokens.typeName(typeBegin)
+			}
+			defs = append(defs, columnDef{
+				name:      tokens[name].Value,
+				typeName:  typeName,
+				typeBegin: typeBegin,
+				typeEnd:   typeEnd,
+				end:       end,
+			})
+		}
+		begin = end
+	}
+	return defs, true
+}
+
+// createTableRule rewrites the column types of CREATE TABLE which SQLite handles differently.
+// SERIAL columns and MySQL AUTO_INCREMENT columns are rewritten into INTEGER columns because
+// SQLite assigns the row IDs to INTEGER PRIMARY KEY columns automatically, the BIGINT UNSIGNED columns are rewritten
+// into the internal type name because SQLite integers are signed,
+// the decimal columns such as DECIMAL(12, 2) are rewritten into the internal decimal type names such as DECIMAL_TEXT_12_2
+// with the CHECK constraints of the precisions,
+// the array columns such as TEXT[] are rewritten into the internal array type names such as _TEXT
+// with the CHECK constraints of the array literals, and the JSON columns have the CHECK constraints of the JSON texts.
+func createTableRule(tokens Tokens) (Tokens, error) {
+	defs, ok := tokens.columnDefs()
+	if !ok {
+		return tokens, nil
+	}
+	// Rewrite the column definitions from the end not to shift the indexes.
+	for n := len(defs) - 1; 0 <= n; n-- {
+		def := defs[n]
+		if def.typeEnd < 0 {
+			continue
+		}
+		isAutoIncrement := strings.HasSuffix(def.typeName, "SERIAL")
+		for idx := def.typeEnd + 1; idx < def.end; idx++ {
+			if tokens[idx].IsKeyword("AUTO_INCREMENT") {
+				begin := tokens.leadingSpaceBegin(idx)
+				tokens = tokens.Splice(begin, idx)
+				def.end -= idx - begin + 1
+				idx = begin - 1
+				isAutoIncrement = true
+			}
+		}
+		integerType, isInteger := integerTypes[def.typeName]
+		if next := tokens.Next(def.typeEnd); isInteger && tokens.IsKeywordAt(next, "UNSIGNED") {
+			// The MySQL unsigned integer types such as INT UNSIGNED are rewritten by mysqlCreateTableRule.
+			integerType += unsignedSuffix
+			def.typeEnd = next
+		}
+		if isAutoIncrement && !isInteger {
+			integerType = "INT"
+		}
+		if integerTokens := integerColumnTokens(integerType, isAutoIncrement); integerTokens != nil {
+			tokens = tokens.Splice(def.typeBegin, def.typeEnd, integerTokens...)
+			continue
+		}
+		if decimalTypeName, ok := tokens[def.typeBegin : def.typeEnd+1].decimalTypeNameOf(def.typeName); ok {
+			typeTokens := decimalColumnTokens(decimalTypeName)
+			if precision, scale, _ := DecimalTypeOf(decimalTypeName); 0 <= precision {
+				typeTokens = append(typeTokens, decimalConstraintTokens(def.name, precision, scale)...)
+			}
+			tokens = tokens.Splice(def.typeBegin, def.typeEnd, typeTokens...)
+		} else if arrayTypeName, ok := arrayTypeNameOf(def.typeName); ok {
+			typeTokens := append(Tokens{NewWordToken(arrayTypeName)}, arrayConstraintTokens(def.name, arrayTypeName)...)
+			tokens = tokens.Splice(def.typeBegin, def.typeEnd, typeTokens...)
+		} else if isJSONTypeName(def.typeName) {
+			typeTokens := append(append(Tokens{}, tokens[def.typeBegin:def.typeEnd+1]...), jsonConstraintTokens(def.name)...)
+			tokens = tokens.Splice(def.typeBegin, def.typeEnd, typeTokens...)
+		} else if strings.HasSuffix(def.typeName, "[]") {
+			return nil, newErrNotSupported(def.typeName)
+		}
+	}
+	return tokens, nil
+}
+
+// mysqlCreateTableRule removes the MySQL specific column attributes and table options of CREATE TABLE.
+// The inline index definitions such as KEY name (cols) are rewritten into the CREATE INDEX statements which follow CREATE TABLE
+// because SQLite has no inline index definitions, and the FULLTEXT and SPATIAL indexes are removed.
+func mysqlCreateTableRule(tokens Tokens) (Tokens, error) {
+	open, closing, ok := tokens.tableElements()
+	if !ok {
+		return tokens, nil
+	}
+	table := tokens.Prev(open)
+	isIfNotExists := false
+	for n := 0; n < open; n++ {
+		if tokens.IsKeywordsAt(n, "IF", "NOT", "EXISTS") {
+			isIfNotExists = true
+		}
+	}
+	// The table options such as ENGINE=InnoDB follow the table elements.
+	end := tokens.Prev(len(tokens))
+	if 0 <= end && tokens[end].IsPunctuation(";") {
+		end = tokens.Prev(end)
+	}
+	if closing < end {
+		tokens = tokens.Splice(closing+1, end)
+	}
+	elems := Tokens{}
+	indexes := Tokens{}
+	for _, elem := range tokens[open+1 : closing].splitTopLevel(",") {
+		first := elem.Next(-1)
+		switch {
+		case first < 0:
+			continue
+		case elem.IsKeywordAt(first, "KEY"), elem.IsKeywordAt(first, "INDEX"):
+			index, err := elem.createIndexTokens(first, tokens[table], isIfNotExists)
+			if err != nil {
+				return nil, err
+			}
+			indexes = append(indexes, index...)
+			continue
+		case elem.IsKeywordAt(first, "FULLTEXT"), elem.IsKeywordAt(first, "SPATIAL"):
+			continue
+		case elem.IsKeywordsAt(first, "UNIQUE", "KEY"), elem.IsKeywordsAt(first, "UNIQUE", "INDEX"):
+			// UNIQUE KEY name (cols) is rewritten into UNIQUE (cols).
+			paren := first
+			for paren < len(elem) && !elem[paren].IsPunctuation("(") {
+				paren++
+			}
+			elem = append(Tokens{NewSpaceToken(), NewWordToken("UNIQUE"), NewSpaceToken()}, elem[paren:]...)
+		default:
+			elem = elem.withMySQLUnsignedType().withoutMySQLColumnAttributes().withMySQLDecimalDefaults()
+		}
+		if 0 < len(elems) {
+			elems = append(elems, NewPunctuationToken(","))
+		}
+		elems = append(elems, elem...)
+	}
+	tokens = tokens.Splice(open+1, closing-1, elems...)
+	return append(tokens, indexes...), nil
+}
+
+// createIndexTokens returns the CREATE INDEX statement of the inline index definition such as KEY name (cols)
+// of the specified table, which is preceded by a semicolon. The unnamed indexes are named after the table and the first column
+// because the SQLite index names are unique in the database, and the column prefix lengths such as name(10) are removed.
+func (tokens Tokens) createIndexTokens(idx int, table *Token, isIfNotExists bool) (Tokens, error) {
+	open := idx
+	for open < len(tokens) && !tokens[open].IsPunctuation("(") {
+		open++
+	}
+	closing := -1
+	if open < len(tokens) {
+		closing = tokens.MatchingParen(open)
+	}
+	if closing < 0 {
+		return nil, newErrInvalid(tokens.String())
+	}
+	columns := Tokens{}
+	for _, column := range tokens[open+1 : closing].splitTopLevel(",") {
+		column = column.trimSpace()
+		if len(column) == 0 {
+			return nil, newErrInvalid(tokens.String())
+		}
+		if next := column.Next(0); 0 <= next && column[0].IsName() && column[next].IsPunctuation("(") {
+			column = append(Tokens{column[0]}, column[column.MatchingParen(next)+1:]...)
+		}
+		if 0 < len(columns) {
+			columns = append(columns, NewPunctuationToken(","), NewSpaceToken())
+		}
+		columns = append(columns, column...)
+	}
+	name := tokens.Next(idx)
+	var nameToken *Token
+	switch {
+	case name < open && tokens[name].IsName() && !tokens.IsKeywordAt(name, "USING"):
+		nameToken = tokens[name]
+	case columns[0].IsName():
+		nameToken = NewIdentifierToken(table.Value + "_" + columns[0].Value)
+	default:
+		nameToken = NewIdentifierToken(table.Value + "_index")
+	}
+	stmt := Tokens{NewPunctuationToken(";"), NewSpaceToken()}
+	stmt = append(stmt, keywordTokens("CREATE", "INDEX")...)
+	if isIfNotExists {
+		stmt = append(stmt, NewSpaceToken())
+		stmt = append(stmt, keywordTokens("IF", "NOT", "EXISTS")...)
+	}
+	stmt = append(stmt, NewSpaceToken(), nameToken, NewSpaceToken(), NewWordToken("ON"), NewSpaceToken(), table, NewSpaceToken(), NewPunctuationToken("("))
+	stmt = append(stmt, columns...)
+	return append(stmt, NewPunctuationToken(")")), nil
+}
+
+// withoutMySQLColumnAttributes returns the column definition without the MySQL specific column attributes.
+func (tokens Tokens) withoutMySQLColumnAttributes() Tokens {
+	for n := 0; n < len(tokens); n++ {
+		end := -1
+		switch {
+		case tokens[n].IsKeyword("SIGNED"):
+			// UNSIGNED and ZEROFILL are removed by withMySQLUnsignedType.
+			end = n
+		case tokens.IsKeywordsAt(n, "CHARACTER", "SET"):
+			end = tokens.Next(tokens.Next(n))
+		case tokens[n].IsKeyword("CHARSET"), tokens[n].IsKeyword("COLLATE"), tokens[n].IsKeyword("COMMENT"):
+			end = tokens.Next(n)
+		case tokens.IsKeywordsAt(n, "ON", "UPDATE", "CURRENT_TIMESTAMP"), tokens.IsKeywordsAt(n, "ON", "UPDATE", "NOW"):
+			end = tokens.Next(tokens.Next(n))
+			if next := tokens.Next(end); 0 <= next && tokens[next].IsPunctuation("(") {
+				end = tokens.MatchingParen(next)
+			}
+		}
+		if end < 0 {
+			continue
+		}
+		tokens = tokens.Splice(n, end)
+		n--
+	}
+	return tokens
+}
@@ -0,0 +1,446 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+// PostgreSQL: Documentation: 16: 8.1. Numeric Types
+// https://www.postgresql.org/docs/16/datatype-numeric.html#DATATYPE-NUMERIC-DECIMAL
+// MySQL :: MySQL 8.0 Reference Manual :: 13.1.3 Fixed-Point Types (Exact Value) - DECIMAL, NUMERIC
+// https://dev.mysql.com/doc/refman/8.0/en/fixed-point-types.html
+// SQLite: CREATE TRIGGER
+// https://www.sqlite.org/lang_createtrigger.html
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// decimalTypePrefix is the prefix of the internal decimal type names such as DECIMAL_TEXT_12_2.
+// The decimal columns are stored as the texts not to lose the precision, and the type names have
+// the TEXT affinity and keep the precision and scale because SQLite drops the type parameters of the declared types.
+const decimalTypePrefix = "DECIMAL_TEXT"
+
+// decimalCollation is the collation which compares the decimal texts numerically, and it is registered by the function package.
+const decimalCollation = "DECIMAL"
+
+// decimalConstraintPrefix is the name prefix of the CHECK constraints which restrict the decimal columns to the declared precisions.
+const decimalConstraintPrefix = HiddenColumnPrefix + "decimal:"
+
+// decimalTriggerPrefix is the name prefix of the triggers which round the decimal columns to the declared scales.
+const decimalTriggerPrefix = HiddenColumnPrefix + "decimal_round:"
+
+// The decimal functions which are registered by the function package.
+const (
+	decimalRoundFunction  = "decimal_round"
+	decimalFitsFunction   = "decimal_fits"
+	decimalCastFunction   = "decimal_cast"
+	decimalSumFunction    = "decimal_sum"
+	decimalFunctionPrefix = "decimal_"
+)
+
+// maxExactDigits is the number of the significant digits which SQLite keeps in the REAL values.
+const maxExactDigits = 15
+
+// decimalTypeNames represents the decimal type names and the aliases.
+var decimalTypeNames = map[string]bool{
+	"DECIMAL": true, "NUMERIC": true, "DEC": true, "FIXED": true,
+}
+
+// DecimalTypeOf returns the precision and scale of the specified upper case internal decimal type name,
+// and the precision and scale are -1 if they are not specified.
+func DecimalTypeOf(typeName string) (int, int, bool) {
+	params, ok := strings.CutPrefix(typeName, decimalTypePrefix)
+	if !ok {
+		return 0, 0, false
+	}
+	if params == "" {
+		return -1, -1, true
+	}
+	var precision, scale int
+	if _, err := fmt.Sscanf(params, "_%d_%d", &precision, &scale); err != nil {
+		return 0, 0, false
+	}
+	return precision, scale, true
+}
+
+// decimalTypeNameOf returns the internal decimal type name of the specified type tokens such as DECIMAL(12, 2).
+func (tokens Tokens) decimalTypeNameOf(typeName string) (string, bool) {
+	if !decimalTypeNames[typeName] {
+		return "", false
+	}
+	params := []int{}
+	for _, tok := range tokens {
+		if tok.Type != NumberToken {
+			continue
+		}
+		v, err := strconv.Atoi(tok.Text)
+		if err != nil {
+			return "", false
+		}
+		params = append(params, v)
+	}
+	switch len(params) {
+	case 0:
+		return decimalTypePrefix, true
+	case 1:
+		return fmt.Sprintf("%s_%d_0", decimalTypePrefix, params[0]), true
+	case 2:
+		return fmt.Sprintf("%s_%d_%d", decimalTypePrefix, params[0], params[1]), true
+	}
+	return "", false
+}
+
+// decimalColumnTokens returns the column type tokens of the specified internal decimal type name with the decimal collation.
+func decimalColumnTokens(typeName string) Tokens {
+	return Tokens{NewWordToken(typeName), NewSpaceToken(), NewWordToken("COLLATE"), NewSpaceToken(), NewWordToken(decimalCollation)}
+}
+
+// decimalConstraintTokens returns the CHECK constraint tokens which restrict the specified decimal column to the precision and scale.
+func decimalConstraintTokens(column string, precision int, scale int) Tokens {
+	name := decimalConstraintPrefix + strconv.Itoa(precision) + ":" + strconv.Itoa(scale) + ":" + column
+	return Tokens{
+		NewSpaceToken(), NewWordToken("CONSTRAINT"),
+		NewSpaceToken(), NewIdentifierToken(name),
+		NewSpaceToken(), NewWordToken("CHECK"), NewSpaceToken(), NewPunctuationToken("("),
+		NewWordToken(decimalFitsFunction), NewPunctuationToken("("), NewIdentifierToken(column), NewPunctuationToken(","),
+		NewSpaceToken(), &Token{Type: NumberToken, Text: strconv.Itoa(precision), Value: strconv.Itoa(precision)}, NewPunctuationToken(","),
+		NewSpaceToken(), &Token{Type: NumberToken, Text: strconv.Itoa(scale), Value: strconv.Itoa(scale)},
+		NewPunctuationToken(")"), NewPunctuationToken(")"),
+	}
+}
+
+// DecimalConstraintOf returns the column name, the precision and the scale of the specified CHECK constraint failure message
+// of a decimal column whose value does not fit the precision.
+func DecimalConstraintOf(msg string) (string, int, int, bool) {
+	_, constraint, ok := strings.Cut(msg, decimalConstraintPrefix)
+	if !ok {
+		return "", 0, 0, false
+	}
+	params := strings.SplitN(constraint, ":", 3)
+	if len(params) != 3 {
+		return "", 0, 0, false
+	}
+	precision, err := strconv.Atoi(params[0])
+	if err != nil {
+		return "", 0, 0, false
+	}
+	scale, err := strconv.Atoi(params[1])
+	if err != nil {
+		return "", 0, 0, false
+	}
+	column := params[2]
+	// The failure message may be followed by the other messages.
+	if idx := strings.IndexAny(column, " \n"); 0 <= idx {
+		column = column[:idx]
+	}
+	return column, precision, scale, true
+}
+
+// DecimalTriggers returns the CREATE TRIGGER statements which round the decimal columns of the specified SQLite CREATE TABLE
+// statement to the declared scales after INSERT and UPDATE, because SQLite stores the values as they are.
+// The statements are nil if the table has no decimal columns of the declared scales or the table is WITHOUT ROWID.
+func DecimalTriggers(stmt string) []string {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return nil
+	}
+	defs, ok := tokens.columnDefs()
+	if !ok {
+		return nil
+	}
+	table := tokens.indexTopLevelKeyword(0, "TABLE")
+	_, closing, _ := tokens.tableElements()
+	for n := closing + 1; n < len(tokens); n++ {
+		if tokens.IsKeywordsAt(n, "WITHOUT", "ROWID") {
+			return nil
+		}
+	}
+	tblName := tokens.tableNameAfter(table)
+	columns := []string{}
+	assignments := []string{}
+	for _, def := range defs {
+		precision, scale, ok := DecimalTypeOf(def.typeName)
+		if !ok || precision < 0 {
+			continue
+		}
+		column := QuoteIdentifier(def.name)
+		columns = append(columns, column)
+		assignments = append(assignments, fmt.Sprintf("%s = %s(%s, %d)", column, decimalRoundFunction, column, scale))
+	}
+	if tblName == "" || len(columns) == 0 {
+		return nil
+	}
+	// The triggers of the temporary tables should be temporary.
+	create := "CREATE TRIGGER"
+	for n := 0; n < table; n++ {
+		if tokens[n].IsKeyword("TEMP") || tokens[n].IsKeyword("TEMPORARY") {
+			create = "CREATE TEMP TRIGGER"
+		}
+	}
+	update := fmt.Sprintf("UPDATE %s SET %s WHERE rowid = NEW.rowid;", QuoteIdentifier(tblName), strings.Join(assignments, ", "))
+	return []string{
+		fmt.Sprintf("%s IF NOT EXISTS %s AFTER INSERT ON %s BEGIN %s END",
+			create, QuoteIdentifier(decimalTriggerPrefix+"insert:"+tblName), QuoteIdentifier(tblName), update),
+		fmt.Sprintf("%s IF NOT EXISTS %s AFTER UPDATE OF %s ON %s BEGIN %s END",
+			create, QuoteIdentifier(decimalTriggerPrefix+"update:"+tblName), strings.Join(columns, ", "), QuoteIdentifier(tblName), update),
+	}
+}
+
+// withMySQLDecimalDefaults returns the column definition whose DECIMAL type has the default precision and scale of MySQL.
+func (tokens Tokens) withMySQLDecimalDefaults() Tokens {
+	name := tokens.Next(-1)
+	typeIdx := tokens.Next(name)
+	if typeIdx < 0 || tokens[typeIdx].Type != WordToken || !decimalTypeNames[strings.ToUpper(tokens[typeIdx].Text)] {
+		return tokens
+	}
+	if next := tokens.Next(typeIdx); 0 <= next && tokens[next].IsPunctuation("(") {
+		return tokens
+	}
+	// DECIMAL is equivalent to DECIMAL(10, 0) in MySQL.
+	params := Tokens{
+		NewPunctuationToken("("),
+		&Token{Type: NumberToken, Text: "10", Value: "10"},
+		NewPunctuationToken(","),
+		&Token{Type: NumberToken, Text: "0", Value: "0"},
+		NewPunctuationToken(")"),
+	}
+	return tokens.Splice(typeIdx+1, typeIdx, params...)
+}
+
+// decimalLiteralRule rewrites the numeric literals which have more significant digits than SQLite keeps into the string literals.
+// SQLite parses the numeric literals into the REAL values, and the string literals are stored into the decimal columns exactly.
+func decimalLiteralRule(tokens Tokens) (Tokens, error) {
+	for n, tok := range tokens {
+		if tok.Type != NumberToken || strings.HasPrefix(strings.ToLower(tok.Text), "0x") {
+			continue
+		}
+		if _, err := strconv.ParseInt(tok.Text, 10, 64); err == nil {
+			continue
+		}
+		// SQLite parses -9223372036854775808 into the minimum integer.
+		if prev := tokens.Prev(n); 0 <= prev && tokens[prev].IsOperator("-") {
+			if _, err := strconv.ParseInt("-"+tok.Text, 10, 64); err == nil {
+				continue
+			}
+		}
+		if significantDigits(tok.Text) <= maxExactDigits {
+			continue
+		}
+		tokens[n] = NewStringToken(tok.Text)
+	}
+	return tokens, nil
+}
+
+// significantDigits returns the number of the significant digits of the specified numeric literal.
+func significantDigits(s string) int {
+	mantissa, _, _ := strings.Cut(strings.ToLower(s), "e")
+	digits := strings.TrimLeft(strings.ReplaceAll(mantissa, ".", ""), "0")
+	if strings.Contains(mantissa, ".") {
+		digits = strings.TrimRight(digits, "0")
+	}
+	return len(digits)
+}
+
+// decimalCastRule returns the rule which rewrites the casts into the decimal types such as CAST(x AS DECIMAL(12, 2)) into
+// the decimal function calls, because SQLite casts them into the REAL values. The decimal types without the precisions
+// are DECIMAL(10, 0) in MySQL, and they are unconstrained in PostgreSQL.
+func decimalCastRule(d Dialect) RewriteRule {
+	return func(tokens Tokens) (Tokens, error) {
+		for n := 0; n < len(tokens); n++ {
+			open := tokens.Next(n)
+			if !tokens[n].IsKeyword("CAST") || open < 0 || !tokens[open].IsPunctuation("(") {
+				continue
+			}
+			closing := tokens.MatchingParen(open)
+			as := tokens.indexTopLevelKeyword(open+1, "AS")
+			if closing < 0 || as < 0 || closing < as {
+				continue
+			}
+			typeBegin := tokens.Next(as)
+			typeName, typeEnd := tokens.typeName(typeBegin)
+			if typeEnd < 0 || tokens.Next(typeEnd) != closing {
+				continue
+			}
+			decimalTypeName, ok := tokens[typeBegin : typeEnd+1].decimalTypeNameOf(typeName)
+			if !ok {
+				continue
+			}
+			precision, scale, _ := DecimalTypeOf(decimalTypeName)
+			if precision < 0 && d == MySQL {
+				precision, scale = 10, 0
+			}
+			call := Tokens{NewWordToken(decimalCastFunction), NewPunctuationToken("(")}
+			call = append(call, tokens[open+1:as].trimSpace()...)
+			if 0 <= precision {
+				call = append(call,
+					NewPunctuationToken(","), NewSpaceToken(), &Token{Type: NumberToken, Text: strconv.Itoa(precision), Value: strconv.Itoa(precision)},
+					NewPunctuationToken(","), NewSpaceToken(), &Token{Type: NumberToken, Text: strconv.Itoa(scale), Value: strconv.Itoa(scale)},
+				)
+			}
+			call = append(call, NewPunctuationToken(")"))
+			tokens = tokens.Splice(n, closing, call...)
+		}
+		return tokens, nil
+	}
+}
+
+// DecimalExpressions rewrites the arithmetic operators and SUM of the specified SQLite statement whose operands are decimal
+// into the decimal functions, because SQLite computes them with the REAL values. The decimal operands are the columns for which
+// the specified function returns true and the results of the decimal functions. The divisions have the scales of the dialect.
+func DecimalExpressions(d Dialect, stmt string, isDecimalColumn func(string) bool) (string, error) {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return "", err
+	}
+	functions := map[string]string{"+": "decimal_add", "-": "decimal_sub", "*": "decimal_mul", "/": "decimal_div"}
+	if d == MySQL {
+		functions["/"] = "mysql_decimal_div"
+	}
+	multiplicative := []string{"*", "/", "%"}
+	additive := []string{"+", "-", "*", "/", "%"}
+	for {
+		var sums, products, sumsOfProducts bool
+		tokens, sums = tokens.rewriteDecimalSums(isDecimalColumn)
+		tokens, products = tokens.rewriteDecimalOperators([]string{"*", "/"}, multiplicative, nil, functions, isDecimalColumn)
+		tokens, sumsOfProducts = tokens.rewriteDecimalOperators([]string{"+", "-"}, additive, multiplicative, functions, isDecimalColumn)
+		if !sums && !products && !sumsOfProducts {
+			break
+		}
+	}
+	return tokens.String(), nil
+}
+
+// rewriteDecimalSums rewrites SUM of the decimal operands into the decimal sum function, and returns true if any is rewritten.
+// The window functions are not rewritten because the decimal sum function is not a window function.
+func (tokens Tokens) rewriteDecimalSums(isDecimalColumn func(string) bool) (Tokens, bool) {
+	rewritten := false
+	for n, tok := range tokens {
+		open := tokens.Next(n)
+		if !tok.IsName() || !strings.EqualFold(tok.Value, "SUM") || open < 0 || !tokens[open].IsPunctuation("(") {
+			continue
+		}
+		closing := tokens.MatchingParen(open)
+		if closing < 0 || tokens.IsKeywordAt(tokens.Next(closing), "OVER") {
+			continue
+		}
+		if !tokens[open+1 : closing].isDecimalOperand(isDecimalColumn) {
+			continue
+		}
+		tokens[n] = NewWordToken(decimalSumFunction)
+		rewritten = true
+	}
+	return tokens, rewritten
+}
+
+// rewriteDecimalOperators rewrites the specified left-associative binary operators whose operands are decimal into the decimal functions,
+// and returns true if any is rewritten. The left operands include the preceding operators of the specified left operators,
+// and the right operands include the following operators of the specified right operators which have the higher precedence.
+func (tokens Tokens) rewriteDecimalOperators(operators []string, leftOperators []string, rightOperators []string, functions map[string]string, isDecimalColumn func(string) bool) (Tokens, bool) {
+	isBinaryOperatorAt := func(n int, operators []string) bool {
+		return 0 <= n && tokens[n].Type == OperatorToken && slices.Contains(operators, tokens[n].Text) && tokens.isOperandEndAt(tokens.Prev(n)) && tokens.isOperandBeginAt(tokens.Next(n))
+	}
+	rewritten := false
+	for n := 0; n < len(tokens); n++ {
+		if !isBinaryOperatorAt(n, operators) {
+			continue
+		}
+		leftBegin := tokens.operandBegin(tokens.Prev(n))
+		for ope := tokens.Prev(leftBegin); isBinaryOperatorAt(ope, leftOperators); ope = tokens.Prev(leftBegin) {
+			leftBegin = tokens.operandBegin(tokens.Prev(ope))
+		}
+		if ope := tokens.Prev(leftBegin); 0 <= ope && (tokens[ope].IsOperator("-") || tokens[ope].IsOperator("+")) && !tokens.isOperandEndAt(tokens.Prev(ope)) {
+			// The unary operators bind more tightly than the binary operators.
+			leftBegin = ope
+		}
+		rightEnd := tokens.operandEnd(tokens.Next(n))
+		for ope := tokens.Next(rightEnd); isBinaryOperatorAt(ope, rightOperators); ope = tokens.Next(rightEnd) {
+			rightEnd = tokens.operandEnd(tokens.Next(ope))
+		}
+		left := append(Tokens{}, tokens[leftBegin:tokens.Prev(n)+1]...)
+		right := append(Tokens{}, tokens[tokens.Next(n):rightEnd+1]...)
+		if !left.isDecimalOperand(isDecimalColumn) && !right.isDecimalOperand(isDecimalColumn) {
+			continue
+		}
+		call := Tokens{NewWordToken(functions[tokens[n].Text]), NewPunctuationToken("(")}
+		call = append(call, left...)
+		call = append(call, NewPunctuationToken(","), NewSpaceToken())
+		call = append(call, right...)
+		call = append(call, NewPunctuationToken(")"))
+		tokens = tokens.Splice(leftBegin, rightEnd, call...)
+		n = leftBegin
+		rewritten = true
+	}
+	return tokens, rewritten
+}
+
+// isOperandEndAt returns true if the token at the specified index ends an operand, and the operators which follow
+// the other tokens such as the keywords and the commas are the unary operators.
+func (tokens Tokens) isOperandEndAt(n int) bool {
+	if n < 0 {
+		return false
+	}
+	switch tok := tokens[n]; tok.Type {
+	case NumberToken, StringToken, ParameterToken, IdentifierToken:
+		return true
+	case WordToken:
+		// CASE ... END is not a primary expression of operandBegin.
+		return !reservedKeywords[strings.ToUpper(tok.Text)] && !tok.IsKeyword("END")
+	}
+	return tokens[n].IsPunctuation(")")
+}
+
+// isOperandBeginAt returns true if the token at the specified index begins an operand which operandEnd is able to find the end.
+func (tokens Tokens) isOperandBeginAt(n int) bool {
+	if n < 0 {
+		return false
+	}
+	switch tok := tokens[n]; tok.Type {
+	case NumberToken, StringToken, ParameterToken, IdentifierToken:
+		return true
+	case WordToken:
+		return !reservedKeywords[strings.ToUpper(tok.Text)]
+	}
+	return tokens[n].IsPunctuation("(") || tokens[n].IsOperator("-") || tokens[n].IsOperator("+")
+}
+
+// isDecimalOperand returns true if the specified operand is a decimal column, a decimal function call or a parenthesized decimal operand
+// with the optional unary operator.
+func (tokens Tokens) isDecimalOperand(isDecimalColumn func(string) bool) bool {
+	tokens = tokens.trimSpace()
+	if 0 < len(tokens) && (tokens[0].IsOperator("-") || tokens[0].IsOperator("+")) {
+		tokens = tokens[1:].trimSpace()
+	}
+	if len(tokens) == 0 {
+		return false
+	}
+	last := len(tokens) - 1
+	if tokens[0].IsPunctuation("(") {
+		return tokens.MatchingParen(0) == last && tokens[1:last].isDecimalOperand(isDecimalColumn)
+	}
+	if open := tokens.Next(0); 0 <= open && tokens[open].IsPunctuation("(") {
+		name := strings.ToLower(tokens[0].Value)
+		isDecimalFunction := strings.HasPrefix(name, decimalFunctionPrefix) || strings.HasPrefix(name, "mysql_"+decimalFunctionPrefix)
+		return tokens[0].IsName() && isDecimalFunction && tokens.MatchingParen(open) == last
+	}
+	// The column names may be qualified with the table names.
+	for idx, n := 0, 0; 0 <= n; idx, n = idx+1, tokens.Next(n) {
+		isName := idx%2 == 0 && tokens[n].IsName() && !reservedKeywords[strings.ToUpper(tokens[n].Text)]
+		if !isName && (idx%2 == 0 || !tokens[n].IsPunctuation(".")) {
+			return false
+		}
+	}
+	return tokens[last].IsName() && isDecimalColumn(tokens[last].Value)
+}
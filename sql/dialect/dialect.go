@@ -0,0 +1,42 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+// Dialect represents a SQL dialect of client queries.
+type Dialect int
+
+const (
+	// SQLite represents the SQLite dialect which is executed as it is.
+	SQLite Dialect = iota
+	// MySQL represents the MySQL dialect.
+	MySQL
+	// PostgreSQL represents the PostgreSQL dialect.
+	PostgreSQL
+)
+
+var dialectStrings = map[Dialect]string{
+	SQLite:     "SQLite",
+	MySQL:      "MySQL",
+	PostgreSQL: "PostgreSQL",
+}
+
+// String returns the string representation.
+func (d Dialect) String() string {
+	s, ok := dialectStrings[d]
+	if !ok {
+		return ""
+	}
+	return s
+}
@@ -0,0 +1,236 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+// PostgreSQL: Documentation: 16: 8.7. Enumerated Types
+// https://www.postgresql.org/docs/16/datatype-enum.html
+// MySQL :: MySQL 8.0 Reference Manual :: 13.3.5 The ENUM Type
+// https://dev.mysql.com/doc/refman/8.0/en/enum.html
+// MySQL :: MySQL 8.0 Reference Manual :: 13.3.6 The SET Type
+// https://dev.mysql.com/doc/refman/8.0/en/set.html
+
+import (
+	"strings"
+)
+
+// The kinds of the enum types.
+const (
+	EnumType = "ENUM"
+	SetType  = "SET"
+)
+
+// enumConstraintPrefix is the name prefix of the CHECK constraints which restrict the enum columns to the labels.
+const enumConstraintPrefix = HiddenColumnPrefix + "enum:"
+
+// SetCheckFunction is the name of the function which returns true if the specified value is a member set of the labels.
+const SetCheckFunction = "mysql_is_set_value"
+
+// Enum represents an enum type such as a PostgreSQL CREATE TYPE ... AS ENUM type and a MySQL inline ENUM or SET column type.
+type Enum struct {
+	Kind   string
+	Name   string
+	Labels []string
+}
+
+// TypeName returns the column type name of the enum type such as ENUM:mood, which is also the collation name of the enum type.
+func (enum *Enum) TypeName() string {
+	return enum.Kind + ":" + enum.Name
+}
+
+// EnumTypeOf returns the kind and the enum type name of the specified column type name such as ENUM:mood.
+func EnumTypeOf(typeName string) (string, string, bool) {
+	kind, name, ok := strings.Cut(typeName, ":")
+	if !ok || (kind != EnumType && kind != SetType) {
+		return "", "", false
+	}
+	return kind, name, true
+}
+
+// IsEnumTypeName returns true if the specified column type name is an enum column type name such as ENUM:mood.
+func IsEnumTypeName(typeName string) bool {
+	_, _, ok := EnumTypeOf(typeName)
+	return ok
+}
+
+// IsEnumTypeStatement returns true if the specified statement is CREATE TYPE or DROP TYPE.
+func IsEnumTypeStatement(stmt string) bool {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return false
+	}
+	first := tokens.Next(-1)
+	return (tokens.IsKeywordAt(first, "CREATE") || tokens.IsKeywordAt(first, "DROP")) && tokens.IsKeywordAt(tokens.Next(first), "TYPE")
+}
+
+// CreatedEnumType returns the enum type of the specified CREATE TYPE name AS ENUM (label, ...) statement.
+func CreatedEnumType(stmt string) (*Enum, error) {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return nil, err
+	}
+	create := tokens.Next(-1)
+	if !tokens.IsKeywordsAt(create, "CREATE", "TYPE") {
+		return nil, newErrInvalid(stmt)
+	}
+	name := tokens.tableNameAfter(tokens.Next(create))
+	as := tokens.indexTopLevelKeyword(create, "AS")
+	if name == "" || as < 0 {
+		return nil, newErrInvalid(stmt)
+	}
+	if !tokens.IsKeywordAt(tokens.Next(as), "ENUM") {
+		return nil, newErrNotSupported(stmt)
+	}
+	labels, ok := tokens[tokens.Next(as)+1:].enumLabels()
+	if !ok {
+		return nil, newErrInvalid(stmt)
+	}
+	return &Enum{Kind: EnumType, Name: strings.ToLower(name), Labels: labels}, nil
+}
+
+// DroppedTypeNames returns the type names and whether IF EXISTS is specified of the specified DROP TYPE statement.
+func DroppedTypeNames(stmt string) ([]string, bool, error) {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return nil, false, err
+	}
+	drop := tokens.Next(-1)
+	if !tokens.IsKeywordsAt(drop, "DROP", "TYPE") {
+		return nil, false, newErrInvalid(stmt)
+	}
+	typ := tokens.Next(drop)
+	ifExists := tokens.IsKeywordsAt(tokens.Next(typ), "IF", "EXISTS")
+	names := []string{}
+	// CASCADE and RESTRICT follow the last type name.
+	for _, elem := range tokens[typ+1:].splitTopLevel(",") {
+		if name := elem.tableNameAfter(-1); name != "" {
+			names = append(names, strings.ToLower(name))
+		}
+	}
+	return names, ifExists, nil
+}
+
+// EnumColumns rewrites the enum columns of the specified CREATE TABLE statement, and returns the rewritten statement
+// and the enum types of the columns. The named enum types are looked up from the specified enum types,
+// and the inline MySQL ENUM and SET types are named with the table and column names such as orders.status.
+// The enum columns are rewritten into the text columns with the collation of the enum type,
+// and the CHECK constraint which restricts the values to the labels.
+func EnumColumns(stmt string, enums map[string]*Enum) (string, map[string]*Enum, error) {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return "", nil, err
+	}
+	defs, ok := tokens.columnDefs()
+	if !ok {
+		return stmt, nil, nil
+	}
+	tblName := strings.ToLower(tokens.tableNameAfter(tokens.indexTopLevelKeyword(0, "TABLE")))
+	columns := map[string]*Enum{}
+	// Rewrite the column definitions from the end not to shift the indexes.
+	for n := len(defs) - 1; 0 <= n; n-- {
+		def := defs[n]
+		if def.typeEnd < 0 {
+			continue
+		}
+		var enum *Enum
+		switch def.typeName {
+		case EnumType, SetType:
+			labels, ok := tokens[def.typeBegin+1 : def.typeEnd+1].enumLabels()
+			if !ok {
+				continue
+			}
+			enum = &Enum{Kind: def.typeName, Name: tblName + "." + strings.ToLower(def.name), Labels: labels}
+		default:
+			enum, ok = enums[strings.ToLower(def.typeName)]
+			if !ok {
+				continue
+			}
+		}
+		columns[def.name] = enum
+		tokens = tokens.Splice(def.typeBegin, def.typeEnd, enumColumnTokens(def.name, enum)...)
+	}
+	return tokens.String(), columns, nil
+}
+
+// enumColumnTokens returns the column type and the CHECK constraint tokens of the specified enum column.
+func enumColumnTokens(column string, enum *Enum) Tokens {
+	tokens := keywordTokens("TEXT", "COLLATE")
+	tokens = append(tokens,
+		NewSpaceToken(), NewIdentifierToken(enum.TypeName()),
+		NewSpaceToken(), NewWordToken("CONSTRAINT"),
+		NewSpaceToken(), NewIdentifierToken(enumConstraintPrefix+enum.TypeName()+":"+column),
+		NewSpaceToken(), NewWordToken("CHECK"), NewSpaceToken(), NewPunctuationToken("("),
+	)
+	labels := Tokens{}
+	for n, label := range enum.Labels {
+		if 0 < n {
+			labels = append(labels, NewPunctuationToken(","), NewSpaceToken())
+		}
+		labels = append(labels, NewStringToken(label))
+	}
+	switch enum.Kind {
+	case SetType:
+		tokens = append(tokens, NewWordToken(SetCheckFunction), NewPunctuationToken("("), NewIdentifierToken(column), NewPunctuationToken(","), NewSpaceToken())
+		tokens = append(tokens, labels...)
+		tokens = append(tokens, NewPunctuationToken(")"))
+	default:
+		tokens = append(tokens, NewIdentifierToken(column), NewSpaceToken(), NewWordToken("IN"), NewSpaceToken(), NewPunctuationToken("("))
+		tokens = append(tokens, labels...)
+		tokens = append(tokens, NewPunctuationToken(")"))
+	}
+	return append(tokens, NewPunctuationToken(")"))
+}
+
+// enumLabels returns the string literals of the parenthesized label list at the beginning of the tokens.
+func (tokens Tokens) enumLabels() ([]string, bool) {
+	open := tokens.Next(-1)
+	if open < 0 || !tokens[open].IsPunctuation("(") {
+		return nil, false
+	}
+	closing := tokens.MatchingParen(open)
+	if closing < 0 {
+		return nil, false
+	}
+	labels := []string{}
+	for _, elem := range tokens[open+1 : closing].splitTopLevel(",") {
+		elem = elem.trimSpace()
+		if len(elem) == 0 && len(labels) == 0 {
+			// PostgreSQL allows the empty enum types.
+			continue
+		}
+		if len(elem) != 1 || elem[0].Type != StringToken {
+			return nil, false
+		}
+		labels = append(labels, elem[0].Value)
+	}
+	return labels, true
+}
+
+// EnumConstraintOf returns the column name and the enum column type name of the specified CHECK constraint failure message.
+func EnumConstraintOf(msg string) (string, string, bool) {
+	_, constraint, ok := strings.Cut(msg, enumConstraintPrefix)
+	if !ok {
+		return "", "", false
+	}
+	kind, constraint, _ := strings.Cut(constraint, ":")
+	name, column, ok := strings.Cut(constraint, ":")
+	if !ok {
+		return "", "", false
+	}
+	// The failure message may be followed by the other messages.
+	if idx := strings.IndexAny(column, " \n"); 0 <= idx {
+		column = column[:idx]
+	}
+	return column, kind + ":" + name, true
+}
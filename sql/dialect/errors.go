@@ -0,0 +1,33 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalid      = errors.New("invalid")
+	ErrNotSupported = errors.New("not supported")
+)
+
+func newErrNotSupported(obj any) error {
+	return fmt.Errorf("%v is %w", obj, ErrNotSupported)
+}
+
+func newErrInvalid(obj any) error {
+	return fmt.Errorf("%v is %w", obj, ErrInvalid)
+}
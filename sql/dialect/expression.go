@@ -0,0 +1,306 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+// SQLite: Datatypes In SQLite
+// https://www.sqlite.org/datatype3.html
+// SQLite: Built-In Aggregate Functions
+// https://www.sqlite.org/lang_aggfunc.html
+
+import (
+	"strings"
+)
+
+// The inferred types of the expression columns, and the integers and the reals are 64-bit in SQLite.
+const (
+	integerExprType = "BIGINT"
+	realExprType    = "DOUBLE"
+	textExprType    = "TEXT"
+	decimalExprType = "DECIMAL"
+	// WildcardExprType is the type of the wildcard items such as * and t.* which are expanded into the table columns.
+	WildcardExprType = "*"
+)
+
+// selectListEndKeywords represents the keywords which end the select list.
+var selectListEndKeywords = []string{"FROM", "WHERE", "GROUP", "HAVING", "WINDOW", "ORDER", "LIMIT", "UNION", "INTERSECT", "EXCEPT"}
+
+// exprFunctionTypes maps the function names into the result types which do not depend on the arguments.
+var exprFunctionTypes = map[string]string{
+	"COUNT":                integerExprType,
+	"LENGTH":               integerExprType,
+	"OCTET_LENGTH":         integerExprType,
+	"INSTR":                integerExprType,
+	"UNICODE":              integerExprType,
+	"ROW_NUMBER":           integerExprType,
+	"RANK":                 integerExprType,
+	"DENSE_RANK":           integerExprType,
+	"NTILE":                integerExprType,
+	"CHANGES":              integerExprType,
+	"TOTAL_CHANGES":        integerExprType,
+	"LAST_INSERT_ROWID":    integerExprType,
+	"MYSQL_LAST_INSERT_ID": integerExprType,
+	"AVG":                  realExprType,
+	"TOTAL":                realExprType,
+	"PERCENT_RANK":         realExprType,
+	"CUME_DIST":            realExprType,
+	"UPPER":                textExprType,
+	"LOWER":                textExprType,
+	"TRIM":                 textExprType,
+	"LTRIM":                textExprType,
+	"RTRIM":                textExprType,
+	"SUBSTR":               textExprType,
+	"SUBSTRING":            textExprType,
+	"REPLACE":              textExprType,
+	"CONCAT":               textExprType,
+	"CONCAT_WS":            textExprType,
+	"GROUP_CONCAT":         textExprType,
+	"STRING_AGG":           textExprType,
+	"PRINTF":               textExprType,
+	"FORMAT":               textExprType,
+	"HEX":                  textExprType,
+	"QUOTE":                textExprType,
+	"TYPEOF":               textExprType,
+	"STRFTIME":             textExprType,
+	"DECIMAL_ADD":          decimalExprType,
+	"DECIMAL_SUB":          decimalExprType,
+	"DECIMAL_MUL":          decimalExprType,
+	"DECIMAL_DIV":          decimalExprType,
+	"MYSQL_DECIMAL_DIV":    decimalExprType,
+	"DECIMAL_CAST":         decimalExprType,
+	"DECIMAL_SUM":          decimalExprType,
+}
+
+// exprFirstArgumentFunctions represents the functions whose result types are the type of the first argument.
+var exprFirstArgumentFunctions = map[string]bool{
+	"COALESCE": true, "IFNULL": true, "NULLIF": true, "MIN": true, "MAX": true, "ABS": true,
+}
+
+// exprBooleanKeywords represents the keywords of the boolean expressions.
+var exprBooleanKeywords = []string{"AND", "OR", "NOT", "IS", "IN", "LIKE", "GLOB", "REGEXP", "BETWEEN", "EXISTS"}
+
+// exprBooleanOperators represents the operators of the boolean expressions.
+var exprBooleanOperators = map[string]bool{
+	"=": true, "==": true, "<>": true, "!=": true, "<": true, ">": true, "<=": true, ">=": true,
+}
+
+// ExpressionTypes returns the inferred types of the result columns of the specified SELECT statement such as
+// INTEGER for COUNT(*) and TEXT for 'a' || 'b', and the types are empty if the types depend on the values.
+// The wildcard items are WildcardExprType because the numbers of the expanded columns are unknown,
+// and the types are nil if the statement is not SELECT.
+func ExpressionTypes(stmt string) []string {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return nil
+	}
+	begin := tokens.indexTopLevelKeyword(0, "SELECT")
+	if begin < 0 {
+		return nil
+	}
+	if next := tokens.Next(begin); tokens.IsKeywordAt(next, "DISTINCT") || tokens.IsKeywordAt(next, "ALL") {
+		begin = next
+	}
+	end := len(tokens)
+	for _, keyword := range selectListEndKeywords {
+		if idx := tokens.indexTopLevelKeyword(begin, keyword); 0 <= idx && idx < end {
+			end = idx
+		}
+	}
+	types := []string{}
+	for _, item := range tokens[begin+1 : end].splitTopLevel(",") {
+		item = item.withoutAlias()
+		if 0 < len(item) && item[len(item)-1].IsOperator("*") {
+			types = append(types, WildcardExprType)
+			continue
+		}
+		types = append(types, item.exprType())
+	}
+	return types
+}
+
+// withoutAlias returns the select list item without the column alias.
+func (tokens Tokens) withoutAlias() Tokens {
+	tokens = tokens.trimSpace()
+	if as := tokens.indexTopLevelKeyword(0, "AS"); 0 <= as && !tokens.IsKeywordAt(0, "CAST") {
+		return tokens[:as].trimSpace()
+	}
+	last := tokens.Prev(len(tokens))
+	prev := tokens.Prev(last)
+	if last < 0 || prev < 0 || !tokens[last].IsName() {
+		return tokens
+	}
+	switch {
+	case tokens[prev].Type == OperatorToken, tokens[prev].IsPunctuation("("), tokens[prev].IsPunctuation("."):
+		return tokens
+	case tokens[prev].Type == WordToken && !tokens[prev].IsKeyword("END"):
+		// The keywords such as NOT and the column names are followed by the operands.
+		return tokens
+	}
+	return tokens[:prev+1]
+}
+
+// exprType returns the inferred type of the specified expression, or an empty string if the type depends on the values.
+func (tokens Tokens) exprType() string {
+	tokens = tokens.trimSpace()
+	if len(tokens) == 0 {
+		return ""
+	}
+	if tokens.IsKeywordAt(0, "CASE") {
+		then := tokens.indexTopLevelKeyword(0, "THEN")
+		if then < 0 {
+			return ""
+		}
+		end := len(tokens)
+		for _, keyword := range []string{"WHEN", "ELSE", "END"} {
+			if idx := tokens.indexTopLevelKeyword(then, keyword); 0 <= idx && idx < end {
+				end = idx
+			}
+		}
+		return tokens[then+1 : end].exprType()
+	}
+	// The boolean expressions are inferred before the arithmetic expressions because they have lower precedence.
+	depth := 0
+	operands := []Tokens{}
+	operand := Tokens{}
+	for _, tok := range tokens {
+		switch {
+		case tok.IsPunctuation("("):
+			depth++
+		case tok.IsPunctuation(")"):
+			depth--
+		case 0 < depth:
+		case tok.Type == OperatorToken && exprBooleanOperators[tok.Text]:
+			return BooleanType
+		case tok.Type == WordToken && isExprBooleanKeyword(tok):
+			return BooleanType
+		case tok.IsOperator("||"):
+			return textExprType
+		case tok.IsOperator("+"), tok.IsOperator("-"), tok.IsOperator("*"), tok.IsOperator("/"), tok.IsOperator("%"):
+			operands = append(operands, operand)
+			operand = Tokens{}
+			continue
+		}
+		operand = append(operand, tok)
+	}
+	if len(operands) == 0 {
+		return operand.operandType()
+	}
+	operands = append(operands, operand)
+	exprType := ""
+	for _, operand := range operands {
+		if len(operand.trimSpace()) == 0 {
+			// The unary operators have no left operands.
+			continue
+		}
+		switch operand.operandType() {
+		case realExprType:
+			exprType = realExprType
+		case integerExprType:
+			if exprType == "" {
+				exprType = integerExprType
+			}
+		default:
+			return ""
+		}
+	}
+	return exprType
+}
+
+// operandType returns the inferred type of the specified operand such as a literal and a function call.
+func (tokens Tokens) operandType() string {
+	tokens = tokens.trimSpace()
+	if len(tokens) == 0 {
+		return ""
+	}
+	first := tokens[0]
+	if len(tokens) == 1 {
+		switch first.Type {
+		case NumberToken:
+			if strings.ContainsAny(first.Text, ".eE") && !strings.HasPrefix(strings.ToLower(first.Text), "0x") {
+				return realExprType
+			}
+			return integerExprType
+		case StringToken:
+			return textExprType
+		case WordToken:
+			if first.IsKeyword("TRUE") || first.IsKeyword("FALSE") {
+				return BooleanType
+			}
+		}
+		return ""
+	}
+	open := tokens.Next(0)
+	switch {
+	case first.IsPunctuation("("):
+		if tokens.IsKeywordAt(open, "SELECT") || tokens.IsKeywordAt(open, "WITH") {
+			return ""
+		}
+		if tokens.MatchingParen(0) == len(tokens)-1 {
+			return tokens[1 : len(tokens)-1].exprType()
+		}
+		return ""
+	case !first.IsName() || open < 0 || !tokens[open].IsPunctuation("("):
+		return ""
+	}
+	closing := tokens.MatchingParen(open)
+	if closing < 0 {
+		return ""
+	}
+	name := strings.ToUpper(first.Value)
+	if name == "CAST" {
+		as := tokens.indexTopLevelKeyword(open+1, "AS")
+		if as < 0 {
+			return ""
+		}
+		typeName, _ := tokens.typeName(tokens.Next(as))
+		return castExprTypeOf(typeName)
+	}
+	// The window functions such as ROW_NUMBER() OVER (...) are typed by the functions.
+	if exprType, ok := exprFunctionTypes[name]; ok {
+		return exprType
+	}
+	if exprFirstArgumentFunctions[name] && closing == len(tokens)-1 {
+		args := tokens[open+1 : closing].splitTopLevel(",")
+		return args[0].exprType()
+	}
+	return ""
+}
+
+// isExprBooleanKeyword returns true if the specified token is a keyword of the boolean expressions.
+func isExprBooleanKeyword(tok *Token) bool {
+	for _, keyword := range exprBooleanKeywords {
+		if tok.IsKeyword(keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// castExprTypeOf returns the inferred type of CAST(... AS typeName) by the type affinity,
+// and the NUMERIC affinity types are inferred from the values.
+func castExprTypeOf(typeName string) string {
+	if typeName == "" {
+		return ""
+	}
+	switch affinity := SQLiteTypeOf(typeName); affinity {
+	case "INTEGER":
+		return integerExprType
+	case "REAL":
+		return realExprType
+	case "NUMERIC":
+		return ""
+	default:
+		return affinity
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExpressionTypes(t *testing.T) {
+	tests := []struct {
+		query string
+		types []string
+	}{
+		{
+			"SELECT COUNT(*), 1 + 1.5, 'a' || 'b', AVG(x), MAX(x), a = b, CAST(x AS INTEGER) FROM t",
+			[]string{"BIGINT", "DOUBLE", "TEXT", "DOUBLE", "", "BOOLEAN", "BIGINT"},
+		},
+		{
+			"SELECT COUNT(*) AS cnt, *, t.*, AVG(i) avg_i FROM t",
+			[]string{"BIGINT", WildcardExprType, WildcardExprType, "DOUBLE"},
+		},
+		{
+			"UPDATE t SET a = 1",
+			nil,
+		},
+	}
+	for _, test := range tests {
+		if types := ExpressionTypes(test.query); !slices.Equal(types, test.types) {
+			t.Errorf("%s: %q != %q", test.query, types, test.types)
+		}
+	}
+}
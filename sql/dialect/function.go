@@ -0,0 +1,299 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+import (
+	"strings"
+)
+
+// mysqlFunctionNames maps the MySQL function names into the SQLite function names.
+// The mysql_ prefixed functions are registered by the function package because their semantics differ from PostgreSQL,
+// and mysql_last_insert_id is registered on the connections of the sessions because it returns the state of the session.
+var mysqlFunctionNames = map[string]string{
+	"CONCAT":            "mysql_concat",
+	"GREATEST":          "mysql_greatest",
+	"LEAST":             "mysql_least",
+	"NOW":               "mysql_now",
+	"SYSDATE":           "mysql_now",
+	"CURRENT_TIMESTAMP": "mysql_now",
+	"LOCALTIME":         "mysql_now",
+	"LOCALTIMESTAMP":    "mysql_now",
+	"CURRENT_DATE":      "curdate",
+	"CURRENT_TIME":      "curtime",
+	"REGEXP_REPLACE":    "mysql_regexp_replace",
+	"STDDEV":            "mysql_stddev",
+	"VARIANCE":          "mysql_variance",
+	"LENGTH":            "octet_length",
+	"CHAR_LENGTH":       "length",
+	"CHARACTER_LENGTH":  "length",
+	"LCASE":             "lower",
+	"UCASE":             "upper",
+	"MID":               "substr",
+	"IF":                "iif",
+	"LAST_INSERT_ID":    "mysql_last_insert_id",
+	"JSON_EXTRACT":      "mysql_json_extract",
+	"JSON_ARRAYAGG":     "json_group_array",
+	"JSON_OBJECTAGG":    "json_group_object",
+}
+
+// postgresqlFunctionNames maps the PostgreSQL function names into the SQLite function names.
+var postgresqlFunctionNames = map[string]string{
+	"RANDOM":           "rand",
+	"CHAR_LENGTH":      "length",
+	"CHARACTER_LENGTH": "length",
+	"CHR":              "char",
+	"BTRIM":            "trim",
+}
+
+// functionNameRule returns a rule which renames the function calls with the specified name mapping.
+func functionNameRule(names map[string]string) RewriteRule {
+	return func(tokens Tokens) (Tokens, error) {
+		for n, tok := range tokens {
+			if tok.Type != WordToken {
+				continue
+			}
+			name, ok := names[strings.ToUpper(tok.Text)]
+			if !ok {
+				continue
+			}
+			if open := tokens.Next(n); open < 0 || !tokens[open].IsPunctuation("(") {
+				continue
+			}
+			if prev := tokens.Prev(n); 0 <= prev && tokens[prev].IsPunctuation(".") {
+				continue
+			}
+			tokens[n] = NewWordToken(name)
+		}
+		return tokens, nil
+	}
+}
+
+// extractRule rewrites EXTRACT(field FROM expr) into extract('field', expr) which is registered by the function package.
+func extractRule(tokens Tokens) (Tokens, error) {
+	for n := 0; n < len(tokens); n++ {
+		if !tokens[n].IsKeyword("EXTRACT") {
+			continue
+		}
+		open := tokens.Next(n)
+		if open < 0 || !tokens[open].IsPunctuation("(") {
+			continue
+		}
+		field := tokens.Next(open)
+		from := tokens.Next(field)
+		if field < 0 || !tokens[field].IsName() || !tokens.IsKeywordAt(from, "FROM") {
+			return nil, newErrInvalid(tokens[n:].String())
+		}
+		tokens = tokens.Splice(field, from, NewStringToken(strings.ToLower(tokens[field].Value)), NewPunctuationToken(","))
+	}
+	return tokens, nil
+}
+
+// mysqlIntervalRule rewrites the temporal intervals such as INTERVAL 1 DAY.
+// DATE_ADD(expr, INTERVAL n unit) is rewritten into date_add(expr, n, 'unit'),
+// and expr + INTERVAL n unit is rewritten into date_add(expr, n, 'unit').
+func mysqlIntervalRule(tokens Tokens) (Tokens, error) {
+	for n := 0; n < len(tokens); n++ {
+		if !tokens[n].IsKeyword("INTERVAL") {
+			continue
+		}
+		valueBegin := tokens.Next(n)
+		if valueBegin < 0 {
+			return nil, newErrInvalid(tokens.String())
+		}
+		valueEnd := valueBegin
+		if tokens[valueEnd].IsOperator("-") || tokens[valueEnd].IsOperator("+") {
+			valueEnd = tokens.Next(valueEnd)
+		}
+		if 0 <= valueEnd && tokens[valueEnd].IsPunctuation("(") {
+			valueEnd = tokens.MatchingParen(valueEnd)
+		}
+		unit := tokens.Next(valueEnd)
+		if valueEnd < 0 || unit < 0 || tokens[unit].Type != WordToken {
+			return nil, newErrInvalid(tokens[n:].String())
+		}
+		value := append(Tokens{}, tokens[valueBegin:valueEnd+1]...)
+		args := append(value, NewPunctuationToken(","), NewSpaceToken(), NewStringToken(strings.ToUpper(tokens[unit].Text)))
+		prev := tokens.Prev(n)
+		switch {
+		case 0 <= prev && tokens[prev].IsPunctuation(","):
+			tokens = tokens.Splice(n, unit, args...)
+		case 0 <= prev && (tokens[prev].IsOperator("+") || tokens[prev].IsOperator("-")):
+			name := "date_add"
+			if tokens[prev].IsOperator("-") {
+				name = "date_sub"
+			}
+			operandEnd := tokens.Prev(prev)
+			if operandEnd < 0 {
+				return nil, newErrInvalid(tokens.String())
+			}
+			begin := tokens.operandBegin(operandEnd)
+			call := Tokens{NewWordToken(name), NewPunctuationToken("(")}
+			call = append(call, tokens[begin:operandEnd+1]...)
+			call = append(call, NewPunctuationToken(","), NewSpaceToken())
+			call = append(call, args...)
+			call = append(call, NewPunctuationToken(")"))
+			tokens = tokens.Splice(begin, unit, call...)
+			n = begin
+		default:
+			return nil, newErrNotSupported(tokens[n : unit+1].String())
+		}
+	}
+	return tokens, nil
+}
+
+// mysqlUnitArgumentRule rewrites the unit keyword of TIMESTAMPADD() and TIMESTAMPDIFF() into a string.
+func mysqlUnitArgumentRule(tokens Tokens) (Tokens, error) {
+	for n, tok := range tokens {
+		if !tok.IsKeyword("TIMESTAMPADD") && !tok.IsKeyword("TIMESTAMPDIFF") {
+			continue
+		}
+		open := tokens.Next(n)
+		if open < 0 || !tokens[open].IsPunctuation("(") {
+			continue
+		}
+		unit := tokens.Next(open)
+		if unit < 0 || tokens[unit].Type != WordToken {
+			continue
+		}
+		tokens[unit] = NewStringToken(strings.ToUpper(tokens[unit].Text))
+	}
+	return tokens, nil
+}
+
+// mysqlGroupConcatRule rewrites GROUP_CONCAT(expr [ORDER BY ...] SEPARATOR 'sep') into group_concat(expr, 'sep' [ORDER BY ...]).
+func mysqlGroupConcatRule(tokens Tokens) (Tokens, error) {
+	for n := 0; n < len(tokens); n++ {
+		if !tokens[n].IsKeyword("GROUP_CONCAT") {
+			continue
+		}
+		open := tokens.Next(n)
+		if open < 0 || !tokens[open].IsPunctuation("(") {
+			continue
+		}
+		closing := tokens.MatchingParen(open)
+		if closing < 0 {
+			return nil, newErrInvalid(tokens[n:].String())
+		}
+		args := tokens[open+1 : closing]
+		sep := args.indexTopLevelKeyword(0, "SEPARATOR")
+		if sep < 0 {
+			continue
+		}
+		sepValue := args.Next(sep)
+		if sepValue < 0 || args[sepValue].Type != StringToken {
+			return nil, newErrInvalid(args.String())
+		}
+		sepArg := Tokens{NewPunctuationToken(","), NewSpaceToken(), args[sepValue], NewSpaceToken()}
+		if args.IsKeywordAt(args.Next(-1), "DISTINCT") {
+			// SQLite allows only the default separator for the DISTINCT aggregates.
+			if args[sepValue].Value != "," {
+				return nil, newErrNotSupported(tokens[n : closing+1].String())
+			}
+			sepArg = Tokens{NewSpaceToken()}
+		}
+		order := args.indexTopLevelKeyword(0, "ORDER")
+		repl := append(Tokens{}, args[:sep]...).trimSpace()
+		if 0 <= order && order < sep {
+			repl = append(Tokens{}, args[:order]...).trimSpace()
+			repl = append(repl, sepArg...)
+			repl = append(repl, args[order:sep]...)
+		} else {
+			repl = append(repl, sepArg...)
+		}
+		repl = append(repl, args[sepValue+1:]...).trimSpace()
+		tokens = tokens.Splice(open+1, closing-1, repl...)
+	}
+	return tokens, nil
+}
+
+// mysqlConvertRule rewrites CONVERT(expr, type) into CAST(expr AS type), and CONVERT(expr USING charset) into (expr)
+// because SQLite stores all texts in UTF-8.
+func mysqlConvertRule(tokens Tokens) (Tokens, error) {
+	for n := 0; n < len(tokens); n++ {
+		if !tokens[n].IsKeyword("CONVERT") {
+			continue
+		}
+		open := tokens.Next(n)
+		if open < 0 || !tokens[open].IsPunctuation("(") {
+			continue
+		}
+		closing := tokens.MatchingParen(open)
+		if closing < 0 {
+			return nil, newErrInvalid(tokens[n:].String())
+		}
+		args := tokens[open+1 : closing]
+		if using := args.indexTopLevelKeyword(0, "USING"); 0 <= using {
+			expr := append(Tokens{NewPunctuationToken("(")}, args[:using].trimSpace()...)
+			tokens = tokens.Splice(n, closing, append(expr, NewPunctuationToken(")"))...)
+			continue
+		}
+		exprs := args.splitTopLevel(",")
+		if len(exprs) != 2 {
+			return nil, newErrInvalid(tokens[n : closing+1].String())
+		}
+		cast := append(Tokens{NewWordToken("CAST"), NewPunctuationToken("(")}, exprs[0].trimSpace()...)
+		cast = append(cast, NewSpaceToken(), NewWordToken("AS"), NewSpaceToken())
+		cast = append(cast, exprs[1].trimSpace()...)
+		tokens = tokens.Splice(n, closing, append(cast, NewPunctuationToken(")"))...)
+	}
+	return tokens, nil
+}
+
+// postgresqlIntervalRule rewrites the interval arithmetic such as expr + INTERVAL '1 day' and expr - '1 day'::interval
+// into date_add(expr, '1 day') and date_subtract(expr, '1 day') which are registered by the function package.
+// The interval casts are rewritten into INTERVAL (expr) by postgresqlCastRule in advance.
+func postgresqlIntervalRule(tokens Tokens) (Tokens, error) {
+	for n := 0; n < len(tokens); n++ {
+		if !tokens[n].IsKeyword("INTERVAL") {
+			continue
+		}
+		end := tokens.Next(n)
+		switch {
+		case end < 0:
+			return nil, newErrInvalid(tokens.String())
+		case tokens[end].IsPunctuation("("):
+			end = tokens.MatchingParen(end)
+		case tokens[end].Type != StringToken:
+			continue
+		}
+		if end < 0 {
+			return nil, newErrInvalid(tokens[n:].String())
+		}
+		value := append(Tokens{}, tokens[tokens.Next(n):end+1]...)
+		prev := tokens.Prev(n)
+		if prev < 0 || (!tokens[prev].IsOperator("+") && !tokens[prev].IsOperator("-")) {
+			// The standalone interval is returned as the interval string.
+			tokens = tokens.Splice(n, end, value...)
+			continue
+		}
+		name := "date_add"
+		if tokens[prev].IsOperator("-") {
+			name = "date_subtract"
+		}
+		operandEnd := tokens.Prev(prev)
+		if operandEnd < 0 {
+			return nil, newErrInvalid(tokens.String())
+		}
+		begin := tokens.operandBegin(operandEnd)
+		call := Tokens{NewWordToken(name), NewPunctuationToken("(")}
+		call = append(call, tokens[begin:operandEnd+1]...)
+		call = append(call, NewPunctuationToken(","), NewSpaceToken())
+		call = append(call, value...)
+		call = append(call, NewPunctuationToken(")"))
+		tokens = tokens.Splice(begin, end, call...)
+		n = begin
+	}
+	return tokens, nil
+}
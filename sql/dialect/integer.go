@@ -0,0 +1,147 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+// PostgreSQL: Documentation: 16: 8.1. Numeric Types
+// https://www.postgresql.org/docs/16/datatype-numeric.html#DATATYPE-INT
+// MySQL :: MySQL 8.0 Reference Manual :: 13.1.2 Integer Types (Exact Value)
+// https://dev.mysql.com/doc/refman/8.0/en/integer-types.html
+// SQLite: ROWIDs and the INTEGER PRIMARY KEY
+// https://www.sqlite.org/lang_createtable.html#rowid
+
+import (
+	"strings"
+)
+
+// UnsignedBigIntType is the type name of the MySQL BIGINT UNSIGNED columns.
+const UnsignedBigIntType = "BIGINT UNSIGNED"
+
+// unsignedBigIntTextType is the internal type name of the BIGINT UNSIGNED columns. The columns are stored as the texts
+// with the decimal collation because SQLite integers are signed 64-bit, and the type name has the TEXT affinity.
+const unsignedBigIntTextType = "UNSIGNED_TEXT_64"
+
+// unsignedSuffix is the suffix of the unsigned integer type names such as INT UNSIGNED.
+const unsignedSuffix = " UNSIGNED"
+
+// integerTypes maps the declared integer type names and the aliases into the integer types.
+var integerTypes = map[string]string{
+	"TINYINT":     "TINYINT",
+	"SMALLINT":    "SMALLINT",
+	"INT2":        "SMALLINT",
+	"SMALLSERIAL": "SMALLINT",
+	"SERIAL2":     "SMALLINT",
+	"MEDIUMINT":   "MEDIUMINT",
+	"INT":         "INT",
+	"INTEGER":     "INT",
+	"INT4":        "INT",
+	"SERIAL":      "INT",
+	"SERIAL4":     "INT",
+	"BIGINT":      "BIGINT",
+	"INT8":        "BIGINT",
+	"BIGSERIAL":   "BIGINT",
+	"SERIAL8":     "BIGINT",
+}
+
+// integerTypeCollations represents the collations which keep the declared integer types of the auto-increment columns,
+// because the columns are declared as INTEGER to be the row ID aliases. The collations are registered by the function package,
+// and they do not affect the integer comparisons.
+var integerTypeCollations = map[string]bool{
+	"TINYINT": true, "SMALLINT": true, "MEDIUMINT": true, "INT": true, "BIGINT": true,
+	"TINYINT UNSIGNED": true, "SMALLINT UNSIGNED": true, "MEDIUMINT UNSIGNED": true, "INT UNSIGNED": true, UnsignedBigIntType: true,
+}
+
+// IntegerTypeOf returns the integer type of the specified upper case declared type name such as BIGINT for INT8
+// and BIGINT UNSIGNED for the internal unsigned type name.
+func IntegerTypeOf(typeName string) (string, bool) {
+	if typeName == unsignedBigIntTextType {
+		return UnsignedBigIntType, true
+	}
+	name, unsigned := strings.CutSuffix(typeName, unsignedSuffix)
+	integerType, ok := integerTypes[name]
+	if !ok {
+		return "", false
+	}
+	if unsigned {
+		integerType += unsignedSuffix
+	}
+	return integerType, true
+}
+
+// IsUnsignedTypeName returns true if the specified type name is an unsigned integer type name such as INT UNSIGNED.
+func IsUnsignedTypeName(typeName string) bool {
+	return strings.HasSuffix(typeName, unsignedSuffix)
+}
+
+// IsAutoIncrementCollation returns true if the specified collation is a collation of the auto-increment columns.
+func IsAutoIncrementCollation(collation string) bool {
+	return integerTypeCollations[strings.ToUpper(collation)]
+}
+
+// integerColumnTokens returns the column type tokens of the specified integer type. The auto-increment columns are declared
+// as INTEGER with the collations of the integer types, and the BIGINT UNSIGNED columns are declared as the internal type.
+func integerColumnTokens(integerType string, isAutoIncrement bool) Tokens {
+	switch {
+	case isAutoIncrement:
+		return Tokens{NewWordToken("INTEGER"), NewSpaceToken(), NewWordToken("COLLATE"), NewSpaceToken(), NewIdentifierToken(integerType)}
+	case integerType == UnsignedBigIntType:
+		return decimalColumnTokens(unsignedBigIntTextType)
+	}
+	return nil
+}
+
+// integerTypeCollationOf returns the integer type of the collation in the specified column constraint tokens.
+func (tokens Tokens) integerTypeCollationOf() (string, bool) {
+	for n := range tokens {
+		if !tokens[n].IsKeyword("COLLATE") {
+			continue
+		}
+		next := tokens.Next(n)
+		if next < 0 || !integerTypeCollations[tokens[next].Value] {
+			return "", false
+		}
+		return tokens[next].Value, true
+	}
+	return "", false
+}
+
+// withMySQLUnsignedType returns the column definition without the UNSIGNED and ZEROFILL attributes, and the unsigned
+// integer type is rewritten into the type name such as INT UNSIGNED without the display width, because SQLite does not
+// allow the type names after the type parameters such as INT(10) UNSIGNED.
+func (tokens Tokens) withMySQLUnsignedType() Tokens {
+	name := tokens.Next(-1)
+	typeIdx := tokens.Next(name)
+	if typeIdx < 0 {
+		return tokens
+	}
+	typeName, typeEnd := tokens.typeName(typeIdx)
+	if typeEnd < 0 {
+		return tokens
+	}
+	unsigned := false
+	for n := len(tokens) - 1; typeEnd < n; n-- {
+		if tokens[n].IsKeyword("UNSIGNED") || tokens[n].IsKeyword("ZEROFILL") {
+			// ZEROFILL implies UNSIGNED.
+			begin := tokens.leadingSpaceBegin(n)
+			tokens = tokens.Splice(begin, n)
+			n = begin
+			unsigned = true
+		}
+	}
+	integerType, ok := integerTypes[typeName]
+	if !unsigned || !ok {
+		return tokens
+	}
+	return tokens.Splice(typeIdx, typeEnd, NewWordToken(integerType), NewSpaceToken(), NewWordToken("UNSIGNED"))
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+// PostgreSQL: Documentation: 16: 9.16. JSON Functions and Operators
+// https://www.postgresql.org/docs/16/functions-json.html
+// SQLite: JSON Functions And Operators
+// https://www.sqlite.org/json1.html#jptr
+
+import (
+	"strings"
+)
+
+// jsonConstraintPrefix is the name prefix of the CHECK constraints which restrict the JSON columns to the well-formed JSON texts.
+const jsonConstraintPrefix = HiddenColumnPrefix + "json:"
+
+// jsonFunctionNames represents the lower case names of the functions which return the JSON values.
+var jsonFunctionNames = map[string]bool{
+	"json": true, "json_object": true, "json_array": true, "json_group_array": true, "json_group_object": true, "json_patch": true,
+	"json_set": true, "json_insert": true, "json_replace": true, "json_remove": true, "jsonb_set": true, "jsonb_concat": true,
+	"to_json": true, "json_extract_path": true, "json_extract_path_op": true,
+}
+
+// postgresqlJSONFunctionNames maps the PostgreSQL JSON function names into the SQLite JSON function names.
+var postgresqlJSONFunctionNames = map[string]string{
+	"JSON_BUILD_OBJECT":       "json_object",
+	"JSONB_BUILD_OBJECT":      "json_object",
+	"JSON_BUILD_ARRAY":        "json_array",
+	"JSONB_BUILD_ARRAY":       "json_array",
+	"JSONB_ARRAY_LENGTH":      "json_array_length",
+	"JSON_AGG":                "json_group_array",
+	"JSONB_AGG":               "json_group_array",
+	"JSON_OBJECT_AGG":         "json_group_object",
+	"JSONB_OBJECT_AGG":        "json_group_object",
+	"JSONB_TYPEOF":            "json_typeof",
+	"JSONB_EXTRACT_PATH":      "json_extract_path",
+	"JSONB_EXTRACT_PATH_TEXT": "json_extract_path_text",
+	"TO_JSONB":                "to_json",
+}
+
+// isJSONTypeName returns true if the specified upper case column type name is JSON or JSONB.
+func isJSONTypeName(typeName string) bool {
+	return typeName == "JSON" || typeName == "JSONB"
+}
+
+// isJSONOperand returns true if the specified operand is a JSON column, a JSON function call, a parenthesized JSON operand
+// or the JSON value of the -> operator.
+func (tokens Tokens) isJSONOperand(columnTypeOf func(string) string) bool {
+	tokens = tokens.trimSpace()
+	if len(tokens) == 0 {
+		return false
+	}
+	if ope := tokens.Prev(tokens.operandBegin(len(tokens) - 1)); 0 <= ope && tokens[ope].IsOperator("->") {
+		return true
+	}
+	return tokens.isTypedOperand(jsonFunctionNames, isJSONTypeName, columnTypeOf)
+}
+
+// jsonConstraintTokens returns the CHECK constraint tokens which restrict the specified JSON column to the well-formed JSON texts.
+func jsonConstraintTokens(column string) Tokens {
+	return Tokens{
+		NewSpaceToken(), NewWordToken("CONSTRAINT"),
+		NewSpaceToken(), NewIdentifierToken(jsonConstraintPrefix + column),
+		NewSpaceToken(), NewWordToken("CHECK"), NewSpaceToken(), NewPunctuationToken("("),
+		NewWordToken("json_valid"), NewPunctuationToken("("), NewIdentifierToken(column), NewPunctuationToken(")"),
+		NewPunctuationToken(")"),
+	}
+}
+
+// JSONConstraintOf returns the column name of the specified CHECK constraint failure message of a JSON column
+// whose value is not a well-formed JSON text.
+func JSONConstraintOf(msg string) (string, bool) {
+	_, column, ok := strings.Cut(msg, jsonConstraintPrefix)
+	if !ok {
+		return "", false
+	}
+	// The failure message may be followed by the other messages.
+	if idx := strings.IndexAny(column, " \n"); 0 <= idx {
+		column = column[:idx]
+	}
+	return column, true
+}
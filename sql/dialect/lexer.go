@@ -0,0 +1,398 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MySQL :: MySQL 8.0 Reference Manual :: 11.1 Literal Values
+// https://dev.mysql.com/doc/refman/8.0/en/literals.html
+// PostgreSQL: Documentation: 16: 4.1. Lexical Structure
+// https://www.postgresql.org/docs/16/sql-syntax-lexical.html
+
+// operators lists multi-character operators in descending order of length.
+var operators = []string{
+	"->>", "#>>", "<=>",
+	"::", "->", "#>", "@>", "<@", "?|", "?&", "&&", "||", "<=", ">=", "<>", "!=", "==", "<<", ">>", "!~", "~*",
+}
+
+// Lexer represents a dialect aware SQL lexer.
+type Lexer struct {
+	dialect Dialect
+	src     []rune
+	pos     int
+}
+
+// NewLexerWith returns a new lexer for the specified dialect.
+func NewLexerWith(d Dialect) *Lexer {
+	return &Lexer{
+		dialect: d,
+		src:     nil,
+		pos:     0,
+	}
+}
+
+// Tokenize splits the specified query into tokens.
+func (lexer *Lexer) Tokenize(query string) (Tokens, error) {
+	lexer.src = []rune(query)
+	lexer.pos = 0
+	tokens := Tokens{}
+	for lexer.pos < len(lexer.src) {
+		tok, err := lexer.next()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, tok)
+	}
+	return tokens, nil
+}
+
+func (lexer *Lexer) peek(offset int) rune {
+	n := lexer.pos + offset
+	if n < 0 || len(lexer.src) <= n {
+		return 0
+	}
+	return lexer.src[n]
+}
+
+func (lexer *Lexer) token(t TokenType, begin int) *Token {
+	text := string(lexer.src[begin:lexer.pos])
+	return &Token{Type: t, Text: text, Value: text}
+}
+
+func (lexer *Lexer) next() (*Token, error) { // nolint:gocyclo
+	begin := lexer.pos
+	c := lexer.peek(0)
+	switch {
+	case unicode.IsSpace(c):
+		for unicode.IsSpace(lexer.peek(0)) {
+			lexer.pos++
+		}
+		return lexer.token(SpaceToken, begin), nil
+	case c == '-' && lexer.peek(1) == '-':
+		return lexer.lineComment(begin), nil
+	case c == '#' && lexer.dialect == MySQL:
+		lexer.pos++
+		tok := lexer.lineComment(begin)
+		tok.Text = "--" + tok.Text[1:]
+		return tok, nil
+	case c == '/' && lexer.peek(1) == '*':
+		lexer.pos += 2
+		for lexer.pos < len(lexer.src) && (lexer.peek(0) != '*' || lexer.peek(1) != '/') {
+			lexer.pos++
+		}
+		if len(lexer.src) <= lexer.pos {
+			return nil, newErrUnterminated("comment", begin)
+		}
+		lexer.pos += 2
+		return lexer.token(CommentToken, begin), nil
+	case c == '\'':
+		return lexer.quotedString(begin, lexer.dialect == MySQL)
+	case c == '"':
+		if lexer.dialect == MySQL {
+			return lexer.quotedString(begin, true)
+		}
+		return lexer.quotedIdentifier(begin, '"')
+	case c == '`':
+		return lexer.quotedIdentifier(begin, '`')
+	case (c == 'E' || c == 'e') && lexer.peek(1) == '\'' && lexer.dialect == PostgreSQL:
+		lexer.pos++
+		return lexer.escapeString(begin)
+	case c == '$' && lexer.dialect == PostgreSQL:
+		if unicode.IsDigit(lexer.peek(1)) {
+			lexer.pos++
+			for unicode.IsDigit(lexer.peek(0)) {
+				lexer.pos++
+			}
+			return lexer.token(ParameterToken, begin), nil
+		}
+		if tok, ok, err := lexer.dollarQuotedString(begin); ok {
+			return tok, err
+		}
+		lexer.pos++
+		return lexer.token(OperatorToken, begin), nil
+	case c == '?' && lexer.dialect != PostgreSQL:
+		lexer.pos++
+		for unicode.IsDigit(lexer.peek(0)) {
+			lexer.pos++
+		}
+		return lexer.token(ParameterToken, begin), nil
+	case c == ':' && isWordStart(lexer.peek(1)) && lexer.peek(-1) != ':':
+		lexer.pos++
+		for isWordPart(lexer.peek(0)) {
+			lexer.pos++
+		}
+		return lexer.token(ParameterToken, begin), nil
+	case unicode.IsDigit(c) || (c == '.' && unicode.IsDigit(lexer.peek(1))):
+		return lexer.number(begin), nil
+	case isWordStart(c) || (c == '@' && lexer.dialect == MySQL):
+		lexer.pos++
+		for isWordPart(lexer.peek(0)) || (lexer.peek(0) == '@' && lexer.dialect == MySQL) {
+			lexer.pos++
+		}
+		return lexer.token(WordToken, begin), nil
+	case strings.ContainsRune("(),;.[]", c):
+		lexer.pos++
+		return lexer.token(PunctuationToken, begin), nil
+	}
+	for _, ope := range operators {
+		if lexer.hasPrefix(ope) {
+			lexer.pos += len([]rune(ope))
+			return lexer.token(OperatorToken, begin), nil
+		}
+	}
+	lexer.pos++
+	return lexer.token(OperatorToken, begin), nil
+}
+
+func (lexer *Lexer) hasPrefix(s string) bool {
+	for n, r := range []rune(s) {
+		if lexer.peek(n) != r {
+			return false
+		}
+	}
+	return true
+}
+
+func (lexer *Lexer) lineComment(begin int) *Token {
+	for lexer.pos < len(lexer.src) && lexer.peek(0) != '\n' {
+		lexer.pos++
+	}
+	return lexer.token(CommentToken, begin)
+}
+
+func (lexer *Lexer) number(begin int) *Token {
+	if lexer.peek(0) == '0' && (lexer.peek(1) == 'x' || lexer.peek(1) == 'X') {
+		lexer.pos += 2
+		for unicode.Is(unicode.ASCII_Hex_Digit, lexer.peek(0)) {
+			lexer.pos++
+		}
+		return lexer.token(NumberToken, begin)
+	}
+	for unicode.IsDigit(lexer.peek(0)) {
+		lexer.pos++
+	}
+	if lexer.peek(0) == '.' && lexer.peek(1) != '.' {
+		lexer.pos++
+		for unicode.IsDigit(lexer.peek(0)) {
+			lexer.pos++
+		}
+	}
+	if c := lexer.peek(0); c == 'e' || c == 'E' {
+		sign := lexer.peek(1)
+		switch {
+		case unicode.IsDigit(sign):
+			lexer.pos++
+		case (sign == '+' || sign == '-') && unicode.IsDigit(lexer.peek(2)):
+			lexer.pos += 2
+		}
+		for unicode.IsDigit(lexer.peek(0)) {
+			lexer.pos++
+		}
+	}
+	return lexer.token(NumberToken, begin)
+}
+
+// quotedString reads a quoted string literal, and returns a token which is normalized into the SQLite representation.
+func (lexer *Lexer) quotedString(begin int, backslashEscapes bool) (*Token, error) {
+	quote := lexer.peek(0)
+	lexer.pos++
+	var b strings.Builder
+	for {
+		if len(lexer.src) <= lexer.pos {
+			return nil, newErrUnterminated("string", begin)
+		}
+		c := lexer.peek(0)
+		switch {
+		case c == quote && lexer.peek(1) == quote:
+			b.WriteRune(c)
+			lexer.pos += 2
+		case c == quote:
+			lexer.pos++
+			return NewStringToken(b.String()), nil
+		case c == '\\' && backslashEscapes:
+			b.WriteString(unescapeSequence(lexer.peek(1)))
+			lexer.pos += 2
+		default:
+			b.WriteRune(c)
+			lexer.pos++
+		}
+	}
+}
+
+// escapeString reads a PostgreSQL escape string constant such as E'\x41\101', and returns a token which is normalized into
+// the SQLite representation. The octal and hexadecimal escapes specify the bytes of the UTF-8 string, and the other characters
+// which follow the backslashes such as \Z are taken literally.
+func (lexer *Lexer) escapeString(begin int) (*Token, error) {
+	lexer.pos++
+	var b []byte
+	for {
+		if len(lexer.src) <= lexer.pos {
+			return nil, newErrUnterminated("string", begin)
+		}
+		c := lexer.peek(0)
+		switch {
+		case c == '\'' && lexer.peek(1) == '\'':
+			b = append(b, '\'')
+			lexer.pos += 2
+			continue
+		case c == '\'':
+			lexer.pos++
+			if !utf8.Valid(b) || bytes.IndexByte(b, 0) != -1 {
+				return nil, newErrInvalid(fmt.Sprintf("escape string at %d", begin))
+			}
+			return NewStringToken(string(b)), nil
+		case c != '\\':
+			b = utf8.AppendRune(b, c)
+			lexer.pos++
+			continue
+		}
+		lexer.pos++
+		c = lexer.peek(0)
+		lexer.pos++
+		switch c {
+		case 'b':
+			b = append(b, '\b')
+		case 'f':
+			b = append(b, '\f')
+		case 'n':
+			b = append(b, '\n')
+		case 'r':
+			b = append(b, '\r')
+		case 't':
+			b = append(b, '\t')
+		case 'x':
+			v, n := lexer.escapedNumber(16, 2)
+			if n == 0 {
+				b = append(b, 'x')
+				continue
+			}
+			b = append(b, byte(v))
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			lexer.pos--
+			v, _ := lexer.escapedNumber(8, 3)
+			b = append(b, byte(v))
+		case 'u', 'U':
+			n := 4
+			if c == 'U' {
+				n = 8
+			}
+			v, digits := lexer.escapedNumber(16, n)
+			if digits != n || !utf8.ValidRune(rune(v)) {
+				return nil, newErrInvalid(fmt.Sprintf("Unicode escape at %d", begin))
+			}
+			b = utf8.AppendRune(b, rune(v))
+		default:
+			b = utf8.AppendRune(b, c)
+		}
+	}
+}
+
+// escapedNumber reads the digits of the specified base up to the specified count, and returns the value and the number of the read digits.
+func (lexer *Lexer) escapedNumber(base int, count int) (int, int) {
+	v := 0
+	n := 0
+	for ; n < count; n++ {
+		d := strings.IndexRune("0123456789abcdef"[:base], unicode.ToLower(lexer.peek(0)))
+		if d < 0 {
+			break
+		}
+		v = v*base + d
+		lexer.pos++
+	}
+	return v, n
+}
+
+// quotedIdentifier reads a quoted identifier, and returns a token which is normalized into the SQLite representation.
+func (lexer *Lexer) quotedIdentifier(begin int, quote rune) (*Token, error) {
+	lexer.pos++
+	var b strings.Builder
+	for {
+		if len(lexer.src) <= lexer.pos {
+			return nil, newErrUnterminated("identifier", begin)
+		}
+		c := lexer.peek(0)
+		switch {
+		case c == quote && lexer.peek(1) == quote:
+			b.WriteRune(c)
+			lexer.pos += 2
+		case c == quote:
+			lexer.pos++
+			return NewIdentifierToken(b.String()), nil
+		default:
+			b.WriteRune(c)
+			lexer.pos++
+		}
+	}
+}
+
+// dollarQuotedString reads a PostgreSQL dollar-quoted string constant such as $$...$$ or $tag$...$tag$.
+func (lexer *Lexer) dollarQuotedString(begin int) (*Token, bool, error) {
+	end := lexer.pos + 1
+	for end < len(lexer.src) && isWordPart(lexer.src[end]) && lexer.src[end] != '$' {
+		end++
+	}
+	if len(lexer.src) <= end || lexer.src[end] != '$' {
+		return nil, false, nil
+	}
+	tag := string(lexer.src[lexer.pos : end+1])
+	body := end + 1
+	idx := strings.Index(string(lexer.src[body:]), tag)
+	if idx < 0 {
+		return nil, true, newErrUnterminated("dollar-quoted string", begin)
+	}
+	s := string(lexer.src[body:])[:idx]
+	lexer.pos = body + len([]rune(s)) + len([]rune(tag))
+	return NewStringToken(s), true, nil
+}
+
+// unescapeSequence returns the characters of the backslash escape sequence of the specified character.
+// \% and \_ are kept with the backslash as MySQL does because they are the escaped wildcards of the LIKE patterns.
+func unescapeSequence(c rune) string {
+	switch c {
+	case '0':
+		return "\x00"
+	case 'b':
+		return "\b"
+	case 'n':
+		return "\n"
+	case 'r':
+		return "\r"
+	case 't':
+		return "\t"
+	case 'Z':
+		return "\x1A"
+	case '%', '_':
+		return "\\" + string(c)
+	}
+	return string(c)
+}
+
+func isWordStart(c rune) bool {
+	return c == '_' || unicode.IsLetter(c)
+}
+
+func isWordPart(c rune) bool {
+	return c == '_' || c == '$' || unicode.IsLetter(c) || unicode.IsDigit(c)
+}
+
+func newErrUnterminated(obj string, pos int) error {
+	return fmt.Errorf("unterminated %s at %d : %w", obj, pos, ErrInvalid)
+}
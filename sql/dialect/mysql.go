@@ -0,0 +1,405 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+// MySQL :: MySQL 8.0 Reference Manual :: 15.2.7 INSERT Statement
+// https://dev.mysql.com/doc/refman/8.0/en/insert.html
+// SQLite: INSERT
+// https://www.sqlite.org/lang_insert.html
+// SQLite: UPSERT
+// https://www.sqlite.org/lang_upsert.html
+
+import (
+	"slices"
+	"strconv"
+)
+
+// NewMySQLRewriter returns a rewriter which translates MySQL queries into SQLite queries.
+// Backtick identifiers, double-quoted strings, backslash escapes and hash comments are normalized by the lexer.
+func NewMySQLRewriter() Rewriter {
+	return newRewriterWith(MySQL,
+		mysqlParameterRule,
+		mysqlStringConcatRule,
+		mysqlInsertRule,
+		decimalLiteralRule,
+		mysqlInsertSetRule,
+		mysqlOnDuplicateKeyUpdateRule,
+		mysqlLimitRule,
+		mysqlOrderedModificationRule,
+		mysqlNullSafeEqualRule,
+		mysqlDivRule,
+		mysqlLikeEscapeRule,
+		mysqlCreateTableRule,
+		createTableRule,
+		mysqlIntervalRule,
+		mysqlUnitArgumentRule,
+		mysqlGroupConcatRule,
+		mysqlConvertRule,
+		functionNameRule(mysqlFunctionNames),
+		decimalCastRule(MySQL),
+		extractRule,
+		lockingReadRule,
+	)
+}
+
+// mysqlParameterRule rewrites the anonymous parameters into the SQLite numbered parameters such as ?1 in the order of appearance,
+// so that the other rules are able to move the parameters without changing the bound arguments.
+func mysqlParameterRule(tokens Tokens) (Tokens, error) {
+	number := 0
+	for n, tok := range tokens {
+		if tok.Type != ParameterToken || tok.Text != "?" {
+			continue
+		}
+		number++
+		tokens[n] = &Token{Type: ParameterToken, Text: "?" + strconv.Itoa(number), Value: tok.Value}
+	}
+	return tokens, nil
+}
+
+// mysqlInsertRule rewrites the INSERT and REPLACE modifiers into the SQLite conflict clauses.
+// INSERT [LOW_PRIORITY | DELAYED | HIGH_PRIORITY] [IGNORE] [INTO] is rewritten into INSERT [OR IGNORE] INTO,
+// and REPLACE [LOW_PRIORITY | DELAYED] [INTO] is rewritten into INSERT OR REPLACE INTO.
+func mysqlInsertRule(tokens Tokens) (Tokens, error) {
+	begin := tokens.Next(-1)
+	isReplace := tokens.IsKeywordAt(begin, "REPLACE")
+	if !isReplace && !tokens.IsKeywordAt(begin, "INSERT") {
+		return tokens, nil
+	}
+	end := begin
+	isIgnore := false
+	for next := tokens.Next(end); ; next = tokens.Next(end) {
+		switch {
+		case tokens.IsKeywordAt(next, "LOW_PRIORITY"), tokens.IsKeywordAt(next, "DELAYED"), tokens.IsKeywordAt(next, "HIGH_PRIORITY"):
+			end = next
+			continue
+		case tokens.IsKeywordAt(next, "IGNORE"):
+			isIgnore = true
+			end = next
+			continue
+		case tokens.IsKeywordAt(next, "INTO"):
+			end = next
+		}
+		break
+	}
+	keywords := []string{"INSERT"}
+	switch {
+	case isReplace:
+		keywords = append(keywords, "OR", "REPLACE")
+	case isIgnore:
+		keywords = append(keywords, "OR", "IGNORE")
+	}
+	keywords = append(keywords, "INTO")
+	tokens = tokens.Splice(begin, end, keywordTokens(keywords...)...)
+	// MySQL accepts VALUE as a synonym for VALUES only after the table name or the column list,
+	// and the other VALUE tokens are the column names.
+	if _, _, idx, ok := tokens.insertColumnsAt(begin); ok && tokens.IsKeywordAt(idx, "VALUE") {
+		tokens[idx] = NewWordToken("VALUES")
+	}
+	return tokens, nil
+}
+
+// mysqlInsertSetRule rewrites INSERT ... SET col = expr, ... into INSERT ... (col, ...) VALUES (expr, ...).
+func mysqlInsertSetRule(tokens Tokens) (Tokens, error) {
+	begin := tokens.Next(-1)
+	if !tokens.IsKeywordAt(begin, "INSERT") {
+		return tokens, nil
+	}
+	set := tokens.indexTopLevelKeyword(begin, "SET")
+	if set < 0 {
+		return tokens, nil
+	}
+	for _, keyword := range []string{"VALUES", "SELECT", "ON"} {
+		if idx := tokens.indexTopLevelKeyword(begin, keyword); 0 <= idx && idx < set {
+			return tokens, nil
+		}
+	}
+	end := len(tokens) - 1
+	if idx := tokens.indexTopLevelKeyword(set, "ON"); 0 <= idx {
+		end = tokens.Prev(idx)
+	}
+	columns := Tokens{NewPunctuationToken("(")}
+	values := Tokens{NewPunctuationToken("(")}
+	for _, assignment := range tokens[tokens.Next(set) : end+1].splitTopLevel(",") {
+		eq := -1
+		for n, tok := range assignment {
+			if tok.IsOperator("=") {
+				eq = n
+				break
+			}
+		}
+		if eq < 0 {
+			return nil, newErrInvalid(assignment.String())
+		}
+		if 1 < len(columns) {
+			columns = append(columns, NewPunctuationToken(","), NewSpaceToken())
+			values = append(values, NewPunctuationToken(","), NewSpaceToken())
+		}
+		columns = append(columns, assignment[:eq].trimSpace()...)
+		values = append(values, assignment[eq+1:].trimSpace()...)
+	}
+	columns = append(columns, NewPunctuationToken(")"), NewSpaceToken(), NewWordToken("VALUES"), NewSpaceToken())
+	values = append(values, NewPunctuationToken(")"))
+	return tokens.Splice(set, end, append(columns, values...)...), nil
+}
+
+// mysqlOnDuplicateKeyUpdateRule rewrites ON DUPLICATE KEY UPDATE into the SQLite upsert clause.
+// VALUES(col) references and row alias references such as new.col are rewritten into excluded.col.
+func mysqlOnDuplicateKeyUpdateRule(tokens Tokens) (Tokens, error) {
+	idx := tokens.indexTopLevelKeyword(0, "ON")
+	for 0 <= idx && !tokens.IsKeywordsAt(idx, "ON", "DUPLICATE", "KEY", "UPDATE") {
+		idx = tokens.indexTopLevelKeyword(idx+1, "ON")
+	}
+	if idx < 0 {
+		return tokens, nil
+	}
+	update := tokens.Next(tokens.Next(tokens.Next(idx)))
+	assignments := append(Tokens{}, tokens[update+1:]...)
+
+	// Rewrite the row alias of VALUES (...) AS alias.
+	alias := ""
+	if 0 <= tokens.indexTopLevelKeyword(0, "VALUES") {
+		prev := tokens.Prev(idx)
+		if tokens[prev].IsPunctuation(")") {
+			open := tokens.MatchingOpenParen(prev)
+			if name := tokens.Prev(open); 0 <= name && tokens[name].IsName() && tokens.IsKeywordAt(tokens.Prev(name), "AS") {
+				return nil, newErrNotSupported("row alias with column aliases")
+			}
+		}
+		if tokens[prev].IsName() && tokens.IsKeywordAt(tokens.Prev(prev), "AS") {
+			alias = tokens[prev].Value
+			as := tokens.leadingSpaceBegin(tokens.Prev(prev))
+			tokens = tokens.Splice(as, prev)
+			idx -= (prev - as + 1)
+		}
+	}
+
+	upsert := append(upsertGuardTokens(tokens, idx), keywordTokens("ON", "CONFLICT", "DO", "UPDATE", "SET")...)
+
+	for n := 0; n < len(assignments); n++ {
+		tok := assignments[n]
+		switch {
+		case tok.IsKeyword("VALUES"):
+			open := assignments.Next(n)
+			if open < 0 || !assignments[open].IsPunctuation("(") {
+				continue
+			}
+			name := assignments.Next(open)
+			closing := assignments.Next(name)
+			if name < 0 || !assignments[name].IsName() || closing < 0 || !assignments[closing].IsPunctuation(")") {
+				return nil, newErrInvalid(assignments[n:].String())
+			}
+			assignments = assignments.Splice(n, closing, excludedTokens(assignments[name])...)
+		case alias != "" && tok.IsName() && tok.Value == alias:
+			dot := assignments.Next(n)
+			if dot < 0 || !assignments[dot].IsPunctuation(".") {
+				continue
+			}
+			if prev := assignments.Prev(n); 0 <= prev && assignments[prev].IsPunctuation(".") {
+				continue
+			}
+			name := assignments.Next(dot)
+			assignments = assignments.Splice(n, name, excludedTokens(assignments[name])...)
+		}
+	}
+
+	stmt := append(Tokens{}, tokens[:idx]...)
+	stmt = append(stmt, upsert...)
+	stmt = append(stmt, assignments...)
+	return stmt, nil
+}
+
+// mysqlLimitRule rewrites LIMIT offset, count into LIMIT count OFFSET offset.
+// The bind parameters are swapped as well because they are numbered by mysqlParameterRule, and the numbers keep the binding order.
+func mysqlLimitRule(tokens Tokens) (Tokens, error) {
+	isLimitValue := func(tok *Token) bool {
+		return tok.Type == NumberToken || tok.Type == ParameterToken
+	}
+	for n := 0; n < len(tokens); n++ {
+		if !tokens[n].IsKeyword("LIMIT") {
+			continue
+		}
+		offset := tokens.Next(n)
+		comma := tokens.Next(offset)
+		count := tokens.Next(comma)
+		if offset < 0 || comma < 0 || count < 0 {
+			continue
+		}
+		if !isLimitValue(tokens[offset]) || !tokens[comma].IsPunctuation(",") || !isLimitValue(tokens[count]) {
+			continue
+		}
+		tokens = tokens.Splice(offset, count,
+			tokens[count], NewSpaceToken(), NewWordToken("OFFSET"), NewSpaceToken(), tokens[offset])
+	}
+	return tokens, nil
+}
+
+// mysqlStringConcatRule concatenates the adjacent string literals such as 'a' 'b' into a string literal as MySQL does.
+func mysqlStringConcatRule(tokens Tokens) (Tokens, error) {
+	for n := 0; n < len(tokens); n++ {
+		if tokens[n].Type != StringToken {
+			continue
+		}
+		next := tokens.Next(n)
+		for 0 <= next && tokens[next].Type == StringToken {
+			tokens = tokens.Splice(n, next, NewStringToken(tokens[n].Value+tokens[next].Value))
+			next = tokens.Next(n)
+		}
+	}
+	return tokens, nil
+}
+
+// mysqlOrderedModificationRule rewrites UPDATE and DELETE with ORDER BY or LIMIT into the statements which modify the rows
+// selected by the rowid subquery such as DELETE FROM t WHERE rowid IN (SELECT rowid FROM t WHERE ... ORDER BY ... LIMIT ...),
+// because SQLite is not built with SQLITE_ENABLE_UPDATE_DELETE_LIMIT.
+func mysqlOrderedModificationRule(tokens Tokens) (Tokens, error) {
+	begin := tokens.Next(-1)
+	var tableBegin, tableEnd int
+	switch {
+	case tokens.IsKeywordAt(begin, "UPDATE"):
+		tableBegin = tokens.Next(begin)
+		set := tokens.indexTopLevelKeyword(begin, "SET")
+		if set < 0 {
+			return tokens, nil
+		}
+		tableEnd = tokens.Prev(set)
+	case tokens.IsKeywordAt(begin, "DELETE"):
+		from := tokens.indexTopLevelKeyword(begin, "FROM")
+		if from < 0 {
+			return tokens, nil
+		}
+		tableBegin = tokens.Next(from)
+	default:
+		return tokens, nil
+	}
+	clause := tokens.indexTopLevelKeyword(begin, "ORDER")
+	if clause < 0 || !tokens.IsKeywordsAt(clause, "ORDER", "BY") {
+		clause = tokens.indexTopLevelKeyword(begin, "LIMIT")
+	}
+	if clause < 0 {
+		return tokens, nil
+	}
+	condEnd := tokens.Prev(clause)
+	where := tokens.indexTopLevelKeyword(begin, "WHERE")
+	if where < 0 || clause < where {
+		where = -1
+	}
+	if tokens.IsKeywordAt(begin, "DELETE") {
+		tableEnd = condEnd
+		if 0 <= where {
+			tableEnd = tokens.Prev(where)
+		}
+	}
+	// The modifiers such as LOW_PRIORITY and IGNORE are not the table references.
+	for tokens.IsKeywordAt(tableBegin, "LOW_PRIORITY") || tokens.IsKeywordAt(tableBegin, "QUICK") || tokens.IsKeywordAt(tableBegin, "IGNORE") {
+		tableBegin = tokens.Next(tableBegin)
+	}
+	if tableBegin < 0 || tableEnd < tableBegin {
+		return nil, newErrInvalid(tokens.String())
+	}
+	subquery := append(keywordTokens("WHERE", "rowid", "IN"), NewSpaceToken(), NewPunctuationToken("("))
+	subquery = append(subquery, keywordTokens("SELECT", "rowid", "FROM")...)
+	subquery = append(subquery, NewSpaceToken())
+	subquery = append(subquery, tokens[tableBegin:tableEnd+1]...)
+	subquery = append(subquery, NewSpaceToken())
+	subqueryBegin := clause
+	if 0 <= where {
+		subqueryBegin = where
+	}
+	subquery = append(subquery, tokens[subqueryBegin:].trimSpace()...)
+	subquery = append(subquery, NewPunctuationToken(")"))
+	return tokens.Splice(subqueryBegin, len(tokens)-1, subquery...), nil
+}
+
+// mysqlNullSafeEqualRule rewrites the NULL-safe equal operator <=> into IS which has the same precedence in SQLite.
+func mysqlNullSafeEqualRule(tokens Tokens) (Tokens, error) {
+	for n := 0; n < len(tokens); n++ {
+		if !tokens[n].IsOperator("<=>") {
+			continue
+		}
+		is := Tokens{NewWordToken("IS")}
+		if n+1 < len(tokens) && tokens[n+1].Type != SpaceToken {
+			is = append(is, NewSpaceToken())
+		}
+		if 0 < n && tokens[n-1].Type != SpaceToken {
+			is = append(Tokens{NewSpaceToken()}, is...)
+		}
+		tokens = tokens.Splice(n, n, is...)
+	}
+	return tokens, nil
+}
+
+// mysqlDivRule rewrites the integer division a DIV b into CAST(a / b AS INTEGER) which truncates the quotient toward zero as MySQL does.
+// The left operand includes the preceding multiplicative operators which have the same precedence.
+func mysqlDivRule(tokens Tokens) (Tokens, error) {
+	isMultiplicativeOperatorAt := func(n int) bool {
+		return 0 <= n && tokens[n].Type == OperatorToken && slices.Contains([]string{"*", "/", "%"}, tokens[n].Text) && tokens.isOperandEndAt(tokens.Prev(n))
+	}
+	for n := 0; n < len(tokens); n++ {
+		if !tokens[n].IsKeyword("DIV") {
+			continue
+		}
+		leftEnd := tokens.Prev(n)
+		rightBegin := tokens.Next(n)
+		if !tokens.isOperandEndAt(leftEnd) || !tokens.isOperandBeginAt(rightBegin) {
+			return nil, newErrInvalid(tokens.String())
+		}
+		leftBegin := tokens.operandBegin(leftEnd)
+		for ope := tokens.Prev(leftBegin); isMultiplicativeOperatorAt(ope); ope = tokens.Prev(leftBegin) {
+			leftBegin = tokens.operandBegin(tokens.Prev(ope))
+		}
+		if ope := tokens.Prev(leftBegin); 0 <= ope && (tokens[ope].IsOperator("-") || tokens[ope].IsOperator("+")) && !tokens.isOperandEndAt(tokens.Prev(ope)) {
+			// The unary operators bind more tightly than the binary operators.
+			leftBegin = ope
+		}
+		rightEnd := tokens.operandEnd(rightBegin)
+		cast := Tokens{NewWordToken("CAST"), NewPunctuationToken("(")}
+		cast = append(cast, tokens[leftBegin:leftEnd+1]...)
+		cast = append(cast, NewSpaceToken(), NewOperatorToken("/"), NewSpaceToken())
+		cast = append(cast, tokens[rightBegin:rightEnd+1]...)
+		cast = append(cast, NewSpaceToken())
+		cast = append(cast, keywordTokens("AS", "INTEGER")...)
+		cast = append(cast, NewPunctuationToken(")"))
+		tokens = tokens.Splice(leftBegin, rightEnd, cast...)
+		n = leftBegin
+	}
+	return tokens, nil
+}
+
+// mysqlLikeEscapeRule appends ESCAPE '\\' to the LIKE patterns which have no ESCAPE clause, because the backslash is
+// the default escape character of MySQL and SQLite has no default escape character.
+func mysqlLikeEscapeRule(tokens Tokens) (Tokens, error) {
+	for n := 0; n < len(tokens); n++ {
+		if !tokens[n].IsKeyword("LIKE") {
+			continue
+		}
+		begin := tokens.Next(n)
+		if !tokens.isOperandBeginAt(begin) {
+			continue
+		}
+		end := tokens.operandEnd(begin)
+		if tokens.IsKeywordAt(tokens.Next(end), "ESCAPE") {
+			continue
+		}
+		escape := append(Tokens{NewSpaceToken()}, keywordTokens("ESCAPE")...)
+		escape = append(escape, NewSpaceToken(), NewStringToken("\\"))
+		tokens = tokens.Splice(end+1, end, escape...)
+	}
+	return tokens, nil
+}
+
+// excludedTokens returns the tokens which refer to the specified column of the SQLite excluded table.
+func excludedTokens(name *Token) Tokens {
+	return Tokens{NewWordToken("excluded"), NewPunctuationToken("."), name}
+}
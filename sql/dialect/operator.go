@@ -0,0 +1,212 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+// PostgreSQL: Documentation: 16: 9.16. JSON Functions and Operators
+// https://www.postgresql.org/docs/16/functions-json.html
+// PostgreSQL: Documentation: 16: 9.19. Array Functions and Operators
+// https://www.postgresql.org/docs/16/functions-array.html
+
+import (
+	"slices"
+	"strings"
+)
+
+// postgresqlOperators maps the PostgreSQL JSON and array operators into the functions which are registered by the function package.
+// The -> and ->> operators are executed by SQLite as they are, and the containment operators accept both JSON values and arrays.
+var postgresqlOperators = map[string]struct {
+	name      string
+	isSwapped bool
+}{
+	"#>":  {name: "json_extract_path_op"},
+	"#>>": {name: "json_extract_path_text_op"},
+	"@>":  {name: "contains_op"},
+	"<@":  {name: "contains_op", isSwapped: true},
+	"&&":  {name: "arrayoverlap"},
+	"?":   {name: "jsonb_exists"},
+	"?|":  {name: "jsonb_exists_any"},
+	"?&":  {name: "jsonb_exists_all"},
+}
+
+// postgresqlOperatorRule rewrites the JSON and array operators such as doc #> '{a,b}' and tags && '{a,b}' into the function calls.
+// The operators are left-associative and bind more tightly than the comparison operators.
+func postgresqlOperatorRule(tokens Tokens) (Tokens, error) {
+	for n := 0; n < len(tokens); n++ {
+		if tokens[n].Type != OperatorToken {
+			continue
+		}
+		ope, ok := postgresqlOperators[tokens[n].Text]
+		if !ok {
+			continue
+		}
+		leftEnd := tokens.Prev(n)
+		rightBegin := tokens.Next(n)
+		if leftEnd < 0 || rightBegin < 0 {
+			return nil, newErrInvalid(tokens.String())
+		}
+		leftBegin := tokens.jsonOperandBegin(leftEnd)
+		rightEnd := tokens.operandEnd(rightBegin)
+		left := append(Tokens{}, tokens[leftBegin:leftEnd+1]...)
+		right := append(Tokens{}, tokens[rightBegin:rightEnd+1]...)
+		if ope.isSwapped {
+			left, right = right, left
+		}
+		call := Tokens{NewWordToken(ope.name), NewPunctuationToken("(")}
+		call = append(call, left...)
+		call = append(call, NewPunctuationToken(","), NewSpaceToken())
+		call = append(call, right...)
+		call = append(call, NewPunctuationToken(")"))
+		tokens = tokens.Splice(leftBegin, rightEnd, call...)
+		n = leftBegin
+	}
+	return tokens, nil
+}
+
+// jsonOperandBegin returns the begin index of the left operand which ends at the specified index,
+// and the operand includes the preceding -> and ->> operators because they have the same precedence.
+func (tokens Tokens) jsonOperandBegin(end int) int {
+	begin := tokens.operandBegin(end)
+	for {
+		ope := tokens.Prev(begin)
+		if ope < 0 || (!tokens[ope].IsOperator("->") && !tokens[ope].IsOperator("->>")) {
+			return begin
+		}
+		operandEnd := tokens.Prev(ope)
+		if operandEnd < 0 {
+			return begin
+		}
+		begin = tokens.operandBegin(operandEnd)
+	}
+}
+
+// operandEnd returns the end index of the primary expression which begins at the specified index.
+func (tokens Tokens) operandEnd(begin int) int {
+	end := begin
+	if tokens[end].IsOperator("-") || tokens[end].IsOperator("+") {
+		if next := tokens.Next(end); 0 <= next {
+			end = next
+		}
+	}
+	for {
+		next := tokens.Next(end)
+		switch {
+		case tokens[end].IsPunctuation("("):
+			if closing := tokens.MatchingParen(end); 0 <= closing {
+				return closing
+			}
+			return end
+		case tokens[end].IsName() && 0 <= next && tokens[next].IsPunctuation("(") && !reservedKeywords[strings.ToUpper(tokens[end].Text)]:
+			end = next
+		case tokens[end].IsName() && 0 <= next && tokens[next].IsPunctuation("."):
+			name := tokens.Next(next)
+			if name < 0 || !tokens[name].IsName() {
+				return end
+			}
+			end = name
+		default:
+			return end
+		}
+	}
+}
+
+// ConcatExpressions rewrites the concatenation operators of the specified SQLite statement whose operands are arrays or JSON values
+// into the functions, because SQLite concatenates them as the strings. arr || arr, arr || elem and elem || arr are rewritten into
+// array_cat(), array_append() and array_prepend(), and doc || doc is rewritten into jsonb_concat(). The string literals such as '{1,2}'
+// are concatenated as the arrays or the JSON values with the array or JSON operands as PostgreSQL does. The array and JSON operands are
+// the columns whose upper case declared types which the specified function returns are the array or JSON types and the results of the functions.
+func ConcatExpressions(stmt string, columnTypeOf func(string) string) (string, error) {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return "", err
+	}
+	// The arithmetic operators bind more tightly than the concatenation operators in PostgreSQL.
+	arithmetic := []string{"+", "-", "*", "/", "%"}
+	isBinaryOperatorAt := func(n int, operators []string) bool {
+		return 0 <= n && tokens[n].Type == OperatorToken && slices.Contains(operators, tokens[n].Text) && tokens.isOperandEndAt(tokens.Prev(n)) && tokens.isOperandBeginAt(tokens.Next(n))
+	}
+	for n := 0; n < len(tokens); n++ {
+		if !isBinaryOperatorAt(n, []string{"||"}) {
+			continue
+		}
+		leftBegin := tokens.jsonOperandBegin(tokens.Prev(n))
+		for ope := tokens.Prev(leftBegin); isBinaryOperatorAt(ope, arithmetic); ope = tokens.Prev(leftBegin) {
+			leftBegin = tokens.operandBegin(tokens.Prev(ope))
+		}
+		rightEnd := tokens.operandEnd(tokens.Next(n))
+		for ope := tokens.Next(rightEnd); isBinaryOperatorAt(ope, arithmetic); ope = tokens.Next(rightEnd) {
+			rightEnd = tokens.operandEnd(tokens.Next(ope))
+		}
+		left := append(Tokens{}, tokens[leftBegin:tokens.Prev(n)+1]...)
+		right := append(Tokens{}, tokens[tokens.Next(n):rightEnd+1]...)
+		isLeftArray, isRightArray := left.isArrayOperand(columnTypeOf), right.isArrayOperand(columnTypeOf)
+		isLeftJSON, isRightJSON := left.isJSONOperand(columnTypeOf), right.isJSONOperand(columnTypeOf)
+		var name string
+		switch {
+		case (isLeftArray && (isRightArray || right.isArrayLiteral())) || (isRightArray && left.isArrayLiteral()):
+			name = "array_cat"
+		case isLeftArray:
+			name = "array_append"
+		case isRightArray:
+			name = "array_prepend"
+		case (isLeftJSON && (isRightJSON || right.isStringLiteral())) || (isRightJSON && left.isStringLiteral()):
+			name = "jsonb_concat"
+		default:
+			continue
+		}
+		call := Tokens{NewWordToken(name), NewPunctuationToken("(")}
+		call = append(call, left...)
+		call = append(call, NewPunctuationToken(","), NewSpaceToken())
+		call = append(call, right...)
+		call = append(call, NewPunctuationToken(")"))
+		tokens = tokens.Splice(leftBegin, rightEnd, call...)
+		n = leftBegin
+	}
+	return tokens.String(), nil
+}
+
+// isTypedOperand returns true if the specified operand is a column whose upper case declared type is the type for which the specified function returns true,
+// a call of the specified lower case function names or a parenthesized operand of them.
+func (tokens Tokens) isTypedOperand(functionNames map[string]bool, isTypeName func(string) bool, columnTypeOf func(string) string) bool {
+	tokens = tokens.trimSpace()
+	if len(tokens) == 0 {
+		return false
+	}
+	last := len(tokens) - 1
+	if tokens[0].IsPunctuation("(") {
+		return tokens.MatchingParen(0) == last && tokens[1:last].isTypedOperand(functionNames, isTypeName, columnTypeOf)
+	}
+	if open := tokens.Next(0); 0 <= open && tokens[open].IsPunctuation("(") {
+		return tokens[0].IsName() && functionNames[strings.ToLower(tokens[0].Value)] && tokens.MatchingParen(open) == last
+	}
+	// The column names may be qualified with the table names.
+	for idx, n := 0, 0; 0 <= n; idx, n = idx+1, tokens.Next(n) {
+		isName := idx%2 == 0 && tokens[n].IsName() && !reservedKeywords[strings.ToUpper(tokens[n].Text)]
+		if !isName && (idx%2 == 0 || !tokens[n].IsPunctuation(".")) {
+			return false
+		}
+	}
+	return tokens[last].IsName() && isTypeName(columnTypeOf(tokens[last].Value))
+}
+
+// isStringLiteral returns true if the specified operand is a string literal.
+func (tokens Tokens) isStringLiteral() bool {
+	tokens = tokens.trimSpace()
+	return len(tokens) == 1 && tokens[0].Type == StringToken
+}
+
+// isArrayLiteral returns true if the specified operand is a string literal of an array literal such as '{1,2}'.
+func (tokens Tokens) isArrayLiteral() bool {
+	return tokens.isStringLiteral() && strings.HasPrefix(strings.TrimSpace(tokens.trimSpace()[0].Value), "{")
+}
@@ -0,0 +1,277 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+// SQLite: Parameters
+// https://www.sqlite.org/lang_expr.html#varparam
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Parameter represents a bind parameter which is assigned to or compared with a table column.
+type Parameter struct {
+	// Number is the parameter number which starts from 1.
+	Number int
+	// Table is the table name of the column.
+	Table string
+	// Column is the column name, or an empty string if INSERT has no column list.
+	Column string
+	// Index is the index of the column in the table columns if INSERT has no column list, otherwise -1.
+	Index int
+}
+
+// parameterComparisonOperators represents the comparison operators whose operands are inferred from the other operands.
+var parameterComparisonOperators = []string{"=", "==", "<>", "!=", "<", "<=", ">", ">="}
+
+// tableAliasStopKeywords represents the keywords which follow the table names and are not the table aliases.
+var tableAliasStopKeywords = []string{
+	"WHERE", "SET", "VALUES", "DEFAULT", "SELECT", "RETURNING", "ON", "USING", "JOIN", "INNER", "LEFT", "RIGHT",
+	"FULL", "CROSS", "NATURAL", "GROUP", "HAVING", "ORDER", "LIMIT", "OFFSET", "WINDOW", "UNION", "EXCEPT",
+	"INTERSECT", "INDEXED", "NOT",
+}
+
+// Parameters returns the bind parameters of the specified SQLite statement whose columns are inferred from the VALUES rows of INSERT,
+// the SET clause of UPDATE and the comparisons such as col = ?, col LIKE ?, col IN (?, ?) and col BETWEEN ? AND ?.
+// The parameters are numbered as SQLite does, and the parameters whose columns are not inferred are not included.
+func Parameters(stmt string) []*Parameter {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return nil
+	}
+	numbers := tokens.parameterNumbers()
+	if len(numbers) == 0 {
+		return nil
+	}
+	tblName, aliases := tokens.parameterTables()
+	params := tokens.insertParameters(numbers)
+	for n, tok := range tokens {
+		number, ok := numbers[tok]
+		if !ok {
+			continue
+		}
+		column := tokens.comparedColumnIndex(n)
+		if column < 0 {
+			continue
+		}
+		param := &Parameter{Number: number, Table: tblName, Column: tokens[column].Value, Index: -1}
+		if dot := tokens.Prev(column); 0 <= dot && tokens[dot].IsPunctuation(".") {
+			if qualifier := tokens.Prev(dot); 0 <= qualifier && tokens[qualifier].IsName() {
+				param.Table = tokens[qualifier].Value
+				if tblName, ok := aliases[strings.ToLower(param.Table)]; ok {
+					param.Table = tblName
+				}
+			}
+		}
+		if param.Table == "" {
+			continue
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// parameterNumbers returns the numbers of the parameter tokens. The parameters without numbers are numbered one greater than
+// the largest number so far, and the named parameters of the same name have the same number as SQLite does.
+func (tokens Tokens) parameterNumbers() map[*Token]int {
+	numbers := map[*Token]int{}
+	names := map[string]int{}
+	maxNumber := 0
+	for _, tok := range tokens {
+		if tok.Type != ParameterToken {
+			continue
+		}
+		var number int
+		switch {
+		case tok.Text == "?":
+			number = maxNumber + 1
+		case strings.HasPrefix(tok.Text, "?"):
+			var err error
+			number, err = strconv.Atoi(tok.Text[1:])
+			if err != nil {
+				continue
+			}
+		default:
+			number = names[tok.Text]
+			if number == 0 {
+				number = maxNumber + 1
+				names[tok.Text] = number
+			}
+		}
+		numbers[tok] = number
+		maxNumber = max(maxNumber, number)
+	}
+	return numbers
+}
+
+// parameterTables returns the table name of the statement and the table names of the aliases which are declared in the statement.
+func (tokens Tokens) parameterTables() (string, map[string]string) {
+	tblName := ""
+	aliases := map[string]string{}
+	for _, end := range tokens.tableReferenceIndexes() {
+		if tblName == "" {
+			tblName = tokens[end].Value
+		}
+		alias := tokens.tableAliasIndexAfter(end)
+		if alias < 0 {
+			continue
+		}
+		aliases[strings.ToLower(tokens[alias].Value)] = tokens[end].Value
+	}
+	return tblName, aliases
+}
+
+// tableReferenceIndexes returns the indexes of the table names which follow FROM, JOIN, INTO and UPDATE
+// including the comma separated table names such as FROM t1, t2.
+func (tokens Tokens) tableReferenceIndexes() []int {
+	idxes := []int{}
+	for n, tok := range tokens {
+		switch {
+		case tok.IsKeyword("FROM"), tok.IsKeyword("JOIN"), tok.IsKeyword("INTO"):
+		case tok.IsKeyword("UPDATE") && !tokens.IsKeywordAt(tokens.Prev(n), "DO"):
+			if tokens.IsKeywordAt(tokens.Next(n), "OR") {
+				n = tokens.Next(tokens.Next(n))
+			}
+		default:
+			continue
+		}
+		for end := tokens.tableNameIndexAfter(n); 0 <= end; {
+			idxes = append(idxes, end)
+			comma := tokens.Next(end)
+			if alias := tokens.tableAliasIndexAfter(end); 0 <= alias {
+				comma = tokens.Next(alias)
+			}
+			if comma < 0 || !tokens[comma].IsPunctuation(",") {
+				break
+			}
+			end = tokens.tableNameIndexAfter(comma)
+		}
+	}
+	return idxes
+}
+
+// tableAliasIndexAfter returns the index of the table alias which follows the table name at the specified index, or -1 if not found.
+func (tokens Tokens) tableAliasIndexAfter(end int) int {
+	alias := tokens.Next(end)
+	if tokens.IsKeywordAt(alias, "AS") {
+		alias = tokens.Next(alias)
+	}
+	if alias < 0 || !tokens[alias].IsName() || slices.ContainsFunc(tableAliasStopKeywords, tokens[alias].IsKeyword) {
+		return -1
+	}
+	return alias
+}
+
+// insertParameters returns the parameters of the VALUES rows of INSERT.
+func (tokens Tokens) insertParameters(numbers map[*Token]int) []*Parameter {
+	begin := tokens.statementKeywordIndex()
+	if !tokens.IsKeywordAt(begin, "INSERT") && !tokens.IsKeywordAt(begin, "REPLACE") {
+		return nil
+	}
+	end, columns, n, ok := tokens.insertColumnsAt(begin)
+	if !ok || !tokens.IsKeywordAt(n, "VALUES") {
+		return nil
+	}
+	params := []*Parameter{}
+	for {
+		n = tokens.Next(n)
+		if n < 0 || !tokens[n].IsPunctuation("(") {
+			break
+		}
+		closeIdx := tokens.MatchingParen(n)
+		if closeIdx < 0 {
+			break
+		}
+		for idx, value := range tokens[n+1 : closeIdx].splitTopLevel(",") {
+			value = value.trimSpace()
+			if len(value) != 1 || value[0].Type != ParameterToken {
+				continue
+			}
+			param := &Parameter{Number: numbers[value[0]], Table: tokens[end].Value, Column: "", Index: idx}
+			if columns != nil {
+				if len(columns) <= idx {
+					continue
+				}
+				param.Column = columns[idx]
+				param.Index = -1
+			}
+			params = append(params, param)
+		}
+		n = tokens.Next(closeIdx)
+		if n < 0 || !tokens[n].IsPunctuation(",") {
+			break
+		}
+	}
+	return params
+}
+
+// comparedColumnIndex returns the index of the column which the parameter at the specified index is compared with or assigned to,
+// or -1 if the parameter is not compared with a column.
+func (tokens Tokens) comparedColumnIndex(n int) int {
+	isOperator := func(idx int) bool {
+		return 0 <= idx && tokens[idx].Type == OperatorToken && slices.Contains(parameterComparisonOperators, tokens[idx].Text)
+	}
+	columnBefore := func(idx int) int {
+		idx = tokens.Prev(idx)
+		if tokens.IsKeywordAt(idx, "NOT") {
+			idx = tokens.Prev(idx)
+		}
+		if idx < 0 || !tokens[idx].IsName() {
+			return -1
+		}
+		return idx
+	}
+	prev := tokens.Prev(n)
+	switch {
+	case isOperator(prev):
+		return columnBefore(prev)
+	case tokens.IsKeywordAt(prev, "LIKE"), tokens.IsKeywordAt(prev, "GLOB"), tokens.IsKeywordAt(prev, "BETWEEN"):
+		return columnBefore(prev)
+	case tokens.IsKeywordAt(prev, "AND"):
+		// The upper bound of BETWEEN follows the lower bound which is a single token.
+		if between := tokens.Prev(tokens.Prev(prev)); tokens.IsKeywordAt(between, "BETWEEN") {
+			return columnBefore(between)
+		}
+	case 0 <= prev && (tokens[prev].IsPunctuation("(") || tokens[prev].IsPunctuation(",")):
+		// The parameters of the IN list are compared with the column before IN.
+		open := prev
+		for 0 <= open && !tokens[open].IsPunctuation("(") {
+			open = tokens.Prev(open)
+			if 0 <= open && tokens[open].IsPunctuation(")") {
+				return -1
+			}
+		}
+		if in := tokens.Prev(open); tokens.IsKeywordAt(in, "IN") {
+			return columnBefore(in)
+		}
+	}
+	if next := tokens.Next(n); isOperator(next) {
+		column := tokens.Next(next)
+		if column < 0 || !tokens[column].IsName() {
+			return -1
+		}
+		// The qualified column name such as t.c is followed by the column name.
+		if dot := tokens.Next(column); 0 <= dot && tokens[dot].IsPunctuation(".") {
+			column = tokens.Next(dot)
+			if column < 0 || !tokens[column].IsName() {
+				return -1
+			}
+		}
+		return column
+	}
+	return -1
+}
@@ -0,0 +1,507 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+import (
+	"strings"
+)
+
+// PostgreSQL: Documentation: 16: 4.2. Value Expressions
+// https://www.postgresql.org/docs/16/sql-expressions.html
+// PostgreSQL: Documentation: 16: INSERT
+// https://www.postgresql.org/docs/16/sql-insert.html
+// PostgreSQL: Documentation: 16: SELECT
+// https://www.postgresql.org/docs/16/sql-select.html
+
+// HiddenColumnPrefix is the prefix of the helper columns which are added by the rewriter, and should not be returned to clients.
+const HiddenColumnPrefix = "__sqlserver_"
+
+// IsHiddenColumn returns true if the specified column is a helper column which is added by the rewriter.
+func IsHiddenColumn(name string) bool {
+	return strings.HasPrefix(name, HiddenColumnPrefix)
+}
+
+// reservedKeywords lists the keywords which can precede a parenthesized expression, and are never function names.
+var reservedKeywords = map[string]bool{
+	"AND": true, "AS": true, "BETWEEN": true, "BY": true, "CASE": true, "DISTINCT": true, "ELSE": true,
+	"EXISTS": true, "FROM": true, "HAVING": true, "IN": true, "IS": true, "JOIN": true, "LIKE": true,
+	"LIMIT": true, "NOT": true, "OFFSET": true, "ON": true, "OR": true, "RETURNING": true, "SELECT": true,
+	"SET": true, "THEN": true, "USING": true, "VALUES": true, "WHEN": true, "WHERE": true,
+}
+
+// NewPostgreSQLRewriter returns a rewriter which translates PostgreSQL queries into SQLite queries.
+// Escape string constants and dollar-quoted strings are normalized by the lexer.
+func NewPostgreSQLRewriter() Rewriter {
+	return newRewriterWith(PostgreSQL,
+		postgresqlParameterRule,
+		decimalLiteralRule,
+		postgresqlArrayRule,
+		postgresqlTypedLiteralRule,
+		postgresqlCastRule,
+		decimalCastRule(PostgreSQL),
+		postgresqlIntervalRule,
+		postgresqlOperatorRule,
+		functionNameRule(postgresqlFunctionNames),
+		functionNameRule(postgresqlJSONFunctionNames),
+		extractRule,
+		postgresqlUnnestRule,
+		postgresqlILikeRule,
+		postgresqlDistinctFromRule,
+		postgresqlDistinctOnRule,
+		postgresqlInsertAliasRule,
+		postgresqlOnConflictRule,
+		postgresqlLimitAllRule,
+		createTableRule,
+		lockingReadRule,
+	)
+}
+
+// postgresqlParameterRule rewrites the positional parameters such as $1 into the SQLite numbered parameters such as ?1.
+func postgresqlParameterRule(tokens Tokens) (Tokens, error) {
+	for n, tok := range tokens {
+		if tok.Type != ParameterToken || !strings.HasPrefix(tok.Text, "$") {
+			continue
+		}
+		tokens[n] = &Token{Type: ParameterToken, Text: "?" + tok.Text[1:], Value: tok.Value}
+	}
+	return tokens, nil
+}
+
+// postgresqlCastRule rewrites the PostgreSQL-style casts such as expr::type into CAST(expr AS type).
+func postgresqlCastRule(tokens Tokens) (Tokens, error) {
+	for n := 0; n < len(tokens); n++ {
+		if !tokens[n].IsOperator("::") {
+			continue
+		}
+		end := tokens.Prev(n)
+		if end < 0 {
+			return nil, newErrInvalid(tokens.String())
+		}
+		begin := tokens.operandBegin(end)
+		typeBegin := tokens.Next(n)
+		typeName, typeEnd := tokens.typeName(typeBegin)
+		if typeEnd < 0 {
+			return nil, newErrInvalid(tokens[begin:].String())
+		}
+		operand := append(Tokens{}, tokens[begin:end+1]...)
+		cast := castTokens(operand, typeName)
+		if decimalTypeNames[typeName] {
+			// The decimal types keep the precisions and scales for decimalCastRule.
+			cast = append(Tokens{NewWordToken("CAST"), NewPunctuationToken("(")}, operand...)
+			cast = append(cast, NewSpaceToken(), NewWordToken("AS"), NewSpaceToken())
+			cast = append(cast, tokens[typeBegin:typeEnd+1]...)
+			cast = append(cast, NewPunctuationToken(")"))
+		}
+		tokens = tokens.Splice(begin, typeEnd, cast...)
+		n = begin + len(cast) - 1
+	}
+	// The standard casts such as CAST(expr AS date) are rewritten from the innermost ones in the same way.
+	for n := len(tokens) - 1; 0 <= n; n-- {
+		open := tokens.Next(n)
+		if !tokens[n].IsKeyword("CAST") || open < 0 || !tokens[open].IsPunctuation("(") {
+			continue
+		}
+		closing := tokens.MatchingParen(open)
+		as := tokens.indexTopLevelKeyword(open+1, "AS")
+		if closing < 0 || as < 0 || closing < as {
+			continue
+		}
+		typeName, typeEnd := tokens.typeName(tokens.Next(as))
+		if typeEnd < 0 || tokens.Next(typeEnd) != closing {
+			return nil, newErrInvalid(tokens[n : closing+1].String())
+		}
+		if decimalTypeNames[typeName] {
+			continue
+		}
+		tokens = tokens.Splice(n, closing, castTokens(tokens[open+1:as].trimSpace(), typeName)...)
+	}
+	return tokens, nil
+}
+
+// postgresqlTypedLiteralTypeNames lists the types of the typed literals such as DATE '2024-01-02' which are rewritten into the casts.
+var postgresqlTypedLiteralTypeNames = map[string]bool{
+	"DATE": true, "TIME": true, "TIMETZ": true, "TIMESTAMP": true, "TIMESTAMPTZ": true,
+	"TIME WITH TIME ZONE": true, "TIME WITHOUT TIME ZONE": true, "TIMESTAMP WITH TIME ZONE": true, "TIMESTAMP WITHOUT TIME ZONE": true,
+	"JSON": true, "JSONB": true,
+}
+
+// postgresqlTypedLiteralRule rewrites the typed literals such as TIMESTAMP '2024-01-02 03:04:05' into the casts of the string literals.
+// The interval literals are rewritten by postgresqlIntervalRule.
+func postgresqlTypedLiteralRule(tokens Tokens) (Tokens, error) {
+	for n := 0; n < len(tokens); n++ {
+		if tokens[n].Type != WordToken {
+			continue
+		}
+		typeName, typeEnd := tokens.typeName(n)
+		literal := tokens.Next(typeEnd)
+		if !postgresqlTypedLiteralTypeNames[typeName] || literal < 0 || tokens[literal].Type != StringToken {
+			continue
+		}
+		cast := castTokens(Tokens{tokens[literal]}, typeName)
+		tokens = tokens.Splice(n, literal, cast...)
+		n += len(cast) - 1
+	}
+	return tokens, nil
+}
+
+// postgresqlILikeRule rewrites x [NOT] ILIKE y into lower(x) [NOT] LIKE lower(y) because the LIKE operator of the PostgreSQL sessions
+// is case-sensitive. The operands are the primary expressions next to ILIKE.
+func postgresqlILikeRule(tokens Tokens) (Tokens, error) {
+	for n := 0; n < len(tokens); n++ {
+		if !tokens[n].IsKeyword("ILIKE") {
+			continue
+		}
+		leftEnd := tokens.Prev(n)
+		if tokens.IsKeywordAt(leftEnd, "NOT") {
+			leftEnd = tokens.Prev(leftEnd)
+		}
+		rightBegin := tokens.Next(n)
+		if leftEnd < 0 || rightBegin < 0 {
+			return nil, newErrInvalid(tokens.String())
+		}
+		rightEnd := tokens.operandEnd(rightBegin)
+		right := append(Tokens{NewWordToken("lower"), NewPunctuationToken("(")}, tokens[rightBegin:rightEnd+1]...)
+		tokens = tokens.Splice(rightBegin, rightEnd, append(right, NewPunctuationToken(")"))...)
+		tokens[n] = NewWordToken("LIKE")
+		leftBegin := tokens.operandBegin(leftEnd)
+		left := append(Tokens{NewWordToken("lower"), NewPunctuationToken("(")}, tokens[leftBegin:leftEnd+1]...)
+		tokens = tokens.Splice(leftBegin, leftEnd, append(left, NewPunctuationToken(")"))...)
+		n += len(left) + 1 - (leftEnd - leftBegin + 1)
+	}
+	return tokens, nil
+}
+
+// postgresqlDistinctFromRule rewrites IS [NOT] DISTINCT FROM into the SQLite IS [NOT] operators.
+func postgresqlDistinctFromRule(tokens Tokens) (Tokens, error) {
+	for n := 0; n < len(tokens); n++ {
+		switch {
+		case tokens.IsKeywordsAt(n, "IS", "DISTINCT", "FROM"):
+			end := tokens.Next(tokens.Next(n))
+			tokens = tokens.Splice(n, end, keywordTokens("IS", "NOT")...)
+		case tokens.IsKeywordsAt(n, "IS", "NOT", "DISTINCT", "FROM"):
+			end := tokens.Next(tokens.Next(tokens.Next(n)))
+			tokens = tokens.Splice(n, end, keywordTokens("IS")...)
+		}
+	}
+	return tokens, nil
+}
+
+// postgresqlDistinctOnRule rewrites SELECT DISTINCT ON (exprs) ... into a subquery with the ROW_NUMBER window function.
+// The ORDER BY clause is kept by the other row number of the whole rows, and the row number columns are the hidden columns
+// which should be removed from the result set.
+func postgresqlDistinctOnRule(tokens Tokens) (Tokens, error) {
+	for n := 0; n < len(tokens); n++ {
+		if !tokens.IsKeywordsAt(n, "SELECT", "DISTINCT", "ON") {
+			continue
+		}
+		open := tokens.Next(tokens.Next(tokens.Next(n)))
+		if open < 0 || !tokens[open].IsPunctuation("(") {
+			return nil, newErrInvalid(tokens[n:].String())
+		}
+		closing := tokens.MatchingParen(open)
+		if closing < 0 {
+			return nil, newErrInvalid(tokens[n:].String())
+		}
+		end := tokens.enclosingEnd(n)
+		stmt := tokens[n : end+1]
+		from := stmt.indexTopLevelKeyword(closing-n+1, "FROM")
+		order := stmt.indexTopLevelKeyword(closing-n+1, "ORDER")
+		limit := stmt.indexTopLevelKeyword(closing-n+1, "LIMIT")
+		if limit < 0 {
+			limit = stmt.indexTopLevelKeyword(closing-n+1, "OFFSET")
+		}
+		if from < 0 {
+			return nil, newErrInvalid(stmt.String())
+		}
+		bodyEnd := len(stmt)
+		if 0 <= limit {
+			bodyEnd = limit
+		}
+		orderBy := Tokens{}
+		if 0 <= order {
+			orderBy = stmt[order:bodyEnd].trimSpace()
+		}
+		selectors := stmt[closing-n+1 : from].trimSpace()
+		partition := stmt[open-n+1 : closing-n].trimSpace()
+		rn := NewIdentifierToken(HiddenColumnPrefix + "rn")
+		ord := NewIdentifierToken(HiddenColumnPrefix + "ord")
+
+		rewritten := keywordTokens("SELECT", "*", "FROM")
+		rewritten = append(rewritten, NewSpaceToken(), NewPunctuationToken("("))
+		rewritten = append(rewritten, keywordTokens("SELECT")...)
+		rewritten = append(rewritten, NewSpaceToken())
+		rewritten = append(rewritten, selectors...)
+		rewritten = append(rewritten, NewPunctuationToken(","), NewSpaceToken(), NewWordToken("ROW_NUMBER"),
+			NewPunctuationToken("("), NewPunctuationToken(")"), NewSpaceToken(), NewWordToken("OVER"), NewSpaceToken(),
+			NewPunctuationToken("("))
+		rewritten = append(rewritten, keywordTokens("PARTITION", "BY")...)
+		rewritten = append(rewritten, NewSpaceToken())
+		rewritten = append(rewritten, partition...)
+		if 0 < len(orderBy) {
+			rewritten = append(rewritten, NewSpaceToken())
+			rewritten = append(rewritten, orderBy...)
+		}
+		rewritten = append(rewritten, NewPunctuationToken(")"), NewSpaceToken(), NewWordToken("AS"), NewSpaceToken(), rn)
+		if 0 < len(orderBy) {
+			rewritten = append(rewritten, NewPunctuationToken(","), NewSpaceToken(), NewWordToken("ROW_NUMBER"),
+				NewPunctuationToken("("), NewPunctuationToken(")"), NewSpaceToken(), NewWordToken("OVER"), NewSpaceToken(),
+				NewPunctuationToken("("))
+			rewritten = append(rewritten, orderBy...)
+			rewritten = append(rewritten, NewPunctuationToken(")"), NewSpaceToken(), NewWordToken("AS"), NewSpaceToken(), ord)
+		}
+		rewritten = append(rewritten, NewSpaceToken())
+		rewritten = append(rewritten, stmt[from:bodyEnd].trimSpace()...)
+		rewritten = append(rewritten, NewPunctuationToken(")"), NewSpaceToken())
+		rewritten = append(rewritten, keywordTokens("WHERE")...)
+		rewritten = append(rewritten, NewSpaceToken(), rn, NewSpaceToken(), NewOperatorToken("="), NewSpaceToken(), &Token{Type: NumberToken, Text: "1", Value: "1"})
+		if 0 < len(orderBy) {
+			rewritten = append(rewritten, NewSpaceToken())
+			rewritten = append(rewritten, keywordTokens("ORDER", "BY")...)
+			rewritten = append(rewritten, NewSpaceToken(), ord)
+		}
+		if 0 <= limit {
+			rewritten = append(rewritten, NewSpaceToken())
+			rewritten = append(rewritten, stmt[limit:].trimSpace()...)
+		}
+		tokens = tokens.Splice(n, n+len(stmt.trimSpace())-1, rewritten...)
+	}
+	return tokens, nil
+}
+
+// postgresqlInsertAliasRule removes the target table alias of INSERT INTO table AS alias because SQLite does not support it.
+// The alias references are rewritten into the table name references.
+func postgresqlInsertAliasRule(tokens Tokens) (Tokens, error) {
+	begin := tokens.Next(-1)
+	if !tokens.IsKeywordsAt(begin, "INSERT", "INTO") {
+		return tokens, nil
+	}
+	name := tokens.Next(tokens.Next(begin))
+	for dot := tokens.Next(name); 0 <= dot && tokens[dot].IsPunctuation("."); dot = tokens.Next(name) {
+		name = tokens.Next(dot)
+	}
+	if name < 0 || !tokens[name].IsName() {
+		return tokens, nil
+	}
+	as := tokens.Next(name)
+	if !tokens.IsKeywordAt(as, "AS") {
+		return tokens, nil
+	}
+	aliasIdx := tokens.Next(as)
+	if aliasIdx < 0 || !tokens[aliasIdx].IsName() {
+		return nil, newErrInvalid(tokens.String())
+	}
+	alias := tokens[aliasIdx].Value
+	table := tokens[name]
+	tokens = tokens.Splice(name+1, aliasIdx)
+	for n := name + 1; n < len(tokens); n++ {
+		tok := tokens[n]
+		if !tok.IsName() || tok.Value != alias {
+			continue
+		}
+		if dot := tokens.Next(n); dot < 0 || !tokens[dot].IsPunctuation(".") {
+			continue
+		}
+		if prev := tokens.Prev(n); 0 <= prev && tokens[prev].IsPunctuation(".") {
+			continue
+		}
+		tokens[n] = table
+	}
+	return tokens, nil
+}
+
+// postgresqlOnConflictRule rewrites ON CONFLICT ON CONSTRAINT name into ON CONFLICT because SQLite does not support constraint names as the conflict target.
+func postgresqlOnConflictRule(tokens Tokens) (Tokens, error) {
+	idx := tokens.indexTopLevelKeyword(0, "ON")
+	for 0 <= idx && !tokens.IsKeywordsAt(idx, "ON", "CONFLICT") {
+		idx = tokens.indexTopLevelKeyword(idx+1, "ON")
+	}
+	if idx < 0 {
+		return tokens, nil
+	}
+	conflict := tokens.Next(idx)
+	if tokens.IsKeywordsAt(tokens.Next(conflict), "ON", "CONSTRAINT") {
+		constraint := tokens.Next(tokens.Next(conflict))
+		name := tokens.Next(constraint)
+		if name < 0 {
+			return nil, newErrInvalid(tokens.String())
+		}
+		tokens = tokens.Splice(conflict+1, name)
+	}
+	guard := upsertGuardTokens(tokens, idx)
+	if 0 < len(guard) {
+		tokens = tokens.Splice(idx, idx, append(guard, tokens[idx])...)
+	}
+	return tokens, nil
+}
+
+// postgresqlLimitAllRule rewrites LIMIT ALL into the SQLite unlimited LIMIT -1.
+func postgresqlLimitAllRule(tokens Tokens) (Tokens, error) {
+	for n := 0; n < len(tokens); n++ {
+		if !tokens.IsKeywordsAt(n, "LIMIT", "ALL") {
+			continue
+		}
+		all := tokens.Next(n)
+		tokens[all] = &Token{Type: NumberToken, Text: "-1", Value: "-1"}
+	}
+	return tokens, nil
+}
+
+// operandBegin returns the begin index of the primary expression which ends at the specified index.
+func (tokens Tokens) operandBegin(end int) int {
+	begin := end
+	if tokens[end].IsPunctuation(")") {
+		begin = tokens.MatchingOpenParen(end)
+		if begin < 0 {
+			return end
+		}
+		if fn := tokens.Prev(begin); 0 <= fn && tokens[fn].IsName() && !reservedKeywords[strings.ToUpper(tokens[fn].Text)] {
+			begin = fn
+		}
+	}
+	for {
+		dot := tokens.Prev(begin)
+		if dot < 0 || !tokens[dot].IsPunctuation(".") {
+			break
+		}
+		name := tokens.Prev(dot)
+		if name < 0 || !tokens[name].IsName() {
+			break
+		}
+		begin = name
+	}
+	return begin
+}
+
+// typeName returns the upper case type name which begins at the specified index, and the end index of the type name.
+func (tokens Tokens) typeName(begin int) (string, int) {
+	if begin < 0 || !tokens[begin].IsName() {
+		return "", -1
+	}
+	words := []string{strings.ToUpper(tokens[begin].Value)}
+	end := begin
+	for next := tokens.Next(end); 0 <= next; next = tokens.Next(end) {
+		switch {
+		case tokens.IsKeywordAt(next, "PRECISION"), tokens.IsKeywordAt(next, "VARYING"):
+			words = append(words, strings.ToUpper(tokens[next].Text))
+			end = next
+			continue
+		case tokens.IsKeywordsAt(next, "WITH", "TIME", "ZONE"), tokens.IsKeywordsAt(next, "WITHOUT", "TIME", "ZONE"):
+			words = append(words, strings.ToUpper(tokens[next].Text), "TIME", "ZONE")
+			end = tokens.Next(tokens.Next(next))
+			continue
+		case tokens[next].IsPunctuation("("):
+			closing := tokens.MatchingParen(next)
+			if closing < 0 {
+				return "", -1
+			}
+			end = closing
+			continue
+		case tokens.IsKeywordAt(next, "ARRAY"):
+			// INTEGER ARRAY is equivalent to INTEGER[].
+			words = append(words, "[]")
+			end = next
+			continue
+		case tokens[next].IsPunctuation("["):
+			closing := tokens.Next(next)
+			for 0 <= closing && !tokens[closing].IsPunctuation("]") {
+				closing = tokens.Next(closing)
+			}
+			if closing < 0 {
+				return "", -1
+			}
+			words = append(words, "[]")
+			end = closing
+			continue
+		}
+		break
+	}
+	return strings.Join(words, " "), end
+}
+
+// enclosingEnd returns the end index of the statement or the parenthesized subquery which contains the specified index.
+func (tokens Tokens) enclosingEnd(idx int) int {
+	depth := 0
+	for n := idx; n < len(tokens); n++ {
+		switch {
+		case tokens[n].IsPunctuation("("):
+			depth++
+		case tokens[n].IsPunctuation(")"):
+			depth--
+			if depth < 0 {
+				return n - 1
+			}
+		}
+	}
+	return len(tokens) - 1
+}
+
+// castTokens returns the SQLite expression which casts the specified operand into the specified PostgreSQL type.
+func castTokens(operand Tokens, typeName string) Tokens {
+	if strings.HasSuffix(typeName, "[]") {
+		// array_in() validates and normalizes the array literal.
+		tokens := Tokens{NewWordToken("array_in"), NewPunctuationToken("(")}
+		tokens = append(tokens, operand...)
+		return append(tokens, NewPunctuationToken(")"))
+	}
+	switch typeName {
+	case "REGCLASS", "REGTYPE", "REGPROC", "OID":
+		return operand
+	case "JSON", "JSONB":
+		// SQLite json() validates and minifies the JSON text.
+		tokens := Tokens{NewWordToken("json"), NewPunctuationToken("(")}
+		tokens = append(tokens, operand...)
+		return append(tokens, NewPunctuationToken(")"))
+	case "INTERVAL":
+		// The interval is rewritten by postgresqlIntervalRule.
+		tokens := Tokens{NewWordToken("INTERVAL"), NewSpaceToken(), NewPunctuationToken("(")}
+		tokens = append(tokens, operand...)
+		return append(tokens, NewPunctuationToken(")"))
+	case "BOOL", "BOOLEAN":
+		// SQLite has no boolean type, and the boolean literals such as 't' and 'yes' are converted into 1 or 0.
+		tokens := Tokens{NewPunctuationToken("("), NewWordToken("lower"), NewPunctuationToken("(")}
+		tokens = append(tokens, operand...)
+		tokens = append(tokens, NewPunctuationToken(")"), NewSpaceToken(), NewWordToken("IN"), NewSpaceToken(), NewPunctuationToken("("))
+		for n, literal := range []string{"t", "true", "y", "yes", "on", "1"} {
+			if 0 < n {
+				tokens = append(tokens, NewPunctuationToken(","), NewSpaceToken())
+			}
+			tokens = append(tokens, NewStringToken(literal))
+		}
+		return append(tokens, NewPunctuationToken(")"), NewPunctuationToken(")"))
+	}
+	tokens := Tokens{NewWordToken("CAST"), NewPunctuationToken("(")}
+	tokens = append(tokens, operand...)
+	tokens = append(tokens, NewSpaceToken(), NewWordToken("AS"), NewSpaceToken(), NewWordToken(SQLiteTypeOf(typeName)), NewPunctuationToken(")"))
+	return tokens
+}
+
+// SQLiteTypeOf returns the SQLite type name which has the equivalent type affinity of the specified PostgreSQL type name.
+func SQLiteTypeOf(typeName string) string {
+	// Datatypes In SQLite
+	// https://sqlite.org/datatype3.html
+	switch {
+	case strings.HasSuffix(typeName, "[]"):
+		return "TEXT"
+	case strings.Contains(typeName, "INT"), strings.HasSuffix(typeName, "SERIAL"):
+		return "INTEGER"
+	case strings.HasPrefix(typeName, "FLOAT"), strings.HasPrefix(typeName, "DOUBLE"), typeName == "REAL":
+		return "REAL"
+	case typeName == "NUMERIC", typeName == "DECIMAL":
+		return "NUMERIC"
+	case typeName == "BYTEA":
+		return "BLOB"
+	}
+	return "TEXT"
+}
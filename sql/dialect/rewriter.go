@@ -0,0 +1,339 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+import (
+	"strings"
+)
+
+// Rewriter represents a query rewriter which translates client queries into SQLite queries.
+type Rewriter interface {
+	// Dialect returns the source dialect.
+	Dialect() Dialect
+	// Rewrite rewrites the specified query into the equivalent SQLite query.
+	Rewrite(query string) (string, error)
+	// RewriteStatements rewrites the specified query, and returns the SQLite statements.
+	RewriteStatements(query string) ([]string, error)
+}
+
+// RewriteRule represents a rewrite rule which transforms the tokens of a statement.
+type RewriteRule func(Tokens) (Tokens, error)
+
+type rewriter struct {
+	dialect Dialect
+	rules   []RewriteRule
+}
+
+// NewRewriterFor returns a rewriter for the specified dialect.
+func NewRewriterFor(d Dialect) Rewriter {
+	switch d {
+	case MySQL:
+		return NewMySQLRewriter()
+	case PostgreSQL:
+		return NewPostgreSQLRewriter()
+	}
+	return newRewriterWith(SQLite)
+}
+
+func newRewriterWith(d Dialect, rules ...RewriteRule) *rewriter {
+	return &rewriter{
+		dialect: d,
+		rules:   rules,
+	}
+}
+
+// Dialect returns the source dialect.
+func (r *rewriter) Dialect() Dialect {
+	return r.dialect
+}
+
+// Rewrite rewrites the specified query into the equivalent SQLite query.
+func (r *rewriter) Rewrite(query string) (string, error) {
+	stmts, err := r.RewriteStatements(query)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(stmts, "; "), nil
+}
+
+// RewriteStatements rewrites the specified query, and returns the SQLite statements.
+func (r *rewriter) RewriteStatements(query string) ([]string, error) {
+	tokens, err := NewLexerWith(r.dialect).Tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+	stmts := []string{}
+	for _, stmt := range tokens.Statements() {
+		for _, rule := range r.rules {
+			stmt, err = rule(stmt)
+			if err != nil {
+				return nil, err
+			}
+		}
+		// The rules may append the statements which complete the statement such as CREATE INDEX.
+		for _, stmt := range stmt.Statements() {
+			stmts = append(stmts, stmt.trimSpace().String())
+		}
+	}
+	return stmts, nil
+}
+
+// IsQuery returns true if the specified SQLite statement returns rows.
+func IsQuery(stmt string) bool {
+	switch StatementKeyword(stmt) {
+	case "SELECT", "VALUES", "PRAGMA", "EXPLAIN":
+		return true
+	}
+	return HasReturningClause(stmt)
+}
+
+// StatementKeyword returns the upper case leading keyword of the specified statement after the common table expressions,
+// such as INSERT for WITH ... INSERT, or an empty string if the statement has no leading keyword.
+func StatementKeyword(stmt string) string {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return ""
+	}
+	idx := tokens.statementKeywordIndex()
+	if idx < 0 {
+		return ""
+	}
+	return strings.ToUpper(tokens[idx].Text)
+}
+
+// HasReturningClause returns true if the specified INSERT, UPDATE, DELETE or REPLACE statement has the RETURNING clause.
+func HasReturningClause(stmt string) bool {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return false
+	}
+	idx := tokens.statementKeywordIndex()
+	if idx < 0 {
+		return false
+	}
+	switch strings.ToUpper(tokens[idx].Text) {
+	case "INSERT", "UPDATE", "DELETE", "REPLACE":
+		return tokens[idx:].hasTopLevelKeyword("RETURNING")
+	}
+	return false
+}
+
+// statementKeywordIndex returns the index of the leading keyword after the common table expressions, or -1 if not found.
+func (tokens Tokens) statementKeywordIndex() int {
+	depth := 0
+	isCTE := false
+	for n, tok := range tokens {
+		switch {
+		case tok.IsPunctuation("("):
+			depth++
+			continue
+		case tok.IsPunctuation(")"):
+			depth--
+			continue
+		case tok.Type != WordToken || 0 < depth:
+			continue
+		}
+		switch strings.ToUpper(tok.Text) {
+		case "SELECT", "VALUES", "PRAGMA", "EXPLAIN", "INSERT", "UPDATE", "DELETE", "REPLACE":
+			return n
+		case "WITH":
+			isCTE = true
+		default:
+			if !isCTE {
+				return n
+			}
+		}
+	}
+	return -1
+}
+
+// hasTopLevelKeyword returns true if the tokens have the specified keyword outside of parentheses.
+func (tokens Tokens) hasTopLevelKeyword(keyword string) bool {
+	return 0 <= tokens.indexTopLevelKeyword(0, keyword)
+}
+
+// indexTopLevelKeyword returns the index of the specified keyword outside of parentheses from the specified index, or -1 if not found.
+func (tokens Tokens) indexTopLevelKeyword(from int, keyword string) int {
+	depth := 0
+	for n := from; n < len(tokens); n++ {
+		tok := tokens[n]
+		switch {
+		case tok.IsPunctuation("("):
+			depth++
+		case tok.IsPunctuation(")"):
+			depth--
+		case depth == 0 && tok.IsKeyword(keyword):
+			return n
+		}
+	}
+	return -1
+}
+
+// lockingReadRule removes locking read clauses such as FOR UPDATE and LOCK IN SHARE MODE because SQLite locks the whole database.
+func lockingReadRule(tokens Tokens) (Tokens, error) {
+	for n := 0; n < len(tokens); n++ {
+		end := -1
+		switch {
+		case tokens.IsKeywordsAt(n, "FOR", "UPDATE"), tokens.IsKeywordsAt(n, "FOR", "SHARE"),
+			tokens.IsKeywordsAt(n, "FOR", "NO", "KEY", "UPDATE"), tokens.IsKeywordsAt(n, "FOR", "KEY", "SHARE"):
+			end = tokens.Next(n)
+			for !tokens.IsKeywordAt(end, "UPDATE") && !tokens.IsKeywordAt(end, "SHARE") {
+				end = tokens.Next(end)
+			}
+			next := tokens.Next(end)
+			if tokens.IsKeywordAt(next, "OF") {
+				end = tokens.Next(next)
+				for next = tokens.Next(end); 0 <= next && tokens[next].IsPunctuation(","); next = tokens.Next(end) {
+					end = tokens.Next(next)
+				}
+			}
+			next = tokens.Next(end)
+			switch {
+			case tokens.IsKeywordAt(next, "NOWAIT"):
+				end = next
+			case tokens.IsKeywordsAt(next, "SKIP", "LOCKED"):
+				end = tokens.Next(next)
+			}
+		case tokens.IsKeywordsAt(n, "LOCK", "IN", "SHARE", "MODE"):
+			end = tokens.Next(tokens.Next(tokens.Next(n)))
+		}
+		if end < 0 {
+			continue
+		}
+		tokens = tokens.Splice(n, end)
+	}
+	return tokens, nil
+}
+
+// splitTopLevel splits the tokens by the specified punctuation outside of parentheses.
+func (tokens Tokens) splitTopLevel(p string) []Tokens {
+	parts := []Tokens{}
+	part := Tokens{}
+	depth := 0
+	for _, tok := range tokens {
+		switch {
+		case tok.IsPunctuation("("):
+			depth++
+		case tok.IsPunctuation(")"):
+			depth--
+		case tok.IsPunctuation(p) && depth == 0:
+			parts = append(parts, part)
+			part = Tokens{}
+			continue
+		}
+		part = append(part, tok)
+	}
+	return append(parts, part)
+}
+
+// trimSpace returns the tokens without the leading and trailing insignificant tokens.
+func (tokens Tokens) trimSpace() Tokens {
+	begin := tokens.Next(-1)
+	if begin < 0 {
+		return Tokens{}
+	}
+	end := tokens.Prev(len(tokens))
+	return tokens[begin : end+1]
+}
+
+// leadingSpaceBegin returns the index of the insignificant tokens which precede the specified token,
+// or the index of the token if no insignificant tokens precede it, so that the removed tokens leave no double spaces.
+func (tokens Tokens) leadingSpaceBegin(idx int) int {
+	begin := idx
+	for 0 < begin && !tokens[begin-1].IsSignificant() {
+		begin--
+	}
+	return begin
+}
+
+// keywordTokens returns the tokens of the specified keywords separated by spaces.
+func keywordTokens(keywords ...string) Tokens {
+	tokens := Tokens{}
+	for n, keyword := range keywords {
+		if 0 < n {
+			tokens = append(tokens, NewSpaceToken())
+		}
+		tokens = append(tokens, NewWordToken(keyword))
+	}
+	return tokens
+}
+
+// LeadingKeyword returns the first keyword of the specified SQLite statement in upper case, or an empty string if not found.
+func LeadingKeyword(stmt string) string {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return ""
+	}
+	idx := tokens.Next(-1)
+	if idx < 0 || tokens[idx].Type != WordToken {
+		return ""
+	}
+	return strings.ToUpper(tokens[idx].Text)
+}
+
+// SplitStatements splits the specified query of the dialect into the statements by the top-level semicolons
+// without rewriting them, and the empty statements are skipped.
+func SplitStatements(d Dialect, query string) ([]string, error) {
+	tokens, err := NewLexerWith(d).Tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+	stmts := []string{}
+	for _, stmt := range tokens.Statements() {
+		// The rules may append the statements which complete the statement such as CREATE INDEX.
+		for _, stmt := range stmt.Statements() {
+			stmts = append(stmts, stmt.trimSpace().String())
+		}
+	}
+	return stmts, nil
+}
+
+// upsertGuardTokens returns a WHERE clause which should be inserted before the upsert clause at the specified index.
+// SQLite requires a WHERE clause in INSERT ... SELECT to avoid a parsing ambiguity with the join constraint.
+func upsertGuardTokens(tokens Tokens, on int) Tokens {
+	selectIdx := tokens.indexTopLevelKeyword(0, "SELECT")
+	if selectIdx < 0 || on < selectIdx {
+		return Tokens{}
+	}
+	for _, keyword := range []string{"WHERE", "GROUP", "ORDER", "LIMIT"} {
+		if n := tokens.indexTopLevelKeyword(selectIdx, keyword); 0 <= n && n < on {
+			return Tokens{}
+		}
+	}
+	return append(keywordTokens("WHERE", "true"), NewSpaceToken())
+}
+
+// HasSchemaName returns true if the specified statement refers to a table in any of the specified schemas.
+func HasSchemaName(stmt string, schemaNames ...string) bool {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return false
+	}
+	for n, tok := range tokens {
+		if !tok.IsName() {
+			continue
+		}
+		dot := tokens.Next(n)
+		if dot < 0 || !tokens[dot].IsPunctuation(".") {
+			continue
+		}
+		for _, schemaName := range schemaNames {
+			if strings.EqualFold(tok.Value, schemaName) {
+				return true
+			}
+		}
+	}
+	return false
+}
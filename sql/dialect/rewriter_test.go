@@ -0,0 +1,263 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+import (
+	"errors"
+	"testing"
+)
+
+// rewriterTest represents a query and the expected SQLite statements of the rewriter which are joined by semicolons.
+type rewriterTest struct {
+	query    string
+	expected string
+}
+
+// testRewriter tests the specified rewriter rewrites the queries into the expected SQLite statements.
+func testRewriter(t *testing.T, rewriter Rewriter, tests []rewriterTest) {
+	t.Helper()
+	for _, test := range tests {
+		stmt, err := rewriter.Rewrite(test.query)
+		if err != nil {
+			t.Errorf("%s: %s", test.query, err)
+			continue
+		}
+		if stmt != test.expected {
+			t.Errorf("%s:\n got: %s\nwant: %s", test.query, stmt, test.expected)
+		}
+	}
+}
+
+func TestMySQLRewriter(t *testing.T) {
+	testRewriter(t, NewMySQLRewriter(), []rewriterTest{
+		// mysqlParameterRule
+		{"SELECT * FROM t WHERE a = ? AND b = ?", "SELECT * FROM t WHERE a = ?1 AND b = ?2"},
+		// mysqlStringConcatRule
+		{"SELECT 'a' 'b'", "SELECT 'ab'"},
+		// mysqlInsertRule
+		{"INSERT IGNORE INTO t VALUES (1)", "INSERT OR IGNORE INTO t VALUES (1)"},
+		{"REPLACE INTO t VALUES (1)", "INSERT OR REPLACE INTO t VALUES (1)"},
+		{"INSERT LOW_PRIORITY INTO t VALUES (1)", "INSERT INTO t VALUES (1)"},
+		{"INSERT INTO t VALUE (1)", "INSERT INTO t VALUES (1)"},
+		{"INSERT INTO t (value) VALUE (1)", "INSERT INTO t (value) VALUES (1)"},
+		{"INSERT INTO t SET value = 1", "INSERT INTO t (value) VALUES (1)"},
+		{"INSERT INTO t SELECT value FROM s", "INSERT INTO t SELECT value FROM s"},
+		{"INSERT INTO t (k, value) VALUE (1, 2) ON DUPLICATE KEY UPDATE value = VALUES(value)", "INSERT INTO t (k, value) VALUES (1, 2) ON CONFLICT DO UPDATE SET value = excluded.value"},
+		// decimalLiteralRule
+		{"SELECT 12345678901234567.89", "SELECT '12345678901234567.89'"},
+		// mysqlInsertSetRule
+		{"INSERT INTO t SET a = 1, b = 'x'", "INSERT INTO t (a, b) VALUES (1, 'x')"},
+		// mysqlOnDuplicateKeyUpdateRule
+		{"INSERT INTO t (a, b) VALUES (1, 2) ON DUPLICATE KEY UPDATE b = VALUES(b)", "INSERT INTO t (a, b) VALUES (1, 2) ON CONFLICT DO UPDATE SET b = excluded.b"},
+		{"INSERT INTO t (a, b) VALUES (1, 2) AS new ON DUPLICATE KEY UPDATE b = new.b", "INSERT INTO t (a, b) VALUES (1, 2) ON CONFLICT DO UPDATE SET b = excluded.b"},
+		// mysqlLimitRule
+		{"SELECT * FROM t LIMIT 5, 10", "SELECT * FROM t LIMIT 10 OFFSET 5"},
+		{"SELECT * FROM t WHERE a = ? LIMIT ?, ?", "SELECT * FROM t WHERE a = ?1 LIMIT ?3 OFFSET ?2"},
+		{"SELECT * FROM t LIMIT ?", "SELECT * FROM t LIMIT ?1"},
+		// mysqlOrderedModificationRule
+		{"UPDATE t SET a = 1 WHERE b = 2 ORDER BY c LIMIT 1", "UPDATE t SET a = 1 WHERE rowid IN (SELECT rowid FROM t WHERE b = 2 ORDER BY c LIMIT 1)"},
+		{"DELETE FROM t ORDER BY c LIMIT 2", "DELETE FROM t WHERE rowid IN (SELECT rowid FROM t ORDER BY c LIMIT 2)"},
+		// mysqlNullSafeEqualRule
+		{"SELECT * FROM t WHERE a <=> b", "SELECT * FROM t WHERE a IS b"},
+		// mysqlDivRule
+		{"SELECT 7 DIV 2", "SELECT CAST(7 / 2 AS INTEGER)"},
+		// mysqlLikeEscapeRule
+		{`SELECT * FROM t WHERE a LIKE 'a\_b%'`, `SELECT * FROM t WHERE a LIKE 'a\_b%' ESCAPE '\'`},
+		{"SELECT * FROM t WHERE a LIKE 'a|%' ESCAPE '|'", "SELECT * FROM t WHERE a LIKE 'a|%' ESCAPE '|'"},
+		// mysqlCreateTableRule and createTableRule
+		{
+			"CREATE TABLE t (id INT AUTO_INCREMENT PRIMARY KEY, v INT UNSIGNED, d DECIMAL(5,2), j JSON, KEY idx (v)) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4",
+			`CREATE TABLE t (id INTEGER COLLATE "INT" PRIMARY KEY, v INT UNSIGNED, d DECIMAL_TEXT_5_2 COLLATE DECIMAL CONSTRAINT "__sqlserver_decimal:5:2:d" CHECK (decimal_fits("d", 5, 2)), j JSON CONSTRAINT "__sqlserver_json:j" CHECK (json_valid("j"))); CREATE INDEX idx ON t (v)`,
+		},
+		{
+			"CREATE TABLE IF NOT EXISTS t (a INT, b VARCHAR(20), INDEX (a), KEY idx_b USING BTREE (b(10), a DESC), UNIQUE KEY u (a), FULLTEXT KEY f (b))",
+			"CREATE TABLE IF NOT EXISTS t (a INT, b VARCHAR(20), UNIQUE (a)); CREATE INDEX IF NOT EXISTS \"t_a\" ON t (a); CREATE INDEX IF NOT EXISTS idx_b ON t (b, a DESC)",
+		},
+		// mysqlIntervalRule and mysqlUnitArgumentRule
+		{"SELECT DATE_ADD(d, INTERVAL 1 DAY), d + INTERVAL 2 HOUR FROM t", "SELECT DATE_ADD(d, 1, 'DAY'), date_add(d, 2, 'HOUR') FROM t"},
+		{"SELECT TIMESTAMPDIFF(DAY, a, b) FROM t", "SELECT TIMESTAMPDIFF('DAY', a, b) FROM t"},
+		// mysqlGroupConcatRule
+		{"SELECT GROUP_CONCAT(a ORDER BY a SEPARATOR ';') FROM t", "SELECT GROUP_CONCAT(a, ';' ORDER BY a) FROM t"},
+		// mysqlConvertRule
+		{"SELECT CONVERT(x, SIGNED), CONVERT(x USING utf8mb4) FROM t", "SELECT CAST(x AS SIGNED), (x) FROM t"},
+		// functionNameRule
+		{"SELECT CONCAT(a, b), GREATEST(1, 2), LEAST(1, 2) FROM t", "SELECT mysql_concat(a, b), mysql_greatest(1, 2), mysql_least(1, 2) FROM t"},
+		// decimalCastRule
+		{"SELECT CAST(x AS DECIMAL(5,2)), CAST(x AS DECIMAL) FROM t", "SELECT decimal_cast(x, 5, 2), decimal_cast(x, 10, 0) FROM t"},
+		// extractRule
+		{"SELECT EXTRACT(YEAR FROM d) FROM t", "SELECT EXTRACT('year', d) FROM t"},
+		// lockingReadRule
+		{"SELECT * FROM t FOR UPDATE", "SELECT * FROM t"},
+		// The escapes, the quotes and the comments of the lexer
+		{`SELECT 'a\nb', 'it\'s', "dq", 'a''b', ` + "`col`" + ` FROM t # comment`, "SELECT 'a\nb', 'it''s', 'dq', 'a''b', \"col\" FROM t"},
+		{`SELECT 'a\0b'`, "SELECT ('a' || char(0) || 'b')"},
+	})
+}
+
+func TestPostgreSQLRewriter(t *testing.T) {
+	testRewriter(t, NewPostgreSQLRewriter(), []rewriterTest{
+		// postgresqlParameterRule
+		{"SELECT * FROM t WHERE a = $1 AND b = $2", "SELECT * FROM t WHERE a = ?1 AND b = ?2"},
+		{"SELECT * FROM t WHERE b = $2 AND a = $1::int", "SELECT * FROM t WHERE b = ?2 AND a = CAST(?1 AS INTEGER)"},
+		// decimalLiteralRule
+		{"SELECT 12345678901234567.89", "SELECT '12345678901234567.89'"},
+		// postgresqlArrayRule
+		{"SELECT ARRAY[1, 2], nums[1], nums[1:2] FROM t", "SELECT array_construct(1, 2), array_element(nums, 1), array_slice(nums, 1, 2) FROM t"},
+		{"SELECT '{1,2}'::int[]", "SELECT array_in('{1,2}')"},
+		// postgresqlTypedLiteralRule
+		{"SELECT DATE '2024-01-01', TIMESTAMP '2024-01-01 10:00:00'", "SELECT CAST('2024-01-01' AS TEXT), CAST('2024-01-01 10:00:00' AS TEXT)"},
+		// postgresqlCastRule
+		{"SELECT a::text, '1'::int, CAST(a AS varchar(10)) FROM t", "SELECT CAST(a AS TEXT), CAST('1' AS INTEGER), CAST(a AS TEXT) FROM t"},
+		{"SELECT 't'::boolean", "SELECT (lower('t') IN ('t', 'true', 'y', 'yes', 'on', '1'))"},
+		// decimalCastRule
+		{"SELECT a::numeric(5,2) FROM t", "SELECT decimal_cast(a, 5, 2) FROM t"},
+		// postgresqlIntervalRule
+		{"SELECT d + INTERVAL '1 day', d - '2 hours'::interval FROM t", "SELECT date_add(d, '1 day'), date_subtract(d, ('2 hours')) FROM t"},
+		// postgresqlOperatorRule
+		{"SELECT doc -> 'a' ->> 'b', doc @> '{}', doc ? 'k', doc #> '{a,b}' FROM t", "SELECT doc -> 'a' ->> 'b', contains_op(doc, '{}'), jsonb_exists(doc, 'k'), json_extract_path_op(doc, '{a,b}') FROM t"},
+		// functionNameRule
+		{"SELECT jsonb_build_object('a', 1), string_agg(a, ',') FROM t", "SELECT json_object('a', 1), string_agg(a, ',') FROM t"},
+		// extractRule
+		{"SELECT EXTRACT(YEAR FROM d) FROM t", "SELECT EXTRACT('year', d) FROM t"},
+		// postgresqlUnnestRule
+		{"SELECT * FROM unnest(ARRAY[1, 2]) AS u", `SELECT * FROM (SELECT value AS "u" FROM json_each(array_to_json(array_construct(1, 2)))) AS "u"`},
+		{"SELECT k, unnest(nums) FROM t", `SELECT k, "__sqlserver_unnest".value AS unnest FROM t, json_each(array_to_json(nums)) AS "__sqlserver_unnest"`},
+		// postgresqlILikeRule
+		{"SELECT * FROM t WHERE a ILIKE 'x%' AND b NOT ILIKE 'y'", "SELECT * FROM t WHERE lower(a) LIKE lower('x%') AND lower(b) NOT LIKE lower('y')"},
+		{"SELECT * FROM t WHERE t.a ILIKE concat(b, '%') ESCAPE '!'", "SELECT * FROM t WHERE lower(t.a) LIKE lower(concat(b, '%')) ESCAPE '!'"},
+		// postgresqlDistinctFromRule
+		{"SELECT * FROM t WHERE a IS DISTINCT FROM b AND c IS NOT DISTINCT FROM d", "SELECT * FROM t WHERE a IS NOT b AND c IS d"},
+		// postgresqlDistinctOnRule
+		{
+			"SELECT DISTINCT ON (a) a, b FROM t ORDER BY a, b DESC",
+			`SELECT * FROM (SELECT a, b, ROW_NUMBER() OVER (PARTITION BY a ORDER BY a, b DESC) AS "__sqlserver_rn", ROW_NUMBER() OVER (ORDER BY a, b DESC) AS "__sqlserver_ord" FROM t ORDER BY a, b DESC) WHERE "__sqlserver_rn" = 1 ORDER BY "__sqlserver_ord"`,
+		},
+		// postgresqlInsertAliasRule and postgresqlOnConflictRule
+		{"INSERT INTO t AS x VALUES (1, 2) ON CONFLICT (a) DO UPDATE SET b = x.b + EXCLUDED.b", "INSERT INTO t VALUES (1, 2) ON CONFLICT (a) DO UPDATE SET b = t.b + EXCLUDED.b"},
+		{"INSERT INTO t VALUES (1) ON CONFLICT DO NOTHING", "INSERT INTO t VALUES (1) ON CONFLICT DO NOTHING"},
+		// postgresqlLimitAllRule
+		{"SELECT * FROM t LIMIT ALL OFFSET 2", "SELECT * FROM t LIMIT -1 OFFSET 2"},
+		// createTableRule
+		{
+			"CREATE TABLE t (id SERIAL PRIMARY KEY, doc JSONB, nums INT[], d NUMERIC(5,2), b BOOLEAN)",
+			`CREATE TABLE t (id INTEGER COLLATE "INT" PRIMARY KEY, doc JSONB CONSTRAINT "__sqlserver_json:doc" CHECK (json_valid("doc")), nums _INT4 CONSTRAINT "__sqlserver_array:_INT4:nums" CHECK (array_valid("nums", '_INT4')), d DECIMAL_TEXT_5_2 COLLATE DECIMAL CONSTRAINT "__sqlserver_decimal:5:2:d" CHECK (decimal_fits("d", 5, 2)), b BOOLEAN)`,
+		},
+		// lockingReadRule
+		{"SELECT * FROM t FOR UPDATE SKIP LOCKED", "SELECT * FROM t"},
+		// The escape strings of the lexer
+		{`SELECT E'a\nb', E'\101', E'\x41', E'\u0041', 'a\nb', 'it''s'`, "SELECT 'a\nb', 'A', 'A', 'A', 'a\\nb', 'it''s'"},
+	})
+}
+
+func TestConcatExpressions(t *testing.T) {
+	columnTypes := map[string]string{"nums": "_INT4", "doc": "JSONB", "name": "TEXT"}
+	tests := []rewriterTest{
+		{"SELECT nums || 4, 0 || nums, nums || nums, nums || array_construct(5) FROM t", "SELECT array_append(nums, 4), array_prepend(0, nums), array_cat(nums, nums), array_cat(nums, array_construct(5)) FROM t"},
+		{`SELECT doc || '{"a":1}', doc -> 'a' || '[1]' FROM t`, `SELECT jsonb_concat(doc, '{"a":1}'), jsonb_concat(doc -> 'a', '[1]') FROM t`},
+		{"SELECT name || 'x', 'a' || 'b' FROM t", "SELECT name || 'x', 'a' || 'b' FROM t"},
+	}
+	for _, test := range tests {
+		stmt, err := ConcatExpressions(test.query, func(name string) string { return columnTypes[name] })
+		if err != nil {
+			t.Errorf("%s: %s", test.query, err)
+			continue
+		}
+		if stmt != test.expected {
+			t.Errorf("%s:\n got: %s\nwant: %s", test.query, stmt, test.expected)
+		}
+	}
+}
+
+func TestDecimalExpressions(t *testing.T) {
+	const query = "SELECT price + price, price * qty, price / 3, SUM(price), qty + 1 FROM t"
+	tests := []struct {
+		dialect  Dialect
+		expected string
+	}{
+		{MySQL, "SELECT decimal_add(price, price), decimal_mul(price, qty), mysql_decimal_div(price, 3), decimal_sum(price), qty + 1 FROM t"},
+		{PostgreSQL, "SELECT decimal_add(price, price), decimal_mul(price, qty), decimal_div(price, 3), decimal_sum(price), qty + 1 FROM t"},
+	}
+	for _, test := range tests {
+		stmt, err := DecimalExpressions(test.dialect, query, func(name string) bool { return name == "price" })
+		if err != nil {
+			t.Errorf("%s: %s", test.dialect, err)
+			continue
+		}
+		if stmt != test.expected {
+			t.Errorf("%s:\n got: %s\nwant: %s", test.dialect, stmt, test.expected)
+		}
+	}
+}
+
+func TestStringAssignments(t *testing.T) {
+	tests := []struct {
+		query       string
+		assignments []Assignment
+		numbers     map[int]string
+		expected    string
+	}{
+		{
+			"INSERT INTO t (a, b, c) VALUES ('x', 1, ?), (?, 'y', 'z')",
+			[]Assignment{{"a", 0, 1, "x", 0, nil}, {"c", 2, 1, "", 1, nil}, {"a", 0, 2, "", 2, nil}, {"b", 1, 2, "y", 0, nil}, {"c", 2, 2, "z", 0, nil}},
+			map[int]string{0: "0", 3: "12"},
+			"INSERT INTO t (a, b, c) VALUES (0, 1, ?), (?, 12, 'z')",
+		},
+		{
+			"UPDATE t SET a = ?2, b = 'x' WHERE id = ?1",
+			[]Assignment{{"a", 0, 1, "", 2, nil}, {"b", 1, 1, "x", 0, nil}},
+			map[int]string{1: "0"},
+			"UPDATE t SET a = ?2, b = 0 WHERE id = ?1",
+		},
+	}
+	for _, test := range tests {
+		tblName, assignments, ok := StringAssignments(test.query)
+		if !ok || tblName != "t" || len(assignments) != len(test.assignments) {
+			t.Errorf("%s: %s %v", test.query, tblName, assignments)
+			continue
+		}
+		for n, assignment := range assignments {
+			assignment.token = nil
+			if *assignment != test.assignments[n] {
+				t.Errorf("%s: %v != %v", test.query, *assignment, test.assignments[n])
+			}
+		}
+		stmt, err := ReplaceStringAssignments(test.query, test.numbers)
+		if err != nil {
+			t.Errorf("%s: %s", test.query, err)
+			continue
+		}
+		if stmt != test.expected {
+			t.Errorf("%s:\n got: %s\nwant: %s", test.query, stmt, test.expected)
+		}
+	}
+}
+
+func TestRewriterErrors(t *testing.T) {
+	tests := []struct {
+		rewriter Rewriter
+		query    string
+	}{
+		{NewMySQLRewriter(), "INSERT INTO t (a) VALUES (1) AS new (x) ON DUPLICATE KEY UPDATE a = x"},
+		{NewMySQLRewriter(), "SELECT GROUP_CONCAT(DISTINCT a SEPARATOR ';') FROM t"},
+		{NewPostgreSQLRewriter(), "SELECT ARRAY[[1, 2], [3, 4]]"},
+		{NewPostgreSQLRewriter(), "SELECT unnest(a), unnest(b) FROM t"},
+	}
+	for _, test := range tests {
+		_, err := test.rewriter.RewriteStatements(test.query)
+		if !errors.Is(err, ErrNotSupported) {
+			t.Errorf("%s: %v", test.query, err)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+// PostgreSQL: Documentation: 16: SET
+// https://www.postgresql.org/docs/16/sql-set.html
+// PostgreSQL: Documentation: 16: RESET
+// https://www.postgresql.org/docs/16/sql-reset.html
+// MySQL :: MySQL 8.0 Reference Manual :: 15.7.7.42 SHOW WARNINGS Statement
+// https://dev.mysql.com/doc/refman/8.0/en/show-warnings.html
+
+import (
+	"strings"
+)
+
+// ParameterSetting returns the lower case parameter name and the value of the specified PostgreSQL SET or RESET statement
+// of a session parameter, and the value is empty for RESET and SET ... TO DEFAULT.
+func ParameterSetting(stmt string) (string, string, bool) {
+	tokens, ok := singleStatementTokens(PostgreSQL, stmt)
+	if !ok {
+		return "", "", false
+	}
+	n := tokens.Next(-1)
+	isReset := tokens.IsKeywordAt(n, "RESET")
+	if !isReset && !tokens.IsKeywordAt(n, "SET") {
+		return "", "", false
+	}
+	n = tokens.Next(n)
+	if !isReset && tokens.IsKeywordAt(n, "SESSION") {
+		n = tokens.Next(n)
+	}
+	if n < 0 || !tokens[n].IsName() {
+		return "", "", false
+	}
+	name := strings.ToLower(tokens[n].Value)
+	n = tokens.Next(n)
+	if isReset {
+		return name, "", n < 0
+	}
+	if !tokens.IsKeywordAt(n, "TO") && (n < 0 || !tokens[n].IsOperator("=")) {
+		return "", "", false
+	}
+	value := tokens.Next(n)
+	if value < 0 || tokens.Next(value) != -1 {
+		return "", "", false
+	}
+	switch tok := tokens[value]; {
+	case tok.IsKeyword("DEFAULT"):
+		return name, "", true
+	case tok.IsName(), tok.Type == StringToken, tok.Type == NumberToken:
+		return name, tok.Value, true
+	}
+	return "", "", false
+}
+
+// IsShowWarningsStatement returns true if the specified MySQL statement is SHOW WARNINGS, and true as the count
+// if the statement is SHOW COUNT(*) WARNINGS.
+func IsShowWarningsStatement(stmt string) (bool, bool) {
+	tokens, ok := singleStatementTokens(MySQL, stmt)
+	if !ok {
+		return false, false
+	}
+	keywords := []string{}
+	for _, tok := range tokens {
+		if tok.IsSignificant() {
+			keywords = append(keywords, strings.ToUpper(tok.Text))
+		}
+	}
+	switch strings.Join(keywords, " ") {
+	case "SHOW WARNINGS":
+		return false, true
+	case "SHOW COUNT ( * ) WARNINGS":
+		return true, true
+	}
+	return false, false
+}
+
+// singleStatementTokens returns the tokens of the specified query if the query has only one statement.
+func singleStatementTokens(d Dialect, query string) (Tokens, bool) {
+	tokens, err := NewLexerWith(d).Tokenize(query)
+	if err != nil {
+		return nil, false
+	}
+	stmts := tokens.Statements()
+	if len(stmts) != 1 {
+		return nil, false
+	}
+	return stmts[0].trimSpace(), true
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+// SQLite: The Schema Table
+// https://www.sqlite.org/schematab.html
+// SQLite: Prepared Statement Object
+// https://www.sqlite.org/c3ref/stmt.html
+
+import (
+	"slices"
+	"strings"
+)
+
+// NormalizedStatement returns the specified SQLite statement whose comments and white spaces are collapsed into single spaces,
+// so that the statements which differ only in the layout have the same text.
+func NormalizedStatement(stmt string) string {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return stmt
+	}
+	var b strings.Builder
+	space := false
+	for _, tok := range tokens {
+		if !tok.IsSignificant() {
+			space = true
+			continue
+		}
+		if space && 0 < b.Len() {
+			b.WriteString(" ")
+		}
+		space = false
+		b.WriteString(tok.Text)
+	}
+	return b.String()
+}
+
+// ReferencedTableNames returns the lower case table names which the specified SQLite statement refers to after FROM, JOIN, INTO
+// and UPDATE including the tables of the subqueries.
+func ReferencedTableNames(stmt string) []string {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return nil
+	}
+	names := []string{}
+	for _, n := range tokens.tableReferenceIndexes() {
+		name := strings.ToLower(tokens[n].Value)
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// SchemaChangedTableNames returns the lower case table names whose schemas are changed by the specified SQLite statement
+// such as CREATE TABLE, ALTER TABLE, DROP TABLE and CREATE INDEX, or false if the statement changes no schema.
+// The names are empty if the changed tables are unknown such as DROP INDEX, CREATE VIEW and CREATE TRIGGER.
+func SchemaChangedTableNames(stmt string) ([]string, bool) {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return nil, false
+	}
+	n := tokens.Next(-1)
+	if !tokens.IsKeywordAt(n, "CREATE") && !tokens.IsKeywordAt(n, "ALTER") && !tokens.IsKeywordAt(n, "DROP") {
+		return nil, false
+	}
+	object := tokens.Next(n)
+	for _, modifier := range []string{"TEMP", "TEMPORARY", "UNIQUE", "VIRTUAL"} {
+		if tokens.IsKeywordAt(object, modifier) {
+			object = tokens.Next(object)
+		}
+	}
+	names := []string{}
+	switch {
+	case tokens.IsKeywordAt(n, "DROP") && tokens.IsKeywordAt(object, "TABLE"):
+		names = DroppedTableNames(stmt)
+	case tokens.IsKeywordAt(object, "TABLE"):
+		names = append(names, tokens.tableNameAfter(object))
+	case tokens.IsKeywordAt(n, "CREATE") && tokens.IsKeywordAt(object, "INDEX"):
+		if on := tokens.indexTopLevelKeyword(object, "ON"); 0 <= on {
+			names = append(names, tokens.tableNameAfter(on))
+		}
+	}
+	lowerNames := []string{}
+	for _, name := range names {
+		if name != "" {
+			lowerNames = append(lowerNames, strings.ToLower(name))
+		}
+	}
+	return lowerNames, true
+}
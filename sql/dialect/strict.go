@@ -0,0 +1,198 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+// SQLite: STRICT Tables
+// https://www.sqlite.org/stricttables.html
+// SQLite: Datatypes In SQLite (Determination Of Column Affinity)
+// https://www.sqlite.org/datatype3.html#determination_of_column_affinity
+
+import (
+	"strconv"
+	"strings"
+)
+
+// The datatypes of the STRICT table columns.
+const (
+	StrictIntegerType = "INTEGER"
+	StrictIntType     = "INT"
+	StrictRealType    = "REAL"
+	StrictTextType    = "TEXT"
+	StrictBlobType    = "BLOB"
+	StrictAnyType     = "ANY"
+)
+
+// lengthConstraintPrefix is the name prefix of the CHECK constraints which restrict the character columns to the declared lengths.
+const lengthConstraintPrefix = HiddenColumnPrefix + "length:"
+
+// characterTypeNames represents the character types which have the declared lengths.
+var characterTypeNames = map[string]bool{
+	"CHAR": true, "CHARACTER": true, "BPCHAR": true, "NCHAR": true,
+	"VARCHAR": true, "CHARACTER VARYING": true, "NVARCHAR": true,
+}
+
+// StrictColumns rewrites the specified CREATE TABLE statement into the STRICT table, and returns the rewritten statement
+// and the upper case declared types of the columns whose types are rewritten. The column types are rewritten into
+// the STRICT datatypes of the type affinities such as INTEGER for BIGINT, and the character columns such as VARCHAR(10)
+// have the CHECK constraints which restrict the values to the declared lengths.
+// The statement is returned as it is if the statement is not CREATE TABLE or already STRICT.
+func StrictColumns(stmt string) (string, map[string]string, error) {
+	tokens, err := NewLexerWith(SQLite).Tokenize(stmt)
+	if err != nil {
+		return "", nil, err
+	}
+	defs, ok := tokens.columnDefs()
+	if !ok {
+		return stmt, nil, nil
+	}
+	_, closing, _ := tokens.tableElements()
+	for n := closing + 1; n < len(tokens); n++ {
+		if tokens[n].IsKeyword("STRICT") {
+			return stmt, nil, nil
+		}
+	}
+	// The table options such as WITHOUT ROWID are separated by commas.
+	option := Tokens{NewSpaceToken(), NewWordToken("STRICT")}
+	end := tokens.Prev(len(tokens))
+	if 0 <= end && tokens[end].IsPunctuation(";") {
+		end = tokens.Prev(end)
+	}
+	if closing < end {
+		option = append(Tokens{NewPunctuationToken(",")}, option...)
+	}
+	tokens = tokens.Splice(end+1, end, option...)
+	declTypes := map[string]string{}
+	// Rewrite the column definitions from the end not to shift the indexes.
+	for n := len(defs) - 1; 0 <= n; n-- {
+		def := defs[n]
+		if def.typeEnd < 0 {
+			continue
+		}
+		declType := strings.ToUpper(tokens[def.typeBegin : def.typeEnd+1].String())
+		typeTokens := Tokens{NewWordToken(strictTypeOf(def.typeName))}
+		if length, ok := tokens[def.typeBegin : def.typeEnd+1].characterLengthOf(def.typeName); ok {
+			typeTokens = append(typeTokens, lengthConstraintTokens(def.name, def.typeName, length)...)
+		}
+		if declType == typeTokens[0].Text && len(typeTokens) == 1 {
+			continue
+		}
+		declTypes[def.name] = declType
+		tokens = tokens.Splice(def.typeBegin, def.typeEnd, typeTokens...)
+	}
+	return tokens.String(), declTypes, nil
+}
+
+// strictTypeOf returns the STRICT datatype of the specified upper case type name by the type affinity rules of SQLite,
+// and the array types which are stored as the texts are TEXT. The NUMERIC affinity types such as BOOLEAN and DATE are ANY
+// because the values are stored as they are. The integer types except INTEGER are INT not to make the primary keys
+// the row ID aliases.
+func strictTypeOf(typeName string) string {
+	switch {
+	case IsArrayTypeName(typeName):
+		return StrictTextType
+	case typeName == StrictIntegerType:
+		return StrictIntegerType
+	case strings.Contains(typeName, "INT"):
+		return StrictIntType
+	case strings.Contains(typeName, "CHAR"), strings.Contains(typeName, "CLOB"), strings.Contains(typeName, "TEXT"):
+		return StrictTextType
+	case strings.Contains(typeName, "BLOB"):
+		return StrictBlobType
+	case strings.Contains(typeName, "REAL"), strings.Contains(typeName, "FLOA"), strings.Contains(typeName, "DOUB"):
+		return StrictRealType
+	}
+	return StrictAnyType
+}
+
+// characterLengthOf returns the declared length of the specified character type tokens such as VARCHAR(10).
+func (tokens Tokens) characterLengthOf(typeName string) (int, bool) {
+	if !characterTypeNames[typeName] {
+		return 0, false
+	}
+	params := []string{}
+	for _, tok := range tokens {
+		if tok.Type == NumberToken {
+			params = append(params, tok.Text)
+		}
+	}
+	if len(params) != 1 {
+		return 0, false
+	}
+	length, err := strconv.Atoi(params[0])
+	if err != nil {
+		return 0, false
+	}
+	return length, true
+}
+
+// lengthConstraintTokens returns the CHECK constraint tokens which restrict the specified character column to the length.
+func lengthConstraintTokens(column string, typeName string, length int) Tokens {
+	return Tokens{
+		NewSpaceToken(), NewWordToken("CONSTRAINT"),
+		NewSpaceToken(), NewIdentifierToken(lengthConstraintPrefix + typeName + ":" + strconv.Itoa(length) + ":" + column),
+		NewSpaceToken(), NewWordToken("CHECK"), NewSpaceToken(), NewPunctuationToken("("),
+		NewWordToken("length"), NewPunctuationToken("("), NewIdentifierToken(column), NewPunctuationToken(")"),
+		NewSpaceToken(), NewOperatorToken("<="), NewSpaceToken(), &Token{Type: NumberToken, Text: strconv.Itoa(length), Value: strconv.Itoa(length)},
+		NewPunctuationToken(")"),
+	}
+}
+
+// LengthConstraintOf returns the column name, the upper case character type name and the declared length
+// of the specified CHECK constraint failure message of a character column.
+func LengthConstraintOf(msg string) (string, string, int, bool) {
+	_, constraint, ok := strings.Cut(msg, lengthConstraintPrefix)
+	if !ok {
+		return "", "", 0, false
+	}
+	typeName, constraint, _ := strings.Cut(constraint, ":")
+	length, column, ok := strings.Cut(constraint, ":")
+	if !ok {
+		return "", "", 0, false
+	}
+	n, err := strconv.Atoi(length)
+	if err != nil {
+		return "", "", 0, false
+	}
+	// The failure message may be followed by the other messages.
+	if idx := strings.IndexAny(column, " \n"); 0 <= idx {
+		column = column[:idx]
+	}
+	return column, typeName, n, true
+}
+
+// StrictDatatypeErrorOf returns the table name, the column name and the STRICT datatype of the column
+// of the specified datatype error message such as "cannot store TEXT value in INTEGER column t.c".
+func StrictDatatypeErrorOf(msg string) (string, string, string, bool) {
+	_, msg, ok := strings.Cut(msg, "cannot store ")
+	if !ok {
+		return "", "", "", false
+	}
+	_, msg, ok = strings.Cut(msg, " value in ")
+	if !ok {
+		return "", "", "", false
+	}
+	datatype, column, ok := strings.Cut(msg, " column ")
+	if !ok {
+		return "", "", "", false
+	}
+	if idx := strings.IndexAny(column, " \n"); 0 <= idx {
+		column = column[:idx]
+	}
+	idx := strings.LastIndex(column, ".")
+	if idx < 0 {
+		return "", "", "", false
+	}
+	return column[:idx], column[idx+1:], datatype, true
+}
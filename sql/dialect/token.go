@@ -0,0 +1,257 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+import (
+	"strings"
+)
+
+// TokenType represents a lexical token type.
+type TokenType int
+
+const (
+	// SpaceToken represents white spaces.
+	SpaceToken TokenType = iota
+	// CommentToken represents a comment.
+	CommentToken
+	// WordToken represents an unquoted keyword or identifier.
+	WordToken
+	// IdentifierToken represents a quoted identifier.
+	IdentifierToken
+	// StringToken represents a string literal.
+	StringToken
+	// NumberToken represents a numeric literal.
+	NumberToken
+	// ParameterToken represents a bind parameter.
+	ParameterToken
+	// OperatorToken represents an operator.
+	OperatorToken
+	// PunctuationToken represents a punctuation such as parentheses, commas, semicolons and dots.
+	PunctuationToken
+)
+
+// Token represents a lexical token.
+type Token struct {
+	// Type is the token type.
+	Type TokenType
+	// Text is the SQLite representation of the token.
+	Text string
+	// Value is the unquoted value for string literals and quoted identifiers, otherwise the same as Text.
+	Value string
+}
+
+// Tokens represents a token list.
+type Tokens []*Token
+
+// NewWordToken returns a new word token.
+func NewWordToken(word string) *Token {
+	return &Token{Type: WordToken, Text: word, Value: word}
+}
+
+// NewIdentifierToken returns a new quoted identifier token.
+func NewIdentifierToken(name string) *Token {
+	return &Token{Type: IdentifierToken, Text: QuoteIdentifier(name), Value: name}
+}
+
+// NewStringToken returns a new string literal token. The strings which have the NUL characters are represented as
+// the concatenations with char(0) because the SQLite statements are terminated by the NUL characters.
+func NewStringToken(s string) *Token {
+	if !strings.Contains(s, "\x00") {
+		return &Token{Type: StringToken, Text: QuoteString(s), Value: s}
+	}
+	exprs := []string{}
+	for n, part := range strings.Split(s, "\x00") {
+		if 0 < n {
+			exprs = append(exprs, "char(0)")
+		}
+		if part != "" {
+			exprs = append(exprs, QuoteString(part))
+		}
+	}
+	text := "(" + strings.Join(exprs, " || ") + ")"
+	return &Token{Type: StringToken, Text: text, Value: s}
+}
+
+// NewOperatorToken returns a new operator token.
+func NewOperatorToken(ope string) *Token {
+	return &Token{Type: OperatorToken, Text: ope, Value: ope}
+}
+
+// NewPunctuationToken returns a new punctuation token.
+func NewPunctuationToken(p string) *Token {
+	return &Token{Type: PunctuationToken, Text: p, Value: p}
+}
+
+// NewSpaceToken returns a new single space token.
+func NewSpaceToken() *Token {
+	return &Token{Type: SpaceToken, Text: " ", Value: " "}
+}
+
+// QuoteIdentifier returns the SQLite quoted identifier.
+func QuoteIdentifier(name string) string {
+	return "\"" + strings.ReplaceAll(name, "\"", "\"\"") + "\""
+}
+
+// QuoteString returns the SQLite string literal.
+func QuoteString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// IsSignificant returns true if the token is neither a space nor a comment.
+func (tok *Token) IsSignificant() bool {
+	return tok.Type != SpaceToken && tok.Type != CommentToken
+}
+
+// IsKeyword returns true if the token is the specified keyword.
+func (tok *Token) IsKeyword(keyword string) bool {
+	return tok.Type == WordToken && strings.EqualFold(tok.Text, keyword)
+}
+
+// IsPunctuation returns true if the token is the specified punctuation.
+func (tok *Token) IsPunctuation(p string) bool {
+	return tok.Type == PunctuationToken && tok.Text == p
+}
+
+// IsOperator returns true if the token is the specified operator.
+func (tok *Token) IsOperator(ope string) bool {
+	return tok.Type == OperatorToken && tok.Text == ope
+}
+
+// IsName returns true if the token is an unquoted or a quoted identifier.
+func (tok *Token) IsName() bool {
+	return tok.Type == WordToken || tok.Type == IdentifierToken
+}
+
+// String returns the SQLite representation of the tokens.
+func (tokens Tokens) String() string {
+	var b strings.Builder
+	for _, tok := range tokens {
+		b.WriteString(tok.Text)
+	}
+	return b.String()
+}
+
+// Next returns the index of the next significant token after the specified index, or -1 if not found.
+func (tokens Tokens) Next(idx int) int {
+	for n := idx + 1; n < len(tokens); n++ {
+		if tokens[n].IsSignificant() {
+			return n
+		}
+	}
+	return -1
+}
+
+// Prev returns the index of the previous significant token before the specified index, or -1 if not found.
+func (tokens Tokens) Prev(idx int) int {
+	for n := idx - 1; 0 <= n; n-- {
+		if tokens[n].IsSignificant() {
+			return n
+		}
+	}
+	return -1
+}
+
+// IsKeywordAt returns true if the token at the specified index is the specified keyword.
+func (tokens Tokens) IsKeywordAt(idx int, keyword string) bool {
+	if idx < 0 || len(tokens) <= idx {
+		return false
+	}
+	return tokens[idx].IsKeyword(keyword)
+}
+
+// IsKeywordsAt returns true if the significant tokens from the specified index are the specified keywords.
+func (tokens Tokens) IsKeywordsAt(idx int, keywords ...string) bool {
+	for n, keyword := range keywords {
+		if !tokens.IsKeywordAt(idx, keyword) {
+			return false
+		}
+		if n < len(keywords)-1 {
+			idx = tokens.Next(idx)
+		}
+	}
+	return true
+}
+
+// MatchingParen returns the index of the closing parenthesis which matches the opening parenthesis at the specified index, or -1 if not found.
+func (tokens Tokens) MatchingParen(idx int) int {
+	depth := 0
+	for n := idx; n < len(tokens); n++ {
+		switch {
+		case tokens[n].IsPunctuation("("):
+			depth++
+		case tokens[n].IsPunctuation(")"):
+			depth--
+			if depth == 0 {
+				return n
+			}
+		}
+	}
+	return -1
+}
+
+// MatchingOpenParen returns the index of the opening parenthesis which matches the closing parenthesis at the specified index, or -1 if not found.
+func (tokens Tokens) MatchingOpenParen(idx int) int {
+	depth := 0
+	for n := idx; 0 <= n; n-- {
+		switch {
+		case tokens[n].IsPunctuation(")"):
+			depth++
+		case tokens[n].IsPunctuation("("):
+			depth--
+			if depth == 0 {
+				return n
+			}
+		}
+	}
+	return -1
+}
+
+// Splice returns new tokens which replaces the tokens from the begin index to the end index (inclusive) with the specified tokens.
+func (tokens Tokens) Splice(begin int, end int, repl ...*Token) Tokens {
+	spliced := make(Tokens, 0, len(tokens)-(end-begin+1)+len(repl))
+	spliced = append(spliced, tokens[:begin]...)
+	spliced = append(spliced, repl...)
+	spliced = append(spliced, tokens[end+1:]...)
+	return spliced
+}
+
+// Statements splits the tokens into statements by top-level semicolons.
+func (tokens Tokens) Statements() []Tokens {
+	stmts := []Tokens{}
+	stmt := Tokens{}
+	depth := 0
+	for _, tok := range tokens {
+		switch {
+		case tok.IsPunctuation("("):
+			depth++
+		case tok.IsPunctuation(")"):
+			depth--
+		case tok.IsPunctuation(";") && depth == 0:
+			stmts = append(stmts, stmt)
+			stmt = Tokens{}
+			continue
+		}
+		stmt = append(stmt, tok)
+	}
+	stmts = append(stmts, stmt)
+	nonEmptyStmts := []Tokens{}
+	for _, stmt := range stmts {
+		if stmt.Next(-1) < 0 {
+			continue
+		}
+		nonEmptyStmts = append(nonEmptyStmts, stmt)
+	}
+	return nonEmptyStmts
+}
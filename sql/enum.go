@@ -0,0 +1,290 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// PostgreSQL: Documentation: 16: CREATE TYPE
+// https://www.postgresql.org/docs/16/sql-createtype.html
+// SQLite: Define New Collating Sequences
+// https://www.sqlite.org/c3ref/create_collation.html
+// SQLite: Collation Needed Callbacks
+// https://www.sqlite.org/c3ref/collation_needed.html
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/cybergarage/go-sqlserver/sql/dialect"
+	"github.com/cybergarage/go-sqlserver/sql/function"
+	"github.com/ncruces/go-sqlite3"
+	sqlite3driver "github.com/ncruces/go-sqlite3/driver"
+)
+
+// typeTableName is the hidden table which keeps the enum types, because SQLite has no user-defined types.
+var typeTableName = dialect.QuoteIdentifier(dialect.HiddenColumnPrefix + "types")
+
+// enumObjectIDBase is the first OID of the enum types, because PostgreSQL assigns the OIDs of the user-defined objects from 16384.
+const enumObjectIDBase = 16384
+
+// enumType represents an enum type in the side catalog.
+type enumType struct {
+	*dialect.Enum
+	objectID int32
+}
+
+// EnumValueError represents an error of a value which is not a label of the enum column.
+type EnumValueError struct {
+	Column string
+	Enum   *dialect.Enum
+}
+
+// Error returns the error message.
+func (err *EnumValueError) Error() string {
+	return fmt.Sprintf("invalid input value for %s column (%s)", err.Enum.TypeName(), err.Column)
+}
+
+// loadEnumTypes creates the enum type table if not exists, and loads and registers the enum types.
+func (db *Database) loadEnumTypes() error {
+	_, err := db.exec("CREATE TABLE IF NOT EXISTS " + typeTableName + " (type_name TEXT PRIMARY KEY, labels TEXT, oid INTEGER)")
+	if err != nil {
+		return err
+	}
+	rows, err := db.Query("SELECT type_name, labels, oid FROM " + typeTableName)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	enums := []*enumType{}
+	for rows.Next() {
+		var typeName, labels string
+		var oid int32
+		if err := rows.Scan(&typeName, &labels, &oid); err != nil {
+			return err
+		}
+		kind, name, ok := dialect.EnumTypeOf(typeName)
+		if !ok {
+			return newErrInvalid(typeName)
+		}
+		enum := &enumType{Enum: &dialect.Enum{Kind: kind, Name: name}, objectID: oid}
+		if err := json.Unmarshal([]byte(labels), &enum.Labels); err != nil {
+			return err
+		}
+		enums = append(enums, enum)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, enum := range enums {
+		if err := db.registerEnumType(enum); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enumCollation represents the collation of an enum type which is shared by the connections of the sessions.
+// The comparison function is replaced when the enum type is created again with the other labels,
+// because SQLite keeps the collations registered on the connections.
+type enumCollation struct {
+	compare atomic.Pointer[func(a, b []byte) int]
+}
+
+// Compare compares the specified values with the current comparison function of the enum type.
+func (collation *enumCollation) Compare(a, b []byte) int {
+	return (*collation.compare.Load())(a, b)
+}
+
+// registerEnumType registers the collation of the specified enum type, and adds the enum type to the side catalog.
+// The collation is created on the connections when the statements use it at first.
+func (db *Database) registerEnumType(enum *enumType) error {
+	compare := function.NewEnumCollation(enum.Labels)
+	if enum.Kind == dialect.SetType {
+		compare = function.NewSetCollation(enum.Labels)
+	}
+	db.mutex.Lock()
+	collation, ok := db.collations[enum.TypeName()]
+	if !ok {
+		collation = &enumCollation{}
+		db.collations[enum.TypeName()] = collation
+	}
+	collation.compare.Store(&compare)
+	db.enums[enum.TypeName()] = enum
+	db.mutex.Unlock()
+	return nil
+}
+
+// setCollationNeeded sets the callback of the connection which creates the collations of the enum types when they are used,
+// because the enum types are created by the other sessions after the connection is opened.
+func (db *Database) setCollationNeeded() error {
+	return db.conn.Raw(func(driverConn any) error {
+		conn, ok := driverConn.(sqlite3driver.Conn)
+		if !ok {
+			return newErrNotSupported(driverConn)
+		}
+		return conn.Raw().CollationNeeded(func(c *sqlite3.Conn, name string) {
+			db.mutex.Lock()
+			collation, ok := db.collations[name]
+			db.mutex.Unlock()
+			if ok {
+				c.CreateCollation(name, collation.Compare)
+			}
+		})
+	})
+}
+
+// createEnumType registers the specified enum type, and stores the enum type into the enum type table.
+func (db *Database) createEnumType(enum *dialect.Enum) error {
+	labels, err := json.Marshal(enum.Labels)
+	if err != nil {
+		return err
+	}
+	db.mutex.Lock()
+	oid := int32(enumObjectIDBase)
+	for _, e := range db.enums {
+		if oid <= e.objectID {
+			oid = e.objectID + 1
+		}
+	}
+	db.mutex.Unlock()
+	_, err = db.exec("INSERT OR REPLACE INTO "+typeTableName+" (type_name, labels, oid) VALUES (?, ?, ?)", enum.TypeName(), string(labels), oid)
+	if err != nil {
+		return err
+	}
+	return db.registerEnumType(&enumType{Enum: enum, objectID: oid})
+}
+
+// deleteEnumType deletes the specified enum type from the enum type table.
+// The collation is kept registered because SQLite has no way to unregister it.
+func (db *Database) deleteEnumType(typeName string) error {
+	if _, err := db.exec("DELETE FROM "+typeTableName+" WHERE type_name = ?", typeName); err != nil {
+		return err
+	}
+	db.mutex.Lock()
+	delete(db.enums, typeName)
+	db.mutex.Unlock()
+	return nil
+}
+
+// execEnumTypeStatement executes the specified CREATE TYPE or DROP TYPE statement on the side catalog.
+func (db *Database) execEnumTypeStatement(query string) (sql.Result, error) {
+	if dialect.LeadingKeyword(query) == "CREATE" {
+		enum, err := dialect.CreatedEnumType(query)
+		if err != nil {
+			return nil, err
+		}
+		if _, _, ok := db.EnumType(enum.TypeName()); ok {
+			return nil, newErrTypeExist(enum.Name)
+		}
+		if err := db.createEnumType(enum); err != nil {
+			return nil, err
+		}
+		return driver.RowsAffected(0), nil
+	}
+	names, ifExists, err := dialect.DroppedTypeNames(query)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		typeName := (&dialect.Enum{Kind: dialect.EnumType, Name: name}).TypeName()
+		if _, _, ok := db.EnumType(typeName); !ok {
+			if ifExists {
+				continue
+			}
+			return nil, newErrTypeNotExist(name)
+		}
+		if db.hasCatalogType(typeName) {
+			return nil, newErrTypeDependent(name)
+		}
+		if err := db.deleteEnumType(typeName); err != nil {
+			return nil, err
+		}
+	}
+	return driver.RowsAffected(0), nil
+}
+
+// rewriteEnumColumns rewrites the enum columns of the specified CREATE TABLE statement, and registers the inline enum types
+// such as the MySQL ENUM and SET column types before the table is created with the collations.
+func (db *Database) rewriteEnumColumns(query string) (string, map[string]*dialect.Enum, error) {
+	db.mutex.Lock()
+	enums := map[string]*dialect.Enum{}
+	for _, enum := range db.enums {
+		if enum.Kind == dialect.EnumType {
+			enums[enum.Name] = enum.Enum
+		}
+	}
+	db.mutex.Unlock()
+	query, columns, err := dialect.EnumColumns(query, enums)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, enum := range columns {
+		if enums[enum.Name] == enum {
+			continue
+		}
+		if err := db.createEnumType(enum); err != nil {
+			return "", nil, err
+		}
+	}
+	return query, columns, nil
+}
+
+// deleteInlineEnumTypes deletes the inline enum types of the columns of the specified table.
+func (db *Database) deleteInlineEnumTypes(tblName string) error {
+	db.mutex.Lock()
+	typeNames := []string{}
+	for typeName, enum := range db.enums {
+		if strings.HasPrefix(enum.Name, strings.ToLower(tblName)+".") {
+			typeNames = append(typeNames, typeName)
+		}
+	}
+	db.mutex.Unlock()
+	for _, typeName := range typeNames {
+		if err := db.deleteEnumType(typeName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EnumType returns the enum type and the OID of the specified column type name such as ENUM:mood.
+func (db *Database) EnumType(typeName string) (*dialect.Enum, int32, bool) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	enum, ok := db.enums[typeName]
+	if !ok {
+		return nil, 0, false
+	}
+	return enum.Enum, enum.objectID, true
+}
+
+// enumValueErrorOf returns the enum value error if the specified error is a CHECK constraint failure of an enum column,
+// otherwise returns the specified error as it is.
+func (db *Database) enumValueErrorOf(err error) error {
+	if err == nil {
+		return nil
+	}
+	column, typeName, ok := dialect.EnumConstraintOf(err.Error())
+	if !ok {
+		return err
+	}
+	enum, _, ok := db.EnumType(typeName)
+	if !ok {
+		return err
+	}
+	return &EnumValueError{Column: column, Enum: enum}
+}
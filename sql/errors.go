@@ -75,6 +75,30 @@ func newErrSchemaNotExist(obj string) error {
 	return newErrNotExist(fmt.Sprintf("schema (%s)", obj))
 }
 
+func newErrTypeExist(obj string) error {
+	return newErrExist(fmt.Sprintf("type (%s)", obj))
+}
+
+func newErrTypeNotExist(obj string) error {
+	return newErrNotExist(fmt.Sprintf("type (%s)", obj))
+}
+
+func newErrTypeDependent(obj string) error {
+	return newErrInvalid(fmt.Sprintf("dropping type (%s) used by columns", obj))
+}
+
+func newErrCursorExist(obj string) error {
+	return newErrExist(fmt.Sprintf("cursor (%s)", obj))
+}
+
+func newErrCursorNotExist(obj string) error {
+	return newErrNotExist(fmt.Sprintf("cursor (%s)", obj))
+}
+
+func newErrCursorNotInTransaction(obj string) error {
+	return newErrInvalid(fmt.Sprintf("cursor (%s) declared outside of transaction blocks", obj))
+}
+
 func newErrIndexNotSupported(obj string) error {
 	return newErrNotSupported(fmt.Sprintf("index (%s)", obj))
 }
@@ -99,6 +123,10 @@ func newErrConnectionExist(obj string) error {
 	return newErrExist(fmt.Sprintf("connection (%s)", obj))
 }
 
+func newErrInvalidValue(typeName string, obj any) error {
+	return newErrInvalid(fmt.Sprintf("%s value (%v)", typeName, obj))
+}
+
 // Not implemented error functions
 
 func newErrJoinQueryNotSupported(obj any) error {
@@ -24,12 +24,13 @@ import (
 	"github.com/cybergarage/go-sqlparser/sql/query"
 	sql "github.com/cybergarage/go-sqlparser/sql/query/response/resultset"
 	"github.com/cybergarage/go-sqlparser/sql/system"
+	"github.com/cybergarage/go-sqlserver/sql/dialect"
 )
 
 // Begin should handle a BEGIN statement.
 func (server *server) Begin(conn net.Conn, stmt query.Begin) error {
 	log.Debugf("%v", stmt)
-	db, err := server.LookupDatabase(conn.Database())
+	db, err := server.databaseOf(conn)
 	if err != nil {
 		return err
 	}
@@ -39,7 +40,7 @@ func (server *server) Begin(conn net.Conn, stmt query.Begin) error {
 // Commit should handle a COMMIT statement.
 func (server *server) Commit(conn net.Conn, stmt query.Commit) error {
 	log.Debugf("%v", stmt)
-	db, err := server.LookupDatabase(conn.Database())
+	db, err := server.databaseOf(conn)
 	if err != nil {
 		return err
 	}
@@ -49,7 +50,7 @@ func (server *server) Commit(conn net.Conn, stmt query.Commit) error {
 // Rollback should handle a ROLLBACK statement.
 func (server *server) Rollback(conn net.Conn, stmt query.Rollback) error {
 	log.Debugf("%v", stmt)
-	db, err := server.LookupDatabase(conn.Database())
+	db, err := server.databaseOf(conn)
 	if err != nil {
 		return err
 	}
@@ -87,6 +88,11 @@ func (server *server) CreateDatabase(conn net.Conn, stmt query.CreateDatabase) e
 		filename := fmt.Sprintf("%s.%s", dbName, DatabaseFilenameExt)
 		opts = append(opts, WithDatabaseFilename(filename))
 	}
+	ok, err = server.IsStrictModeEnabled(dbName)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, WithDatabaseStrictMode(ok))
 
 	db, err := NewDatabaseWith(opts...)
 	if err != nil {
@@ -119,21 +125,31 @@ func (server *server) DropDatabase(conn net.Conn, stmt query.DropDatabase) error
 	return server.Databases.DropDatabase(db)
 }
 
-// CreateTable should handle a CREATE table statement.
+// CreateTable should handle a CREATE table statement. The statement is rewritten as the other statements
+// because the column types such as DECIMAL(12, 2) and BIGINT UNSIGNED are stored as the internal types.
 func (server *server) CreateTable(conn net.Conn, stmt query.CreateTable) error {
 	log.Debugf("%v", stmt)
-	db, err := server.LookupDatabase(conn.Database())
+	db, err := server.databaseOf(conn)
 	if err != nil {
 		return err
 	}
-	_, err = db.Exec(stmt.String())
-	return err
+	// The inline index definitions are rewritten into the CREATE INDEX statements which follow CREATE TABLE.
+	stmts, err := rewriteQuery(conn, stmt.String())
+	if err != nil {
+		return err
+	}
+	for _, q := range stmts {
+		if _, err := db.Exec(q); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // AlterTable should handle a ALTER table statement.
 func (server *server) AlterTable(conn net.Conn, stmt query.AlterTable) error {
 	log.Debugf("%v", stmt)
-	db, err := server.LookupDatabase(conn.Database())
+	db, err := server.databaseOf(conn)
 	if err != nil {
 		return err
 	}
@@ -157,7 +173,7 @@ func (server *server) AlterTable(conn net.Conn, stmt query.AlterTable) error {
 // DropTable should handle a DROP table statement.
 func (server *server) DropTable(conn net.Conn, stmt query.DropTable) error {
 	log.Debugf("%v", stmt)
-	db, err := server.LookupDatabase(conn.Database())
+	db, err := server.databaseOf(conn)
 	if err != nil {
 		return err
 	}
@@ -165,62 +181,76 @@ func (server *server) DropTable(conn net.Conn, stmt query.DropTable) error {
 	return err
 }
 
+// Insert should handle a INSERT statement.
 func (server *server) Insert(conn net.Conn, stmt query.Insert) error {
+	_, err := server.insert(conn, stmt)
+	return err
+}
+
+// insert executes the specified INSERT statement, and returns the result set which has the affected rows, the last insert ID
+// and the warnings.
+// The query executors of MySQL and PostgreSQL return them in the responses because the SQL executor returns only the error.
+func (server *server) insert(conn net.Conn, stmt query.Insert) (sql.ResultSet, error) {
 	log.Debugf("%v", stmt)
-	db, err := server.LookupDatabase(conn.Database())
+	db, err := server.databaseOf(conn)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	_, err = db.Exec(stmt.String())
-	return err
+	q, err := rewriteStatement(conn, stmt.String())
+	if err != nil {
+		return nil, err
+	}
+	return server.exec(conn, db, q)
 }
 
 // Update should handle a UPDATE statement.
 func (server *server) Update(conn net.Conn, stmt query.Update) (sql.ResultSet, error) {
 	log.Debugf("%v", stmt)
-	db, err := server.LookupDatabase(conn.Database())
+	db, err := server.databaseOf(conn)
 	if err != nil {
 		return nil, err
 	}
-	result, err := db.Exec(stmt.String())
+	q, err := rewriteStatement(conn, stmt.String())
 	if err != nil {
 		return nil, err
 	}
-	return NewResultSet(
-		WithResultSetResult(result),
-	)
+	if dialect.HasReturningClause(q) {
+		// The returned rows are sent as the result set instead of the affected rows.
+		return server.executeStatement(conn, q)
+	}
+	return server.exec(conn, db, q)
 }
 
 // Delete should handle a DELETE statement.
 func (server *server) Delete(conn net.Conn, stmt query.Delete) (sql.ResultSet, error) {
 	log.Debugf("%v", stmt)
-	db, err := server.LookupDatabase(conn.Database())
+	db, err := server.databaseOf(conn)
 	if err != nil {
 		return nil, err
 	}
-	result, err := db.Exec(stmt.String())
+	q, err := rewriteStatement(conn, stmt.String())
 	if err != nil {
 		return nil, err
 	}
-	return NewResultSet(
-		WithResultSetResult(result),
-	)
+	if dialect.HasReturningClause(q) {
+		// The returned rows are sent as the result set instead of the affected rows.
+		return server.executeStatement(conn, q)
+	}
+	return server.exec(conn, db, q)
 }
 
 // Select should handle a SELECT statement.
 func (server *server) Select(conn net.Conn, stmt query.Select) (sql.ResultSet, error) {
 	log.Debugf("%v", stmt)
-	db, err := server.LookupDatabase(conn.Database())
+	db, err := server.databaseOf(conn)
 	if err != nil {
 		return nil, err
 	}
-	rows, err := db.Query(stmt.String())
+	q, err := rewriteStatement(conn, stmt.String())
 	if err != nil {
 		return nil, err
 	}
-	return NewResultSet(
-		WithResultSetRows(rows),
-	)
+	return server.query(conn, db, q)
 }
 
 // SystemSelect should handle a system SELECT statement.
@@ -0,0 +1,186 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+// PostgreSQL: Documentation: 16: 9.21. Aggregate Functions
+// https://www.postgresql.org/docs/16/functions-aggregate.html
+// MySQL :: MySQL 8.0 Reference Manual :: 14.19.1 Aggregate Function Descriptions
+// https://dev.mysql.com/doc/refman/8.0/en/aggregate-functions.html
+
+import (
+	"math"
+	"strings"
+
+	"github.com/ncruces/go-sqlite3"
+)
+
+var aggregateFunctions = []aggregateFunction{
+	// Common functions
+	{"stddev", 1, newVarianceAggregate(true, true)},
+	{"stddev_samp", 1, newVarianceAggregate(true, true)},
+	{"stddev_pop", 1, newVarianceAggregate(true, false)},
+	{"variance", 1, newVarianceAggregate(false, true)},
+	{"var_samp", 1, newVarianceAggregate(false, true)},
+	{"var_pop", 1, newVarianceAggregate(false, false)},
+	{"bit_and", 1, newBitAggregate(func(a, b int64) int64 { return a & b }, -1)},
+	{"bit_or", 1, newBitAggregate(func(a, b int64) int64 { return a | b }, 0)},
+	{"decimal_sum", 1, newDecimalSumAggregate},
+	// PostgreSQL functions
+	{"array_agg", 1, newArrayAggregate},
+	{"bool_and", 1, newBoolAggregate(true)},
+	{"every", 1, newBoolAggregate(true)},
+	{"bool_or", 1, newBoolAggregate(false)},
+	// MySQL functions
+	{MySQLPrefix + "stddev", 1, newVarianceAggregate(true, false)},
+	{MySQLPrefix + "variance", 1, newVarianceAggregate(false, false)},
+	{"std", 1, newVarianceAggregate(true, false)},
+	{"bit_xor", 1, newBitAggregate(func(a, b int64) int64 { return a ^ b }, 0)},
+}
+
+// arrayAggregate represents the array_agg() aggregate function which returns the PostgreSQL array literal such as {1,2,NULL}.
+type arrayAggregate struct {
+	elems []string
+}
+
+func newArrayAggregate() sqlite3.AggregateFunction {
+	return &arrayAggregate{elems: []string{}}
+}
+
+// Step adds the specified value to the array.
+func (agg *arrayAggregate) Step(ctx sqlite3.Context, args ...sqlite3.Value) {
+	agg.elems = append(agg.elems, arrayElement(args[0]))
+}
+
+// Value returns the array literal.
+func (agg *arrayAggregate) Value(ctx sqlite3.Context) {
+	if len(agg.elems) == 0 {
+		ctx.ResultNull()
+		return
+	}
+	ctx.ResultText("{" + strings.Join(agg.elems, ",") + "}")
+}
+
+// boolAggregate represents the bool_and() and bool_or() aggregate functions.
+type boolAggregate struct {
+	isAnd  bool
+	result *bool
+}
+
+func newBoolAggregate(isAnd bool) func() sqlite3.AggregateFunction {
+	return func() sqlite3.AggregateFunction {
+		return &boolAggregate{isAnd: isAnd, result: nil}
+	}
+}
+
+// Step adds the specified value to the aggregation, and ignores NULL values.
+func (agg *boolAggregate) Step(ctx sqlite3.Context, args ...sqlite3.Value) {
+	if args[0].Type() == sqlite3.NULL {
+		return
+	}
+	v := args[0].Bool()
+	if agg.result == nil {
+		agg.result = &v
+		return
+	}
+	if agg.isAnd {
+		*agg.result = *agg.result && v
+	} else {
+		*agg.result = *agg.result || v
+	}
+}
+
+// Value returns the aggregated boolean value, or NULL if all values are NULL.
+func (agg *boolAggregate) Value(ctx sqlite3.Context) {
+	if agg.result == nil {
+		ctx.ResultNull()
+		return
+	}
+	ctx.ResultBool(*agg.result)
+}
+
+// bitAggregate represents the bitwise aggregate functions.
+type bitAggregate struct {
+	op     func(int64, int64) int64
+	result int64
+	isNull bool
+}
+
+func newBitAggregate(op func(int64, int64) int64, init int64) func() sqlite3.AggregateFunction {
+	return func() sqlite3.AggregateFunction {
+		return &bitAggregate{op: op, result: init, isNull: true}
+	}
+}
+
+// Step adds the specified value to the aggregation, and ignores NULL values.
+func (agg *bitAggregate) Step(ctx sqlite3.Context, args ...sqlite3.Value) {
+	if args[0].Type() == sqlite3.NULL {
+		return
+	}
+	agg.result = agg.op(agg.result, args[0].Int64())
+	agg.isNull = false
+}
+
+// Value returns the aggregated value, or NULL if all values are NULL.
+func (agg *bitAggregate) Value(ctx sqlite3.Context) {
+	if agg.isNull {
+		ctx.ResultNull()
+		return
+	}
+	ctx.ResultInt64(agg.result)
+}
+
+// varianceAggregate represents the variance and standard deviation aggregate functions with the Welford's online algorithm.
+type varianceAggregate struct {
+	isStddev bool
+	isSample bool
+	count    int64
+	mean     float64
+	m2       float64
+}
+
+func newVarianceAggregate(isStddev bool, isSample bool) func() sqlite3.AggregateFunction {
+	return func() sqlite3.AggregateFunction {
+		return &varianceAggregate{isStddev: isStddev, isSample: isSample}
+	}
+}
+
+// Step adds the specified value to the aggregation, and ignores NULL values.
+func (agg *varianceAggregate) Step(ctx sqlite3.Context, args ...sqlite3.Value) {
+	if args[0].Type() == sqlite3.NULL {
+		return
+	}
+	v := args[0].Float()
+	agg.count++
+	delta := v - agg.mean
+	agg.mean += delta / float64(agg.count)
+	agg.m2 += delta * (v - agg.mean)
+}
+
+// Value returns the variance or the standard deviation, or NULL if there are not enough values.
+func (agg *varianceAggregate) Value(ctx sqlite3.Context) {
+	n := agg.count
+	if agg.isSample {
+		n--
+	}
+	if n <= 0 {
+		ctx.ResultNull()
+		return
+	}
+	v := agg.m2 / float64(n)
+	if agg.isStddev {
+		v = math.Sqrt(v)
+	}
+	ctx.ResultFloat(v)
+}
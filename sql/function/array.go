@@ -0,0 +1,661 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+// PostgreSQL: Documentation: 16: 8.15. Arrays
+// https://www.postgresql.org/docs/16/arrays.html#ARRAYS-IO
+// PostgreSQL: Documentation: 16: 9.19. Array Functions and Operators
+// https://www.postgresql.org/docs/16/functions-array.html
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/ncruces/go-sqlite3"
+)
+
+// The arrays are stored as the one-dimensional PostgreSQL array literals such as {a,"b c",NULL},
+// and the array elements which look like numbers are returned as the SQL numbers.
+var arrayFunctions = []scalarFunction{
+	newNullableFunction("array_construct", Variadic, deterministic, arrayConstruct),
+	newFunction("array_in", 1, deterministic, arrayIn),
+	newFunction("array_valid", 2, deterministic, arrayValid),
+	newFunction("array_element", 2, deterministic, arrayElementAt),
+	newNullableFunction("array_slice", 3, deterministic, arraySlice),
+	newFunction("array_length", 2, deterministic, arrayLength),
+	newFunction("array_upper", 2, deterministic, arrayLength),
+	newFunction("array_lower", 2, deterministic, arrayLower),
+	newFunction("array_ndims", 1, deterministic, arrayNdims),
+	newFunction("cardinality", 1, deterministic, cardinality),
+	newNullableFunction("array_position", 2, deterministic, arrayPosition),
+	newNullableFunction("array_position", 3, deterministic, arrayPosition),
+	newNullableFunction("array_positions", 2, deterministic, arrayPositions),
+	newNullableFunction("array_append", 2, deterministic, arrayAppend),
+	newNullableFunction("array_prepend", 2, deterministic, arrayPrepend),
+	newNullableFunction("array_cat", 2, deterministic, arrayCat),
+	newNullableFunction("array_remove", 2, deterministic, arrayRemove),
+	newNullableFunction("array_replace", 3, deterministic, arrayReplace),
+	newNullableFunction("array_to_string", 2, deterministic, arrayToString),
+	newNullableFunction("array_to_string", 3, deterministic, arrayToString),
+	newNullableFunction("string_to_array", 2, deterministic, stringToArray),
+	newNullableFunction("string_to_array", 3, deterministic, stringToArray),
+	newFunction("array_to_json", 1, deterministic, arrayToJSON),
+	newNullableFunction("array_any", 3, deterministic, arrayCompare(false)),
+	newNullableFunction("array_all", 3, deterministic, arrayCompare(true)),
+	newFunction("arraycontains", 2, deterministic, arrayContains),
+	newFunction("arrayoverlap", 2, deterministic, arrayOverlap),
+	newFunction("contains_op", 2, deterministic, containsOp),
+}
+
+// ParseArrayLiteral parses the one-dimensional PostgreSQL array literal such as {a,"b c",NULL}, and NULL elements are returned as nil.
+func ParseArrayLiteral(s string) ([]*string, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "{") || !strings.HasSuffix(s, "}") {
+		return nil, newErrInvalid(s)
+	}
+	body := []rune(s[1 : len(s)-1])
+	elems := []*string{}
+	if strings.TrimSpace(string(body)) == "" {
+		return elems, nil
+	}
+	for n := 0; n <= len(body); n++ {
+		for n < len(body) && body[n] == ' ' {
+			n++
+		}
+		var elem strings.Builder
+		isQuoted := n < len(body) && body[n] == '"'
+		if isQuoted {
+			n++
+			for ; n < len(body) && body[n] != '"'; n++ {
+				if body[n] == '\\' && n+1 < len(body) {
+					n++
+				}
+				elem.WriteRune(body[n])
+			}
+			if len(body) <= n {
+				return nil, newErrInvalid(s)
+			}
+			n++
+			for n < len(body) && body[n] == ' ' {
+				n++
+			}
+		} else {
+			for ; n < len(body) && body[n] != ','; n++ {
+				if body[n] == '{' || body[n] == '"' {
+					return nil, newErrNotSupported(s)
+				}
+				if body[n] == '\\' && n+1 < len(body) {
+					n++
+				}
+				elem.WriteRune(body[n])
+			}
+		}
+		if n < len(body) && body[n] != ',' {
+			return nil, newErrInvalid(s)
+		}
+		v := elem.String()
+		if !isQuoted {
+			v = strings.TrimSpace(v)
+			if strings.EqualFold(v, "NULL") {
+				elems = append(elems, nil)
+				continue
+			}
+		}
+		elems = append(elems, &v)
+	}
+	return elems, nil
+}
+
+// IsValidArrayLiteral returns true if the specified string is a valid one-dimensional array literal of the specified internal array type name
+// such as _INT4, and the elements of the integer, floating-point and boolean arrays are validated as PostgreSQL does.
+func IsValidArrayLiteral(s string, typeName string) bool {
+	elems, err := ParseArrayLiteral(s)
+	if err != nil {
+		return false
+	}
+	for _, elem := range elems {
+		if elem != nil && !isValidArrayElement(*elem, typeName) {
+			return false
+		}
+	}
+	return true
+}
+
+// isValidArrayElement returns true if the specified non-NULL element is valid for the specified internal array type name.
+func isValidArrayElement(elem string, typeName string) bool {
+	var err error
+	switch strings.ToUpper(typeName) {
+	case "_INT2":
+		_, err = strconv.ParseInt(elem, 10, 16)
+	case "_INT4":
+		_, err = strconv.ParseInt(elem, 10, 32)
+	case "_INT8":
+		_, err = strconv.ParseInt(elem, 10, 64)
+	case "_FLOAT4", "_FLOAT8", "_NUMERIC":
+		_, err = strconv.ParseFloat(elem, 64)
+	case "_BOOL":
+		switch strings.ToLower(elem) {
+		case "t", "f", "true", "false", "y", "n", "yes", "no", "on", "off", "1", "0":
+		default:
+			return false
+		}
+	}
+	return err == nil
+}
+
+// parseTextArray parses the PostgreSQL text array literal which has no NULL elements.
+func parseTextArray(s string) ([]string, error) {
+	elems, err := ParseArrayLiteral(s)
+	if err != nil {
+		return nil, err
+	}
+	strs := make([]string, len(elems))
+	for n, elem := range elems {
+		if elem == nil {
+			return nil, newErrInvalid(s)
+		}
+		strs[n] = *elem
+	}
+	return strs, nil
+}
+
+// quoteArrayElement returns the representation of the specified string as an element of the PostgreSQL array literal.
+func quoteArrayElement(s string) string {
+	if s != "" && !strings.EqualFold(s, "NULL") && !strings.ContainsAny(s, "{}\",\\ \t\n\r") {
+		return s
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// arrayElement returns the representation of the specified value as an element of the PostgreSQL array literal.
+func arrayElement(v sqlite3.Value) string {
+	switch v.Type() {
+	case sqlite3.NULL:
+		return "NULL"
+	case sqlite3.INTEGER, sqlite3.FLOAT:
+		return v.Text()
+	}
+	return quoteArrayElement(v.Text())
+}
+
+// FormatArrayLiteral returns the PostgreSQL array literal of the specified elements, and nil elements are formatted as NULL.
+func FormatArrayLiteral(elems []*string) string {
+	var b strings.Builder
+	b.WriteString("{")
+	for n, elem := range elems {
+		if 0 < n {
+			b.WriteString(",")
+		}
+		if elem == nil {
+			b.WriteString("NULL")
+			continue
+		}
+		b.WriteString(quoteArrayElement(*elem))
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// arrayArg returns the elements of the specified array argument, and NULL arrays are returned as nil.
+func arrayArg(v sqlite3.Value) ([]*string, error) {
+	if v.Type() == sqlite3.NULL {
+		return nil, nil
+	}
+	return ParseArrayLiteral(v.Text())
+}
+
+// elementOf returns the array element of the specified value, and NULL is returned as nil.
+func elementOf(v sqlite3.Value) *string {
+	if v.Type() == sqlite3.NULL {
+		return nil
+	}
+	s := v.Text()
+	return &s
+}
+
+// sqlValueOf returns the SQL value of the specified array element, and the elements which look like numbers are returned as the numbers.
+func sqlValueOf(elem *string) any {
+	if elem == nil {
+		return nil
+	}
+	if n, err := strconv.ParseInt(*elem, 10, 64); err == nil {
+		return n
+	}
+	if isDecimalNumber(*elem) {
+		if f, err := strconv.ParseFloat(*elem, 64); err == nil {
+			return f
+		}
+	}
+	return *elem
+}
+
+// isDecimalNumber returns true if the specified string is a decimal number such as -1.5 and 1e3, and Inf and NaN are not.
+func isDecimalNumber(s string) bool {
+	s = strings.TrimLeft(s, "+-")
+	return s != "" && (s[0] == '.' || ('0' <= s[0] && s[0] <= '9'))
+}
+
+// isNotDistinctElement returns true if the specified elements are equal, and NULL elements are equal to each other.
+func isNotDistinctElement(a *string, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return compareElements(a, b) == 0
+}
+
+// compareElements compares the specified non-NULL elements numerically if both look like numbers, otherwise as strings.
+func compareElements(a *string, b *string) int {
+	fa, aok := sqlValueOf(a).(float64)
+	if ia, ok := sqlValueOf(a).(int64); ok {
+		fa, aok = float64(ia), true
+	}
+	fb, bok := sqlValueOf(b).(float64)
+	if ib, ok := sqlValueOf(b).(int64); ok {
+		fb, bok = float64(ib), true
+	}
+	switch {
+	case aok && bok && fa < fb:
+		return -1
+	case aok && bok && fa > fb:
+		return 1
+	case aok && bok:
+		return 0
+	}
+	return strings.Compare(*a, *b)
+}
+
+// arrayConstruct returns the array literal of the specified elements as ARRAY[...] does.
+func arrayConstruct(args ...sqlite3.Value) (any, error) {
+	elems := make([]string, len(args))
+	for n, arg := range args {
+		elems[n] = arrayElement(arg)
+	}
+	return "{" + strings.Join(elems, ",") + "}", nil
+}
+
+// arrayIn returns the normalized array literal of the specified array literal.
+func arrayIn(args ...sqlite3.Value) (any, error) {
+	elems, err := ParseArrayLiteral(args[0].Text())
+	if err != nil {
+		return nil, err
+	}
+	return FormatArrayLiteral(elems), nil
+}
+
+// arrayValid returns true if the specified value is a valid array literal of the specified internal array type name.
+func arrayValid(args ...sqlite3.Value) (any, error) {
+	return IsValidArrayLiteral(args[0].Text(), args[1].Text()), nil
+}
+
+// arrayElementAt returns the element at the specified one-based index, or NULL if the index is out of range.
+func arrayElementAt(args ...sqlite3.Value) (any, error) {
+	elems, err := ParseArrayLiteral(args[0].Text())
+	if err != nil {
+		return nil, err
+	}
+	idx := args[1].Int()
+	if idx < 1 || len(elems) < idx {
+		return nil, nil
+	}
+	return sqlValueOf(elems[idx-1]), nil
+}
+
+// arraySlice returns the elements between the specified one-based bounds, and NULL bounds are the array bounds.
+func arraySlice(args ...sqlite3.Value) (any, error) {
+	elems, err := arrayArg(args[0])
+	if err != nil || elems == nil {
+		return nil, err
+	}
+	lower, upper := 1, len(elems)
+	if args[1].Type() != sqlite3.NULL {
+		lower = max(args[1].Int(), 1)
+	}
+	if args[2].Type() != sqlite3.NULL {
+		upper = min(args[2].Int(), len(elems))
+	}
+	if upper < lower {
+		return "{}", nil
+	}
+	return FormatArrayLiteral(elems[lower-1 : upper]), nil
+}
+
+// arrayDimension parses the specified array and dimension arguments, and returns false if the array has no elements in the dimension.
+func arrayDimension(args ...sqlite3.Value) ([]*string, bool, error) {
+	elems, err := ParseArrayLiteral(args[0].Text())
+	if err != nil {
+		return nil, false, err
+	}
+	return elems, args[1].Int() == 1 && 0 < len(elems), nil
+}
+
+// arrayLength returns the length of the specified dimension, or NULL if the dimension is empty.
+func arrayLength(args ...sqlite3.Value) (any, error) {
+	elems, ok, err := arrayDimension(args...)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return len(elems), nil
+}
+
+// arrayLower returns the lower bound of the specified dimension, or NULL if the dimension is empty.
+func arrayLower(args ...sqlite3.Value) (any, error) {
+	_, ok, err := arrayDimension(args...)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return 1, nil
+}
+
+// arrayNdims returns the number of the dimensions, or NULL if the array is empty.
+func arrayNdims(args ...sqlite3.Value) (any, error) {
+	elems, err := ParseArrayLiteral(args[0].Text())
+	if err != nil || len(elems) == 0 {
+		return nil, err
+	}
+	return 1, nil
+}
+
+// cardinality returns the total number of the elements.
+func cardinality(args ...sqlite3.Value) (any, error) {
+	elems, err := ParseArrayLiteral(args[0].Text())
+	if err != nil {
+		return nil, err
+	}
+	return len(elems), nil
+}
+
+// arrayPosition returns the one-based index of the first element which is not distinct from the specified value.
+func arrayPosition(args ...sqlite3.Value) (any, error) {
+	elems, err := arrayArg(args[0])
+	if err != nil || elems == nil {
+		return nil, err
+	}
+	start := 1
+	if len(args) == 3 {
+		if args[2].Type() == sqlite3.NULL {
+			return nil, newErrInvalid("array_position()")
+		}
+		start = max(args[2].Int(), 1)
+	}
+	v := elementOf(args[1])
+	for n := start - 1; n < len(elems); n++ {
+		if isNotDistinctElement(elems[n], v) {
+			return n + 1, nil
+		}
+	}
+	return nil, nil
+}
+
+// arrayPositions returns the array of the one-based indexes of the elements which are not distinct from the specified value.
+func arrayPositions(args ...sqlite3.Value) (any, error) {
+	elems, err := arrayArg(args[0])
+	if err != nil || elems == nil {
+		return nil, err
+	}
+	v := elementOf(args[1])
+	positions := []string{}
+	for n, elem := range elems {
+		if isNotDistinctElement(elem, v) {
+			positions = append(positions, strconv.Itoa(n+1))
+		}
+	}
+	return "{" + strings.Join(positions, ",") + "}", nil
+}
+
+// arrayAppend returns the array which has the specified element at the end, and NULL arrays are handled as the empty arrays.
+func arrayAppend(args ...sqlite3.Value) (any, error) {
+	elems, err := arrayArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return FormatArrayLiteral(append(elems, elementOf(args[1]))), nil
+}
+
+// arrayPrepend returns the array which has the specified element at the beginning, and NULL arrays are handled as the empty arrays.
+func arrayPrepend(args ...sqlite3.Value) (any, error) {
+	elems, err := arrayArg(args[1])
+	if err != nil {
+		return nil, err
+	}
+	return FormatArrayLiteral(append([]*string{elementOf(args[0])}, elems...)), nil
+}
+
+// arrayCat returns the concatenation of the specified arrays, and NULL arrays are handled as the empty arrays.
+func arrayCat(args ...sqlite3.Value) (any, error) {
+	if args[0].Type() == sqlite3.NULL && args[1].Type() == sqlite3.NULL {
+		return nil, nil
+	}
+	elems := []*string{}
+	for _, arg := range args {
+		arr, err := arrayArg(arg)
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, arr...)
+	}
+	return FormatArrayLiteral(elems), nil
+}
+
+// arrayRemove returns the array without the elements which are not distinct from the specified value.
+func arrayRemove(args ...sqlite3.Value) (any, error) {
+	elems, err := arrayArg(args[0])
+	if err != nil || elems == nil {
+		return nil, err
+	}
+	v := elementOf(args[1])
+	removed := []*string{}
+	for _, elem := range elems {
+		if !isNotDistinctElement(elem, v) {
+			removed = append(removed, elem)
+		}
+	}
+	return FormatArrayLiteral(removed), nil
+}
+
+// arrayReplace returns the array whose elements which are not distinct from the specified value are replaced.
+func arrayReplace(args ...sqlite3.Value) (any, error) {
+	elems, err := arrayArg(args[0])
+	if err != nil || elems == nil {
+		return nil, err
+	}
+	from, to := elementOf(args[1]), elementOf(args[2])
+	for n, elem := range elems {
+		if isNotDistinctElement(elem, from) {
+			elems[n] = to
+		}
+	}
+	return FormatArrayLiteral(elems), nil
+}
+
+// arrayToString returns the elements joined with the specified delimiter, and NULL elements are omitted unless the NULL string is specified.
+func arrayToString(args ...sqlite3.Value) (any, error) {
+	if args[0].Type() == sqlite3.NULL || args[1].Type() == sqlite3.NULL {
+		return nil, nil
+	}
+	elems, err := ParseArrayLiteral(args[0].Text())
+	if err != nil {
+		return nil, err
+	}
+	strs := []string{}
+	for _, elem := range elems {
+		switch {
+		case elem != nil:
+			strs = append(strs, *elem)
+		case len(args) == 3 && args[2].Type() != sqlite3.NULL:
+			strs = append(strs, args[2].Text())
+		}
+	}
+	return strings.Join(strs, args[1].Text()), nil
+}
+
+// stringToArray returns the array of the substrings split by the specified delimiter.
+// The NULL delimiter splits the string into the characters, and the empty delimiter returns the whole string as the element.
+func stringToArray(args ...sqlite3.Value) (any, error) {
+	if args[0].Type() == sqlite3.NULL {
+		return nil, nil
+	}
+	s := args[0].Text()
+	if s == "" {
+		return "{}", nil
+	}
+	var strs []string
+	switch {
+	case args[1].Type() == sqlite3.NULL:
+		strs = strings.Split(s, "")
+	case args[1].Text() == "":
+		strs = []string{s}
+	default:
+		strs = strings.Split(s, args[1].Text())
+	}
+	elems := make([]*string, len(strs))
+	for n := range strs {
+		if len(args) == 3 && args[2].Type() != sqlite3.NULL && strs[n] == args[2].Text() {
+			continue
+		}
+		elems[n] = &strs[n]
+	}
+	return FormatArrayLiteral(elems), nil
+}
+
+// arrayToJSON returns the JSON array of the specified array for the SQLite JSON functions such as json_each().
+func arrayToJSON(args ...sqlite3.Value) (any, error) {
+	elems, err := ParseArrayLiteral(args[0].Text())
+	if err != nil {
+		return nil, err
+	}
+	values := make([]json.RawMessage, len(elems))
+	for n, elem := range elems {
+		switch v := sqlValueOf(elem).(type) {
+		case nil:
+			values[n] = json.RawMessage("null")
+		case string:
+			values[n] = json.RawMessage(quoteJSON(v))
+		case int64:
+			values[n] = json.RawMessage(strconv.FormatInt(v, 10))
+		case float64:
+			values[n] = json.RawMessage(strconv.FormatFloat(v, 'g', -1, 64))
+		}
+	}
+	return string(encodeJSONArray(values)), nil
+}
+
+// arrayCompare returns the function which compares the value with the array elements as x op ANY(arr) and x op ALL(arr) do.
+// The comparisons return NULL if no element decides the result and the value or any element is NULL.
+func arrayCompare(isAll bool) func(args ...sqlite3.Value) (any, error) {
+	return func(args ...sqlite3.Value) (any, error) {
+		elems, err := arrayArg(args[2])
+		if err != nil || elems == nil {
+			return nil, err
+		}
+		ope := args[1].Text()
+		v := elementOf(args[0])
+		hasNullElement := false
+		for _, elem := range elems {
+			if v == nil || elem == nil {
+				hasNullElement = true
+				continue
+			}
+			ok, err := compareWith(compareElements(v, elem), ope)
+			if err != nil {
+				return nil, err
+			}
+			if ok != isAll {
+				return ok, nil
+			}
+		}
+		if hasNullElement {
+			return nil, nil
+		}
+		return isAll, nil
+	}
+}
+
+// compareWith returns the result of the specified comparison operator for the specified comparison result.
+func compareWith(cmp int, ope string) (bool, error) {
+	switch ope {
+	case "=":
+		return cmp == 0, nil
+	case "<>", "!=":
+		return cmp != 0, nil
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return 0 < cmp, nil
+	case ">=":
+		return 0 <= cmp, nil
+	}
+	return false, newErrNotSupported(ope)
+}
+
+// arrayContains returns true if the first array contains all the elements of the second array as the @> operator does.
+func arrayContains(args ...sqlite3.Value) (any, error) {
+	arr, err := ParseArrayLiteral(args[0].Text())
+	if err != nil {
+		return nil, err
+	}
+	elems, err := ParseArrayLiteral(args[1].Text())
+	if err != nil {
+		return nil, err
+	}
+	for _, elem := range elems {
+		if !hasElement(arr, elem) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// arrayOverlap returns true if the specified arrays have any element in common as the && operator does.
+func arrayOverlap(args ...sqlite3.Value) (any, error) {
+	arr, err := ParseArrayLiteral(args[0].Text())
+	if err != nil {
+		return nil, err
+	}
+	elems, err := ParseArrayLiteral(args[1].Text())
+	if err != nil {
+		return nil, err
+	}
+	for _, elem := range elems {
+		if hasElement(arr, elem) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// hasElement returns true if the specified array has the specified element, and NULL elements are never contained.
+func hasElement(arr []*string, elem *string) bool {
+	if elem == nil {
+		return false
+	}
+	for _, e := range arr {
+		if e != nil && compareElements(e, elem) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// containsOp returns the result of the @> operator for the JSON values or the arrays.
+// The arguments are handled as the JSON values if both are valid JSON texts because the array literals such as {a,b} are not.
+func containsOp(args ...sqlite3.Value) (any, error) {
+	if json.Valid([]byte(args[0].Text())) && json.Valid([]byte(args[1].Text())) {
+		return jsonContains(args...)
+	}
+	return arrayContains(args...)
+}
@@ -0,0 +1,725 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+// MySQL :: MySQL 8.0 Reference Manual :: 14.7 Date and Time Functions
+// https://dev.mysql.com/doc/refman/8.0/en/date-and-time-functions.html
+// PostgreSQL: Documentation: 16: 9.9. Date/Time Functions and Operators
+// https://www.postgresql.org/docs/16/functions-datetime.html
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ncruces/go-sqlite3"
+)
+
+// timeKind represents the kind of the date and time values.
+type timeKind int
+
+const (
+	dateKind timeKind = iota
+	timeOfDayKind
+	dateTimeKind
+	timestampTZKind
+)
+
+// timeLayouts represents the supported date and time layouts, which include the RFC 3339 layout of the SQLite driver.
+var timeLayouts = []struct {
+	layout string
+	kind   timeKind
+}{
+	{"2006-01-02T15:04:05.999999999Z07:00", timestampTZKind},
+	{"2006-01-02 15:04:05.999999999Z07:00", timestampTZKind},
+	{"2006-01-02 15:04:05.999999999Z07", timestampTZKind},
+	{"2006-01-02 15:04:05.999999999", dateTimeKind},
+	{"2006-01-02T15:04:05.999999999", dateTimeKind},
+	{"2006-01-02 15:04", dateTimeKind},
+	{"2006-01-02", dateKind},
+	{"15:04:05.999999999", timeOfDayKind},
+	{"15:04", timeOfDayKind},
+}
+
+var datetimeFunctions = []scalarFunction{
+	// Common functions
+	newFunction("date_part", 2, deterministic, datePart),
+	newFunction("extract", 2, deterministic, datePart),
+	// PostgreSQL functions
+	newFunction("now", 0, volatile, now),
+	newFunction("transaction_timestamp", 0, volatile, now),
+	newFunction("statement_timestamp", 0, volatile, now),
+	newFunction("clock_timestamp", 0, volatile, now),
+	newFunction("date_trunc", 2, deterministic, dateTrunc),
+	newFunction("age", 1, volatile, age),
+	newFunction("age", 2, deterministic, age),
+	newFunction("to_char", 2, deterministic, toChar),
+	newFunction("to_timestamp", 1, deterministic, toTimestamp),
+	newFunction("to_timestamp", 2, deterministic, toTimestamp),
+	newFunction("to_date", 2, deterministic, toDate),
+	newFunction("make_date", 3, deterministic, makeDate),
+	newFunction("make_timestamp", 6, deterministic, makeTimestamp),
+	newFunction("date_add", 2, deterministic, dateAddInterval(1)),
+	newFunction("date_subtract", 2, deterministic, dateAddInterval(-1)),
+	// MySQL functions
+	newFunction(MySQLPrefix+"now", Variadic, volatile, mysqlNow),
+	newFunction("curdate", 0, volatile, curdate),
+	newFunction("curtime", Variadic, volatile, curtime),
+	newFunction("utc_timestamp", 0, volatile, utcTimestamp),
+	newFunction("utc_date", 0, volatile, utcDate),
+	newFunction("utc_time", 0, volatile, utcTime),
+	newFunction("unix_timestamp", 0, volatile, unixTimestamp),
+	newFunction("unix_timestamp", 1, deterministic, unixTimestamp),
+	newFunction("from_unixtime", 1, deterministic, fromUnixtime),
+	newFunction("from_unixtime", 2, deterministic, fromUnixtime),
+	newFunction("date_format", 2, deterministic, dateFormat),
+	newFunction("str_to_date", 2, deterministic, strToDate),
+	newFunction("date_add", 3, deterministic, dateAddUnit(1)),
+	newFunction("date_sub", 3, deterministic, dateAddUnit(-1)),
+	newFunction("adddate", 2, deterministic, dateAddUnit(1)),
+	newFunction("adddate", 3, deterministic, dateAddUnit(1)),
+	newFunction("subdate", 2, deterministic, dateAddUnit(-1)),
+	newFunction("subdate", 3, deterministic, dateAddUnit(-1)),
+	newFunction("timestampadd", 3, deterministic, timestampAdd),
+	newFunction("timestampdiff", 3, deterministic, timestampDiff),
+	newFunction("datediff", 2, deterministic, dateDiff),
+	newFunction("year", 1, deterministic, timeField("year")),
+	newFunction("quarter", 1, deterministic, timeField("quarter")),
+	newFunction("month", 1, deterministic, timeField("month")),
+	newFunction("day", 1, deterministic, timeField("day")),
+	newFunction("dayofmonth", 1, deterministic, timeField("day")),
+	newFunction("dayofyear", 1, deterministic, timeField("doy")),
+	newFunction("hour", 1, deterministic, timeField("hour")),
+	newFunction("minute", 1, deterministic, timeField("minute")),
+	newFunction("second", 1, deterministic, timeField("mysql_second")),
+	newFunction("microsecond", 1, deterministic, timeField("mysql_microsecond")),
+	newFunction("dayofweek", 1, deterministic, dayOfWeek),
+	newFunction("weekday", 1, deterministic, weekday),
+	newFunction("week", 1, deterministic, week),
+	newFunction("week", 2, deterministic, week),
+	newFunction("weekofyear", 1, deterministic, timeField("week")),
+	newFunction("last_day", 1, deterministic, lastDay),
+	newFunction("monthname", 1, deterministic, monthName),
+	newFunction("dayname", 1, deterministic, dayName),
+	newFunction("time_to_sec", 1, deterministic, timeToSec),
+	newFunction("sec_to_time", 1, deterministic, secToTime),
+}
+
+// parseTime parses the specified string as a date and time value.
+// The values without time zone are interpreted in the local time zone as MySQL and PostgreSQL do.
+func parseTime(s string) (time.Time, timeKind, error) {
+	s = strings.TrimSpace(s)
+	for _, l := range timeLayouts {
+		t, err := time.ParseInLocation(l.layout, s, time.Local)
+		if err == nil {
+			return t, l.kind, nil
+		}
+	}
+	return time.Time{}, 0, newErrInvalid(fmt.Sprintf("date and time (%s)", s))
+}
+
+// timeArg returns the date and time value of the specified argument.
+// The numeric values are interpreted as the Unix time.
+func timeArg(v sqlite3.Value) (time.Time, timeKind, error) {
+	switch v.Type() {
+	case sqlite3.INTEGER:
+		return time.Unix(v.Int64(), 0), dateTimeKind, nil
+	case sqlite3.FLOAT:
+		sec, frac := math.Modf(v.Float())
+		return time.Unix(int64(sec), int64(frac*1e9)), dateTimeKind, nil
+	default:
+		return parseTime(v.Text())
+	}
+}
+
+// formatTime returns the string representation of the specified time as the specified kind.
+func formatTime(t time.Time, kind timeKind) string {
+	switch kind {
+	case dateKind:
+		return t.Format("2006-01-02")
+	case timeOfDayKind:
+		return t.Format("15:04:05.999999")
+	case timestampTZKind:
+		return t.Format("2006-01-02 15:04:05.999999") + formatZone(t)
+	default:
+		return t.Format("2006-01-02 15:04:05.999999")
+	}
+}
+
+// formatZone returns the time zone offset of the specified time in the PostgreSQL style such as +09 or -03:30.
+func formatZone(t time.Time) string {
+	_, offset := t.Zone()
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	if offset%3600 == 0 {
+		return fmt.Sprintf("%c%02d", sign, offset/3600)
+	}
+	return fmt.Sprintf("%c%02d:%02d", sign, offset/3600, (offset%3600)/60)
+}
+
+// formatFraction returns the fractional seconds of the specified time with the specified precision.
+func formatFraction(t time.Time, fsp int) string {
+	if fsp <= 0 {
+		return ""
+	}
+	if 6 < fsp {
+		fsp = 6
+	}
+	return fmt.Sprintf(".%06d", t.Nanosecond()/1000)[:fsp+1]
+}
+
+// daysIn returns the number of days in the specified month.
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// addMonths adds the specified months to the specified time, and clamps the day to the end of the month as MySQL and PostgreSQL do.
+func addMonths(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	first := time.Date(year, month+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if last := daysIn(first.Year(), first.Month()); last < day {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// truncateTime truncates the specified time to the specified PostgreSQL field.
+func truncateTime(t time.Time, field string) (time.Time, error) {
+	year, month, day := t.Date()
+	loc := t.Location()
+	switch strings.ToLower(field) {
+	case "microseconds":
+		return t.Truncate(time.Microsecond), nil
+	case "milliseconds":
+		return t.Truncate(time.Millisecond), nil
+	case "second":
+		return time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), 0, loc), nil
+	case "minute":
+		return time.Date(year, month, day, t.Hour(), t.Minute(), 0, 0, loc), nil
+	case "hour":
+		return time.Date(year, month, day, t.Hour(), 0, 0, 0, loc), nil
+	case "day":
+		return time.Date(year, month, day, 0, 0, 0, 0, loc), nil
+	case "week":
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, loc), nil
+	case "month":
+		return time.Date(year, month, 1, 0, 0, 0, 0, loc), nil
+	case "quarter":
+		return time.Date(year, month-(month-1)%3, 1, 0, 0, 0, 0, loc), nil
+	case "year":
+		return time.Date(year, 1, 1, 0, 0, 0, 0, loc), nil
+	case "decade":
+		return time.Date(year-year%10, 1, 1, 0, 0, 0, 0, loc), nil
+	case "century":
+		return time.Date(((year-1)/100)*100+1, 1, 1, 0, 0, 0, 0, loc), nil
+	case "millennium":
+		return time.Date(((year-1)/1000)*1000+1, 1, 1, 0, 0, 0, 0, loc), nil
+	}
+	return t, newErrNotSupported(fmt.Sprintf("field (%s)", field))
+}
+
+// timeFieldOf returns the specified field of the specified time.
+func timeFieldOf(t time.Time, field string) (any, error) {
+	switch strings.ToLower(field) {
+	case "year", "years":
+		return t.Year(), nil
+	case "quarter":
+		return (int(t.Month())-1)/3 + 1, nil
+	case "month", "months":
+		return int(t.Month()), nil
+	case "day", "days":
+		return t.Day(), nil
+	case "hour", "hours":
+		return t.Hour(), nil
+	case "minute", "minutes":
+		return t.Minute(), nil
+	case "second", "seconds":
+		if t.Nanosecond() == 0 {
+			return t.Second(), nil
+		}
+		return float64(t.Second()) + float64(t.Nanosecond())/1e9, nil
+	case "mysql_second":
+		return t.Second(), nil
+	case "milliseconds":
+		return float64(t.Second())*1e3 + float64(t.Nanosecond())/1e6, nil
+	case "microseconds":
+		return t.Second()*1000000 + t.Nanosecond()/1000, nil
+	case "microsecond", "mysql_microsecond":
+		return t.Nanosecond() / 1000, nil
+	case "dow":
+		return int(t.Weekday()), nil
+	case "isodow":
+		return (int(t.Weekday())+6)%7 + 1, nil
+	case "doy":
+		return t.YearDay(), nil
+	case "week":
+		_, week := t.ISOWeek()
+		return week, nil
+	case "isoyear":
+		year, _ := t.ISOWeek()
+		return year, nil
+	case "epoch":
+		if t.Nanosecond() == 0 {
+			return t.Unix(), nil
+		}
+		return float64(t.UnixNano()) / 1e9, nil
+	case "decade":
+		return t.Year() / 10, nil
+	case "century":
+		return (t.Year()-1)/100 + 1, nil
+	case "millennium":
+		return (t.Year()-1)/1000 + 1, nil
+	case "year_month":
+		return t.Year()*100 + int(t.Month()), nil
+	case "day_hour":
+		return t.Day()*100 + t.Hour(), nil
+	case "day_minute":
+		return (t.Day()*100+t.Hour())*100 + t.Minute(), nil
+	case "day_second":
+		return ((t.Day()*100+t.Hour())*100+t.Minute())*100 + t.Second(), nil
+	case "hour_minute":
+		return t.Hour()*100 + t.Minute(), nil
+	case "hour_second":
+		return (t.Hour()*100+t.Minute())*100 + t.Second(), nil
+	case "minute_second":
+		return t.Minute()*100 + t.Second(), nil
+	}
+	return nil, newErrNotSupported(fmt.Sprintf("field (%s)", field))
+}
+
+// datePart returns the specified field of the date and time value such as date_part('year', ts) and EXTRACT(YEAR FROM ts).
+func datePart(args ...sqlite3.Value) (any, error) {
+	t, _, err := timeArg(args[1])
+	if err != nil {
+		return nil, err
+	}
+	return timeFieldOf(t, args[0].Text())
+}
+
+// timeField returns a function which returns the specified field of the date and time value.
+func timeField(field string) func(args ...sqlite3.Value) (any, error) {
+	return func(args ...sqlite3.Value) (any, error) {
+		t, _, err := timeArg(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return timeFieldOf(t, field)
+	}
+}
+
+// now returns the current date and time with the time zone as PostgreSQL now() does.
+func now(args ...sqlite3.Value) (any, error) {
+	return formatTime(time.Now(), timestampTZKind), nil
+}
+
+// dateTrunc truncates the date and time value to the specified precision.
+func dateTrunc(args ...sqlite3.Value) (any, error) {
+	t, kind, err := timeArg(args[1])
+	if err != nil {
+		return nil, err
+	}
+	t, err = truncateTime(t, args[0].Text())
+	if err != nil {
+		return nil, err
+	}
+	return formatTime(t, max(kind, dateTimeKind)), nil
+}
+
+// age returns the symbolic interval between the specified timestamps, or between the current date and the specified timestamp.
+func age(args ...sqlite3.Value) (any, error) {
+	var from, to time.Time
+	var err error
+	if len(args) == 1 {
+		year, month, day := time.Now().Date()
+		from = time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+		to, _, err = timeArg(args[0])
+	} else {
+		from, _, err = timeArg(args[0])
+		if err == nil {
+			to, _, err = timeArg(args[1])
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ageOf(from, to).String(), nil
+}
+
+// toChar converts the specified date and time or numeric value into a string with the PostgreSQL template.
+func toChar(args ...sqlite3.Value) (any, error) {
+	switch args[0].Type() {
+	case sqlite3.INTEGER, sqlite3.FLOAT:
+		return formatNumber(args[0].Float(), args[1].Text())
+	}
+	t, _, err := timeArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return formatTemplate(t, args[1].Text()), nil
+}
+
+// toTimestamp converts the specified Unix time, or the specified string with the PostgreSQL template into a timestamp.
+func toTimestamp(args ...sqlite3.Value) (any, error) {
+	if len(args) == 1 {
+		t, _, err := timeArg(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return formatTime(t, timestampTZKind), nil
+	}
+	t, err := parseTemplate(args[0].Text(), args[1].Text())
+	if err != nil {
+		return nil, err
+	}
+	return formatTime(t, timestampTZKind), nil
+}
+
+// toDate converts the specified string with the PostgreSQL template into a date.
+func toDate(args ...sqlite3.Value) (any, error) {
+	t, err := parseTemplate(args[0].Text(), args[1].Text())
+	if err != nil {
+		return nil, err
+	}
+	return formatTime(t, dateKind), nil
+}
+
+// makeDate returns a date from the specified year, month and day.
+func makeDate(args ...sqlite3.Value) (any, error) {
+	t := time.Date(args[0].Int(), time.Month(args[1].Int()), args[2].Int(), 0, 0, 0, 0, time.Local)
+	return formatTime(t, dateKind), nil
+}
+
+// makeTimestamp returns a timestamp from the specified year, month, day, hour, minute and seconds.
+func makeTimestamp(args ...sqlite3.Value) (any, error) {
+	sec, frac := math.Modf(args[5].Float())
+	t := time.Date(args[0].Int(), time.Month(args[1].Int()), args[2].Int(), args[3].Int(), args[4].Int(), int(sec), int(math.Round(frac*1e6))*1000, time.Local)
+	return formatTime(t, dateTimeKind), nil
+}
+
+// dateAddInterval returns a function which adds the specified PostgreSQL interval to the date and time value.
+func dateAddInterval(sign int) func(args ...sqlite3.Value) (any, error) {
+	return func(args ...sqlite3.Value) (any, error) {
+		t, kind, err := timeArg(args[0])
+		if err != nil {
+			return nil, err
+		}
+		iv, err := parseInterval(args[1].Text())
+		if err != nil {
+			return nil, err
+		}
+		return formatTime(iv.addTo(t, sign), max(kind, dateTimeKind)), nil
+	}
+}
+
+// mysqlNow returns the current date and time with the specified fractional seconds precision as MySQL NOW() does.
+func mysqlNow(args ...sqlite3.Value) (any, error) {
+	t := time.Now()
+	fsp := 0
+	if 0 < len(args) {
+		fsp = args[0].Int()
+	}
+	return t.Format("2006-01-02 15:04:05") + formatFraction(t, fsp), nil
+}
+
+// curdate returns the current date.
+func curdate(args ...sqlite3.Value) (any, error) {
+	return formatTime(time.Now(), dateKind), nil
+}
+
+// curtime returns the current time with the specified fractional seconds precision.
+func curtime(args ...sqlite3.Value) (any, error) {
+	t := time.Now()
+	fsp := 0
+	if 0 < len(args) {
+		fsp = args[0].Int()
+	}
+	return t.Format("15:04:05") + formatFraction(t, fsp), nil
+}
+
+// utcTimestamp returns the current UTC date and time.
+func utcTimestamp(args ...sqlite3.Value) (any, error) {
+	return time.Now().UTC().Format("2006-01-02 15:04:05"), nil
+}
+
+// utcDate returns the current UTC date.
+func utcDate(args ...sqlite3.Value) (any, error) {
+	return formatTime(time.Now().UTC(), dateKind), nil
+}
+
+// utcTime returns the current UTC time.
+func utcTime(args ...sqlite3.Value) (any, error) {
+	return time.Now().UTC().Format("15:04:05"), nil
+}
+
+// unixTimestamp returns the Unix time of the current time or the specified date and time value.
+func unixTimestamp(args ...sqlite3.Value) (any, error) {
+	t := time.Now()
+	if 0 < len(args) {
+		var err error
+		t, _, err = timeArg(args[0])
+		if err != nil {
+			return nil, err
+		}
+		if t.Nanosecond() != 0 {
+			return float64(t.UnixMicro()) / 1e6, nil
+		}
+	}
+	return t.Unix(), nil
+}
+
+// fromUnixtime returns the date and time of the specified Unix time, and formats it with the MySQL format if specified.
+func fromUnixtime(args ...sqlite3.Value) (any, error) {
+	t, _, err := timeArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 2 {
+		return formatMySQLDate(t, args[1].Text()), nil
+	}
+	return formatTime(t, dateTimeKind), nil
+}
+
+// dateFormat formats the date and time value with the MySQL format.
+func dateFormat(args ...sqlite3.Value) (any, error) {
+	t, _, err := timeArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return formatMySQLDate(t, args[1].Text()), nil
+}
+
+// strToDate parses the specified string with the MySQL format.
+func strToDate(args ...sqlite3.Value) (any, error) {
+	t, kind, err := parseMySQLDate(args[0].Text(), args[1].Text())
+	if err != nil {
+		return nil, err
+	}
+	return formatTime(t, kind), nil
+}
+
+// dateAddUnit returns a function which adds the MySQL interval such as INTERVAL 1 DAY to the date and time value.
+// The dialect rewriter converts INTERVAL expr unit into the expr and unit arguments, and the days are added if no unit is specified.
+func dateAddUnit(sign int) func(args ...sqlite3.Value) (any, error) {
+	return func(args ...sqlite3.Value) (any, error) {
+		t, kind, err := timeArg(args[0])
+		if err != nil {
+			return nil, err
+		}
+		unit := "DAY"
+		if len(args) == 3 {
+			unit = args[2].Text()
+		}
+		iv, err := parseUnitInterval(args[1].Text(), unit)
+		if err != nil {
+			return nil, err
+		}
+		if kind != dateKind || iv.duration != 0 {
+			kind = max(kind, dateTimeKind)
+		}
+		return formatTime(iv.addTo(t, sign), kind), nil
+	}
+}
+
+// timestampAdd adds the specified number of units to the date and time value as MySQL TIMESTAMPADD() does.
+func timestampAdd(args ...sqlite3.Value) (any, error) {
+	t, kind, err := timeArg(args[2])
+	if err != nil {
+		return nil, err
+	}
+	iv, err := parseUnitInterval(args[1].Text(), args[0].Text())
+	if err != nil {
+		return nil, err
+	}
+	if kind != dateKind || iv.duration != 0 {
+		kind = max(kind, dateTimeKind)
+	}
+	return formatTime(iv.addTo(t, 1), kind), nil
+}
+
+// timestampDiff returns the difference between the specified date and time values in the specified unit as MySQL TIMESTAMPDIFF() does.
+// The values without time zone are compared in UTC not to drift across the daylight saving time transitions.
+func timestampDiff(args ...sqlite3.Value) (any, error) {
+	from, fromKind, err := timeArg(args[1])
+	if err != nil {
+		return nil, err
+	}
+	to, toKind, err := timeArg(args[2])
+	if err != nil {
+		return nil, err
+	}
+	if fromKind != timestampTZKind && toKind != timestampTZKind {
+		from = time.Date(from.Year(), from.Month(), from.Day(), from.Hour(), from.Minute(), from.Second(), from.Nanosecond(), time.UTC)
+		to = time.Date(to.Year(), to.Month(), to.Day(), to.Hour(), to.Minute(), to.Second(), to.Nanosecond(), time.UTC)
+	}
+	d := to.Sub(from)
+	switch strings.ToUpper(args[0].Text()) {
+	case "MICROSECOND":
+		return d.Microseconds(), nil
+	case "SECOND":
+		return int64(d / time.Second), nil
+	case "MINUTE":
+		return int64(d / time.Minute), nil
+	case "HOUR":
+		return int64(d / time.Hour), nil
+	case "DAY":
+		return int64(d / (24 * time.Hour)), nil
+	case "WEEK":
+		return int64(d / (7 * 24 * time.Hour)), nil
+	case "MONTH":
+		return monthsBetween(from, to), nil
+	case "QUARTER":
+		return monthsBetween(from, to) / 3, nil
+	case "YEAR":
+		return monthsBetween(from, to) / 12, nil
+	}
+	return nil, newErrNotSupported(fmt.Sprintf("unit (%s)", args[0].Text()))
+}
+
+// monthsBetween returns the number of the complete months between the specified times as MySQL does.
+// The month is not complete if the day of month and the time of the end are earlier than the start,
+// so the months from 2024-01-31 to 2024-02-29 are zero.
+func monthsBetween(from time.Time, to time.Time) int {
+	months := (to.Year()-from.Year())*12 + int(to.Month()-from.Month())
+	switch {
+	case 0 < months && monthTimeOf(to) < monthTimeOf(from):
+		months--
+	case months < 0 && monthTimeOf(from) < monthTimeOf(to):
+		months++
+	}
+	return months
+}
+
+// monthTimeOf returns the elapsed wall clock time of the specified time from the beginning of the month.
+func monthTimeOf(t time.Time) time.Duration {
+	clock := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+	return time.Duration(t.Day()-1)*24*time.Hour + clock + time.Duration(t.Nanosecond())
+}
+
+// dateDiff returns the number of days between the dates of the specified values.
+func dateDiff(args ...sqlite3.Value) (any, error) {
+	to, _, err := timeArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	from, _, err := timeArg(args[1])
+	if err != nil {
+		return nil, err
+	}
+	toDate := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	fromDate := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	return int64(toDate.Sub(fromDate) / (24 * time.Hour)), nil
+}
+
+// dayOfWeek returns the weekday index from 1 (Sunday) to 7 (Saturday).
+func dayOfWeek(args ...sqlite3.Value) (any, error) {
+	t, _, err := timeArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return int(t.Weekday()) + 1, nil
+}
+
+// weekday returns the weekday index from 0 (Monday) to 6 (Sunday).
+func weekday(args ...sqlite3.Value) (any, error) {
+	t, _, err := timeArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return (int(t.Weekday()) + 6) % 7, nil
+}
+
+// week returns the week number as MySQL WEEK() does. Only the mode 0 (the first day is Sunday) and the mode 3 (ISO 8601) are supported.
+func week(args ...sqlite3.Value) (any, error) {
+	t, _, err := timeArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	mode := 0
+	if len(args) == 2 {
+		mode = args[1].Int()
+	}
+	switch mode {
+	case 0:
+		return sundayWeek(t), nil
+	case 3:
+		_, week := t.ISOWeek()
+		return week, nil
+	}
+	return nil, newErrNotSupported(fmt.Sprintf("week mode (%d)", mode))
+}
+
+// sundayWeek returns the week number from 0 to 53 in which the first day of week is Sunday, and the week 1 is the first week with a Sunday in the year.
+func sundayWeek(t time.Time) int {
+	jan1 := time.Date(t.Year(), 1, 1, 0, 0, 0, 0, t.Location())
+	firstSunday := (7 - int(jan1.Weekday())) % 7
+	yday := t.YearDay() - 1
+	if yday < firstSunday {
+		return 0
+	}
+	return (yday-firstSunday)/7 + 1
+}
+
+// lastDay returns the last day of the month of the date and time value.
+func lastDay(args ...sqlite3.Value) (any, error) {
+	t, _, err := timeArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	t = time.Date(t.Year(), t.Month(), daysIn(t.Year(), t.Month()), 0, 0, 0, 0, t.Location())
+	return formatTime(t, dateKind), nil
+}
+
+// monthName returns the full name of the month.
+func monthName(args ...sqlite3.Value) (any, error) {
+	t, _, err := timeArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return t.Month().String(), nil
+}
+
+// dayName returns the full name of the weekday.
+func dayName(args ...sqlite3.Value) (any, error) {
+	t, _, err := timeArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return t.Weekday().String(), nil
+}
+
+// timeToSec returns the number of seconds of the time value.
+func timeToSec(args ...sqlite3.Value) (any, error) {
+	t, _, err := timeArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return t.Hour()*3600 + t.Minute()*60 + t.Second(), nil
+}
+
+// secToTime returns the time value of the specified number of seconds.
+func secToTime(args ...sqlite3.Value) (any, error) {
+	sec := args[0].Int64()
+	sign := ""
+	if sec < 0 {
+		sign = "-"
+		sec = -sec
+	}
+	return fmt.Sprintf("%s%02d:%02d:%02d", sign, sec/3600, (sec%3600)/60, sec%60), nil
+}
@@ -0,0 +1,258 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+// SQLite: Collating Sequences
+// https://www.sqlite.org/datatype3.html#collation
+// PostgreSQL: Documentation: 16: 8.1. Numeric Types
+// https://www.postgresql.org/docs/16/datatype-numeric.html#DATATYPE-NUMERIC-DECIMAL
+// MySQL :: MySQL 8.0 Reference Manual :: 14.24.5 Precision Math Examples
+// https://dev.mysql.com/doc/refman/8.0/en/precision-math-examples.html
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ncruces/go-sqlite3"
+)
+
+// DecimalCollation is the name of the collation which compares the decimal texts numerically.
+// The decimal columns are stored as the texts not to lose the precision, and they are declared with the collation
+// so that the comparisons and the ordering of the columns are numeric.
+const DecimalCollation = "DECIMAL"
+
+// collations lists the collations which are registered by Register.
+var collations = []struct {
+	name string
+	fn   func(a, b []byte) int
+}{
+	{DecimalCollation, compareDecimalTexts},
+}
+
+// The scales of the decimal divisions. PostgreSQL returns at least 16 fractional digits,
+// and MySQL returns 4 more fractional digits than the dividend by the default div_precision_increment.
+const (
+	decimalDivisionMinScale       = 16
+	mysqlDecimalDivisionIncrement = 4
+)
+
+// ErrNumericOverflow is returned when a decimal value does not fit the precision and scale.
+var ErrNumericOverflow = errors.New("numeric field overflow")
+
+var decimalFunctions = []scalarFunction{
+	newFunction("decimal_round", 2, deterministic, decimalRound),
+	newFunction("decimal_fits", 3, deterministic, decimalFits),
+	newFunction("decimal_cast", 1, deterministic, decimalCast),
+	newFunction("decimal_cast", 3, deterministic, decimalCast),
+	newFunction("decimal_add", 2, deterministic, decimalAdd),
+	newFunction("decimal_sub", 2, deterministic, decimalSub),
+	newFunction("decimal_mul", 2, deterministic, decimalMul),
+	newFunction("decimal_div", 2, deterministic, decimalDiv(false)),
+	newFunction(MySQLPrefix+"decimal_div", 2, deterministic, decimalDiv(true)),
+}
+
+// parseDecimal parses the specified decimal text such as -12.30 and 1.5e3 exactly.
+func parseDecimal(s string) (*big.Rat, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.ContainsAny(s, "/") {
+		return nil, false
+	}
+	return new(big.Rat).SetString(s)
+}
+
+// compareDecimalTexts compares the specified texts numerically, and the non-numeric texts are ordered after the numbers.
+func compareDecimalTexts(a, b []byte) int {
+	ra, aok := parseDecimal(string(a))
+	rb, bok := parseDecimal(string(b))
+	switch {
+	case aok && bok:
+		return ra.Cmp(rb)
+	case aok:
+		return -1
+	case bok:
+		return 1
+	}
+	return bytes.Compare(a, b)
+}
+
+// decimalOf returns the exact decimal and the scale of the specified value, and the floating point numbers are converted
+// from the shortest texts which represent them.
+func decimalOf(v sqlite3.Value) (*big.Rat, int, bool) {
+	var s string
+	switch v.Type() {
+	case sqlite3.INTEGER:
+		return new(big.Rat).SetInt64(v.Int64()), 0, true
+	case sqlite3.FLOAT:
+		s = strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	default:
+		s = v.Text()
+	}
+	r, ok := parseDecimal(s)
+	if !ok {
+		return nil, 0, false
+	}
+	return r, decimalScaleOf(s), true
+}
+
+// decimalScaleOf returns the number of the fractional digits of the specified decimal text such as 2 for 1.50 and 1.5e-1.
+func decimalScaleOf(s string) int {
+	mantissa, exp, _ := strings.Cut(strings.ToLower(strings.TrimSpace(s)), "e")
+	_, frac, _ := strings.Cut(mantissa, ".")
+	scale := len(frac)
+	if e, err := strconv.Atoi(exp); err == nil {
+		scale -= e
+	}
+	return max(scale, 0)
+}
+
+// decimalFitsIn returns true if the specified decimal which is rounded to the scale has at most precision - scale integer digits.
+func decimalFitsIn(r *big.Rat, precision int, scale int) bool {
+	integer, _, _ := strings.Cut(strings.TrimPrefix(r.FloatString(scale), "-"), ".")
+	return len(strings.TrimLeft(integer, "0")) <= precision-scale
+}
+
+// decimalRound rounds the specified value to the specified scale, and the values which are not numbers are returned as they are.
+// The function is used by the triggers which quantize the decimal columns.
+func decimalRound(args ...sqlite3.Value) (any, error) {
+	r, _, ok := decimalOf(args[0])
+	if !ok {
+		return args[0], nil
+	}
+	return r.FloatString(args[1].Int()), nil
+}
+
+// decimalFits returns true if the specified value fits the specified precision and scale, or it is not a number.
+// The function is used by the CHECK constraints of the decimal columns.
+func decimalFits(args ...sqlite3.Value) (any, error) {
+	r, _, ok := decimalOf(args[0])
+	if !ok {
+		return true, nil
+	}
+	return decimalFitsIn(r, args[1].Int(), args[2].Int()), nil
+}
+
+// decimalCast converts the specified value into the decimal text, and the value is rounded to the scale if the precision
+// and the scale are specified.
+func decimalCast(args ...sqlite3.Value) (any, error) {
+	r, scale, ok := decimalOf(args[0])
+	if !ok {
+		return nil, newErrInvalid(args[0].Text())
+	}
+	if len(args) == 1 {
+		return r.FloatString(scale), nil
+	}
+	precision, scale := args[1].Int(), args[2].Int()
+	if !decimalFitsIn(r, precision, scale) {
+		return nil, ErrNumericOverflow
+	}
+	return r.FloatString(scale), nil
+}
+
+// decimalOperands returns the exact decimals and the scales of the specified operands.
+func decimalOperands(args ...sqlite3.Value) (*big.Rat, int, *big.Rat, int, error) {
+	a, as, ok := decimalOf(args[0])
+	if !ok {
+		return nil, 0, nil, 0, newErrInvalid(args[0].Text())
+	}
+	b, bs, ok := decimalOf(args[1])
+	if !ok {
+		return nil, 0, nil, 0, newErrInvalid(args[1].Text())
+	}
+	return a, as, b, bs, nil
+}
+
+// decimalAdd returns the exact sum of the specified operands whose scale is the larger scale of the operands.
+func decimalAdd(args ...sqlite3.Value) (any, error) {
+	a, as, b, bs, err := decimalOperands(args...)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Rat).Add(a, b).FloatString(max(as, bs)), nil
+}
+
+// decimalSub returns the exact difference of the specified operands whose scale is the larger scale of the operands.
+func decimalSub(args ...sqlite3.Value) (any, error) {
+	a, as, b, bs, err := decimalOperands(args...)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Rat).Sub(a, b).FloatString(max(as, bs)), nil
+}
+
+// decimalMul returns the exact product of the specified operands whose scale is the sum of the scales of the operands.
+func decimalMul(args ...sqlite3.Value) (any, error) {
+	a, as, b, bs, err := decimalOperands(args...)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Rat).Mul(a, b).FloatString(as + bs), nil
+}
+
+// decimalDiv returns a function which returns the quotient of the specified operands which is rounded to the division scale
+// of MySQL or PostgreSQL, and the division by zero returns NULL as SQLite does.
+func decimalDiv(isMySQL bool) func(args ...sqlite3.Value) (any, error) {
+	return func(args ...sqlite3.Value) (any, error) {
+		a, as, b, bs, err := decimalOperands(args...)
+		if err != nil {
+			return nil, err
+		}
+		if b.Sign() == 0 {
+			return nil, nil
+		}
+		scale := max(decimalDivisionMinScale, as, bs)
+		if isMySQL {
+			scale = as + mysqlDecimalDivisionIncrement
+		}
+		return new(big.Rat).Quo(a, b).FloatString(scale), nil
+	}
+}
+
+// decimalSumAggregate represents the decimal_sum() aggregate function which sums the decimal values exactly.
+type decimalSumAggregate struct {
+	sum   *big.Rat
+	scale int
+}
+
+func newDecimalSumAggregate() sqlite3.AggregateFunction {
+	return &decimalSumAggregate{sum: nil, scale: 0}
+}
+
+// Step adds the specified value to the sum, and ignores NULL values. The values which are not numbers are added as zero as SQLite does.
+func (agg *decimalSumAggregate) Step(ctx sqlite3.Context, args ...sqlite3.Value) {
+	if args[0].Type() == sqlite3.NULL {
+		return
+	}
+	if agg.sum == nil {
+		agg.sum = new(big.Rat)
+	}
+	r, scale, ok := decimalOf(args[0])
+	if !ok {
+		return
+	}
+	agg.sum.Add(agg.sum, r)
+	agg.scale = max(agg.scale, scale)
+}
+
+// Value returns the sum whose scale is the largest scale of the values, or NULL if all values are NULL.
+func (agg *decimalSumAggregate) Value(ctx sqlite3.Context) {
+	if agg.sum == nil {
+		ctx.ResultNull()
+		return
+	}
+	ctx.ResultText(agg.sum.FloatString(agg.scale))
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+// PostgreSQL: Documentation: 16: 8.7. Enumerated Types
+// https://www.postgresql.org/docs/16/datatype-enum.html
+// MySQL :: MySQL 8.0 Reference Manual :: 13.3.6 The SET Type
+// https://dev.mysql.com/doc/refman/8.0/en/set.html
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/ncruces/go-sqlite3"
+)
+
+var enumFunctions = []scalarFunction{
+	newFunction(MySQLPrefix+"is_set_value", Variadic, deterministic, isSetValue),
+}
+
+// isSetValue returns true if the first argument is a set value whose members are the other arguments.
+// The function is used by the CHECK constraints of the MySQL SET columns.
+func isSetValue(args ...sqlite3.Value) (any, error) {
+	if len(args) < 1 {
+		return nil, newErrInvalid("mysql_is_set_value()")
+	}
+	labels := make([]string, len(args)-1)
+	for n, arg := range args[1:] {
+		labels[n] = arg.Text()
+	}
+	_, ok := setBitsOf(args[0].Text(), labels)
+	return ok, nil
+}
+
+// NewEnumCollation returns the collation which orders the enum labels in the declaration order,
+// and the other texts are ordered after the labels.
+func NewEnumCollation(labels []string) func(a, b []byte) int {
+	indexes := map[string]int{}
+	for n, label := range labels {
+		indexes[label] = n
+	}
+	return func(a, b []byte) int {
+		ia, aok := indexes[string(a)]
+		ib, bok := indexes[string(b)]
+		switch {
+		case aok && bok:
+			return ia - ib
+		case aok:
+			return -1
+		case bok:
+			return 1
+		}
+		return bytes.Compare(a, b)
+	}
+}
+
+// NewSetCollation returns the collation which orders the set values by the bits of the members as MySQL does,
+// and the other texts are ordered after the set values.
+func NewSetCollation(labels []string) func(a, b []byte) int {
+	return func(a, b []byte) int {
+		ba, aok := setBitsOf(string(a), labels)
+		bb, bok := setBitsOf(string(b), labels)
+		switch {
+		case aok && bok:
+			switch {
+			case ba < bb:
+				return -1
+			case bb < ba:
+				return 1
+			}
+			return 0
+		case aok:
+			return -1
+		case bok:
+			return 1
+		}
+		return bytes.Compare(a, b)
+	}
+}
+
+// setBitsOf returns the bits of the members of the specified comma-separated set value,
+// and false if any member is not a label.
+func setBitsOf(value string, labels []string) (uint64, bool) {
+	if value == "" {
+		return 0, true
+	}
+	var bits uint64
+	for _, member := range strings.Split(value, ",") {
+		n := 0
+		for n < len(labels) && labels[n] != member {
+			n++
+		}
+		if len(labels) <= n || 64 <= n {
+			return 0, false
+		}
+		bits |= 1 << n
+	}
+	return bits, true
+}
@@ -0,0 +1,446 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+// MySQL :: MySQL 8.0 Reference Manual :: 14.7 Date and Time Functions (DATE_FORMAT)
+// https://dev.mysql.com/doc/refman/8.0/en/date-and-time-functions.html#function_date-format
+// PostgreSQL: Documentation: 16: 9.8. Data Type Formatting Functions
+// https://www.postgresql.org/docs/16/functions-formatting.html
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ordinalSuffix returns the English ordinal suffix of the specified number such as st and nd.
+func ordinalSuffix(n int) string {
+	if 11 <= n%100 && n%100 <= 13 {
+		return "th"
+	}
+	switch n % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	}
+	return "th"
+}
+
+// hour12 returns the hour in the 12-hour clock.
+func hour12(t time.Time) int {
+	if h := t.Hour() % 12; h != 0 {
+		return h
+	}
+	return 12
+}
+
+// formatMySQLDate formats the specified time with the MySQL DATE_FORMAT() specifiers.
+func formatMySQLDate(t time.Time, format string) string { // nolint:gocyclo
+	var b strings.Builder
+	for n := 0; n < len(format); n++ {
+		if format[n] != '%' || len(format) <= n+1 {
+			b.WriteByte(format[n])
+			continue
+		}
+		n++
+		switch format[n] {
+		case 'a':
+			b.WriteString(t.Weekday().String()[:3])
+		case 'b':
+			b.WriteString(t.Month().String()[:3])
+		case 'c':
+			fmt.Fprintf(&b, "%d", t.Month())
+		case 'D':
+			fmt.Fprintf(&b, "%d%s", t.Day(), ordinalSuffix(t.Day()))
+		case 'd':
+			fmt.Fprintf(&b, "%02d", t.Day())
+		case 'e':
+			fmt.Fprintf(&b, "%d", t.Day())
+		case 'f':
+			fmt.Fprintf(&b, "%06d", t.Nanosecond()/1000)
+		case 'H':
+			fmt.Fprintf(&b, "%02d", t.Hour())
+		case 'h', 'I':
+			fmt.Fprintf(&b, "%02d", hour12(t))
+		case 'i':
+			fmt.Fprintf(&b, "%02d", t.Minute())
+		case 'j':
+			fmt.Fprintf(&b, "%03d", t.YearDay())
+		case 'k':
+			fmt.Fprintf(&b, "%d", t.Hour())
+		case 'l':
+			fmt.Fprintf(&b, "%d", hour12(t))
+		case 'M':
+			b.WriteString(t.Month().String())
+		case 'm':
+			fmt.Fprintf(&b, "%02d", t.Month())
+		case 'p':
+			b.WriteString(t.Format("PM"))
+		case 'r':
+			b.WriteString(t.Format("03:04:05 PM"))
+		case 'S', 's':
+			fmt.Fprintf(&b, "%02d", t.Second())
+		case 'T':
+			b.WriteString(t.Format("15:04:05"))
+		case 'U':
+			fmt.Fprintf(&b, "%02d", sundayWeek(t))
+		case 'u', 'v':
+			_, week := t.ISOWeek()
+			fmt.Fprintf(&b, "%02d", week)
+		case 'W':
+			b.WriteString(t.Weekday().String())
+		case 'w':
+			fmt.Fprintf(&b, "%d", t.Weekday())
+		case 'X', 'x':
+			year, _ := t.ISOWeek()
+			fmt.Fprintf(&b, "%04d", year)
+		case 'Y':
+			fmt.Fprintf(&b, "%04d", t.Year())
+		case 'y':
+			fmt.Fprintf(&b, "%02d", t.Year()%100)
+		default:
+			b.WriteByte(format[n])
+		}
+	}
+	return b.String()
+}
+
+// parseMySQLDate parses the specified string with the MySQL STR_TO_DATE() specifiers.
+func parseMySQLDate(s string, format string) (time.Time, timeKind, error) {
+	var layout strings.Builder
+	hasDate := false
+	hasTime := false
+	for n := 0; n < len(format); n++ {
+		if format[n] != '%' || len(format) <= n+1 {
+			layout.WriteByte(format[n])
+			continue
+		}
+		n++
+		spec := format[n]
+		switch spec {
+		case 'Y', 'y', 'm', 'c', 'd', 'e', 'M', 'b', 'j', 'W', 'a':
+			hasDate = true
+		case 'H', 'k', 'h', 'I', 'l', 'i', 'S', 's', 'f', 'p', 'T', 'r':
+			hasTime = true
+		}
+		switch spec {
+		case 'Y':
+			layout.WriteString("2006")
+		case 'y':
+			layout.WriteString("06")
+		case 'm':
+			layout.WriteString("01")
+		case 'c':
+			layout.WriteString("1")
+		case 'd':
+			layout.WriteString("02")
+		case 'e':
+			layout.WriteString("2")
+		case 'M':
+			layout.WriteString("January")
+		case 'b':
+			layout.WriteString("Jan")
+		case 'j':
+			layout.WriteString("002")
+		case 'W':
+			layout.WriteString("Monday")
+		case 'a':
+			layout.WriteString("Mon")
+		case 'H', 'k':
+			layout.WriteString("15")
+		case 'h', 'I':
+			layout.WriteString("03")
+		case 'l':
+			layout.WriteString("3")
+		case 'i':
+			layout.WriteString("04")
+		case 'S', 's':
+			layout.WriteString("05")
+		case 'f':
+			// The fractional seconds must follow a period in the Go layouts.
+			if strings.HasSuffix(layout.String(), ".") {
+				l := strings.TrimSuffix(layout.String(), ".")
+				layout.Reset()
+				layout.WriteString(l)
+			}
+			layout.WriteString(".999999")
+		case 'p':
+			layout.WriteString("PM")
+		case 'T':
+			layout.WriteString("15:04:05")
+		case 'r':
+			layout.WriteString("03:04:05 PM")
+		case '%':
+			layout.WriteString("%")
+		default:
+			return time.Time{}, 0, newErrNotSupported(fmt.Sprintf("format (%%%c)", spec))
+		}
+	}
+	t, err := time.ParseInLocation(layout.String(), s, time.Local)
+	if err != nil {
+		return time.Time{}, 0, newErrInvalid(fmt.Sprintf("date (%s)", s))
+	}
+	switch {
+	case hasDate && hasTime:
+		return t, dateTimeKind, nil
+	case hasTime:
+		return t, timeOfDayKind, nil
+	}
+	return t, dateKind, nil
+}
+
+// templatePattern represents a PostgreSQL template pattern for the date and time formatting.
+type templatePattern struct {
+	pattern string
+	layout  string
+	format  func(t time.Time, fm bool) string
+}
+
+// padNumber returns the zero-padded number unless the FM modifier is specified.
+func padNumber(v int, width int, fm bool) string {
+	if fm {
+		return strconv.Itoa(v)
+	}
+	return fmt.Sprintf("%0*d", width, v)
+}
+
+// padName returns the space-padded name unless the FM modifier is specified.
+func padName(name string, fm bool) string {
+	if fm {
+		return name
+	}
+	return fmt.Sprintf("%-9s", name)
+}
+
+// templatePatterns represents the supported PostgreSQL template patterns, which are ordered by the length to match the longest pattern.
+var templatePatterns = []templatePattern{
+	{"HH24", "15", func(t time.Time, fm bool) string { return padNumber(t.Hour(), 2, fm) }},
+	{"HH12", "03", func(t time.Time, fm bool) string { return padNumber(hour12(t), 2, fm) }},
+	{"YYYY", "2006", func(t time.Time, fm bool) string { return padNumber(t.Year(), 4, fm) }},
+	{"MONTH", "January", func(t time.Time, fm bool) string { return padName(strings.ToUpper(t.Month().String()), fm) }},
+	{"Month", "January", func(t time.Time, fm bool) string { return padName(t.Month().String(), fm) }},
+	{"month", "January", func(t time.Time, fm bool) string { return padName(strings.ToLower(t.Month().String()), fm) }},
+	{"A.M.", "", func(t time.Time, fm bool) string { return strings.ReplaceAll(t.Format("PM"), "M", ".M.") }},
+	{"P.M.", "", func(t time.Time, fm bool) string { return strings.ReplaceAll(t.Format("PM"), "M", ".M.") }},
+	{"YYY", "", func(t time.Time, fm bool) string { return padNumber(t.Year()%1000, 3, fm) }},
+	{"MON", "Jan", func(t time.Time, fm bool) string { return strings.ToUpper(t.Month().String()[:3]) }},
+	{"Mon", "Jan", func(t time.Time, fm bool) string { return t.Month().String()[:3] }},
+	{"mon", "Jan", func(t time.Time, fm bool) string { return strings.ToLower(t.Month().String()[:3]) }},
+	{"DAY", "Monday", func(t time.Time, fm bool) string { return padName(strings.ToUpper(t.Weekday().String()), fm) }},
+	{"Day", "Monday", func(t time.Time, fm bool) string { return padName(t.Weekday().String(), fm) }},
+	{"day", "Monday", func(t time.Time, fm bool) string { return padName(strings.ToLower(t.Weekday().String()), fm) }},
+	{"DDD", "002", func(t time.Time, fm bool) string { return padNumber(t.YearDay(), 3, fm) }},
+	{"YY", "06", func(t time.Time, fm bool) string { return padNumber(t.Year()%100, 2, fm) }},
+	{"MM", "01", func(t time.Time, fm bool) string { return padNumber(int(t.Month()), 2, fm) }},
+	{"DD", "02", func(t time.Time, fm bool) string { return padNumber(t.Day(), 2, fm) }},
+	{"DY", "Mon", func(t time.Time, fm bool) string { return strings.ToUpper(t.Weekday().String()[:3]) }},
+	{"Dy", "Mon", func(t time.Time, fm bool) string { return t.Weekday().String()[:3] }},
+	{"dy", "Mon", func(t time.Time, fm bool) string { return strings.ToLower(t.Weekday().String()[:3]) }},
+	{"HH", "03", func(t time.Time, fm bool) string { return padNumber(hour12(t), 2, fm) }},
+	{"MI", "04", func(t time.Time, fm bool) string { return padNumber(t.Minute(), 2, fm) }},
+	{"SS", "05", func(t time.Time, fm bool) string { return padNumber(t.Second(), 2, fm) }},
+	{"MS", "000", func(t time.Time, fm bool) string { return padNumber(t.Nanosecond()/1000000, 3, fm) }},
+	{"US", "000000", func(t time.Time, fm bool) string { return padNumber(t.Nanosecond()/1000, 6, fm) }},
+	{"AM", "PM", func(t time.Time, fm bool) string { return t.Format("PM") }},
+	{"PM", "PM", func(t time.Time, fm bool) string { return t.Format("PM") }},
+	{"am", "pm", func(t time.Time, fm bool) string { return t.Format("pm") }},
+	{"pm", "pm", func(t time.Time, fm bool) string { return t.Format("pm") }},
+	{"IW", "", func(t time.Time, fm bool) string { _, w := t.ISOWeek(); return padNumber(w, 2, fm) }},
+	{"WW", "", func(t time.Time, fm bool) string { return padNumber((t.YearDay()-1)/7+1, 2, fm) }},
+	{"TZ", "MST", func(t time.Time, fm bool) string { return t.Format("MST") }},
+	{"OF", "-07", func(t time.Time, fm bool) string { return formatZone(t) }},
+	{"Y", "", func(t time.Time, fm bool) string { return strconv.Itoa(t.Year() % 10) }},
+	{"Q", "", func(t time.Time, fm bool) string { return strconv.Itoa((int(t.Month())-1)/3 + 1) }},
+	{"D", "", func(t time.Time, fm bool) string { return strconv.Itoa(int(t.Weekday()) + 1) }},
+}
+
+// matchTemplatePattern returns the template pattern which matches the specified template at the beginning.
+// The numeric patterns are case-insensitive, and the name patterns are case-sensitive as PostgreSQL does.
+func matchTemplatePattern(template string) (templatePattern, bool) {
+	for _, p := range templatePatterns {
+		if strings.HasPrefix(template, p.pattern) {
+			return p, true
+		}
+	}
+	upper := strings.ToUpper(template)
+	for _, p := range templatePatterns {
+		if p.pattern == strings.ToUpper(p.pattern) && p.layout != "January" && p.layout != "Monday" && p.layout != "Jan" && p.layout != "Mon" && strings.HasPrefix(upper, p.pattern) {
+			return p, true
+		}
+	}
+	return templatePattern{}, false
+}
+
+// formatTemplate formats the specified time with the PostgreSQL template such as 'YYYY-MM-DD HH24:MI:SS'.
+func formatTemplate(t time.Time, template string) string {
+	var b strings.Builder
+	for 0 < len(template) {
+		if strings.HasPrefix(template, `"`) {
+			end := strings.Index(template[1:], `"`)
+			if end < 0 {
+				b.WriteString(template[1:])
+				break
+			}
+			b.WriteString(template[1 : end+1])
+			template = template[end+2:]
+			continue
+		}
+		fm := false
+		if strings.HasPrefix(strings.ToUpper(template), "FM") {
+			fm = true
+			template = template[2:]
+		}
+		p, ok := matchTemplatePattern(template)
+		if !ok {
+			b.WriteByte(template[0])
+			template = template[1:]
+			continue
+		}
+		s := p.format(t, fm)
+		template = template[len(p.pattern):]
+		if strings.HasPrefix(strings.ToUpper(template), "TH") {
+			if v, err := strconv.Atoi(strings.TrimSpace(s)); err == nil {
+				suffix := ordinalSuffix(v)
+				if strings.HasPrefix(template, "TH") {
+					suffix = strings.ToUpper(suffix)
+				}
+				s += suffix
+			}
+			template = template[2:]
+		}
+		b.WriteString(s)
+	}
+	return b.String()
+}
+
+// parseTemplate parses the specified string with the PostgreSQL template such as 'YYYY-MM-DD HH24:MI:SS'.
+func parseTemplate(s string, template string) (time.Time, error) {
+	var layout strings.Builder
+	for 0 < len(template) {
+		if strings.HasPrefix(template, `"`) {
+			end := strings.Index(template[1:], `"`)
+			if end < 0 {
+				break
+			}
+			layout.WriteString(template[1 : end+1])
+			template = template[end+2:]
+			continue
+		}
+		if strings.HasPrefix(strings.ToUpper(template), "FM") {
+			template = template[2:]
+			continue
+		}
+		p, ok := matchTemplatePattern(template)
+		if !ok {
+			layout.WriteByte(template[0])
+			template = template[1:]
+			continue
+		}
+		if p.layout == "" {
+			return time.Time{}, newErrNotSupported(fmt.Sprintf("template pattern (%s)", p.pattern))
+		}
+		l := p.layout
+		if p.pattern == "MS" || p.pattern == "US" {
+			// The fractional seconds must follow a period in the Go layouts.
+			if !strings.HasSuffix(layout.String(), ".") {
+				return time.Time{}, newErrNotSupported(fmt.Sprintf("template pattern (%s)", p.pattern))
+			}
+		}
+		layout.WriteString(l)
+		template = template[len(p.pattern):]
+	}
+	t, err := time.ParseInLocation(layout.String(), strings.TrimSpace(s), time.Local)
+	if err != nil {
+		return time.Time{}, newErrInvalid(fmt.Sprintf("date (%s)", s))
+	}
+	return t, nil
+}
+
+// formatNumber formats the specified number with the PostgreSQL numeric template such as '999,999.99' and 'FM0000'.
+func formatNumber(v float64, template string) (string, error) {
+	fm := false
+	if strings.HasPrefix(strings.ToUpper(template), "FM") {
+		fm = true
+		template = template[2:]
+	}
+	template = strings.NewReplacer("D", ".", "d", ".", "G", ",", "g", ",").Replace(template)
+	intPattern, fracPattern, _ := strings.Cut(template, ".")
+	for _, c := range template {
+		if !strings.ContainsRune("90.,", c) {
+			return "", newErrNotSupported(fmt.Sprintf("template pattern (%c)", c))
+		}
+	}
+	nFrac := strings.Count(fracPattern, "9") + strings.Count(fracPattern, "0")
+	digits := strconv.FormatFloat(math.Abs(v), 'f', nFrac, 64)
+	intDigits, fracDigits, _ := strings.Cut(digits, ".")
+	if intDigits == "0" {
+		intDigits = ""
+	}
+	nInt := strings.Count(intPattern, "9") + strings.Count(intPattern, "0")
+	if nInt < len(intDigits) {
+		return strings.Repeat("#", len(template)+1), nil
+	}
+
+	// Fill the integer part from the right.
+	out := make([]byte, len(intPattern))
+	digit := len(intDigits) - 1
+	for n := len(intPattern) - 1; 0 <= n; n-- {
+		switch c := intPattern[n]; c {
+		case '9', '0':
+			switch {
+			case 0 <= digit:
+				out[n] = intDigits[digit]
+			case c == '0' || strings.Contains(intPattern[:n], "0"):
+				out[n] = '0'
+			default:
+				out[n] = ' '
+			}
+			digit--
+		default:
+			if 0 <= digit || strings.Contains(intPattern[:n], "0") {
+				out[n] = ','
+			} else {
+				out[n] = ' '
+			}
+		}
+	}
+	if fm {
+		// The FM modifier suppresses the trailing zeros of the 9 patterns.
+		nTrailing := len(fracPattern) - len(strings.TrimRight(fracPattern, "9"))
+		for ; 0 < nTrailing && strings.HasSuffix(fracDigits, "0"); nTrailing-- {
+			fracDigits = fracDigits[:len(fracDigits)-1]
+		}
+	}
+	s := string(out)
+	if strings.Contains(template, ".") {
+		s += "." + fracDigits
+	}
+
+	sign := " "
+	if v < 0 {
+		sign = "-"
+	}
+	begin := len(s) - len(strings.TrimLeft(s, " "))
+	s = s[:begin] + sign + s[begin:]
+	if fm {
+		s = strings.TrimSpace(s)
+	}
+	return s, nil
+}
@@ -0,0 +1,163 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package function provides the MySQL and PostgreSQL built-in functions which SQLite lacks.
+// The functions whose semantics differ between MySQL and PostgreSQL are registered with
+// the MySQLPrefix, and the MySQL dialect rewriter renames the function calls to them.
+package function
+
+// SQLite: Application-Defined SQL Functions
+// https://www.sqlite.org/appfunc.html
+// MySQL :: MySQL 8.0 Reference Manual :: 14.1 Built-In Function and Operator Reference
+// https://dev.mysql.com/doc/refman/8.0/en/built-in-function-reference.html
+// PostgreSQL: Documentation: 16: Chapter 9. Functions and Operators
+// https://www.postgresql.org/docs/16/functions.html
+
+import (
+	"bytes"
+
+	"github.com/ncruces/go-sqlite3"
+)
+
+// MySQLPrefix is the name prefix of the functions which have the MySQL specific semantics.
+const MySQLPrefix = "mysql_"
+
+// Variadic represents the number of arguments of the variadic functions.
+const Variadic = -1
+
+const (
+	deterministic = sqlite3.DETERMINISTIC | sqlite3.INNOCUOUS
+	volatile      = sqlite3.INNOCUOUS
+)
+
+// scalarFunction represents a scalar function.
+type scalarFunction struct {
+	name string
+	nArg int
+	flag sqlite3.FunctionFlag
+	fn   sqlite3.ScalarFunction
+}
+
+// aggregateFunction represents an aggregate function.
+type aggregateFunction struct {
+	name string
+	nArg int
+	fn   func() sqlite3.AggregateFunction
+}
+
+// Register registers all built-in functions and collations to the specified SQLite connection.
+func Register(conn *sqlite3.Conn) error {
+	for _, fns := range [][]scalarFunction{datetimeFunctions, stringFunctions, mathFunctions, jsonFunctions, arrayFunctions, enumFunctions, decimalFunctions, miscFunctions} {
+		for _, fn := range fns {
+			if err := conn.CreateFunction(fn.name, fn.nArg, fn.flag, fn.fn); err != nil {
+				return err
+			}
+		}
+	}
+	for _, fn := range aggregateFunctions {
+		if err := conn.CreateWindowFunction(fn.name, fn.nArg, deterministic, fn.fn); err != nil {
+			return err
+		}
+	}
+	for _, collation := range collations {
+		if err := conn.CreateCollation(collation.name, collation.fn); err != nil {
+			return err
+		}
+	}
+	for _, name := range integerTypeCollations {
+		if err := conn.CreateCollation(name, bytes.Compare); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RegisterPostgreSQL registers the functions which override the SQLite built-in functions as PostgreSQL does
+// to the specified SQLite connection of a PostgreSQL session.
+func RegisterPostgreSQL(conn *sqlite3.Conn) error {
+	for _, fn := range postgresqlFunctions {
+		if err := conn.CreateFunction(fn.name, fn.nArg, fn.flag, fn.fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newFunction returns a scalar function which returns NULL if any of the arguments is NULL.
+func newFunction(name string, nArg int, flag sqlite3.FunctionFlag, fn func(args ...sqlite3.Value) (any, error)) scalarFunction {
+	return scalarFunction{
+		name: name,
+		nArg: nArg,
+		flag: flag,
+		fn: func(ctx sqlite3.Context, args ...sqlite3.Value) {
+			if hasNull(args...) {
+				ctx.ResultNull()
+				return
+			}
+			v, err := fn(args...)
+			setResult(ctx, v, err)
+		},
+	}
+}
+
+// newNullableFunction returns a scalar function which handles the NULL arguments by itself.
+func newNullableFunction(name string, nArg int, flag sqlite3.FunctionFlag, fn func(args ...sqlite3.Value) (any, error)) scalarFunction {
+	return scalarFunction{
+		name: name,
+		nArg: nArg,
+		flag: flag,
+		fn: func(ctx sqlite3.Context, args ...sqlite3.Value) {
+			v, err := fn(args...)
+			setResult(ctx, v, err)
+		},
+	}
+}
+
+// hasNull returns true if any of the specified arguments is NULL.
+func hasNull(args ...sqlite3.Value) bool {
+	for _, arg := range args {
+		if arg.Type() == sqlite3.NULL {
+			return true
+		}
+	}
+	return false
+}
+
+// setResult sets the specified value as the function result.
+func setResult(ctx sqlite3.Context, v any, err error) {
+	if err != nil {
+		ctx.ResultError(err)
+		return
+	}
+	switch v := v.(type) {
+	case nil:
+		ctx.ResultNull()
+	case bool:
+		ctx.ResultBool(v)
+	case int:
+		ctx.ResultInt64(int64(v))
+	case int64:
+		ctx.ResultInt64(v)
+	case float64:
+		ctx.ResultFloat(v)
+	case string:
+		ctx.ResultText(v)
+	case []byte:
+		ctx.ResultBlob(v)
+	case sqlite3.Value:
+		ctx.ResultValue(v)
+	default:
+		ctx.ResultError(newErrNotSupported(v))
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ncruces/go-sqlite3"
+	"github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+)
+
+// TestFunctions tests the registered functions return the expected values on SQLite.
+func TestFunctions(t *testing.T) {
+	db, err := driver.Open(":memory:", Register)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	tests := []struct {
+		expr     string
+		expected any
+	}{
+		// Date and time functions.
+		{"date_format('2024-01-31 10:20:30', '%Y/%m/%d %H:%i:%s')", "2024/01/31 10:20:30"},
+		{"date_add('2024-01-31', 1, 'MONTH')", "2024-02-29"},
+		{"date_sub('2024-03-01', 1, 'DAY')", "2024-02-29"},
+		{"timestampdiff('DAY', '2024-01-01', '2024-01-31')", int64(30)},
+		{"timestampdiff('MONTH', '2024-01-31', '2024-02-29')", int64(0)},
+		{"timestampdiff('MONTH', '2024-01-31', '2024-03-31')", int64(2)},
+		{"timestampdiff('MONTH', '2024-01-31 10:00:00', '2024-03-31 09:59:59')", int64(1)},
+		{"timestampdiff('MONTH', '2024-02-29', '2024-01-31')", int64(0)},
+		{"timestampdiff('YEAR', '2023-02-28', '2024-02-27')", int64(0)},
+		{"datediff('2024-01-31', '2024-01-01')", int64(30)},
+		{"last_day('2023-02-10')", "2023-02-28"},
+		{"dayofweek('2024-02-10')", int64(7)},
+		{"extract('year', '2024-02-10')", int64(2024)},
+		{"date_trunc('month', '2024-02-10 10:20:30')", "2024-02-01 00:00:00"},
+		{"to_char('2024-02-10 10:20:30', 'YYYY-MM-DD HH24:MI:SS')", "2024-02-10 10:20:30"},
+		{"make_date(2024, 2, 29)", "2024-02-29"},
+		{"unix_timestamp('1970-01-02 00:00:00')", int64(86400)},
+		{"sec_to_time(3661)", "01:01:01"},
+		{"time_to_sec('01:01:01')", int64(3661)},
+		// String functions, and the MySQL variants return NULL if any argument is NULL.
+		{"left('abcdef', 2)", "ab"},
+		{"right('abcdef', 2)", "ef"},
+		{"lpad('7', 3, '0')", "007"},
+		{"rpad('ab', 4, '.')", "ab.."},
+		{"repeat('ab', 3)", "ababab"},
+		{"reverse('abc')", "cba"},
+		{"strpos('hello', 'l')", int64(3)},
+		{"split_part('a,b,c', ',', 2)", "b"},
+		{"initcap('hello world')", "Hello World"},
+		{"locate('b', 'abcb', 3)", int64(4)},
+		{"substring_index('a.b.c', '.', -2)", "b.c"},
+		{"space(3)", "   "},
+		{"elt(2, 'a', 'b', 'c')", "b"},
+		{"field('b', 'a', 'b', 'c')", int64(2)},
+		{"find_in_set('b', 'a,b,c')", int64(2)},
+		{"find_in_set('d', 'a,b,c')", int64(0)},
+		{"md5('abc')", "900150983cd24fb0d6963f7d28e17f72"},
+		{"to_base64('abc')", "YWJj"},
+		{"from_base64('YWJj')", "abc"},
+		{"mysql_concat('a', NULL, 'b')", nil},
+		{"concat('a', NULL, 'b')", "ab"},
+		{"regexp_replace('abc', 'b', 'x')", "axc"},
+		// Math functions, and ROUND rounds the exact decimals half away from zero.
+		{"greatest(1, NULL, 3)", int64(3)},
+		{"mysql_greatest(1, NULL, 3)", nil},
+		{"least(2, 1, 3)", int64(1)},
+		{"trunc(1.259, 2)", float64(1.25)},
+		{"truncate(-1.259, 1)", float64(-1.2)},
+		{"round(1.005, 2)", float64(1.01)},
+		{"round(2.5)", float64(3)},
+		{"round(-2.5)", float64(-3)},
+		{"round(1250, -2)", int64(1300)},
+		{"round(7)", int64(7)},
+		// JSON functions, and jsonb_concat merges the objects and appends to the arrays.
+		{`json_extract('{"a":{"b":[1,2]}}', '$.a.b[1]')`, int64(2)},
+		{`json_unquote('"abc"')`, "abc"},
+		{`jsonb_set('{"a":1}', '{b}', '2')`, "{\"a\":1,\"b\":2}"},
+		{`json_extract_path_text('{"a":{"b":"x"}}', 'a', 'b')`, "x"},
+		{"json_typeof('[1]')", "array"},
+		{`jsonb_concat('{"a":1,"b":2}', '{"b":3,"c":4}')`, "{\"a\":1,\"b\":3,\"c\":4}"},
+		{"jsonb_concat('[1,2]', '3')", "[1,2,3]"},
+		{`jsonb_exists('{"a":1}', 'a')`, int64(1)},
+		{`jsonb_contains('{"a":1,"b":2}', '{"a":1}')`, int64(1)},
+		// Array functions of the PostgreSQL array literals.
+		{"array_construct(1, 2, 3)", "{1,2,3}"},
+		{"array_element('{1,2,3}', 2)", int64(2)},
+		{"array_slice('{1,2,3,4}', 2, 3)", "{2,3}"},
+		{"array_length('{1,2,3}', 1)", int64(3)},
+		{"array_append('{1,2}', 3)", "{1,2,3}"},
+		{"array_prepend(0, '{1,2}')", "{0,1,2}"},
+		{"array_cat('{1,2}', '{3}')", "{1,2,3}"},
+		{"array_remove('{1,2,1}', 1)", "{2}"},
+		{"array_position('{a,b,c}', 'b')", int64(2)},
+		{"array_to_string('{a,b,c}', '-')", "a-b-c"},
+		{"string_to_array('a,b,c', ',')", "{a,b,c}"},
+		{"array_in('{1, 2}')", "{1,2}"},
+		{"array_valid('{1,2}', '_INT4')", int64(1)},
+		{"array_valid('{1,x}', '_INT4')", int64(0)},
+		{"cardinality('{1,2,3}')", int64(3)},
+		// Decimal functions of the exact decimal strings.
+		{"decimal_add('1.10', '2.205')", "3.305"},
+		{"decimal_sub('1.10', '2.2')", "-1.10"},
+		{"decimal_mul('1.10', '3')", "3.30"},
+		{"decimal_div('1', '3')", "0.3333333333333333"},
+		{"mysql_decimal_div('1', '3')", "0.3333"},
+		{"decimal_cast('1.005', 5, 2)", "1.01"},
+		{"decimal_round('1.005', 2)", "1.01"},
+		{"decimal_fits('123.45', 5, 2)", int64(1)},
+		{"decimal_fits('1234.5', 5, 2)", int64(0)},
+	}
+	for _, test := range tests {
+		var v any
+		if err := db.QueryRow("SELECT " + test.expr).Scan(&v); err != nil {
+			t.Errorf("%s: %s", test.expr, err)
+			continue
+		}
+		if b, ok := v.([]byte); ok {
+			v = string(b)
+		}
+		if v != test.expected {
+			t.Errorf("%s: %v (%T) != %v (%T)", test.expr, v, v, test.expected, test.expected)
+		}
+	}
+}
+
+// TestTimestampDiffAcrossDST tests the differences of the times without time zone do not drift across
+// the daylight saving time transitions of the local time zone.
+func TestTimestampDiffAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	local := time.Local
+	time.Local = loc
+	defer func() { time.Local = local }()
+
+	db, err := driver.Open(":memory:", Register)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	tests := []struct {
+		expr     string
+		expected int64
+	}{
+		{"timestampdiff('DAY', '2024-03-09 12:00:00', '2024-03-10 12:00:00')", 1},
+		{"timestampdiff('HOUR', '2024-03-10 00:00:00', '2024-03-10 04:00:00')", 4},
+		{"timestampdiff('DAY', '2024-11-02 12:00:00', '2024-11-03 11:30:00')", 0},
+	}
+	for _, test := range tests {
+		var v int64
+		if err := db.QueryRow("SELECT " + test.expr).Scan(&v); err != nil {
+			t.Errorf("%s: %s", test.expr, err)
+			continue
+		}
+		if v != test.expected {
+			t.Errorf("%s: %d != %d", test.expr, v, test.expected)
+		}
+	}
+}
+
+// TestPostgreSQLLike tests the LIKE operator is case-sensitive on the connections which the PostgreSQL functions are registered to,
+// and the escape characters are handled as PostgreSQL does.
+func TestPostgreSQLLike(t *testing.T) {
+	db, err := driver.Open(":memory:", func(conn *sqlite3.Conn) error {
+		if err := Register(conn); err != nil {
+			return err
+		}
+		return RegisterPostgreSQL(conn)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	tests := []struct {
+		expr     string
+		expected bool
+	}{
+		{"'Alpha' LIKE 'Al%'", true},
+		{"'Alpha' LIKE 'al%'", false},
+		{"'Alpha' NOT LIKE 'al%'", true},
+		{"'Alpha' LIKE '_lph_'", true},
+		{"'Älpha' LIKE '_lpha'", true},
+		{"'Alpha' LIKE '%h%'", true},
+		{"'Alpha' LIKE 'A%b'", false},
+		{"'50%' LIKE '50\\%'", true},
+		{"'500' LIKE '50\\%'", false},
+		{"'50%' LIKE '50!%' ESCAPE '!'", true},
+		{"'a\\b' LIKE 'a\\b' ESCAPE ''", true},
+		{"lower('Alpha') LIKE lower('AL%')", true},
+	}
+	for _, test := range tests {
+		var v bool
+		if err := db.QueryRow("SELECT " + test.expr).Scan(&v); err != nil {
+			t.Errorf("%s: %s", test.expr, err)
+			continue
+		}
+		if v != test.expected {
+			t.Errorf("%s: %t != %t", test.expr, v, test.expected)
+		}
+	}
+
+	for _, expr := range []string{"'a' LIKE 'a\\'", "'a' LIKE 'a' ESCAPE '!!'"} {
+		if _, err := db.Exec("SELECT " + expr); err == nil {
+			t.Errorf("%s: no error", expr)
+		}
+	}
+}
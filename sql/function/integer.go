@@ -0,0 +1,26 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+// SQLite: ROWIDs and the INTEGER PRIMARY KEY
+// https://www.sqlite.org/lang_createtable.html#rowid
+
+// integerTypeCollations lists the collations which keep the declared integer types of the auto-increment columns,
+// because the auto-increment columns are declared as INTEGER to be the row ID aliases.
+// The collations compare the texts as BINARY does, and they do not affect the integer comparisons.
+var integerTypeCollations = []string{
+	"TINYINT", "SMALLINT", "MEDIUMINT", "INT", "BIGINT",
+	"TINYINT UNSIGNED", "SMALLINT UNSIGNED", "MEDIUMINT UNSIGNED", "INT UNSIGNED", "BIGINT UNSIGNED",
+}
@@ -0,0 +1,289 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+// PostgreSQL: Documentation: 16: 8.5. Date/Time Types (Interval Input)
+// https://www.postgresql.org/docs/16/datatype-datetime.html#DATATYPE-INTERVAL-INPUT
+// MySQL :: MySQL 8.0 Reference Manual :: 11.1.11 Temporal Intervals
+// https://dev.mysql.com/doc/refman/8.0/en/expressions.html#temporal-intervals
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// interval represents a time interval which has the month, day and time parts separately as PostgreSQL does.
+type interval struct {
+	months   int
+	days     int
+	duration time.Duration
+}
+
+// mysqlUnitFields represents the fields of the MySQL interval units.
+var mysqlUnitFields = map[string][]string{
+	"MICROSECOND":        {"microsecond"},
+	"SECOND":             {"second"},
+	"MINUTE":             {"minute"},
+	"HOUR":               {"hour"},
+	"DAY":                {"day"},
+	"WEEK":               {"week"},
+	"MONTH":              {"month"},
+	"QUARTER":            {"quarter"},
+	"YEAR":               {"year"},
+	"SECOND_MICROSECOND": {"second", "microsecond"},
+	"MINUTE_MICROSECOND": {"minute", "second", "microsecond"},
+	"MINUTE_SECOND":      {"minute", "second"},
+	"HOUR_MICROSECOND":   {"hour", "minute", "second", "microsecond"},
+	"HOUR_SECOND":        {"hour", "minute", "second"},
+	"HOUR_MINUTE":        {"hour", "minute"},
+	"DAY_MICROSECOND":    {"day", "hour", "minute", "second", "microsecond"},
+	"DAY_SECOND":         {"day", "hour", "minute", "second"},
+	"DAY_MINUTE":         {"day", "hour", "minute"},
+	"DAY_HOUR":           {"day", "hour"},
+	"YEAR_MONTH":         {"year", "month"},
+}
+
+// add adds the specified amount of the specified field to the interval.
+func (iv *interval) add(field string, v float64) error {
+	unit := strings.ToLower(field)
+	switch unit {
+	case "ms":
+		unit = "millisecond"
+	case "us":
+		unit = "microsecond"
+	}
+	switch strings.TrimSuffix(unit, "s") {
+	case "millennium", "millennia":
+		iv.months += int(v * 12000)
+	case "century", "centurie":
+		iv.months += int(v * 1200)
+	case "decade":
+		iv.months += int(v * 120)
+	case "year", "yr", "y":
+		iv.months += int(math.Round(v * 12))
+	case "quarter":
+		iv.months += int(v * 3)
+	case "month", "mon":
+		iv.months += int(v)
+		iv.days += int(math.Round((v - math.Trunc(v)) * 30))
+	case "week", "w":
+		iv.days += int(v * 7)
+	case "day", "d":
+		iv.days += int(v)
+		iv.duration += time.Duration((v - math.Trunc(v)) * float64(24*time.Hour))
+	case "hour", "hr", "h":
+		iv.duration += time.Duration(v * float64(time.Hour))
+	case "minute", "min", "m":
+		iv.duration += time.Duration(v * float64(time.Minute))
+	case "second", "sec":
+		iv.duration += time.Duration(v * float64(time.Second))
+	case "millisecond":
+		iv.duration += time.Duration(v * float64(time.Millisecond))
+	case "microsecond":
+		iv.duration += time.Duration(v * float64(time.Microsecond))
+	default:
+		return newErrInvalid(fmt.Sprintf("interval unit (%s)", field))
+	}
+	return nil
+}
+
+// parseInterval parses the specified string as a PostgreSQL interval such as '1 day 02:00:00' and '@ 3 mons ago'.
+func parseInterval(s string) (interval, error) {
+	iv := interval{}
+	fields := strings.Fields(strings.ToLower(strings.TrimPrefix(strings.TrimSpace(s), "@")))
+	ago := false
+	for n := 0; n < len(fields); n++ {
+		field := fields[n]
+		switch {
+		case field == "ago":
+			ago = true
+			continue
+		case strings.Contains(field, ":"):
+			d, err := parseClock(field)
+			if err != nil {
+				return iv, err
+			}
+			iv.duration += d
+			continue
+		}
+		num, unit := splitNumber(field)
+		if unit == "" {
+			if len(fields) <= n+1 {
+				return iv, newErrInvalid(fmt.Sprintf("interval (%s)", s))
+			}
+			n++
+			unit = fields[n]
+		}
+		v, err := strconv.ParseFloat(num, 64)
+		if err != nil {
+			return iv, newErrInvalid(fmt.Sprintf("interval (%s)", s))
+		}
+		if err := iv.add(unit, v); err != nil {
+			return iv, err
+		}
+	}
+	if ago {
+		iv = iv.negate()
+	}
+	return iv, nil
+}
+
+// parseUnitInterval parses the specified value as a MySQL interval of the specified unit such as '1:30' HOUR_MINUTE.
+func parseUnitInterval(value string, unit string) (interval, error) {
+	iv := interval{}
+	fields, ok := mysqlUnitFields[strings.ToUpper(unit)]
+	if !ok {
+		return iv, newErrNotSupported(fmt.Sprintf("interval unit (%s)", unit))
+	}
+	value = strings.TrimSpace(value)
+	negative := strings.HasPrefix(value, "-")
+	if len(fields) == 1 {
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return iv, newErrInvalid(fmt.Sprintf("interval (%s %s)", value, unit))
+		}
+		if fields[0] != "second" && fields[0] != "microsecond" {
+			v = math.Round(v)
+		}
+		err = iv.add(fields[0], v)
+		return iv, err
+	}
+	nums := strings.FieldsFunc(value, func(c rune) bool { return c < '0' || '9' < c })
+	if len(fields) < len(nums) {
+		return iv, newErrInvalid(fmt.Sprintf("interval (%s %s)", value, unit))
+	}
+	// The omitted leading fields are assumed to be zero.
+	fields = fields[len(fields)-len(nums):]
+	for n, num := range nums {
+		v, err := strconv.ParseFloat(num, 64)
+		if err != nil {
+			return iv, newErrInvalid(fmt.Sprintf("interval (%s %s)", value, unit))
+		}
+		if err := iv.add(fields[n], v); err != nil {
+			return iv, err
+		}
+	}
+	if negative {
+		iv = iv.negate()
+	}
+	return iv, nil
+}
+
+// parseClock parses the specified string as a time of the hh:mm[:ss] format.
+func parseClock(s string) (time.Duration, error) {
+	negative := strings.HasPrefix(s, "-")
+	parts := strings.Split(strings.TrimLeft(s, "+-"), ":")
+	if 3 < len(parts) {
+		return 0, newErrInvalid(fmt.Sprintf("interval (%s)", s))
+	}
+	var d time.Duration
+	for n, unit := range []time.Duration{time.Hour, time.Minute, time.Second}[:len(parts)] {
+		v, err := strconv.ParseFloat(parts[n], 64)
+		if err != nil {
+			return 0, newErrInvalid(fmt.Sprintf("interval (%s)", s))
+		}
+		d += time.Duration(v * float64(unit))
+	}
+	if negative {
+		d = -d
+	}
+	return d, nil
+}
+
+// splitNumber splits the specified string into the leading number and the following unit such as 3days.
+func splitNumber(s string) (string, string) {
+	for n, c := range s {
+		if (c < '0' || '9' < c) && c != '.' && c != '-' && c != '+' {
+			return s[:n], s[n:]
+		}
+	}
+	return s, ""
+}
+
+// negate returns the negated interval.
+func (iv interval) negate() interval {
+	return interval{months: -iv.months, days: -iv.days, duration: -iv.duration}
+}
+
+// addTo adds the interval multiplied by the specified sign to the specified time.
+func (iv interval) addTo(t time.Time, sign int) time.Time {
+	t = addMonths(t, sign*iv.months)
+	t = t.AddDate(0, 0, sign*iv.days)
+	return t.Add(time.Duration(sign) * iv.duration)
+}
+
+// ageOf returns the symbolic interval from the specified time to the specified time as PostgreSQL age() does.
+func ageOf(from time.Time, to time.Time) interval {
+	if from.Before(to) {
+		return ageOf(to, from).negate()
+	}
+	years := from.Year() - to.Year()
+	months := int(from.Month() - to.Month())
+	days := from.Day() - to.Day()
+	d := time.Duration(from.Hour()-to.Hour())*time.Hour +
+		time.Duration(from.Minute()-to.Minute())*time.Minute +
+		time.Duration(from.Second()-to.Second())*time.Second +
+		time.Duration(from.Nanosecond()-to.Nanosecond())
+	if d < 0 {
+		d += 24 * time.Hour
+		days--
+	}
+	for days < 0 {
+		days += daysIn(to.Year(), to.Month())
+		months--
+	}
+	for months < 0 {
+		months += 12
+		years--
+	}
+	return interval{months: years*12 + months, days: days, duration: d}
+}
+
+// String returns the PostgreSQL representation of the interval such as '1 year 2 mons 3 days 04:05:06'.
+func (iv interval) String() string {
+	parts := []string{}
+	plural := func(v int, unit string) string {
+		if v == 1 || v == -1 {
+			return fmt.Sprintf("%d %s", v, unit)
+		}
+		return fmt.Sprintf("%d %ss", v, unit)
+	}
+	if years := iv.months / 12; years != 0 {
+		parts = append(parts, plural(years, "year"))
+	}
+	if months := iv.months % 12; months != 0 {
+		parts = append(parts, plural(months, "mon"))
+	}
+	if iv.days != 0 {
+		parts = append(parts, plural(iv.days, "day"))
+	}
+	if iv.duration != 0 || len(parts) == 0 {
+		d := iv.duration
+		sign := ""
+		if d < 0 {
+			sign = "-"
+			d = -d
+		}
+		clock := fmt.Sprintf("%s%02d:%02d:%02d", sign, int(d/time.Hour), int(d%time.Hour/time.Minute), int(d%time.Minute/time.Second))
+		if frac := d % time.Second; frac != 0 {
+			clock += strings.TrimRight(fmt.Sprintf(".%06d", frac/time.Microsecond), "0")
+		}
+		parts = append(parts, clock)
+	}
+	return strings.Join(parts, " ")
+}
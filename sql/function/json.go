@@ -0,0 +1,807 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+// SQLite: JSON Functions And Operators
+// https://www.sqlite.org/json1.html
+// PostgreSQL: Documentation: 16: 9.16. JSON Functions and Operators
+// https://www.postgresql.org/docs/16/functions-json.html
+// MySQL :: MySQL 8.0 Reference Manual :: 14.17 JSON Functions
+// https://dev.mysql.com/doc/refman/8.0/en/json-functions.html
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ncruces/go-sqlite3"
+)
+
+var jsonFunctions = []scalarFunction{
+	// PostgreSQL functions
+	newFunction("jsonb_contains", 2, deterministic, jsonContains),
+	newFunction("jsonb_exists", 2, deterministic, jsonExists),
+	newFunction("jsonb_exists_any", 2, deterministic, jsonExistsKeys(false)),
+	newFunction("jsonb_exists_all", 2, deterministic, jsonExistsKeys(true)),
+	newFunction("json_extract_path", Variadic, deterministic, jsonExtractPath(false)),
+	newFunction("json_extract_path_text", Variadic, deterministic, jsonExtractPath(true)),
+	newFunction("json_extract_path_op", 2, deterministic, jsonExtractPathOp(false)),
+	newFunction("json_extract_path_text_op", 2, deterministic, jsonExtractPathOp(true)),
+	newFunction("jsonb_set", 3, deterministic, jsonSet),
+	newFunction("jsonb_set", 4, deterministic, jsonSet),
+	newFunction("jsonb_concat", 2, deterministic, jsonConcat),
+	newFunction("json_typeof", 1, deterministic, jsonTypeOf),
+	newFunction("to_json", 1, deterministic, toJSON),
+	// MySQL functions
+	newFunction(MySQLPrefix+"json_extract", Variadic, deterministic, jsonExtract),
+	newFunction("json_unquote", 1, deterministic, jsonUnquote),
+	newFunction("json_contains", 2, deterministic, jsonContains),
+	newFunction("json_contains", 3, deterministic, jsonContains),
+	newFunction("json_contains_path", Variadic, deterministic, jsonContainsPath),
+	newFunction("json_length", 1, deterministic, jsonLength),
+	newFunction("json_length", 2, deterministic, jsonLength),
+}
+
+// jsonMember represents a member of a JSON object.
+type jsonMember struct {
+	key   string
+	value json.RawMessage
+}
+
+// jsonOf returns the JSON text of the specified value. Texts must be well-formed JSON, and numbers are returned as they are.
+func jsonOf(v sqlite3.Value) (json.RawMessage, error) {
+	switch v.Type() {
+	case sqlite3.NULL:
+		return json.RawMessage("null"), nil
+	case sqlite3.INTEGER, sqlite3.FLOAT:
+		return json.RawMessage(v.Text()), nil
+	}
+	raw := bytes.TrimSpace(v.RawText())
+	if !json.Valid(raw) {
+		return nil, newErrInvalid(strconv.Quote(v.Text()))
+	}
+	return json.RawMessage(bytes.Clone(raw)), nil
+}
+
+// jsonKind returns the first character of the specified JSON text such as '{', '[' and '"'.
+func jsonKind(raw json.RawMessage) byte {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return 0
+	}
+	return raw[0]
+}
+
+// jsonMembers returns the members of the specified JSON object in the document order.
+func jsonMembers(raw json.RawMessage) ([]jsonMember, bool) {
+	if jsonKind(raw) != '{' {
+		return nil, false
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if _, err := dec.Token(); err != nil {
+		return nil, false
+	}
+	members := []jsonMember{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, false
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, false
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, false
+		}
+		members = append(members, jsonMember{key: key, value: value})
+	}
+	return members, true
+}
+
+// jsonElements returns the elements of the specified JSON array.
+func jsonElements(raw json.RawMessage) ([]json.RawMessage, bool) {
+	if jsonKind(raw) != '[' {
+		return nil, false
+	}
+	var elems []json.RawMessage
+	if err := json.Unmarshal(raw, &elems); err != nil {
+		return nil, false
+	}
+	return elems, true
+}
+
+// jsonMemberOf returns the value of the specified key in the specified JSON object.
+func jsonMemberOf(raw json.RawMessage, key string) (json.RawMessage, bool) {
+	members, ok := jsonMembers(raw)
+	if !ok {
+		return nil, false
+	}
+	// The last member wins for the duplicate keys as PostgreSQL and MySQL do.
+	for n := len(members) - 1; 0 <= n; n-- {
+		if members[n].key == key {
+			return members[n].value, true
+		}
+	}
+	return nil, false
+}
+
+// jsonIndexOf returns the element index of the specified JSON array, and negative indexes count from the end.
+func jsonIndexOf(elems []json.RawMessage, idx int) (int, bool) {
+	if idx < 0 {
+		idx += len(elems)
+	}
+	if idx < 0 || len(elems) <= idx {
+		return 0, false
+	}
+	return idx, true
+}
+
+// quoteJSON returns the JSON string literal of the specified string without the HTML escapes.
+func quoteJSON(s string) string {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		return strconv.Quote(s)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// compactJSON returns the compact JSON text without the insignificant spaces as SQLite returns.
+func compactJSON(raw json.RawMessage) string {
+	var b bytes.Buffer
+	if err := json.Compact(&b, raw); err != nil {
+		return string(raw)
+	}
+	return b.String()
+}
+
+// encodeJSONObject returns the JSON text of the specified object members.
+func encodeJSONObject(members []jsonMember) json.RawMessage {
+	var b strings.Builder
+	b.WriteString("{")
+	for n, member := range members {
+		if 0 < n {
+			b.WriteString(",")
+		}
+		b.WriteString(quoteJSON(member.key))
+		b.WriteString(":")
+		b.Write(member.value)
+	}
+	b.WriteString("}")
+	return json.RawMessage(b.String())
+}
+
+// encodeJSONArray returns the JSON text of the specified array elements.
+func encodeJSONArray(elems []json.RawMessage) json.RawMessage {
+	var b strings.Builder
+	b.WriteString("[")
+	for n, elem := range elems {
+		if 0 < n {
+			b.WriteString(",")
+		}
+		b.Write(elem)
+	}
+	b.WriteString("]")
+	return json.RawMessage(b.String())
+}
+
+// unquoteJSON returns the SQL value of the specified JSON text, strings are unquoted and null is returned as NULL.
+func unquoteJSON(raw json.RawMessage) any {
+	switch jsonKind(raw) {
+	case 'n':
+		return nil
+	case '"':
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			return s
+		}
+	}
+	return compactJSON(raw)
+}
+
+// decodeJSON decodes the specified JSON text with the exact numbers.
+func decodeJSON(raw json.RawMessage) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// containsJSON returns true if the specified candidate is contained in the specified target.
+// Objects contain the subset of their members, and arrays contain the subset of their elements or the scalar element.
+func containsJSON(target any, candidate any) bool {
+	switch t := target.(type) {
+	case map[string]any:
+		c, ok := candidate.(map[string]any)
+		if !ok {
+			return false
+		}
+		for key, cv := range c {
+			tv, ok := t[key]
+			if !ok || !containsJSON(tv, cv) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		cs, ok := candidate.([]any)
+		if !ok {
+			cs = []any{candidate}
+		}
+		for _, cv := range cs {
+			_, isArray := cv.([]any)
+			found := false
+			for _, tv := range t {
+				// The scalar element matches only the top-level scalar elements.
+				if _, ok := tv.([]any); ok && !isArray {
+					continue
+				}
+				if containsJSON(tv, cv) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		c, ok := candidate.(json.Number)
+		if !ok {
+			return false
+		}
+		x, xerr := t.Float64()
+		y, yerr := c.Float64()
+		if xerr != nil || yerr != nil {
+			return t == c
+		}
+		return x == y
+	}
+	return target == candidate
+}
+
+// jsonPathStep represents a step of the MySQL and SQLite JSON path such as .key and [n].
+type jsonPathStep struct {
+	key     string
+	index   int
+	isIndex bool
+	isLast  bool
+}
+
+// parseJSONPath parses the MySQL and SQLite JSON path such as $.a."b c"[0][last-1], and the wildcards are not supported.
+func parseJSONPath(path string) ([]jsonPathStep, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, newErrInvalid(path)
+	}
+	steps := []jsonPathStep{}
+	s := path[1:]
+	for 0 < len(s) {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+			if strings.HasPrefix(s, "*") {
+				return nil, newErrNotSupported(path)
+			}
+			if strings.HasPrefix(s, `"`) {
+				end := strings.Index(s[1:], `"`)
+				if end < 0 {
+					return nil, newErrInvalid(path)
+				}
+				steps = append(steps, jsonPathStep{key: s[1 : end+1]})
+				s = s[end+2:]
+				continue
+			}
+			end := strings.IndexAny(s, ".[")
+			if end < 0 {
+				end = len(s)
+			}
+			if end == 0 {
+				return nil, newErrInvalid(path)
+			}
+			steps = append(steps, jsonPathStep{key: s[:end]})
+			s = s[end:]
+		case '[':
+			end := strings.Index(s, "]")
+			if end < 0 {
+				return nil, newErrInvalid(path)
+			}
+			step, err := parseJSONPathIndex(strings.TrimSpace(s[1:end]))
+			if err != nil {
+				return nil, newErrInvalid(path)
+			}
+			steps = append(steps, step)
+			s = s[end+1:]
+		case ' ':
+			s = s[1:]
+		default:
+			return nil, newErrInvalid(path)
+		}
+	}
+	return steps, nil
+}
+
+// parseJSONPathIndex parses the array index such as 1, last, last-1 and #-1.
+func parseJSONPathIndex(s string) (jsonPathStep, error) {
+	for _, last := range []string{"last", "#"} {
+		if !strings.HasPrefix(s, last) {
+			continue
+		}
+		s = strings.TrimSpace(s[len(last):])
+		if s == "" {
+			return jsonPathStep{isIndex: true, isLast: true, index: 0}, nil
+		}
+		if !strings.HasPrefix(s, "-") {
+			return jsonPathStep{}, newErrInvalid(s)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(s[1:]))
+		if err != nil {
+			return jsonPathStep{}, err
+		}
+		if last == "#" {
+			// SQLite [#-1] is the last element which MySQL represents as [last].
+			n--
+		}
+		return jsonPathStep{isIndex: true, isLast: true, index: n}, nil
+	}
+	if s == "*" {
+		return jsonPathStep{}, newErrNotSupported(s)
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return jsonPathStep{}, err
+	}
+	return jsonPathStep{isIndex: true, index: n}, nil
+}
+
+// extractJSONPath returns the value at the specified path in the specified JSON text.
+func extractJSONPath(raw json.RawMessage, steps []jsonPathStep) (json.RawMessage, bool) {
+	for _, step := range steps {
+		if !step.isIndex {
+			v, ok := jsonMemberOf(raw, step.key)
+			if !ok {
+				return nil, false
+			}
+			raw = v
+			continue
+		}
+		elems, ok := jsonElements(raw)
+		if !ok {
+			// MySQL treats a scalar value as a single element array.
+			if step.index != 0 || jsonKind(raw) == '{' {
+				return nil, false
+			}
+			continue
+		}
+		idx := step.index
+		if step.isLast {
+			idx = len(elems) - 1 - step.index
+		}
+		if idx < 0 || len(elems) <= idx {
+			return nil, false
+		}
+		raw = elems[idx]
+	}
+	return raw, true
+}
+
+// extractJSONKeys returns the value at the specified PostgreSQL path elements which are object keys or array indexes.
+func extractJSONKeys(raw json.RawMessage, keys []string) (json.RawMessage, bool) {
+	for _, key := range keys {
+		if elems, ok := jsonElements(raw); ok {
+			n, err := strconv.Atoi(strings.TrimSpace(key))
+			if err != nil {
+				return nil, false
+			}
+			idx, ok := jsonIndexOf(elems, n)
+			if !ok {
+				return nil, false
+			}
+			raw = elems[idx]
+			continue
+		}
+		v, ok := jsonMemberOf(raw, key)
+		if !ok {
+			return nil, false
+		}
+		raw = v
+	}
+	return raw, true
+}
+
+// setJSONKeys returns the JSON text which replaces the value at the specified PostgreSQL path elements with the specified value.
+// The missing last path element is added if createMissing is true, and the other missing path elements are ignored.
+func setJSONKeys(raw json.RawMessage, keys []string, value json.RawMessage, createMissing bool) (json.RawMessage, error) {
+	if len(keys) == 0 {
+		return value, nil
+	}
+	key, rest := keys[0], keys[1:]
+	if elems, ok := jsonElements(raw); ok {
+		n, err := strconv.Atoi(strings.TrimSpace(key))
+		if err != nil {
+			return nil, newErrInvalid(key)
+		}
+		if idx, ok := jsonIndexOf(elems, n); ok {
+			v, err := setJSONKeys(elems[idx], rest, value, createMissing)
+			if err != nil {
+				return nil, err
+			}
+			elems[idx] = v
+			return encodeJSONArray(elems), nil
+		}
+		if !createMissing || 0 < len(rest) {
+			return raw, nil
+		}
+		if n < 0 {
+			return encodeJSONArray(append([]json.RawMessage{value}, elems...)), nil
+		}
+		return encodeJSONArray(append(elems, value)), nil
+	}
+	members, ok := jsonMembers(raw)
+	if !ok {
+		return raw, nil
+	}
+	for n := range members {
+		if members[n].key != key {
+			continue
+		}
+		v, err := setJSONKeys(members[n].value, rest, value, createMissing)
+		if err != nil {
+			return nil, err
+		}
+		members[n].value = v
+		return encodeJSONObject(members), nil
+	}
+	if !createMissing || 0 < len(rest) {
+		return raw, nil
+	}
+	return encodeJSONObject(append(members, jsonMember{key: key, value: value})), nil
+}
+
+// jsonContains returns true if the target JSON contains the candidate JSON as PostgreSQL @> and MySQL JSON_CONTAINS() do.
+func jsonContains(args ...sqlite3.Value) (any, error) {
+	target, err := jsonOf(args[0])
+	if err != nil {
+		return nil, err
+	}
+	candidate, err := jsonOf(args[1])
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 3 {
+		steps, err := parseJSONPath(args[2].Text())
+		if err != nil {
+			return nil, err
+		}
+		var ok bool
+		target, ok = extractJSONPath(target, steps)
+		if !ok {
+			return nil, nil
+		}
+	}
+	t, err := decodeJSON(target)
+	if err != nil {
+		return nil, err
+	}
+	c, err := decodeJSON(candidate)
+	if err != nil {
+		return nil, err
+	}
+	return containsJSON(t, c), nil
+}
+
+// jsonContainsPath returns true if the JSON document has one or all of the specified paths.
+func jsonContainsPath(args ...sqlite3.Value) (any, error) {
+	if len(args) < 3 {
+		return nil, newErrInvalid("json_contains_path()")
+	}
+	doc, err := jsonOf(args[0])
+	if err != nil {
+		return nil, err
+	}
+	isAll := false
+	switch mode := strings.ToLower(args[1].Text()); mode {
+	case "one":
+	case "all":
+		isAll = true
+	default:
+		return nil, newErrInvalid(mode)
+	}
+	for _, arg := range args[2:] {
+		steps, err := parseJSONPath(arg.Text())
+		if err != nil {
+			return nil, err
+		}
+		_, ok := extractJSONPath(doc, steps)
+		if ok && !isAll {
+			return true, nil
+		}
+		if !ok && isAll {
+			return false, nil
+		}
+	}
+	return isAll, nil
+}
+
+// jsonExists returns true if the specified string exists as a top-level key or an array element string.
+func jsonExists(args ...sqlite3.Value) (any, error) {
+	doc, err := jsonOf(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return hasJSONKey(doc, args[1].Text()), nil
+}
+
+// jsonExistsKeys returns a function which returns true if any or all of the specified strings exist as top-level keys.
+func jsonExistsKeys(isAll bool) func(args ...sqlite3.Value) (any, error) {
+	return func(args ...sqlite3.Value) (any, error) {
+		doc, err := jsonOf(args[0])
+		if err != nil {
+			return nil, err
+		}
+		keys, err := parseTextArray(args[1].Text())
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			ok := hasJSONKey(doc, key)
+			if ok && !isAll {
+				return true, nil
+			}
+			if !ok && isAll {
+				return false, nil
+			}
+		}
+		return isAll, nil
+	}
+}
+
+// hasJSONKey returns true if the specified key exists as a top-level key or an array element string.
+func hasJSONKey(doc json.RawMessage, key string) bool {
+	if _, ok := jsonMemberOf(doc, key); ok {
+		return true
+	}
+	elems, ok := jsonElements(doc)
+	if !ok {
+		return false
+	}
+	for _, elem := range elems {
+		if v, ok := unquoteJSON(elem).(string); ok && jsonKind(elem) == '"' && v == key {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonExtractPath returns a function which returns the value at the specified path elements.
+func jsonExtractPath(isText bool) func(args ...sqlite3.Value) (any, error) {
+	return func(args ...sqlite3.Value) (any, error) {
+		if len(args) < 1 {
+			return nil, newErrInvalid("json_extract_path()")
+		}
+		keys := make([]string, len(args)-1)
+		for n, arg := range args[1:] {
+			keys[n] = arg.Text()
+		}
+		return extractJSONPathResult(args[0], keys, isText)
+	}
+}
+
+// jsonExtractPathOp returns a function which returns the value at the specified path elements of the text array as PostgreSQL #> and #>> do.
+func jsonExtractPathOp(isText bool) func(args ...sqlite3.Value) (any, error) {
+	return func(args ...sqlite3.Value) (any, error) {
+		keys, err := parseTextArray(args[1].Text())
+		if err != nil {
+			return nil, err
+		}
+		return extractJSONPathResult(args[0], keys, isText)
+	}
+}
+
+// extractJSONPathResult returns the JSON text, or the unquoted text if isText is true, at the specified path elements.
+func extractJSONPathResult(v sqlite3.Value, keys []string, isText bool) (any, error) {
+	doc, err := jsonOf(v)
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := extractJSONKeys(doc, keys)
+	if !ok {
+		return nil, nil
+	}
+	if isText {
+		return unquoteJSON(raw), nil
+	}
+	return compactJSON(raw), nil
+}
+
+// jsonSet returns the JSON text which replaces the value at the specified path elements with the specified JSON value.
+func jsonSet(args ...sqlite3.Value) (any, error) {
+	doc, err := jsonOf(args[0])
+	if err != nil {
+		return nil, err
+	}
+	keys, err := parseTextArray(args[1].Text())
+	if err != nil {
+		return nil, err
+	}
+	value, err := jsonOf(args[2])
+	if err != nil {
+		return nil, err
+	}
+	createMissing := true
+	if len(args) == 4 {
+		createMissing = args[3].Bool()
+	}
+	raw, err := setJSONKeys(doc, keys, json.RawMessage(compactJSON(value)), createMissing)
+	if err != nil {
+		return nil, err
+	}
+	return compactJSON(raw), nil
+}
+
+// jsonConcat returns the concatenation of the specified JSON values as the jsonb || operator does. The objects are merged and
+// the members of the right object win, and the other values are concatenated as the arrays whose non-array values are the elements.
+func jsonConcat(args ...sqlite3.Value) (any, error) {
+	left, err := jsonOf(args[0])
+	if err != nil {
+		return nil, err
+	}
+	right, err := jsonOf(args[1])
+	if err != nil {
+		return nil, err
+	}
+	leftMembers, isLeftObject := jsonMembers(left)
+	rightMembers, isRightObject := jsonMembers(right)
+	if isLeftObject && isRightObject {
+		members := leftMembers
+		for _, member := range rightMembers {
+			idx := slices.IndexFunc(members, func(m jsonMember) bool { return m.key == member.key })
+			if idx < 0 {
+				members = append(members, member)
+				continue
+			}
+			members[idx] = member
+		}
+		return compactJSON(encodeJSONObject(members)), nil
+	}
+	elems := []json.RawMessage{}
+	for _, raw := range []json.RawMessage{left, right} {
+		if arr, ok := jsonElements(raw); ok {
+			elems = append(elems, arr...)
+			continue
+		}
+		elems = append(elems, raw)
+	}
+	return compactJSON(encodeJSONArray(elems)), nil
+}
+
+// jsonTypeOf returns the PostgreSQL type name of the specified JSON value.
+func jsonTypeOf(args ...sqlite3.Value) (any, error) {
+	doc, err := jsonOf(args[0])
+	if err != nil {
+		return nil, err
+	}
+	switch jsonKind(doc) {
+	case '{':
+		return "object", nil
+	case '[':
+		return "array", nil
+	case '"':
+		return "string", nil
+	case 't', 'f':
+		return "boolean", nil
+	case 'n':
+		return "null", nil
+	}
+	return "number", nil
+}
+
+// toJSON returns the JSON text of the specified SQL value, and texts are returned as JSON strings.
+func toJSON(args ...sqlite3.Value) (any, error) {
+	switch args[0].Type() {
+	case sqlite3.INTEGER, sqlite3.FLOAT:
+		return args[0].Text(), nil
+	}
+	return quoteJSON(args[0].Text()), nil
+}
+
+// jsonExtract returns the JSON text at the specified path as MySQL JSON_EXTRACT() does,
+// and the matched values are returned as a JSON array if multiple paths are specified.
+func jsonExtract(args ...sqlite3.Value) (any, error) {
+	if len(args) < 2 {
+		return nil, newErrInvalid("json_extract()")
+	}
+	doc, err := jsonOf(args[0])
+	if err != nil {
+		return nil, err
+	}
+	matches := []json.RawMessage{}
+	for _, arg := range args[1:] {
+		steps, err := parseJSONPath(arg.Text())
+		if err != nil {
+			return nil, err
+		}
+		if raw, ok := extractJSONPath(doc, steps); ok {
+			matches = append(matches, json.RawMessage(compactJSON(raw)))
+		}
+	}
+	switch {
+	case len(matches) == 0:
+		return nil, nil
+	case len(args) == 2:
+		return jsonNumberOf(matches[0]), nil
+	}
+	return string(encodeJSONArray(matches)), nil
+}
+
+// jsonNumberOf returns the SQL number of the specified JSON number to compare it with the numbers, and the other values are returned as the JSON texts.
+func jsonNumberOf(raw json.RawMessage) any {
+	if n, err := strconv.ParseInt(string(raw), 10, 64); err == nil {
+		return n
+	}
+	if c := jsonKind(raw); c == '-' || ('0' <= c && c <= '9') {
+		if f, err := strconv.ParseFloat(string(raw), 64); err == nil {
+			return f
+		}
+	}
+	return string(raw)
+}
+
+// jsonUnquote returns the unquoted string of the specified JSON string, and the other values are returned as they are.
+func jsonUnquote(args ...sqlite3.Value) (any, error) {
+	s := args[0].Text()
+	if len(s) < 2 || !strings.HasPrefix(s, `"`) || !strings.HasSuffix(s, `"`) {
+		return args[0], nil
+	}
+	var v string
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return nil, newErrInvalid(s)
+	}
+	return v, nil
+}
+
+// jsonLength returns the number of the members or the elements, and 1 for scalar values as MySQL JSON_LENGTH() does.
+func jsonLength(args ...sqlite3.Value) (any, error) {
+	doc, err := jsonOf(args[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 2 {
+		steps, err := parseJSONPath(args[1].Text())
+		if err != nil {
+			return nil, err
+		}
+		var ok bool
+		doc, ok = extractJSONPath(doc, steps)
+		if !ok {
+			return nil, nil
+		}
+	}
+	if members, ok := jsonMembers(doc); ok {
+		return len(members), nil
+	}
+	if elems, ok := jsonElements(doc); ok {
+		return len(elems), nil
+	}
+	return 1, nil
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+// MySQL :: MySQL 8.0 Reference Manual :: 14.6.2 Mathematical Functions
+// https://dev.mysql.com/doc/refman/8.0/en/mathematical-functions.html
+// PostgreSQL: Documentation: 16: 9.3. Mathematical Functions and Operators
+// https://www.postgresql.org/docs/16/functions-math.html
+
+import (
+	"math"
+	"math/big"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	"github.com/ncruces/go-sqlite3"
+)
+
+var mathFunctions = []scalarFunction{
+	// Common functions
+	newFunction("rand", 0, volatile, random),
+	newFunction("rand", 1, volatile, random),
+	newFunction("round", 1, deterministic, round),
+	newFunction("round", 2, deterministic, round),
+	// PostgreSQL functions
+	newNullableFunction("greatest", Variadic, deterministic, extremum(1, false)),
+	newNullableFunction("least", Variadic, deterministic, extremum(-1, false)),
+	newFunction("trunc", 2, deterministic, truncate),
+	// MySQL functions
+	newNullableFunction(MySQLPrefix+"greatest", Variadic, deterministic, extremum(1, true)),
+	newNullableFunction(MySQLPrefix+"least", Variadic, deterministic, extremum(-1, true)),
+	newFunction("truncate", 2, deterministic, truncate),
+}
+
+// isNumeric returns true if the specified value is an integer or a floating point number.
+func isNumeric(v sqlite3.Value) bool {
+	return v.Type() == sqlite3.INTEGER || v.Type() == sqlite3.FLOAT
+}
+
+// compareValues compares the specified values numerically if both are numeric, or as strings otherwise.
+func compareValues(a sqlite3.Value, b sqlite3.Value) int {
+	switch {
+	case a.Type() == sqlite3.INTEGER && b.Type() == sqlite3.INTEGER:
+		switch x, y := a.Int64(), b.Int64(); {
+		case x < y:
+			return -1
+		case y < x:
+			return 1
+		}
+		return 0
+	case isNumeric(a) && isNumeric(b):
+		switch x, y := a.Float(), b.Float(); {
+		case x < y:
+			return -1
+		case y < x:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(a.Text(), b.Text())
+}
+
+// extremum returns a function which returns the greatest value if the sign is positive, or the least value if the sign is negative.
+// PostgreSQL ignores NULL arguments, and MySQL returns NULL if any of the arguments is NULL.
+func extremum(sign int, isNullStrict bool) func(args ...sqlite3.Value) (any, error) {
+	return func(args ...sqlite3.Value) (any, error) {
+		var result *sqlite3.Value
+		for n := range args {
+			arg := args[n]
+			if arg.Type() == sqlite3.NULL {
+				if isNullStrict {
+					return nil, nil
+				}
+				continue
+			}
+			if result == nil || 0 < sign*compareValues(arg, *result) {
+				result = &args[n]
+			}
+		}
+		if result == nil {
+			return nil, nil
+		}
+		return *result, nil
+	}
+}
+
+// random returns a random floating point number in the range 0 <= v < 1.0.
+func random(args ...sqlite3.Value) (any, error) {
+	if len(args) == 1 {
+		// The same number is returned for the same seed.
+		seed := uint64(args[0].Int64())
+		return rand.New(rand.NewPCG(seed, seed)).Float64(), nil // nolint:gosec
+	}
+	return rand.Float64(), nil // nolint:gosec
+}
+
+// round rounds the number to the specified decimal places half away from zero. The built-in function of SQLite is replaced
+// because it rounds the binary floating point numbers such as 1.005 to 1.0, and MySQL and PostgreSQL round the exact decimals
+// such as 1.005 to 1.01. The floating point numbers are rounded as the shortest texts which represent them.
+func round(args ...sqlite3.Value) (any, error) {
+	places := 0
+	if len(args) == 2 {
+		places = args[1].Int()
+	}
+	if args[0].Type() == sqlite3.INTEGER && 0 <= places {
+		return args[0].Int64(), nil
+	}
+	r, _, ok := decimalOf(args[0])
+	if !ok {
+		// The texts which are not numbers are zeros as SQLite converts them.
+		return 0.0, nil
+	}
+	s := r.FloatString(max(places, 0))
+	if places < 0 {
+		// The negative places round the integer digits such as 1250 to 1300 for -2.
+		unit := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-places)), nil))
+		q, _ := new(big.Rat).SetString(new(big.Rat).Quo(r, unit).FloatString(0))
+		s = q.Mul(q, unit).FloatString(0)
+	}
+	if args[0].Type() == sqlite3.INTEGER {
+		return strconv.ParseInt(s, 10, 64)
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// truncate truncates the number to the specified decimal places.
+func truncate(args ...sqlite3.Value) (any, error) {
+	places := args[1].Int()
+	if args[0].Type() == sqlite3.INTEGER && 0 <= places {
+		return args[0].Int64(), nil
+	}
+	scale := math.Pow10(places)
+	v := math.Trunc(args[0].Float()*scale) / scale
+	if places <= 0 {
+		return int64(v), nil
+	}
+	return v, nil
+}
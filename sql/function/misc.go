@@ -0,0 +1,46 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+// PostgreSQL: Documentation: 16: 9.14. UUID Functions
+// https://www.postgresql.org/docs/16/functions-uuid.html
+// MySQL :: MySQL 8.0 Reference Manual :: 14.24 Miscellaneous Functions
+// https://dev.mysql.com/doc/refman/8.0/en/miscellaneous-functions.html
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/ncruces/go-sqlite3"
+)
+
+var miscFunctions = []scalarFunction{
+	// PostgreSQL functions
+	newFunction("gen_random_uuid", 0, volatile, randomUUID),
+	newFunction("uuid_generate_v4", 0, volatile, randomUUID),
+	// MySQL functions
+	newFunction("uuid", 0, volatile, randomUUID),
+}
+
+// randomUUID returns a random (version 4) UUID string.
+func randomUUID(args ...sqlite3.Value) (any, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return nil, err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
@@ -0,0 +1,458 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+// MySQL :: MySQL 8.0 Reference Manual :: 14.8 String Functions and Operators
+// https://dev.mysql.com/doc/refman/8.0/en/string-functions.html
+// PostgreSQL: Documentation: 16: 9.4. String Functions and Operators
+// https://www.postgresql.org/docs/16/functions-string.html
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/ncruces/go-sqlite3"
+)
+
+var stringFunctions = []scalarFunction{
+	// Common functions
+	newFunction("left", 2, deterministic, left),
+	newFunction("right", 2, deterministic, right),
+	newFunction("lpad", 2, deterministic, pad(true)),
+	newFunction("lpad", 3, deterministic, pad(true)),
+	newFunction("rpad", 2, deterministic, pad(false)),
+	newFunction("rpad", 3, deterministic, pad(false)),
+	newFunction("repeat", 2, deterministic, repeat),
+	newFunction("reverse", 1, deterministic, reverse),
+	newFunction("md5", 1, deterministic, md5Hex),
+	newFunction("sha1", 1, deterministic, sha1Hex),
+	newFunction("sha2", 2, deterministic, sha2Hex),
+	newFunction("regexp", 2, deterministic, regexpMatch),
+	newFunction("regexp_like", 2, deterministic, regexpLike),
+	newFunction("regexp_replace", 3, deterministic, regexpReplace(false)),
+	newFunction("regexp_replace", 4, deterministic, regexpReplace(false)),
+	// PostgreSQL functions
+	newFunction("strpos", 2, deterministic, strpos),
+	newFunction("split_part", 3, deterministic, splitPart),
+	newFunction("initcap", 1, deterministic, initcap),
+	newFunction("encode", 2, deterministic, encode),
+	newFunction("decode", 2, deterministic, decode),
+	// MySQL functions
+	newNullableFunction(MySQLPrefix+"concat", Variadic, deterministic, mysqlConcat),
+	newFunction(MySQLPrefix+"regexp_replace", 3, deterministic, regexpReplace(true)),
+	newFunction("locate", 2, deterministic, locate),
+	newFunction("locate", 3, deterministic, locate),
+	newFunction("substring_index", 3, deterministic, substringIndex),
+	newFunction("space", 1, deterministic, space),
+	newNullableFunction("elt", Variadic, deterministic, elt),
+	newNullableFunction("field", Variadic, deterministic, field),
+	newFunction("find_in_set", 2, deterministic, findInSet),
+	newFunction("to_base64", 1, deterministic, toBase64),
+	newFunction("from_base64", 1, deterministic, fromBase64),
+}
+
+// postgresqlFunctions are the functions which override the SQLite built-in functions on the connections of the PostgreSQL sessions.
+// like() overrides the LIKE operator, which is case-insensitive for ASCII characters on SQLite and MySQL, to be case-sensitive.
+var postgresqlFunctions = []scalarFunction{
+	newFunction("like", 2, deterministic, like),
+	newFunction("like", 3, deterministic, like),
+}
+
+// substring returns the substring of the specified runes.
+func substring(runes []rune, begin int, end int) string {
+	begin = max(0, min(begin, len(runes)))
+	end = max(begin, min(end, len(runes)))
+	return string(runes[begin:end])
+}
+
+// left returns the first n characters, or all but the last |n| characters if n is negative as PostgreSQL does.
+func left(args ...sqlite3.Value) (any, error) {
+	runes := []rune(args[0].Text())
+	n := args[1].Int()
+	if n < 0 {
+		return substring(runes, 0, len(runes)+n), nil
+	}
+	return substring(runes, 0, n), nil
+}
+
+// right returns the last n characters, or all but the first |n| characters if n is negative as PostgreSQL does.
+func right(args ...sqlite3.Value) (any, error) {
+	runes := []rune(args[0].Text())
+	n := args[1].Int()
+	if n < 0 {
+		return substring(runes, -n, len(runes)), nil
+	}
+	return substring(runes, len(runes)-n, len(runes)), nil
+}
+
+// pad returns a function which pads the string to the specified length, and truncates the string if it is longer.
+func pad(isLeft bool) func(args ...sqlite3.Value) (any, error) {
+	return func(args ...sqlite3.Value) (any, error) {
+		runes := []rune(args[0].Text())
+		n := args[1].Int()
+		fill := []rune(" ")
+		if len(args) == 3 {
+			fill = []rune(args[2].Text())
+		}
+		if n <= len(runes) {
+			return substring(runes, 0, n), nil
+		}
+		if len(fill) == 0 {
+			return string(runes), nil
+		}
+		padding := make([]rune, 0, n-len(runes))
+		for len(padding) < n-len(runes) {
+			padding = append(padding, fill[len(padding)%len(fill)])
+		}
+		if isLeft {
+			return string(padding) + string(runes), nil
+		}
+		return string(runes) + string(padding), nil
+	}
+}
+
+// repeat returns the string repeated the specified number of times.
+func repeat(args ...sqlite3.Value) (any, error) {
+	return strings.Repeat(args[0].Text(), max(0, args[1].Int())), nil
+}
+
+// reverse returns the reversed string.
+func reverse(args ...sqlite3.Value) (any, error) {
+	runes := []rune(args[0].Text())
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes), nil
+}
+
+// md5Hex returns the MD5 hash of the specified value as a hex string.
+func md5Hex(args ...sqlite3.Value) (any, error) {
+	sum := md5.Sum(args[0].RawBlob())
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// sha1Hex returns the SHA-1 hash of the specified value as a hex string.
+func sha1Hex(args ...sqlite3.Value) (any, error) {
+	sum := sha1.Sum(args[0].RawBlob()) // nolint:gosec
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// sha2Hex returns the SHA-2 hash of the specified value with the specified bits as a hex string.
+func sha2Hex(args ...sqlite3.Value) (any, error) {
+	b := args[0].RawBlob()
+	switch args[1].Int() {
+	case 224:
+		sum := sha256.Sum224(b)
+		return hex.EncodeToString(sum[:]), nil
+	case 0, 256:
+		sum := sha256.Sum256(b)
+		return hex.EncodeToString(sum[:]), nil
+	case 384:
+		sum := sha512.Sum384(b)
+		return hex.EncodeToString(sum[:]), nil
+	case 512:
+		sum := sha512.Sum512(b)
+		return hex.EncodeToString(sum[:]), nil
+	}
+	return nil, nil
+}
+
+// regexpMatch returns true if the string of the second argument matches the regular expression, which enables the SQLite REGEXP operator.
+func regexpMatch(args ...sqlite3.Value) (any, error) {
+	re, err := regexp.Compile(args[0].Text())
+	if err != nil {
+		return nil, err
+	}
+	return re.MatchString(args[1].Text()), nil
+}
+
+// regexpLike returns true if the string of the first argument matches the regular expression.
+func regexpLike(args ...sqlite3.Value) (any, error) {
+	return regexpMatch(args[1], args[0])
+}
+
+// regexpReplace returns a function which replaces the substrings matching the regular expression.
+// PostgreSQL replaces only the first match unless the g flag is specified, and MySQL replaces all matches.
+func regexpReplace(all bool) func(args ...sqlite3.Value) (any, error) {
+	return func(args ...sqlite3.Value) (any, error) {
+		pattern := args[1].Text()
+		replaceAll := all
+		if len(args) == 4 {
+			flags := args[3].Text()
+			replaceAll = replaceAll || strings.Contains(flags, "g")
+			if strings.Contains(flags, "i") {
+				pattern = "(?i)" + pattern
+			}
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		// The back references such as \1 are converted into the Go template such as ${1}.
+		repl := regexp.MustCompile(`\\(\d)`).ReplaceAllString(args[2].Text(), "$${$1}")
+		s := args[0].Text()
+		if replaceAll {
+			return re.ReplaceAllString(s, repl), nil
+		}
+		loc := re.FindStringSubmatchIndex(s)
+		if loc == nil {
+			return s, nil
+		}
+		return s[:loc[0]] + string(re.ExpandString(nil, repl, s, loc)) + s[loc[1]:], nil
+	}
+}
+
+// strpos returns the 1-based position of the substring, or 0 if not found.
+func strpos(args ...sqlite3.Value) (any, error) {
+	s := args[0].Text()
+	idx := strings.Index(s, args[1].Text())
+	if idx < 0 {
+		return 0, nil
+	}
+	return len([]rune(s[:idx])) + 1, nil
+}
+
+// splitPart splits the string on the delimiter and returns the n-th field, counting from the end if n is negative.
+func splitPart(args ...sqlite3.Value) (any, error) {
+	fields := strings.Split(args[0].Text(), args[1].Text())
+	n := args[2].Int()
+	switch {
+	case n == 0:
+		return nil, newErrInvalid("field position (0)")
+	case 0 < n && n <= len(fields):
+		return fields[n-1], nil
+	case n < 0 && -n <= len(fields):
+		return fields[len(fields)+n], nil
+	}
+	return "", nil
+}
+
+// initcap converts the first letter of each word to upper case and the rest to lower case.
+func initcap(args ...sqlite3.Value) (any, error) {
+	runes := []rune(args[0].Text())
+	inWord := false
+	for n, r := range runes {
+		if inWord {
+			runes[n] = unicode.ToLower(r)
+		} else {
+			runes[n] = unicode.ToUpper(r)
+		}
+		inWord = unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+	return string(runes), nil
+}
+
+// encode encodes the binary data into the textual representation of the specified format.
+func encode(args ...sqlite3.Value) (any, error) {
+	b := args[0].RawBlob()
+	switch strings.ToLower(args[1].Text()) {
+	case "base64":
+		return base64.StdEncoding.EncodeToString(b), nil
+	case "hex":
+		return hex.EncodeToString(b), nil
+	case "escape":
+		return string(b), nil
+	}
+	return nil, newErrNotSupported(fmt.Sprintf("encoding (%s)", args[1].Text()))
+}
+
+// decode decodes the binary data from the textual representation of the specified format.
+func decode(args ...sqlite3.Value) (any, error) {
+	s := args[0].Text()
+	switch strings.ToLower(args[1].Text()) {
+	case "base64":
+		return base64.StdEncoding.DecodeString(s)
+	case "hex":
+		return hex.DecodeString(s)
+	case "escape":
+		return []byte(s), nil
+	}
+	return nil, newErrNotSupported(fmt.Sprintf("encoding (%s)", args[1].Text()))
+}
+
+// like returns true if the value of the second argument matches the LIKE pattern of the first argument case-sensitively as PostgreSQL does.
+// The escape character is the optional third argument which defaults to the backslash, and the empty escape disables escaping.
+func like(args ...sqlite3.Value) (any, error) {
+	escape := []rune("\\")
+	if 2 < len(args) {
+		escape = []rune(args[2].Text())
+		if 1 < len(escape) {
+			return nil, newErrInvalid("ESCAPE '" + string(escape) + "'")
+		}
+	}
+	pattern := []rune(args[0].Text())
+	if 0 < len(escape) && 0 < len(pattern) && pattern[len(pattern)-1] == escape[0] {
+		n := 0
+		for n < len(pattern) && pattern[len(pattern)-1-n] == escape[0] {
+			n++
+		}
+		if n%2 == 1 {
+			return nil, newErrInvalid("LIKE pattern '" + string(pattern) + "' ending with the escape character")
+		}
+	}
+	return likeMatch(pattern, []rune(args[1].Text()), escape), nil
+}
+
+// likeMatch returns true if the specified value matches the specified LIKE pattern.
+func likeMatch(pattern []rune, value []rune, escape []rune) bool {
+	for 0 < len(pattern) {
+		switch {
+		case 0 < len(escape) && pattern[0] == escape[0] && 1 < len(pattern):
+			if len(value) == 0 || value[0] != pattern[1] {
+				return false
+			}
+			pattern, value = pattern[2:], value[1:]
+		case pattern[0] == '%':
+			for 0 < len(pattern) && pattern[0] == '%' {
+				pattern = pattern[1:]
+			}
+			if len(pattern) == 0 {
+				return true
+			}
+			for n := 0; n <= len(value); n++ {
+				if likeMatch(pattern, value[n:], escape) {
+					return true
+				}
+			}
+			return false
+		case pattern[0] == '_':
+			if len(value) == 0 {
+				return false
+			}
+			pattern, value = pattern[1:], value[1:]
+		default:
+			if len(value) == 0 || value[0] != pattern[0] {
+				return false
+			}
+			pattern, value = pattern[1:], value[1:]
+		}
+	}
+	return len(value) == 0
+}
+
+// mysqlConcat concatenates the arguments, and returns NULL if any of the arguments is NULL as MySQL CONCAT() does.
+func mysqlConcat(args ...sqlite3.Value) (any, error) {
+	var b strings.Builder
+	for _, arg := range args {
+		if arg.Type() == sqlite3.NULL {
+			return nil, nil
+		}
+		b.WriteString(arg.Text())
+	}
+	return b.String(), nil
+}
+
+// locate returns the 1-based position of the first occurrence of the substring starting at the specified position.
+func locate(args ...sqlite3.Value) (any, error) {
+	runes := []rune(args[1].Text())
+	pos := 1
+	if len(args) == 3 {
+		pos = args[2].Int()
+	}
+	if pos < 1 || len(runes)+1 < pos {
+		return 0, nil
+	}
+	idx := strings.Index(string(runes[pos-1:]), args[0].Text())
+	if idx < 0 {
+		return 0, nil
+	}
+	return pos + len([]rune(string(runes[pos-1:])[:idx])), nil
+}
+
+// substringIndex returns the substring before the count-th occurrence of the delimiter, or after the |count|-th occurrence from the end if count is negative.
+func substringIndex(args ...sqlite3.Value) (any, error) {
+	s := args[0].Text()
+	delim := args[1].Text()
+	count := args[2].Int()
+	if delim == "" || count == 0 {
+		return "", nil
+	}
+	fields := strings.Split(s, delim)
+	if len(fields) <= max(count, -count) {
+		return s, nil
+	}
+	if 0 < count {
+		return strings.Join(fields[:count], delim), nil
+	}
+	return strings.Join(fields[len(fields)+count:], delim), nil
+}
+
+// space returns the string of the specified number of spaces.
+func space(args ...sqlite3.Value) (any, error) {
+	return strings.Repeat(" ", max(0, args[0].Int())), nil
+}
+
+// elt returns the n-th string of the following arguments.
+func elt(args ...sqlite3.Value) (any, error) {
+	if len(args) < 2 || args[0].Type() == sqlite3.NULL {
+		return nil, nil
+	}
+	n := args[0].Int()
+	if n < 1 || len(args)-1 < n {
+		return nil, nil
+	}
+	return args[n], nil
+}
+
+// field returns the 1-based index of the first argument in the following arguments, or 0 if not found.
+func field(args ...sqlite3.Value) (any, error) {
+	if len(args) < 2 || args[0].Type() == sqlite3.NULL {
+		return 0, nil
+	}
+	s := args[0].Text()
+	for n, arg := range args[1:] {
+		if arg.Type() != sqlite3.NULL && arg.Text() == s {
+			return n + 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// findInSet returns the 1-based index of the first argument in the comma-separated list of the second argument, or 0 if not found.
+func findInSet(args ...sqlite3.Value) (any, error) {
+	list := args[1].Text()
+	if list == "" {
+		return 0, nil
+	}
+	s := args[0].Text()
+	for n, item := range strings.Split(list, ",") {
+		if item == s {
+			return n + 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// toBase64 encodes the string into the base-64 string.
+func toBase64(args ...sqlite3.Value) (any, error) {
+	return base64.StdEncoding.EncodeToString(args[0].RawBlob()), nil
+}
+
+// fromBase64 decodes the base-64 string.
+func fromBase64(args ...sqlite3.Value) (any, error) {
+	b, err := base64.StdEncoding.DecodeString(args[0].Text())
+	if err != nil {
+		return nil, nil
+	}
+	return b, nil
+}
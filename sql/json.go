@@ -0,0 +1,53 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// JSONValueError represents an error of a value which is not a well-formed JSON text of the JSON column.
+type JSONValueError struct {
+	// Column is the column name.
+	Column string
+	// Value is the rejected string literal, or an empty string if the value is not a string literal.
+	Value string
+}
+
+// Error returns the error message.
+func (err *JSONValueError) Error() string {
+	return fmt.Sprintf("invalid JSON text for column (%s)", err.Column)
+}
+
+// Position returns the zero-based byte position of the syntax error in the rejected value.
+func (err *JSONValueError) Position() int {
+	var syntaxErr *json.SyntaxError
+	if jsonErr := json.Unmarshal([]byte(err.Value), new(any)); errors.As(jsonErr, &syntaxErr) && 0 < syntaxErr.Offset {
+		return int(syntaxErr.Offset) - 1
+	}
+	return 0
+}
+
+// jsonValueErrorOf returns the JSON value error of the specified CHECK constraint failure of a JSON column in the specified query.
+func (db *Database) jsonValueErrorOf(query string, column string) *JSONValueError {
+	valueErr := &JSONValueError{Column: column, Value: ""}
+	isRejected := func(v string) bool { return !json.Valid([]byte(v)) }
+	if assignment, ok := db.assignmentOf(query, column, isRejected); ok {
+		valueErr.Value = assignment.Value
+	}
+	return valueErr
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// PostgreSQL: Documentation: 16: 8.6. Boolean Type
+// https://www.postgresql.org/docs/16/datatype-boolean.html
+// PostgreSQL: Documentation: 16: 8.12. UUID Type
+// https://www.postgresql.org/docs/16/datatype-uuid.html
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cybergarage/go-sqlserver/sql/dialect"
+)
+
+// The layouts of the logical date and time values.
+const (
+	dateLayout        = "2006-01-02"
+	timeLayout        = "15:04:05.999999"
+	timestampLayout   = "2006-01-02 15:04:05.999999"
+	timestampTZLayout = "2006-01-02 15:04:05.999999-07"
+)
+
+// timeLayouts lists the layouts which the stored date and time texts are parsed with.
+var timeLayouts = map[string][]string{
+	dialect.DateType: {dateLayout, time.RFC3339Nano, timestampLayout},
+	dialect.TimeType: {"15:04:05.999999999", "15:04", "15:04:05.999999999Z07:00", "15:04:05.999999999-07"},
+	dialect.TimestampTZType: {
+		"2006-01-02 15:04:05.999999999Z07:00", "2006-01-02 15:04:05.999999999-07", time.RFC3339Nano,
+		"2006-01-02 15:04:05.999999999", "2006-01-02T15:04:05.999999999", dateLayout,
+	},
+}
+
+// newLogicalValueFrom returns the Go value of the specified logical type from the stored SQLite value.
+// BOOLEAN values are returned as bool, DATE, TIME and TIMESTAMPTZ values as time.Time, and UUID values as string.
+func newLogicalValueFrom(typeName string, v any) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if b, ok := v.([]byte); ok {
+		if typeName == dialect.UUIDType && len(b) == 16 {
+			return uuidStringOf(hex.EncodeToString(b)), nil
+		}
+		v = string(b)
+	}
+	switch typeName {
+	case dialect.BooleanType:
+		return boolValueOf(v)
+	case dialect.DateType, dialect.TimeType, dialect.TimestampTZType:
+		return timeValueOf(typeName, v)
+	case dialect.UUIDType:
+		return uuidStringOf(fmt.Sprintf("%v", v)), nil
+	}
+	return v, nil
+}
+
+// boolValueOf returns the boolean value of the specified stored value such as 1, 't' and 'true'.
+func boolValueOf(v any) (bool, error) {
+	switch v := v.(type) {
+	case bool:
+		return v, nil
+	case int64:
+		return v != 0, nil
+	case float64:
+		return v != 0, nil
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "t", "true", "y", "yes", "on", "1":
+			return true, nil
+		case "f", "false", "n", "no", "off", "0":
+			return false, nil
+		}
+	}
+	return false, newErrInvalidValue(dialect.BooleanType, v)
+}
+
+// timeValueOf returns the time value of the specified stored value, and the values without time zones are regarded as UTC.
+func timeValueOf(typeName string, v any) (time.Time, error) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		s := strings.TrimSpace(v)
+		for _, layout := range timeLayouts[typeName] {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, nil
+			}
+		}
+	}
+	return time.Time{}, newErrInvalidValue(typeName, v)
+}
+
+// uuidStringOf returns the canonical lower case UUID string of the specified UUID text such as {A0EEBC99-9C0B-...}.
+func uuidStringOf(s string) string {
+	digits := strings.ToLower(strings.NewReplacer("-", "", "{", "", "}", "").Replace(strings.TrimSpace(s)))
+	if len(digits) != 32 {
+		return s
+	}
+	if _, err := hex.DecodeString(digits); err != nil {
+		return s
+	}
+	return strings.Join([]string{digits[0:8], digits[8:12], digits[12:16], digits[16:20], digits[20:32]}, "-")
+}
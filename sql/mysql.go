@@ -0,0 +1,651 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// MySQL: Text Protocol
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_command_phase_text.html
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/cybergarage/go-logger/log"
+	"github.com/cybergarage/go-mysql/mysql"
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+	"github.com/cybergarage/go-mysql/mysql/query"
+	sql "github.com/cybergarage/go-sqlparser/sql/query/response/resultset"
+	"github.com/cybergarage/go-sqlserver/sql/dialect"
+)
+
+// mysqlCommandHandler represents a MySQL command handler which executes DML queries
+// without the go-sqlparser AST, because the AST drops the MySQL specific syntax such as
+// ON DUPLICATE KEY UPDATE, INSERT IGNORE and REPLACE INTO.
+type mysqlCommandHandler struct {
+	protocol.CommandHandler
+	server     *server
+	lastStmtID atomic.Uint32
+}
+
+// mysqlCommandHandlerSetter represents a MySQL server which is able to replace the command handler.
+type mysqlCommandHandlerSetter interface {
+	SetCommandHandler(protocol.CommandHandler)
+}
+
+// mysqlCapabilitySetter represents a MySQL server which is able to replace the capability flags.
+type mysqlCapabilitySetter interface {
+	Capability() protocol.Capability
+	SetCapability(protocol.Capability)
+}
+
+// newMySQLCommandHandlerWith returns a new MySQL command handler which delegates the other commands to the specified handler.
+func newMySQLCommandHandlerWith(server *server, handler protocol.CommandHandler) *mysqlCommandHandler {
+	return &mysqlCommandHandler{
+		CommandHandler: handler,
+		server:         server,
+		lastStmtID:     atomic.Uint32{},
+	}
+}
+
+// setupMySQLCommandHandler replaces the command handler of the MySQL server.
+func (server *server) setupMySQLCommandHandler() {
+	myServer := server.MySQLServer()
+	handler, ok := myServer.(protocol.CommandHandler)
+	if !ok {
+		return
+	}
+	setter, ok := myServer.(mysqlCommandHandlerSetter)
+	if !ok {
+		return
+	}
+	myHandler := newMySQLCommandHandlerWith(server, handler)
+	setter.SetCommandHandler(myHandler)
+	myServer.SetErrorHandler(myHandler)
+	// The multiple statements and results are announced because the command handler executes them in sequence.
+	if capSetter, ok := myServer.(mysqlCapabilitySetter); ok {
+		capSetter.SetCapability(capSetter.Capability() | protocol.ClientMultiStatements | protocol.ClientMultiResults)
+	}
+}
+
+// mysqlQueryExecutor represents a MySQL query executor which returns the affected rows and the last insert ID of INSERT
+// in the OK packets, and delegates the other queries to the specified executor.
+type mysqlQueryExecutor struct {
+	mysql.QueryExecutor
+	server *server
+}
+
+// setupMySQLQueryExecutor replaces the query executor of the MySQL server.
+func (server *server) setupMySQLQueryExecutor() {
+	myServer := server.MySQLServer()
+	myServer.SetQueryExecutor(&mysqlQueryExecutor{
+		QueryExecutor: myServer.QueryExecutor(),
+		server:        server,
+	})
+}
+
+// Insert handles a INSERT query.
+func (executor *mysqlQueryExecutor) Insert(conn mysql.Conn, stmt query.Insert) (mysql.Response, error) {
+	rs, err := executor.server.insert(conn, stmt)
+	if err != nil {
+		if isMySQLValueError(err) {
+			return newMySQLValueERRFrom(err)
+		}
+		return protocol.NewResponseWithError(err)
+	}
+	executor.server.setWarnings(conn, warningsOf(rs))
+	return newMySQLOKFrom("INSERT", rs)
+}
+
+// HandleQuery handles a query command. The multiple statements are executed in sequence if the client enables
+// CLIENT_MULTI_STATEMENTS, and every statement returns a result.
+func (handler *mysqlCommandHandler) HandleQuery(conn protocol.Conn, q *protocol.Query) (protocol.Response, error) {
+	srcStmts, err := dialect.SplitStatements(dialect.MySQL, q.Query())
+	if err == nil && 1 < len(srcStmts) {
+		if q.Capability().IsDisabled(protocol.ClientMultiStatements) {
+			return newMySQLMultiStatementsERR()
+		}
+		return nil, handler.executeStatements(conn, q, srcStmts)
+	}
+	if count, ok := dialect.IsShowWarningsStatement(q.Query()); ok {
+		_, err := handler.writeWarnings(newMySQLResponseWriterFor(conn, q), conn, count)
+		return nil, err
+	}
+	handler.server.setWarnings(conn, nil)
+	stmts, err := dialect.NewMySQLRewriter().RewriteStatements(q.Query())
+	if err != nil || len(stmts) == 0 || (!isMySQLDMLStatement(stmts[0]) && !dialect.HasExtendedColumnType(stmts[0])) {
+		return handler.CommandHandler.HandleQuery(conn, q)
+	}
+	return handler.executeStatement(conn, newMySQLResponseWriterFor(conn, q), stmts)
+}
+
+// ParserError handles a parser error.
+func (handler *mysqlCommandHandler) ParserError(conn mysql.Conn, q string, err error) (mysql.Response, error) {
+	log.Warn(err.Error())
+	return protocol.NewResponseWithError(fmt.Errorf("parser error : %w", err))
+}
+
+// executeStatement executes the specified SQLite statements which are rewritten from a statement with the bind arguments,
+// and returns the MySQL response of the first statement. The result set of the query is written with the specified writer directly,
+// and no response is returned.
+func (handler *mysqlCommandHandler) executeStatement(conn protocol.Conn, w *mysqlResponseWriter, stmts []string, args ...any) (protocol.Response, error) {
+	stmt := stmts[0]
+	rs, err := handler.server.executeStatements(conn, stmts, args...)
+	if err != nil {
+		if isMySQLValueError(err) {
+			return newMySQLValueERRFrom(err)
+		}
+		return nil, err
+	}
+	handler.server.setWarnings(conn, warningsOf(rs))
+	if dialect.IsQuery(stmt) {
+		defer rs.Close()
+		_, err := w.writeResultSet(rs)
+		return nil, err
+	}
+	return newMySQLOKFrom(dialect.StatementKeyword(stmt), rs)
+}
+
+// mysqlStatementHandler represents a MySQL server which is able to handle a parsed statement.
+type mysqlStatementHandler interface {
+	HandleStatement(protocol.Conn, query.Statement) (protocol.Response, error)
+}
+
+// mysqlServerStatusSetter represents a response packet which has the server status flags.
+type mysqlServerStatusSetter interface {
+	SetServerStatus(protocol.ServerStatus)
+}
+
+// executeStatements executes the specified statements in sequence, and writes the results to the connection.
+// The results except the last one have SERVER_MORE_RESULTS_EXISTS, and the execution stops at the first error
+// which is returned as the last result. The preceding statements are not rolled back as MySQL does.
+func (handler *mysqlCommandHandler) executeStatements(conn protocol.Conn, q *protocol.Query, srcStmts []string) error {
+	w := newMySQLResponseWriterFor(conn, q)
+	for n, srcStmt := range srcStmts {
+		w.status = 0
+		if n < len(srcStmts)-1 {
+			w.status = protocol.ServerMoreResultsExists
+		}
+		ok, err := handler.writeStatement(w, conn, srcStmt)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+	return nil
+}
+
+// writeStatement executes the specified MySQL statement, and writes the result to the connection.
+// It returns false if the result is an ERR packet.
+func (handler *mysqlCommandHandler) writeStatement(w *mysqlResponseWriter, conn protocol.Conn, srcStmt string) (bool, error) {
+	if count, ok := dialect.IsShowWarningsStatement(srcStmt); ok {
+		return handler.writeWarnings(w, conn, count)
+	}
+	handler.server.setWarnings(conn, nil)
+	stmts, err := dialect.NewMySQLRewriter().RewriteStatements(srcStmt)
+	if err == nil && 0 < len(stmts) && (isMySQLDMLStatement(stmts[0]) || dialect.HasExtendedColumnType(stmts[0])) {
+		stmt := stmts[0]
+		rs, err := handler.server.executeStatements(conn, stmts)
+		if err != nil {
+			return false, w.writeError(err)
+		}
+		handler.server.setWarnings(conn, warningsOf(rs))
+		if dialect.IsQuery(stmt) {
+			defer rs.Close()
+			return w.writeResultSet(rs)
+		}
+		res, err := newMySQLOKFrom(dialect.StatementKeyword(stmt), rs, protocol.WithOKServerStatus(w.status))
+		if err != nil {
+			return false, err
+		}
+		return true, w.write(res)
+	}
+
+	stmtHandler, ok := handler.CommandHandler.(mysqlStatementHandler)
+	if !ok {
+		return false, w.writeError(newErrNotSupported(srcStmt))
+	}
+	parsedStmts, err := query.NewParser().ParseString(srcStmt)
+	if err != nil {
+		res, err := handler.ParserError(conn, srcStmt, err)
+		if err != nil {
+			return false, err
+		}
+		return false, w.write(res)
+	}
+	for _, parsedStmt := range parsedStmts {
+		res, err := stmtHandler.HandleStatement(conn, parsedStmt)
+		if err != nil {
+			return false, w.writeError(err)
+		}
+		if res == nil {
+			res, err = protocol.NewOK()
+			if err != nil {
+				return false, err
+			}
+		}
+		if _, ok := res.(*protocol.ERR); ok {
+			return false, w.write(res)
+		}
+		if setter, ok := res.(mysqlServerStatusSetter); ok {
+			setter.SetServerStatus(w.status)
+		}
+		if err := w.write(res); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// newMySQLOKFrom returns the OK packet of the specified statement keyword and the result set which has the warning count,
+// and the OK packets of INSERT have the last insert IDs. The last insert ID of the multiple row INSERT is the ID of the first inserted row as MySQL returns,
+// and it is zero if no rows are inserted such as by INSERT IGNORE. The rows updated by ON DUPLICATE KEY UPDATE are counted as two affected rows as MySQL counts.
+func newMySQLOKFrom(keyword string, rs sql.ResultSet, opts ...protocol.OKOption) (*protocol.OK, error) {
+	affectedRows := uint64(rs.RowsAffected())
+	if upserted, ok := rs.(upsertedRowser); ok && keyword == "INSERT" {
+		affectedRows += uint64(upserted.UpdatedRows())
+	}
+	opts = append(opts,
+		protocol.WithOKAffectedRows(affectedRows),
+		protocol.WithOKWarnings(mysqlWarningCountOf(rs)),
+	)
+	if ider, ok := rs.(lastInsertIDer); ok && keyword == "INSERT" && 0 < ider.LastInsertID() {
+		opts = append(opts, protocol.WithOKLastInsertID(uint64(ider.LastInsertID())))
+	}
+	return protocol.NewOK(opts...)
+}
+
+// newMySQLEnumValueERR returns the ERR packet of the specified invalid enum value as MySQL returns in the strict SQL mode.
+func newMySQLEnumValueERR(err *EnumValueError) (*protocol.ERR, error) {
+	// The state should precede the message because WithERRState overwrites the message.
+	return protocol.NewERR(
+		protocol.WithERRCode(1265),
+		protocol.WithERRState("01000"),
+		protocol.WithERRMessage(fmt.Sprintf("Data truncated for column '%s' at row 1", err.Column)),
+	)
+}
+
+// isMySQLValueError returns true if the specified error is an invalid enum value error, a decimal value error, a JSON value error or a strict value error.
+func isMySQLValueError(err error) bool {
+	var enumErr *EnumValueError
+	var decimalErr *DecimalValueError
+	var jsonErr *JSONValueError
+	var strictErr *StrictValueError
+	return errors.As(err, &enumErr) || errors.As(err, &decimalErr) || errors.As(err, &jsonErr) || errors.As(err, &strictErr)
+}
+
+// newMySQLValueERRFrom returns the ERR packet of the specified invalid enum value error, decimal value error, JSON value error or strict value error.
+func newMySQLValueERRFrom(err error) (*protocol.ERR, error) {
+	var strictErr *StrictValueError
+	if errors.As(err, &strictErr) {
+		return newMySQLStrictValueERR(strictErr)
+	}
+	var decimalErr *DecimalValueError
+	if errors.As(err, &decimalErr) {
+		// The state should precede the message because WithERRState overwrites the message.
+		return protocol.NewERR(
+			protocol.WithERRCode(1264),
+			protocol.WithERRState("22003"),
+			protocol.WithERRMessage(fmt.Sprintf("Out of range value for column '%s' at row 1", decimalErr.Column)),
+		)
+	}
+	var jsonErr *JSONValueError
+	if errors.As(err, &jsonErr) {
+		return protocol.NewERR(
+			protocol.WithERRCode(3140),
+			protocol.WithERRState("22032"),
+			protocol.WithERRMessage(fmt.Sprintf("Invalid JSON text: \"Invalid value.\" at position %d in value for column '%s'.", jsonErr.Position(), jsonErr.Column)),
+		)
+	}
+	var enumErr *EnumValueError
+	if errors.As(err, &enumErr) {
+		return newMySQLEnumValueERR(enumErr)
+	}
+	return protocol.NewERR(protocol.WithERRMessage(err.Error()))
+}
+
+// newMySQLStrictValueERR returns the ERR packet of the specified value rejected by a strict table as MySQL returns
+// in the strict SQL mode.
+func newMySQLStrictValueERR(err *StrictValueError) (*protocol.ERR, error) {
+	// The state should precede the message because WithERRState overwrites the message.
+	if err.IsTooLong() {
+		return protocol.NewERR(
+			protocol.WithERRCode(1406),
+			protocol.WithERRState("22001"),
+			protocol.WithERRMessage(fmt.Sprintf("Data too long for column '%s' at row %d", err.Column, err.Row)),
+		)
+	}
+	valueType, ok := numericValueTypeOf(err.Type)
+	if !ok {
+		valueType = strings.ToLower(err.Type)
+	}
+	return protocol.NewERR(
+		protocol.WithERRCode(mysqlIncorrectValueCode),
+		protocol.WithERRState("HY000"),
+		protocol.WithERRMessage(fmt.Sprintf("Incorrect %s value: '%s' for column '%s' at row %d", valueType, err.Value, err.Column, err.Row)),
+	)
+}
+
+// newMySQLMultiStatementsERR returns the ERR packet of the multiple statements which are sent without CLIENT_MULTI_STATEMENTS.
+func newMySQLMultiStatementsERR() (*protocol.ERR, error) {
+	return protocol.NewERR(
+		protocol.WithERRCode(1064),
+		protocol.WithERRState("42000"),
+		protocol.WithERRMessage("You have an error in your SQL syntax; multiple statements are not allowed without CLIENT_MULTI_STATEMENTS"),
+	)
+}
+
+// mysqlCommandPacket represents a command packet which the response packets follow.
+type mysqlCommandPacket interface {
+	Capability() protocol.Capability
+	SequenceID() protocol.SequenceID
+}
+
+// mysqlResponseWriter represents a writer of the response packets of a query command. The packets are numbered
+// in sequence across the results, and the terminal packets of the results have the server status flags.
+// The rows of the result sets are written in the binary protocol for COM_STMT_EXECUTE.
+type mysqlResponseWriter struct {
+	conn   protocol.Conn
+	caps   protocol.Capability
+	seqID  protocol.SequenceID
+	status protocol.ServerStatus
+	binary bool
+}
+
+// newMySQLResponseWriterFor returns a new response writer whose sequence IDs follow the specified command.
+func newMySQLResponseWriterFor(conn protocol.Conn, cmd mysqlCommandPacket) *mysqlResponseWriter {
+	return &mysqlResponseWriter{
+		conn:   conn,
+		caps:   cmd.Capability(),
+		seqID:  cmd.SequenceID(),
+		status: 0,
+		binary: false,
+	}
+}
+
+// write writes the specified packet with the next sequence ID.
+func (w *mysqlResponseWriter) write(pkt protocol.Response) error {
+	w.seqID = w.seqID.Next()
+	return w.conn.ResponsePacket(pkt,
+		protocol.WithResponseCapability(w.caps),
+		protocol.WithResponseSequenceID(w.seqID),
+	)
+}
+
+// writeError writes the ERR packet of the specified error, and the invalid enum values and the values rejected
+// by the strict tables are reported as MySQL does.
+func (w *mysqlResponseWriter) writeError(err error) error {
+	if isMySQLValueError(err) {
+		res, err := newMySQLValueERRFrom(err)
+		if err != nil {
+			return err
+		}
+		return w.write(res)
+	}
+	w.seqID = w.seqID.Next()
+	return w.conn.ResponseError(err,
+		protocol.WithERRCapability(w.caps),
+		protocol.WithERRSecuenceID(w.seqID),
+	)
+}
+
+// writeResultSet writes the text or binary result set of the specified result set to the connection row by row,
+// so that the rows are not buffered in the server memory. It returns false if the result set is terminated by an ERR packet.
+func (w *mysqlResponseWriter) writeResultSet(rs sql.ResultSet) (bool, error) {
+	columnDefs, err := newMySQLColumnDefsFrom(rs)
+	if err != nil {
+		return false, err
+	}
+	caps := w.caps
+	columnCount := protocol.NewColumnCount(
+		protocol.WithColumnCount(uint64(len(columnDefs))),
+		protocol.WithColumnCountCapability(caps),
+	)
+	if err := w.write(columnCount); err != nil {
+		return false, err
+	}
+	if caps.IsDisabled(protocol.ClientOptionalResultsetMetadata) || columnCount.MetadataFollows() == protocol.ResultsetMetadataFull {
+		for _, columnDef := range columnDefs {
+			if err := w.write(columnDef); err != nil {
+				return false, err
+			}
+		}
+	}
+	if caps.IsDisabled(protocol.ClientDeprecateEOF) {
+		eof, err := protocol.NewEOF(protocol.WithEOFCapability(caps))
+		if err != nil {
+			return false, err
+		}
+		if err := w.write(eof); err != nil {
+			return false, err
+		}
+	}
+
+	for rs.Next() {
+		textRow, err := newMySQLTextResultSetRowFrom(rs)
+		if err != nil {
+			// The ERR packet terminates the result set because the preceding rows have already been sent.
+			return false, w.writeError(err)
+		}
+		var row protocol.Response = textRow
+		if w.binary {
+			row, err = newMySQLBinaryResultSetRowFrom(columnDefs, textRow.columns)
+			if err != nil {
+				return false, w.writeError(err)
+			}
+		}
+		if err := w.write(row); err != nil {
+			return false, err
+		}
+	}
+	if err := resultSetErrOf(rs); err != nil {
+		// The rows which fail while stepping such as the constraint errors are reported by the ERR packet instead of the EOF packet.
+		return false, w.writeError(err)
+	}
+
+	if caps.IsEnabled(protocol.ClientDeprecateEOF) {
+		ok, err := protocol.NewOK(
+			protocol.WithOKCapability(caps),
+			protocol.WithOKServerStatus(w.status),
+			protocol.WithOKWarnings(mysqlWarningCountOf(rs)),
+		)
+		if err != nil {
+			return false, err
+		}
+		return true, w.write(ok)
+	}
+	eof, err := protocol.NewEOF(
+		protocol.WithEOFCapability(caps),
+		protocol.WithEOFServerStatus(w.status),
+		protocol.WithEOFWarnings(mysqlWarningCountOf(rs)),
+	)
+	if err != nil {
+		return false, err
+	}
+	return true, w.write(eof)
+}
+
+// newMySQLColumnDefsFrom returns the column definitions of the specified result set. The JSON, decimal, enum, integer
+// and logical type columns are returned as MYSQL_TYPE_JSON, MYSQL_TYPE_NEWDECIMAL, MYSQL_TYPE_STRING and the corresponding types,
+// and the base table columns have the original tables, the original names and the constraint flags.
+func newMySQLColumnDefsFrom(rs sql.ResultSet) ([]*mysqlColumnDef, error) {
+	baseColumnDefs, err := protocol.NewColumnDefsFromResultSet(rs)
+	if err != nil {
+		return nil, err
+	}
+	columnDefs := make([]*mysqlColumnDef, len(baseColumnDefs))
+	for n, baseColumnDef := range baseColumnDefs {
+		columnDef := newMySQLColumnDefFrom(baseColumnDef)
+		if namer, ok := rs.(columnTypeNamer); ok {
+			columnDef.setTypeName(namer.ColumnTypeName(n))
+		}
+		if originer, ok := rs.(columnOriginer); ok {
+			if origin := originer.ColumnOrigin(n); origin != nil {
+				columnDef.setOrigin(origin)
+			}
+		}
+		columnDefs[n] = columnDef
+	}
+	return columnDefs, nil
+}
+
+// mysqlLogicalFieldTypes maps the logical column types into the MySQL field types, and BOOLEAN is returned as TINYINT(1).
+var mysqlLogicalFieldTypes = map[string]query.FieldType{
+	dialect.BooleanType:     query.MySQLTypeTiny,
+	dialect.DateType:        query.MySQLTypeDate,
+	dialect.TimeType:        query.MySQLTypeTime,
+	dialect.TimestampTZType: query.MySQLTypeTimestamp,
+	dialect.UUIDType:        query.MySQLTypeString,
+}
+
+// mysqlIntegerFieldTypes maps the integer types into the MySQL field types.
+var mysqlIntegerFieldTypes = map[string]query.FieldType{
+	"TINYINT":   query.MySQLTypeTiny,
+	"SMALLINT":  query.MySQLTypeShort,
+	"MEDIUMINT": query.MySQLTypeInt24,
+	"INT":       query.MySQLTypeLong,
+	"BIGINT":    query.MySQLTypeLongLong,
+}
+
+// isMySQLIntegerTypeName returns true if the specified type name is an integer type name such as BIGINT and INT UNSIGNED.
+func isMySQLIntegerTypeName(typeName string) bool {
+	_, ok := dialect.IntegerTypeOf(typeName)
+	return ok
+}
+
+// newMySQLTextResultSetRowFrom returns the text result set row of the current row of the specified result set.
+func newMySQLTextResultSetRowFrom(rs sql.ResultSet) (*mysqlTextResultSetRow, error) {
+	namer, hasTypeNames := rs.(columnTypeNamer)
+	columns := rs.Schema().Columns()
+	row, err := rs.Row()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]*string, len(row.Values()))
+	for n, v := range row.Values() {
+		if v == nil {
+			continue
+		}
+		if hasTypeNames {
+			if s, ok := mysqlValueOf(namer.ColumnTypeName(n), v); ok {
+				values[n] = &s
+				continue
+			}
+		}
+		if len(columns) <= n {
+			return nil, newErrCoulumNotExist(n)
+		}
+		s, err := protocol.NewTextResultSetRowValueFrom(columns[n].DataType(), v)
+		if err != nil {
+			return nil, err
+		}
+		values[n] = &s
+	}
+	return newMySQLTextResultSetRowWith(values), nil
+}
+
+// mysqlNullColumn is the column value which represents NULL in the text resultset rows.
+const mysqlNullColumn = 0xFB
+
+// mysqlPacket represents a MySQL packet which is returned by protocol.NewPacket.
+type mysqlPacket interface {
+	SetCapability(protocol.Capability)
+	SetSequenceID(protocol.SequenceID)
+	SetPayload([]byte)
+	Bytes() ([]byte, error)
+}
+
+// mysqlTextResultSetRow represents a text resultset row which is able to return NULL columns,
+// because protocol.TextResultSetRow has only the string columns.
+type mysqlTextResultSetRow struct {
+	mysqlPacket
+	columns []*string
+}
+
+// newMySQLTextResultSetRowWith returns a new text resultset row with the specified columns, and the nil columns are returned as NULL.
+func newMySQLTextResultSetRowWith(columns []*string) *mysqlTextResultSetRow {
+	return &mysqlTextResultSetRow{
+		mysqlPacket: protocol.NewPacket(),
+		columns:     columns,
+	}
+}
+
+// Columns returns the columns.
+func (row *mysqlTextResultSetRow) Columns() []any {
+	columns := make([]any, len(row.columns))
+	for n, column := range row.columns {
+		if column != nil {
+			columns[n] = *column
+		}
+	}
+	return columns
+}
+
+// Bytes returns the packet bytes.
+func (row *mysqlTextResultSetRow) Bytes() ([]byte, error) {
+	w := protocol.NewPacketWriter()
+	for _, column := range row.columns {
+		if column == nil {
+			if err := w.WriteByte(mysqlNullColumn); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := w.WriteLengthEncodedString(*column); err != nil {
+			return nil, err
+		}
+	}
+	row.SetPayload(w.Bytes())
+	return row.mysqlPacket.Bytes()
+}
+
+// mysqlValueOf returns the text representation of the specified value of the logical or 64-bit integer type.
+func mysqlValueOf(typeName string, v any) (string, bool) {
+	switch v := v.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case uint64:
+		return strconv.FormatUint(v, 10), true
+	case bool:
+		if v {
+			return "1", true
+		}
+		return "0", true
+	case time.Time:
+		switch typeName {
+		case dialect.DateType:
+			return v.Format(dateLayout), true
+		case dialect.TimeType:
+			return v.Format(timeLayout), true
+		case dialect.TimestampTZType:
+			// MySQL returns TIMESTAMP values in the session time zone which is UTC.
+			return v.UTC().Format(timestampLayout), true
+		}
+	}
+	return "", false
+}
+
+// isMySQLDMLStatement returns true if the specified statement is a DML statement which is executed without the AST.
+func isMySQLDMLStatement(stmt string) bool {
+	switch dialect.LeadingKeyword(stmt) {
+	case "SELECT", "INSERT", "REPLACE", "UPDATE", "DELETE", "WITH", "VALUES":
+		return true
+	}
+	return false
+}
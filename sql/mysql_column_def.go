@@ -0,0 +1,252 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// MySQL: Column Definition
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_query_response_text_resultset_column_definition.html
+
+import (
+	"math"
+	"strings"
+
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+	"github.com/cybergarage/go-mysql/mysql/query"
+	"github.com/cybergarage/go-sqlserver/sql/dialect"
+)
+
+// mysqlColumnDefCatalog is the catalog of the column definitions which is always def.
+const mysqlColumnDefCatalog = "def"
+
+// mysqlColumnDefFixedFieldLength is the length of the fixed length fields of the column definitions.
+const mysqlColumnDefFixedFieldLength = 0x0c
+
+// mysqlCharSetMaxLength is the maximum byte length of a character in the column character set which is utf8.
+const mysqlCharSetMaxLength = 3
+
+// mysqlNotFixedDecimals is the decimals of the floating-point columns which have no fixed decimals.
+const mysqlNotFixedDecimals = 31
+
+// mysqlIntegerLengths maps the integer types into the display widths which include the signs.
+var mysqlIntegerLengths = map[string]uint32{
+	"TINYINT":                  4,
+	"TINYINT UNSIGNED":         3,
+	"SMALLINT":                 6,
+	"SMALLINT UNSIGNED":        5,
+	"MEDIUMINT":                9,
+	"MEDIUMINT UNSIGNED":       8,
+	"INT":                      11,
+	"INT UNSIGNED":             10,
+	"BIGINT":                   20,
+	dialect.UnsignedBigIntType: 20,
+}
+
+// mysqlLogicalLengths maps the logical column types into the column lengths of the formatted values.
+var mysqlLogicalLengths = map[string]uint32{
+	dialect.BooleanType:     1,
+	dialect.DateType:        10,
+	dialect.TimeType:        10,
+	dialect.TimestampTZType: 19,
+	dialect.UUIDType:        36 * mysqlCharSetMaxLength,
+}
+
+// mysqlColumnDef represents a column definition packet which is able to have the column length,
+// because protocol.ColumnDef has no option to set the column length.
+type mysqlColumnDef struct {
+	mysqlPacket
+	schema    string
+	table     string
+	orgTable  string
+	name      string
+	orgName   string
+	charSet   uint16
+	colLength uint32
+	colType   uint8
+	flags     uint16
+	decimals  uint8
+}
+
+// newMySQLColumnDefFrom returns a new column definition which has the same fields as the specified column definition.
+func newMySQLColumnDefFrom(def protocol.ColumnDef) *mysqlColumnDef {
+	return &mysqlColumnDef{
+		mysqlPacket: protocol.NewPacket(),
+		schema:      def.Schema(),
+		table:       def.Table(),
+		orgTable:    def.OrgTable(),
+		name:        def.Name(),
+		orgName:     def.OrgName(),
+		charSet:     def.CharSet(),
+		colLength:   def.ColLength(),
+		colType:     def.ColType(),
+		flags:       def.Flags(),
+		decimals:    def.Decimals(),
+	}
+}
+
+// setTypeName sets the column type, the length and the decimals of the specified declared or logical type name.
+func (def *mysqlColumnDef) setTypeName(typeName string) {
+	switch {
+	case isJSONTypeName(typeName):
+		def.colType = uint8(query.MySQLTypeJSON)
+		def.colLength = math.MaxUint32
+	case dialect.IsEnumTypeName(typeName):
+		flag := protocol.ColumnDefEnum
+		if kind, _, _ := dialect.EnumTypeOf(typeName); kind == dialect.SetType {
+			flag = protocol.ColumnDefSet
+		}
+		def.colType = uint8(query.MySQLTypeString)
+		def.flags |= uint16(flag)
+	case isMySQLIntegerTypeName(typeName):
+		integerType, _ := dialect.IntegerTypeOf(typeName)
+		name, unsigned := strings.CutSuffix(integerType, " UNSIGNED")
+		if unsigned {
+			def.flags |= uint16(protocol.ColumnDefUnsigned)
+		}
+		def.colType = uint8(mysqlIntegerFieldTypes[name])
+		def.colLength = mysqlIntegerLengths[integerType]
+	case dialect.IsLogicalTypeName(typeName):
+		// BOOLEAN is returned as TINYINT(1).
+		def.colType = uint8(mysqlLogicalFieldTypes[typeName])
+		def.colLength = mysqlLogicalLengths[typeName]
+	case isDecimalTypeName(typeName):
+		def.colType = uint8(query.MySQLTypeNewdecimal)
+		if precision, scale := decimalTypeOf(typeName); 0 <= precision {
+			// The column length includes the sign and the decimal point.
+			def.colLength = uint32(precision + 1)
+			if 0 < scale {
+				def.colLength++
+			}
+			def.decimals = uint8(scale)
+		}
+	}
+	switch query.FieldType(def.colType) {
+	case query.MySQLTypeDouble:
+		def.colLength = 22
+		def.decimals = mysqlNotFixedDecimals
+	case query.MySQLTypeFloat:
+		def.colLength = 12
+		def.decimals = mysqlNotFixedDecimals
+	}
+}
+
+// setOrigin sets the original table, the original name, the constraint flags and the character length of the specified base table column.
+func (def *mysqlColumnDef) setOrigin(origin *ColumnOrigin) {
+	def.schema = origin.Database
+	// SQLite returns no table aliases, so the original table is also returned as the table.
+	def.table = origin.Table
+	def.orgTable = origin.Table
+	def.orgName = origin.Column
+	if origin.NotNull || origin.PrimaryKey {
+		// The primary key columns are NOT NULL implicitly in MySQL.
+		def.flags |= uint16(protocol.ColumnDefNotNULL)
+	}
+	if origin.PrimaryKey {
+		def.flags |= uint16(protocol.ColumnDefPriKey)
+	}
+	if origin.AutoIncrement {
+		def.flags |= uint16(protocol.ColumnDefAutoIncrement)
+	}
+	if length, ok := characterLengthOf(origin.DeclType); ok && origin.LogicalType == "" {
+		def.colLength = uint32(length * mysqlCharSetMaxLength)
+	}
+}
+
+// Catalog returns the column catalog.
+func (def *mysqlColumnDef) Catalog() string {
+	return mysqlColumnDefCatalog
+}
+
+// Schema returns the column schema.
+func (def *mysqlColumnDef) Schema() string {
+	return def.schema
+}
+
+// Table returns the column table.
+func (def *mysqlColumnDef) Table() string {
+	return def.table
+}
+
+// OrgTable returns the column original table.
+func (def *mysqlColumnDef) OrgTable() string {
+	return def.orgTable
+}
+
+// Name returns the column name.
+func (def *mysqlColumnDef) Name() string {
+	return def.name
+}
+
+// OrgName returns the column original name.
+func (def *mysqlColumnDef) OrgName() string {
+	return def.orgName
+}
+
+// CharSet returns the column character set.
+func (def *mysqlColumnDef) CharSet() uint16 {
+	return def.charSet
+}
+
+// ColLength returns the column length.
+func (def *mysqlColumnDef) ColLength() uint32 {
+	return def.colLength
+}
+
+// ColType returns the column type.
+func (def *mysqlColumnDef) ColType() uint8 {
+	return def.colType
+}
+
+// Flags returns the column flags.
+func (def *mysqlColumnDef) Flags() uint16 {
+	return def.flags
+}
+
+// Decimals returns the column decimals.
+func (def *mysqlColumnDef) Decimals() uint8 {
+	return def.decimals
+}
+
+// Bytes returns the packet bytes.
+func (def *mysqlColumnDef) Bytes() ([]byte, error) {
+	w := protocol.NewPacketWriter()
+	for _, s := range []string{mysqlColumnDefCatalog, def.schema, def.table, def.orgTable, def.name, def.orgName} {
+		if err := w.WriteLengthEncodedString(s); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.WriteLengthEncodedInt(mysqlColumnDefFixedFieldLength); err != nil {
+		return nil, err
+	}
+	if err := w.WriteInt2(def.charSet); err != nil {
+		return nil, err
+	}
+	if err := w.WriteInt4(def.colLength); err != nil {
+		return nil, err
+	}
+	if err := w.WriteInt1(def.colType); err != nil {
+		return nil, err
+	}
+	if err := w.WriteInt2(def.flags); err != nil {
+		return nil, err
+	}
+	if err := w.WriteInt1(def.decimals); err != nil {
+		return nil, err
+	}
+	// The filler is unused.
+	if err := w.WriteInt2(0); err != nil {
+		return nil, err
+	}
+	def.SetPayload(w.Bytes())
+	return def.mysqlPacket.Bytes()
+}
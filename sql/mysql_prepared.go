@@ -0,0 +1,378 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// MySQL: COM_STMT_PREPARE
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_stmt_prepare.html
+// MySQL: COM_STMT_EXECUTE
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_stmt_execute.html
+// MySQL: Binary Protocol Resultset
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_binary_resultset.html
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+	"github.com/cybergarage/go-mysql/mysql/query"
+	mystmt "github.com/cybergarage/go-mysql/mysql/stmt"
+	"github.com/cybergarage/go-sqlserver/sql/dialect"
+)
+
+// mysqlBinaryRowHeader is the packet header of the binary resultset rows.
+const mysqlBinaryRowHeader = 0x00
+
+// mysqlBinaryRowNullBitmapOffset is the bit offset of the NULL bitmap of the binary resultset rows.
+const mysqlBinaryRowNullBitmapOffset = 2
+
+// mysqlParameterName is the column name of the parameter definitions.
+const mysqlParameterName = "?"
+
+// mysqlPreparedStatement represents a prepared statement which is executed with the rewritten SQLite statement.
+type mysqlPreparedStatement struct {
+	mystmt.PreparedStatement
+	stmt string
+}
+
+// mysqlParameterByter represents a parameter which returns the raw bytes, and the bytes of NULL are nil.
+type mysqlParameterByter interface {
+	Bytes() []byte
+}
+
+// PrepareStatement handles a COM_STMT_PREPARE command. The DML statements are prepared with the rewritten SQLite statements,
+// and the parameters are described with the types of the columns which the parameters are assigned to or compared with.
+func (handler *mysqlCommandHandler) PrepareStatement(conn protocol.Conn, stmtPrep *protocol.StmtPrepare) (*protocol.StmtPrepareResponse, error) {
+	stmts, err := dialect.NewMySQLRewriter().RewriteStatements(stmtPrep.Query())
+	if err != nil || len(stmts) != 1 || !isMySQLDMLStatement(stmts[0]) {
+		return handler.CommandHandler.PrepareStatement(conn, stmtPrep)
+	}
+	db, err := handler.server.databaseOf(conn)
+	if err != nil {
+		return nil, err
+	}
+	typeNames, err := db.ParameterTypes(stmts[0])
+	if err != nil {
+		return nil, err
+	}
+	params := make([]protocol.ColumnDef, len(typeNames))
+	for n, typeName := range typeNames {
+		params[n] = newMySQLParameterColumnDefOf(typeName)
+	}
+	stmtID := handler.nextStatementID(conn)
+	res := protocol.NewStmtPrepareResponse(
+		protocol.WithStmtPrepareResponseStatementID(stmtID),
+		protocol.WithStmtPrepareResponseParams(params),
+	)
+	prepStmt := &mysqlPreparedStatement{
+		PreparedStatement: protocol.NewPreparedStatmentWith(stmtPrep, res),
+		stmt:              stmts[0],
+	}
+	if err := conn.RegisterPreparedStatement(prepStmt); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// nextStatementID returns the next statement ID which is not used by the prepared statements of the specified connection.
+// The IDs are generated by the handler because go-mysql returns the same ID for all prepared statements.
+func (handler *mysqlCommandHandler) nextStatementID(conn protocol.Conn) protocol.StatementID {
+	for {
+		stmtID := protocol.StatementID(handler.lastStmtID.Add(1))
+		if stmtID == 0 {
+			continue
+		}
+		if _, err := conn.LookupPreparedStatementByID(stmtID); err != nil {
+			return stmtID
+		}
+	}
+}
+
+// newMySQLParameterColumnDefOf returns the parameter definition of the specified parameter type name,
+// and the parameters of the unknown types are described as MYSQL_TYPE_VAR_STRING.
+func newMySQLParameterColumnDefOf(typeName string) *mysqlColumnDef {
+	def := newMySQLColumnDefFrom(protocol.NewColumnDef(
+		protocol.WithColumnDefName(mysqlParameterName),
+		protocol.WithColumnDefType(uint8(query.MySQLTypeVarString)),
+	))
+	def.setTypeName(typeName)
+	return def
+}
+
+// ExecuteStatement handles a COM_STMT_EXECUTE command. The prepared DML statements are executed with the bound parameters,
+// and the result sets are written in the binary protocol.
+func (handler *mysqlCommandHandler) ExecuteStatement(conn protocol.Conn, stmtExec *protocol.StmtExecute) (protocol.Response, error) {
+	regStmt, err := conn.LookupPreparedStatementByID(stmtExec.StatementID())
+	if err != nil {
+		return nil, err
+	}
+	prepStmt, ok := regStmt.(*mysqlPreparedStatement)
+	if !ok {
+		return handler.CommandHandler.ExecuteStatement(conn, stmtExec)
+	}
+	params := stmtExec.Parameters()
+	args := make([]any, len(params))
+	for n, param := range params {
+		args[n], err = newMySQLParameterValueFrom(param)
+		if err != nil {
+			return nil, err
+		}
+	}
+	handler.server.setWarnings(conn, nil)
+	w := newMySQLResponseWriterFor(conn, stmtExec)
+	w.binary = true
+	return handler.executeStatement(conn, w, []string{prepStmt.stmt}, args...)
+}
+
+// newMySQLParameterValueFrom returns the bind argument of the specified parameter. The date and time parameters are bound
+// as the texts, and the NULL parameters are nil.
+func newMySQLParameterValueFrom(param mystmt.Parameter) (any, error) {
+	if byter, ok := param.(mysqlParameterByter); ok {
+		b := byter.Bytes()
+		if b == nil {
+			return nil, nil
+		}
+		// The empty strings are not returned by Value.
+		switch param.Type() {
+		case query.MySQLTypeString, query.MySQLTypeVarString, query.MySQLTypeVarchar:
+			return string(b), nil
+		case query.MySQLTypeTinyBlob, query.MySQLTypeMediumBlob, query.MySQLTypeLongBlob, query.MySQLTypeBlob:
+			return b, nil
+		}
+	}
+	v, err := param.Value()
+	if err != nil {
+		return nil, err
+	}
+	switch v := v.(type) {
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case float32:
+		return float64(v), nil
+	case time.Time:
+		if param.Type() == query.MySQLTypeDate {
+			return v.Format(dateLayout), nil
+		}
+		return v.Format(timestampLayout), nil
+	case time.Duration:
+		return mysqlDurationTextOf(v), nil
+	}
+	return v, nil
+}
+
+// mysqlDurationTextOf returns the TIME text such as -838:59:59.000001 of the specified duration.
+func mysqlDurationTextOf(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	s := fmt.Sprintf("%s%02d:%02d:%02d", sign, int64(d.Hours()), int64(d.Minutes())%60, int64(d.Seconds())%60)
+	if us := d.Microseconds() % 1000000; 0 < us {
+		s += strings.TrimRight(fmt.Sprintf(".%06d", us), "0")
+	}
+	return s
+}
+
+// mysqlDurationOf returns the duration of the specified TIME text such as -838:59:59.000001.
+func mysqlDurationOf(s string) (time.Duration, error) {
+	text := strings.TrimSpace(s)
+	text, isNegative := strings.CutPrefix(text, "-")
+	hms, frac, _ := strings.Cut(text, ".")
+	fields := strings.Split(hms, ":")
+	if len(fields) != 3 {
+		return 0, newErrInvalidValue("time", s)
+	}
+	units := []time.Duration{time.Hour, time.Minute, time.Second}
+	d := time.Duration(0)
+	for n, field := range fields {
+		v, err := strconv.ParseUint(field, 10, 32)
+		if err != nil {
+			return 0, newErrInvalidValue("time", s)
+		}
+		d += time.Duration(v) * units[n]
+	}
+	if frac != "" {
+		// The fractional seconds are truncated to the microseconds.
+		frac = (frac + "00000")[:6]
+		us, err := strconv.ParseUint(frac, 10, 32)
+		if err != nil {
+			return 0, newErrInvalidValue("time", s)
+		}
+		d += time.Duration(us) * time.Microsecond
+	}
+	if isNegative {
+		d = -d
+	}
+	return d, nil
+}
+
+// mysqlBinaryResultSetRow represents a binary resultset row which is encoded from the text values by the column types.
+type mysqlBinaryResultSetRow struct {
+	mysqlPacket
+}
+
+// newMySQLBinaryResultSetRowFrom returns a new binary resultset row of the specified text columns, and the nil columns are returned as NULL.
+func newMySQLBinaryResultSetRowFrom(columnDefs []*mysqlColumnDef, columns []*string) (*mysqlBinaryResultSetRow, error) {
+	w := protocol.NewPacketWriter()
+	if err := w.WriteByte(mysqlBinaryRowHeader); err != nil {
+		return nil, err
+	}
+	nullBitmap := protocol.NewNullBitmap(
+		protocol.WithNullBitmapNumFields(len(columns)),
+		protocol.WithNullBitmapOffset(mysqlBinaryRowNullBitmapOffset),
+	)
+	for n, column := range columns {
+		nullBitmap.SetNull(n, column == nil)
+	}
+	if _, err := w.WriteBytes(nullBitmap.Bytes()); err != nil {
+		return nil, err
+	}
+	for n, column := range columns {
+		if column == nil {
+			continue
+		}
+		if len(columnDefs) <= n {
+			return nil, newErrCoulumNotExist(n)
+		}
+		if err := writeMySQLBinaryValue(w, columnDefs[n], *column); err != nil {
+			return nil, err
+		}
+	}
+	row := &mysqlBinaryResultSetRow{
+		mysqlPacket: protocol.NewPacket(),
+	}
+	row.SetPayload(w.Bytes())
+	return row, nil
+}
+
+// writeMySQLBinaryValue writes the binary value of the specified text value of the column. The integer, floating-point,
+// date and time values are written in the fixed length formats, and the other values are written as the length encoded strings.
+func writeMySQLBinaryValue(w *protocol.PacketWriter, def *mysqlColumnDef, s string) error {
+	switch colType := query.FieldType(def.colType); colType {
+	case query.MySQLTypeTiny, query.MySQLTypeShort, query.MySQLTypeYear, query.MySQLTypeLong, query.MySQLTypeInt24, query.MySQLTypeLongLong:
+		var v uint64
+		var err error
+		if def.flags&uint16(protocol.ColumnDefUnsigned) != 0 {
+			v, err = strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+		} else {
+			var i int64
+			i, err = strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+			v = uint64(i)
+		}
+		if err != nil {
+			return newErrInvalidValue("integer", s)
+		}
+		switch colType {
+		case query.MySQLTypeTiny:
+			return w.WriteInt1(uint8(v))
+		case query.MySQLTypeShort, query.MySQLTypeYear:
+			return w.WriteInt2(uint16(v))
+		case query.MySQLTypeLong, query.MySQLTypeInt24:
+			return w.WriteInt4(uint32(v))
+		}
+		return w.WriteInt8(v)
+	case query.MySQLTypeFloat, query.MySQLTypeDouble:
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return newErrInvalidValue("double", s)
+		}
+		if colType == query.MySQLTypeFloat {
+			return w.WriteInt4(math.Float32bits(float32(f)))
+		}
+		return w.WriteInt8(math.Float64bits(f))
+	case query.MySQLTypeDate, query.MySQLTypeDatetime, query.MySQLTypeTimestamp:
+		t, err := timeValueOf(dialect.TimestampTZType, s)
+		if err != nil {
+			return err
+		}
+		return writeMySQLBinaryDatetime(w, t, colType == query.MySQLTypeDate)
+	case query.MySQLTypeTime:
+		d, err := mysqlDurationOf(s)
+		if err != nil {
+			return err
+		}
+		return writeMySQLBinaryTime(w, d)
+	}
+	return w.WriteLengthEncodedString(s)
+}
+
+// writeMySQLBinaryDatetime writes the binary DATE or DATETIME value which has the length, the date, the time and the microseconds.
+func writeMySQLBinaryDatetime(w *protocol.PacketWriter, t time.Time, isDate bool) error {
+	us := t.Nanosecond() / 1000
+	length := 11
+	switch {
+	case isDate:
+		length = 4
+	case us == 0:
+		length = 7
+	}
+	fields := []uint8{uint8(t.Month()), uint8(t.Day()), uint8(t.Hour()), uint8(t.Minute()), uint8(t.Second())}
+	if err := w.WriteInt1(uint8(length)); err != nil {
+		return err
+	}
+	if err := w.WriteInt2(uint16(t.Year())); err != nil {
+		return err
+	}
+	// The fields except the year have one byte.
+	for _, field := range fields[:min(length-2, len(fields))] {
+		if err := w.WriteInt1(field); err != nil {
+			return err
+		}
+	}
+	if length < 11 {
+		return nil
+	}
+	return w.WriteInt4(uint32(us))
+}
+
+// writeMySQLBinaryTime writes the binary TIME value which has the length, the sign, the days, the time and the microseconds.
+func writeMySQLBinaryTime(w *protocol.PacketWriter, d time.Duration) error {
+	isNegative := uint8(0)
+	if d < 0 {
+		isNegative = 1
+		d = -d
+	}
+	us := uint32(d.Microseconds() % 1000000)
+	length := uint8(12)
+	if us == 0 {
+		length = 8
+	}
+	if err := w.WriteInt1(length); err != nil {
+		return err
+	}
+	if err := w.WriteInt1(isNegative); err != nil {
+		return err
+	}
+	if err := w.WriteInt4(uint32(d / (24 * time.Hour))); err != nil {
+		return err
+	}
+	fields := []uint8{uint8(int64(d.Hours()) % 24), uint8(int64(d.Minutes()) % 60), uint8(int64(d.Seconds()) % 60)}
+	for _, field := range fields {
+		if err := w.WriteInt1(field); err != nil {
+			return err
+		}
+	}
+	if length < 12 {
+		return nil
+	}
+	return w.WriteInt4(us)
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// MySQL :: MySQL 8.0 Reference Manual :: 15.7.7.42 SHOW WARNINGS Statement
+// https://dev.mysql.com/doc/refman/8.0/en/show-warnings.html
+
+import (
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+	query "github.com/cybergarage/go-sqlparser/sql/query"
+	sql "github.com/cybergarage/go-sqlparser/sql/query/response/resultset"
+)
+
+// mysqlWarningCountColumn is the column name of SHOW COUNT(*) WARNINGS.
+const mysqlWarningCountColumn = "@@session.warning_count"
+
+// writeWarnings writes the result set of SHOW WARNINGS, or SHOW COUNT(*) WARNINGS if count is true,
+// of the last statement of the specified connection. The warnings are kept for the following SHOW WARNINGS as MySQL does.
+func (handler *mysqlCommandHandler) writeWarnings(w *mysqlResponseWriter, conn protocol.Conn, count bool) (bool, error) {
+	warnings := handler.server.lastWarnings(conn)
+	if count {
+		return w.writeResultSet(newMySQLWarningCountResultSetFrom(warnings))
+	}
+	return w.writeResultSet(newMySQLWarningsResultSetFrom(warnings))
+}
+
+// newMySQLWarningsResultSetFrom returns the result set of SHOW WARNINGS which has the level, code and message columns.
+func newMySQLWarningsResultSetFrom(warnings []*Warning) sql.ResultSet {
+	columns := []sql.Column{
+		sql.NewColumn(sql.WithColumnName("Level"), sql.WithColumnType(query.TextType)),
+		sql.NewColumn(sql.WithColumnName("Code"), sql.WithColumnType(query.IntegerType)),
+		sql.NewColumn(sql.WithColumnName("Message"), sql.WithColumnType(query.TextType)),
+	}
+	rows := []sql.Row{}
+	for _, warning := range warnings {
+		rows = append(rows, sql.NewRow(sql.WithRowValues([]any{warning.Level, int(warning.Code), warning.Message})))
+	}
+	return sql.NewResultSet(
+		sql.WithResultSetSchema(sql.NewSchema(sql.WithSchemaColumns(columns))),
+		sql.WithResultSetRows(rows),
+	)
+}
+
+// newMySQLWarningCountResultSetFrom returns the result set of SHOW COUNT(*) WARNINGS.
+func newMySQLWarningCountResultSetFrom(warnings []*Warning) sql.ResultSet {
+	columns := []sql.Column{
+		sql.NewColumn(sql.WithColumnName(mysqlWarningCountColumn), sql.WithColumnType(query.IntegerType)),
+	}
+	rows := []sql.Row{
+		sql.NewRow(sql.WithRowValues([]any{len(warnings)})),
+	}
+	return sql.NewResultSet(
+		sql.WithResultSetSchema(sql.NewSchema(sql.WithSchemaColumns(columns))),
+		sql.WithResultSetRows(rows),
+	)
+}
+
+// mysqlWarningCountOf returns the number of the warnings of the specified result set for the OK and EOF packets.
+func mysqlWarningCountOf(rs sql.ResultSet) uint16 {
+	n := len(warningsOf(rs))
+	if 0xFFFF < n {
+		return 0xFFFF
+	}
+	return uint16(n) // nolint: gosec
+}
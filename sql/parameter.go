@@ -0,0 +1,96 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// SQLite: Binding Values To Prepared Statements
+// https://www.sqlite.org/c3ref/bind_blob.html
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/cybergarage/go-sqlserver/sql/dialect"
+	"github.com/ncruces/go-sqlite3/driver"
+)
+
+// ParameterTypes returns the type names of the bind parameters of the specified SQLite statement. The types are the declared or
+// logical types of the columns which the parameters are assigned to or compared with, and they are empty if the columns are unknown.
+func (db *Database) ParameterTypes(query string) ([]string, error) {
+	count, err := db.parameterCount(query)
+	if err != nil {
+		return nil, err
+	}
+	types := make([]string, count)
+	type columns struct {
+		names     []string
+		declTypes []string
+	}
+	tables := map[string]*columns{}
+	for _, param := range dialect.Parameters(query) {
+		if param.Number < 1 || count < param.Number || types[param.Number-1] != "" {
+			continue
+		}
+		tblName := strings.ToLower(param.Table)
+		table, ok := tables[tblName]
+		if !ok {
+			names, declTypes, err := db.tableColumns(param.Table)
+			if err != nil {
+				return nil, err
+			}
+			table = &columns{names: names, declTypes: declTypes}
+			tables[tblName] = table
+		}
+		n := param.Index
+		if param.Column != "" {
+			n = slices.IndexFunc(table.names, func(name string) bool {
+				return strings.EqualFold(name, param.Column)
+			})
+		}
+		if n < 0 || len(table.names) <= n {
+			continue
+		}
+		// The type modifiers are removed as the type names of the result set columns.
+		typeName := table.declTypes[n]
+		if i := strings.LastIndexByte(typeName, '('); 0 <= i && strings.HasSuffix(typeName, ")") {
+			typeName = strings.TrimSpace(typeName[:i])
+		}
+		db.mutex.Lock()
+		if logicalType := db.catalog[tblName][strings.ToLower(table.names[n])]; logicalType != "" {
+			typeName = logicalType
+		}
+		db.mutex.Unlock()
+		types[param.Number-1] = typeName
+	}
+	return types, nil
+}
+
+// parameterCount returns the largest parameter number of the specified SQLite statement.
+func (db *Database) parameterCount(query string) (int, error) {
+	count := 0
+	err := db.conn.Raw(func(driverConn any) error {
+		conn, ok := driverConn.(driver.Conn)
+		if !ok {
+			return nil
+		}
+		stmt, _, err := conn.Raw().Prepare(query)
+		if err != nil || stmt == nil {
+			return err
+		}
+		defer stmt.Close()
+		count = stmt.BindCount()
+		return nil
+	})
+	return count, err
+}
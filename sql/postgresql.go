@@ -28,32 +28,58 @@ import (
 	"github.com/cybergarage/go-postgresql/postgresql/query"
 )
 
-// Copy handles a COPY query.
-func (server *server) Copy(conn postgresql.Conn, q query.Copy) (protocol.Responses, error) {
-	/*
-		_, tbl, err := server.LookupDatabaseTable(conn, conn.Database(), q.TableName())
-		if err != nil {
-			return nil, err
-		}
+// postgresqlQueryExecutor represents a PostgreSQL query executor which returns the affected rows of INSERT
+// in the command complete tags, and delegates the other queries to the specified executor.
+type postgresqlQueryExecutor struct {
+	postgresql.QueryExecutor
+	server *server
+}
 
-		return postgresql.NewCopyInResponsesFrom(q, tbl.Schema)
-	*/
-	return nil, nil
+// setupPostgreSQLQueryExecutor replaces the query executor of the PostgreSQL server.
+func (server *server) setupPostgreSQLQueryExecutor() {
+	pgServer := server.PostgreSQLServer()
+	pgServer.SetQueryExecutor(&postgresqlQueryExecutor{
+		QueryExecutor: pgServer.QueryExecutor(),
+		server:        server,
+	})
 }
 
-// Copy handles a COPY DATA protocol.
-func (server *server) CopyData(conn postgresql.Conn, q query.Copy, stream *postgresql.CopyStream) (protocol.Responses, error) {
-	/*
-	   _, tbl, err := server.LookupDatabaseTable(conn, conn.Database(), q.TableName())
+// Insert handles a INSERT query.
+func (executor *postgresqlQueryExecutor) Insert(conn postgresql.Conn, stmt query.Insert) (protocol.Responses, error) {
+	rs, err := executor.server.insert(conn, stmt)
+	if err != nil {
+		return nil, err
+	}
+	notices, err := executor.server.postgresqlNoticesOf(conn, rs)
+	if err != nil {
+		return nil, err
+	}
+	res, err := protocol.NewInsertCompleteResponsesWith(int(rs.RowsAffected()))
+	if err != nil {
+		return nil, err
+	}
+	return append(notices, res...), nil
+}
 
-	   	if err != nil {
-	   		log.Error(err)
-	   		return nil, err
-	   	}
+// Copy handles a COPY query. The COPY FROM STDIN is kept in the session, and CopyData reads the rows of the COPY.
+func (server *server) Copy(conn postgresql.Conn, q query.Copy) (protocol.Responses, error) {
+	copyIn, err := server.newPostgreSQLCopyInFrom(conn, q.String())
+	if err != nil {
+		return newPostgreSQLErrorResponsesFrom(err)
+	}
+	server.sessionOf(conn).copyIn = copyIn
+	return copyIn.CopyInResponses()
+}
 
-	   return postgresql.NewCopyCompleteResponsesFrom(q, stream, conn, tbl.Schema, server.PostgreSQLServer().QueryExecutor())
-	*/
-	return nil, nil
+// CopyData handles a COPY DATA protocol of the COPY FROM STDIN which is started by Copy.
+func (server *server) CopyData(conn postgresql.Conn, q query.Copy, stream *postgresql.CopyStream) (protocol.Responses, error) {
+	session := server.sessionOf(conn)
+	copyIn := session.copyIn
+	session.copyIn = nil
+	if copyIn == nil {
+		return newPostgreSQLErrorResponsesFrom(newErrInvalid("COPY DATA without COPY " + q.String()))
+	}
+	return copyIn.CopyFrom(stream.MessageReader)
 }
 
 // ParserError handles a parser error.
@@ -0,0 +1,523 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// PostgreSQL: Documentation: 16: 55.2.3. Extended Query (Formats and Format Codes)
+// https://www.postgresql.org/docs/16/protocol-overview.html#PROTOCOL-FORMAT-CODES
+// PostgreSQL: Documentation: 16: 8.1.2. Arbitrary Precision Numbers
+// https://www.postgresql.org/docs/16/datatype-numeric.html#DATATYPE-NUMERIC-DECIMAL
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cybergarage/go-postgresql/postgresql/protocol"
+	"github.com/cybergarage/go-postgresql/postgresql/system"
+	"github.com/cybergarage/go-sqlserver/sql/dialect"
+	"github.com/cybergarage/go-sqlserver/sql/function"
+)
+
+// postgresqlEpoch is the epoch of the binary date and time values.
+var postgresqlEpoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// The sign words of the binary numeric values.
+const (
+	postgresqlNumericPositive = 0x0000
+	postgresqlNumericNegative = 0x4000
+	postgresqlNumericNaN      = 0xC000
+)
+
+// postgresqlJSONBVersion is the version byte of the binary JSONB values.
+const postgresqlJSONBVersion = 1
+
+// postgresqlArrayElementObjectIDs maps the PostgreSQL array type OIDs into the element type OIDs.
+var postgresqlArrayElementObjectIDs = map[system.ObjectID]system.ObjectID{
+	1000: system.Bool,
+	1001: system.Bytea,
+	1005: system.Int2,
+	1007: system.Int4,
+	1009: system.Text,
+	1014: system.Bpchar,
+	1015: system.Varchar,
+	1016: system.Int8,
+	1021: system.Float4,
+	1022: system.Float8,
+	1115: system.Timestamp,
+	1182: system.Date,
+	1183: system.Time,
+	1185: system.Timestamptz,
+	1231: system.Numeric,
+	2951: system.UUID,
+	199:  system.JSON,
+	3807: system.JSONb,
+}
+
+// newPostgreSQLBinaryValueFrom returns the binary format bytes of the specified non-NULL value of the type OID.
+// The values of the text types and the enum types are sent as the text bytes because the binary formats are the same.
+func newPostgreSQLBinaryValueFrom(oid system.ObjectID, v any) ([]byte, error) {
+	switch oid {
+	case system.Bool:
+		b, err := boolValueOf(v)
+		if err != nil {
+			return nil, err
+		}
+		if b {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case system.Int2:
+		i, err := postgresqlIntValueOf(v, math.MinInt16, math.MaxInt16)
+		if err != nil {
+			return nil, err
+		}
+		return binary.BigEndian.AppendUint16(nil, uint16(i)), nil
+	case system.Int4:
+		i, err := postgresqlIntValueOf(v, math.MinInt32, math.MaxInt32)
+		if err != nil {
+			return nil, err
+		}
+		return binary.BigEndian.AppendUint32(nil, uint32(i)), nil
+	case system.Int8:
+		i, err := postgresqlIntValueOf(v, math.MinInt64, math.MaxInt64)
+		if err != nil {
+			return nil, err
+		}
+		return binary.BigEndian.AppendUint64(nil, uint64(i)), nil
+	case system.Float4:
+		f, err := postgresqlFloatValueOf(v)
+		if err != nil {
+			return nil, err
+		}
+		return binary.BigEndian.AppendUint32(nil, math.Float32bits(float32(f))), nil
+	case system.Float8:
+		f, err := postgresqlFloatValueOf(v)
+		if err != nil {
+			return nil, err
+		}
+		return binary.BigEndian.AppendUint64(nil, math.Float64bits(f)), nil
+	case system.Numeric:
+		return newPostgreSQLNumericBytesFrom(postgresqlTextValueOf(v))
+	case system.Timestamp, system.Timestamptz:
+		t, err := timeValueOf(dialect.TimestampTZType, v)
+		if err != nil {
+			return nil, err
+		}
+		if oid == system.Timestamp {
+			// The timestamps without time zones are sent as the wall clock times.
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+		}
+		return binary.BigEndian.AppendUint64(nil, uint64(t.Sub(postgresqlEpoch).Microseconds())), nil
+	case system.Date:
+		t, err := timeValueOf(dialect.DateType, v)
+		if err != nil {
+			return nil, err
+		}
+		date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		days := int32(math.Floor(date.Sub(postgresqlEpoch).Hours() / 24))
+		return binary.BigEndian.AppendUint32(nil, uint32(days)), nil
+	case system.Time:
+		t, err := timeValueOf(dialect.TimeType, v)
+		if err != nil {
+			return nil, err
+		}
+		midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+		return binary.BigEndian.AppendUint64(nil, uint64(t.Sub(midnight).Microseconds())), nil
+	case system.UUID:
+		if b, ok := v.([]byte); ok && len(b) == 16 {
+			return b, nil
+		}
+		b, err := hex.DecodeString(strings.ReplaceAll(uuidStringOf(postgresqlTextValueOf(v)), "-", ""))
+		if err != nil || len(b) != 16 {
+			return nil, newErrInvalidValue(dialect.UUIDType, v)
+		}
+		return b, nil
+	case system.Bytea:
+		return postgresqlByteaValueOf(v)
+	case system.JSONb:
+		return append([]byte{postgresqlJSONBVersion}, postgresqlTextValueOf(v)...), nil
+	}
+	if elemOID, ok := postgresqlArrayElementObjectIDs[oid]; ok {
+		return newPostgreSQLArrayBytesFrom(elemOID, postgresqlTextValueOf(v))
+	}
+	return []byte(postgresqlTextValueOf(v)), nil
+}
+
+// postgresqlTextValueOf returns the text of the specified value.
+func postgresqlTextValueOf(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// postgresqlIntValueOf returns the integer of the specified value, and returns an error if the integer is out of the range.
+func postgresqlIntValueOf(v any, minValue int64, maxValue int64) (int64, error) {
+	var i int64
+	switch v := v.(type) {
+	case int64:
+		i = v
+	case bool:
+		if v {
+			i = 1
+		}
+	default:
+		var err error
+		i, err = strconv.ParseInt(strings.TrimSpace(postgresqlTextValueOf(v)), 10, 64)
+		if err != nil {
+			return 0, newErrInvalidValue("integer", v)
+		}
+	}
+	if i < minValue || maxValue < i {
+		return 0, newErrInvalidValue("integer", v)
+	}
+	return i, nil
+}
+
+// postgresqlFloatValueOf returns the floating point number of the specified value.
+func postgresqlFloatValueOf(v any) (float64, error) {
+	if f, ok := v.(float64); ok {
+		return f, nil
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(postgresqlTextValueOf(v)), 64)
+	if err != nil {
+		return 0, newErrInvalidValue("double precision", v)
+	}
+	return f, nil
+}
+
+// postgresqlByteaValueOf returns the bytes of the specified bytea value, and the hex format texts such as \x0102 are decoded.
+func postgresqlByteaValueOf(v any) ([]byte, error) {
+	switch v := v.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		if digits, ok := strings.CutPrefix(v, `\x`); ok {
+			b, err := hex.DecodeString(digits)
+			if err != nil {
+				return nil, newErrInvalidValue("bytea", v)
+			}
+			return b, nil
+		}
+		return []byte(v), nil
+	}
+	return []byte(postgresqlTextValueOf(v)), nil
+}
+
+// postgresqlByteaTextOf returns the hex format text of the specified bytea value such as \x0102.
+func postgresqlByteaTextOf(b []byte) string {
+	return `\x` + hex.EncodeToString(b)
+}
+
+// newPostgreSQLNumericBytesFrom returns the binary numeric bytes of the specified decimal text such as -123.45.
+// The binary numeric values have the base 10000 digits with the weight of the first digit, the sign and the display scale.
+func newPostgreSQLNumericBytesFrom(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "NaN") {
+		// NaN has no digits, and the weight and the display scale are zero.
+		return append(binary.BigEndian.AppendUint16(make([]byte, 4), postgresqlNumericNaN), 0, 0), nil
+	}
+	if strings.ContainsAny(s, "eE") {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, newErrInvalidValue("numeric", s)
+		}
+		s = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	sign := uint16(postgresqlNumericPositive)
+	switch {
+	case strings.HasPrefix(s, "-"):
+		sign = postgresqlNumericNegative
+		s = s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" || strings.Trim(intPart+fracPart, "0123456789") != "" {
+		return nil, newErrInvalidValue("numeric", s)
+	}
+	scale := len(fracPart)
+	// The integer part is padded to the left and the fraction part to the right into the groups of the four digits.
+	if pad := len(intPart) % 4; 0 < pad {
+		intPart = strings.Repeat("0", 4-pad) + intPart
+	}
+	if pad := len(fracPart) % 4; 0 < pad {
+		fracPart += strings.Repeat("0", 4-pad)
+	}
+	groups := intPart + fracPart
+	digits := make([]int16, 0, len(groups)/4)
+	for n := 0; n < len(groups); n += 4 {
+		digit, _ := strconv.Atoi(groups[n : n+4])
+		digits = append(digits, int16(digit))
+	}
+	weight := len(intPart)/4 - 1
+	for 0 < len(digits) && digits[0] == 0 {
+		digits = digits[1:]
+		weight--
+	}
+	for 0 < len(digits) && digits[len(digits)-1] == 0 {
+		digits = digits[:len(digits)-1]
+	}
+	if len(digits) == 0 {
+		sign = postgresqlNumericPositive
+		weight = 0
+	}
+	b := binary.BigEndian.AppendUint16(nil, uint16(len(digits)))
+	b = binary.BigEndian.AppendUint16(b, uint16(int16(weight)))
+	b = binary.BigEndian.AppendUint16(b, sign)
+	b = binary.BigEndian.AppendUint16(b, uint16(scale))
+	for _, digit := range digits {
+		b = binary.BigEndian.AppendUint16(b, uint16(digit))
+	}
+	return b, nil
+}
+
+// newPostgreSQLArrayBytesFrom returns the binary array bytes of the specified one-dimensional array literal such as {1,2,NULL}.
+// The binary arrays have the dimensions, the NULL flag, the element type OID and the elements with the lengths.
+func newPostgreSQLArrayBytesFrom(elemOID system.ObjectID, s string) ([]byte, error) {
+	elems, err := function.ParseArrayLiteral(s)
+	if err != nil {
+		return nil, err
+	}
+	hasNull := uint32(0)
+	for _, elem := range elems {
+		if elem == nil {
+			hasNull = 1
+		}
+	}
+	dims := uint32(1)
+	if len(elems) == 0 {
+		dims = 0
+	}
+	b := binary.BigEndian.AppendUint32(nil, dims)
+	b = binary.BigEndian.AppendUint32(b, hasNull)
+	b = binary.BigEndian.AppendUint32(b, uint32(elemOID))
+	if 0 < dims {
+		b = binary.BigEndian.AppendUint32(b, uint32(len(elems)))
+		// The lower bound of the dimension is one.
+		b = binary.BigEndian.AppendUint32(b, 1)
+	}
+	for _, elem := range elems {
+		if elem == nil {
+			b = binary.BigEndian.AppendUint32(b, math.MaxUint32)
+			continue
+		}
+		v, err := newPostgreSQLBinaryValueFrom(elemOID, *elem)
+		if err != nil {
+			return nil, err
+		}
+		b = binary.BigEndian.AppendUint32(b, uint32(len(v)))
+		b = append(b, v...)
+	}
+	return b, nil
+}
+
+// newPostgreSQLParameterValueFrom returns the bind argument of the specified parameter bytes of the format code and the type OID.
+// NULL parameters are nil, the integer, floating point, boolean and bytea parameters are converted into the Go values,
+// and the other parameters are bound as the texts.
+func newPostgreSQLParameterValueFrom(oid system.ObjectID, format int16, b []byte) (any, error) {
+	if b == nil {
+		return nil, nil
+	}
+	if format == protocol.BinaryFormat {
+		return newPostgreSQLValueFromBinary(oid, b)
+	}
+	s := string(b)
+	switch oid {
+	case system.Int2, system.Int4, system.Int8:
+		return postgresqlIntValueOf(s, math.MinInt64, math.MaxInt64)
+	case system.Float4, system.Float8:
+		return postgresqlFloatValueOf(s)
+	case system.Bool:
+		return boolValueOf(s)
+	case system.Bytea:
+		return postgresqlByteaValueOf(s)
+	}
+	return s, nil
+}
+
+// newPostgreSQLValueFromBinary returns the bind argument of the specified binary format bytes of the type OID.
+// The date and time values are returned as the texts of the logical types.
+func newPostgreSQLValueFromBinary(oid system.ObjectID, b []byte) (any, error) {
+	hasLength := func(n int) bool {
+		return len(b) == n
+	}
+	switch oid {
+	case system.Bool:
+		if hasLength(1) {
+			return b[0] != 0, nil
+		}
+	case system.Int2:
+		if hasLength(2) {
+			return int64(int16(binary.BigEndian.Uint16(b))), nil
+		}
+	case system.Int4:
+		if hasLength(4) {
+			return int64(int32(binary.BigEndian.Uint32(b))), nil
+		}
+	case system.Int8:
+		if hasLength(8) {
+			return int64(binary.BigEndian.Uint64(b)), nil
+		}
+	case system.Float4:
+		if hasLength(4) {
+			return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), nil
+		}
+	case system.Float8:
+		if hasLength(8) {
+			return math.Float64frombits(binary.BigEndian.Uint64(b)), nil
+		}
+	case system.Numeric:
+		return postgresqlNumericTextOf(b)
+	case system.Timestamp, system.Timestamptz:
+		if hasLength(8) {
+			t := postgresqlEpoch.Add(time.Duration(int64(binary.BigEndian.Uint64(b))) * time.Microsecond)
+			if oid == system.Timestamp {
+				return t.Format(timestampLayout), nil
+			}
+			return t.Format(timestampTZLayout), nil
+		}
+	case system.Date:
+		if hasLength(4) {
+			return postgresqlEpoch.AddDate(0, 0, int(int32(binary.BigEndian.Uint32(b)))).Format(dateLayout), nil
+		}
+	case system.Time:
+		if hasLength(8) {
+			return postgresqlEpoch.Add(time.Duration(int64(binary.BigEndian.Uint64(b))) * time.Microsecond).Format(timeLayout), nil
+		}
+	case system.UUID:
+		if hasLength(16) {
+			return uuidStringOf(hex.EncodeToString(b)), nil
+		}
+	case system.Bytea:
+		return b, nil
+	case system.JSONb:
+		if 0 < len(b) && b[0] == postgresqlJSONBVersion {
+			return string(b[1:]), nil
+		}
+	default:
+		if elemOID, ok := postgresqlArrayElementObjectIDs[oid]; ok {
+			return postgresqlArrayTextOf(elemOID, b)
+		}
+		return string(b), nil
+	}
+	return nil, newErrInvalidValue("binary", b)
+}
+
+// postgresqlNumericTextOf returns the decimal text of the specified binary numeric bytes.
+func postgresqlNumericTextOf(b []byte) (string, error) {
+	if len(b) < 8 {
+		return "", newErrInvalidValue("numeric", b)
+	}
+	nDigits := int(binary.BigEndian.Uint16(b))
+	weight := int(int16(binary.BigEndian.Uint16(b[2:])))
+	sign := binary.BigEndian.Uint16(b[4:])
+	scale := int(binary.BigEndian.Uint16(b[6:]))
+	if sign == postgresqlNumericNaN {
+		return "NaN", nil
+	}
+	if len(b) != 8+nDigits*2 {
+		return "", newErrInvalidValue("numeric", b)
+	}
+	digitAt := func(n int) int {
+		if n < 0 || nDigits <= n {
+			return 0
+		}
+		return int(binary.BigEndian.Uint16(b[8+n*2:]))
+	}
+	var s strings.Builder
+	if sign == postgresqlNumericNegative {
+		s.WriteString("-")
+	}
+	if weight < 0 {
+		s.WriteString("0")
+	}
+	// The digit at the index k has the weight (weight - k) of the base 10000.
+	for n := 0; n <= weight; n++ {
+		if n == 0 {
+			s.WriteString(strconv.Itoa(digitAt(n)))
+			continue
+		}
+		fmt.Fprintf(&s, "%04d", digitAt(n))
+	}
+	if 0 < scale {
+		var frac strings.Builder
+		for n := weight + 1; frac.Len() < scale; n++ {
+			fmt.Fprintf(&frac, "%04d", digitAt(n))
+		}
+		s.WriteString("." + frac.String()[:scale])
+	}
+	return s.String(), nil
+}
+
+// postgresqlArrayTextOf returns the array literal such as {1,2,NULL} of the specified binary one-dimensional array bytes.
+func postgresqlArrayTextOf(elemOID system.ObjectID, b []byte) (string, error) {
+	readUint32 := func() (uint32, bool) {
+		if len(b) < 4 {
+			return 0, false
+		}
+		v := binary.BigEndian.Uint32(b)
+		b = b[4:]
+		return v, true
+	}
+	dims, ok := readUint32()
+	// The NULL flag and the element type OID follow the dimensions.
+	if !ok || 1 < dims || len(b) < 8 {
+		return "", newErrInvalidValue("array", b)
+	}
+	b = b[8:]
+	nElems := uint32(0)
+	if dims == 1 {
+		// The lower bound follows the number of the elements.
+		if nElems, ok = readUint32(); !ok || len(b) < 4 {
+			return "", newErrInvalidValue("array", b)
+		}
+		b = b[4:]
+	}
+	elems := make([]*string, 0, nElems)
+	for n := uint32(0); n < nElems; n++ {
+		length, ok := readUint32()
+		if !ok {
+			return "", newErrInvalidValue("array", b)
+		}
+		if length == math.MaxUint32 {
+			elems = append(elems, nil)
+			continue
+		}
+		if uint32(len(b)) < length {
+			return "", newErrInvalidValue("array", b)
+		}
+		v, err := newPostgreSQLValueFromBinary(elemOID, b[:length])
+		if err != nil {
+			return "", err
+		}
+		b = b[length:]
+		elem := postgresqlTextValueOf(v)
+		if bytea, ok := v.([]byte); ok {
+			elem = postgresqlByteaTextOf(bytea)
+		}
+		elems = append(elems, &elem)
+	}
+	return function.FormatArrayLiteral(elems), nil
+}
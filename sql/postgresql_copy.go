@@ -0,0 +1,698 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// PostgreSQL: Documentation: 16: COPY
+// https://www.postgresql.org/docs/16/sql-copy.html
+// PostgreSQL: Documentation: 16: 55.2.6. COPY Operations
+// https://www.postgresql.org/docs/16/protocol-flow.html#PROTOCOL-COPY
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cybergarage/go-logger/log"
+	"github.com/cybergarage/go-postgresql/postgresql/protocol"
+	"github.com/cybergarage/go-postgresql/postgresql/system"
+	"github.com/cybergarage/go-sqlserver/sql/dialect"
+)
+
+// postgresqlCopySavepoint is the savepoint which discards the rows of a failed COPY in the transaction blocks.
+var postgresqlCopySavepoint = dialect.QuoteIdentifier(dialect.HiddenColumnPrefix + "copy")
+
+// The SQLSTATEs of the COPY errors.
+const (
+	postgresqlBadCopyFileFormat           = "22P04"
+	postgresqlInvalidTextRepresentation   = "22P02"
+	postgresqlInvalidBinaryRepresentation = "22P03"
+	postgresqlQueryCanceled               = "57014"
+)
+
+// postgresqlCopyError represents an error of COPY, and the table and the line are reported as the context of the error.
+type postgresqlCopyError struct {
+	Table  string
+	Line   int
+	Column string
+	Code   string
+	Err    error
+}
+
+// Error returns the error message.
+func (err *postgresqlCopyError) Error() string {
+	return err.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (err *postgresqlCopyError) Unwrap() error {
+	return err.Err
+}
+
+// Where returns the context of the error such as COPY t, line 2, column c.
+func (err *postgresqlCopyError) Where() string {
+	where := "COPY " + err.Table
+	if 0 < err.Line {
+		where += fmt.Sprintf(", line %d", err.Line)
+	}
+	if err.Column != "" {
+		where += ", column " + err.Column
+	}
+	return where
+}
+
+// newPostgreSQLCopyErrorResponseFrom returns the error response of the specified COPY error.
+// The errors rejected by the strict tables and the enum types are reported with their own SQLSTATEs.
+func newPostgreSQLCopyErrorResponseFrom(copyErr *postgresqlCopyError) (*protocol.ErrorResponse, error) {
+	var res *protocol.ErrorResponse
+	var err error
+	if copyErr.Code == "" {
+		res, err = newPostgreSQLErrorResponseFrom(copyErr.Err)
+	} else {
+		res = protocol.NewErrorResponse()
+		fields := []struct {
+			t protocol.ErrorType
+			v string
+		}{
+			{protocol.SeverityError, "ERROR"},
+			{protocol.CodeError, copyErr.Code},
+			{protocol.MessageError, copyErr.Err.Error()},
+		}
+		for _, field := range fields {
+			if err = res.AppendField(field.t, field.v); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, res.AppendField(protocol.WhereError, copyErr.Where())
+}
+
+// copy handles the specified COPY FROM STDIN or COPY TO STDOUT statement. The CopyInResponse is sent before the CopyData messages are read,
+// and the errors before the copy-in mode are reported without the CopyInResponse.
+func (handler *postgresqlMessageHandler) copy(conn protocol.Conn, stmt string) (protocol.Responses, error) {
+	cp, err := dialect.CopyStatement(stmt)
+	if err != nil {
+		return newPostgreSQLErrorResponsesFrom(err)
+	}
+	if cp.Direction == dialect.CopyTo {
+		return handler.copyTo(conn, cp)
+	}
+	copyIn, err := handler.server.newPostgreSQLCopyIn(conn, cp)
+	if err != nil {
+		return newPostgreSQLErrorResponsesFrom(err)
+	}
+	res, err := copyIn.CopyInResponses()
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.ResponseMessages(res); err != nil {
+		return nil, err
+	}
+	return copyIn.CopyFrom(conn.MessageReader())
+}
+
+// postgresqlCopyIn represents a COPY FROM STDIN which inserts the rows of the CopyData messages into a table.
+type postgresqlCopyIn struct {
+	server  *server
+	conn    protocol.Conn
+	db      *Database
+	cp      *dialect.Copy
+	columns []string
+	oids    []system.ObjectID
+	stmt    string
+	buf     []byte
+	header  bool
+	line    int
+	nRows   int
+	inTx    bool
+	done    bool
+	err     error
+}
+
+// copyColumnsOf returns the column names of the specified COPY, and the hidden columns are not copied
+// if the column names are not specified.
+func (db *Database) copyColumnsOf(cp *dialect.Copy) ([]string, error) {
+	names, _, err := db.tableColumns(cp.Table)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, newErrTableNotExist(cp.Table)
+	}
+	columns := []string{}
+	if len(cp.Columns) == 0 {
+		for _, name := range names {
+			if !dialect.IsHiddenColumn(name) {
+				columns = append(columns, name)
+			}
+		}
+	}
+	for _, column := range cp.Columns {
+		idx := -1
+		for n, name := range names {
+			if strings.EqualFold(name, column) {
+				idx = n
+				break
+			}
+		}
+		if idx < 0 {
+			return nil, newErrCoulumNotExist(column)
+		}
+		columns = append(columns, names[idx])
+	}
+	return columns, nil
+}
+
+// quotedCopyColumnsOf returns the quoted identifiers of the specified column names.
+func quotedCopyColumnsOf(columns []string) []string {
+	quotedColumns := make([]string, len(columns))
+	for n, column := range columns {
+		quotedColumns[n] = dialect.QuoteIdentifier(column)
+	}
+	return quotedColumns
+}
+
+// newPostgreSQLCopyInFrom returns a new COPY FROM STDIN of the specified statement for the connection.
+func (server *server) newPostgreSQLCopyInFrom(conn protocol.Conn, stmt string) (*postgresqlCopyIn, error) {
+	cp, err := dialect.CopyStatement(stmt)
+	if err != nil {
+		return nil, err
+	}
+	return server.newPostgreSQLCopyIn(conn, cp)
+}
+
+// newPostgreSQLCopyIn returns a new COPY FROM STDIN of the specified COPY for the connection.
+func (server *server) newPostgreSQLCopyIn(conn protocol.Conn, cp *dialect.Copy) (*postgresqlCopyIn, error) {
+	if cp.Direction != dialect.CopyFrom {
+		return nil, newErrNotSupported("COPY " + cp.Direction)
+	}
+	db, err := server.databaseOf(conn)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := db.copyColumnsOf(cp)
+	if err != nil {
+		return nil, err
+	}
+	params := make([]string, len(columns))
+	for n := range columns {
+		params[n] = fmt.Sprintf("?%d", n+1)
+	}
+	insertStmt := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		dialect.QuoteIdentifier(cp.Table),
+		strings.Join(quotedCopyColumnsOf(columns), ", "),
+		strings.Join(params, ", "))
+	typeNames, err := db.ParameterTypes(insertStmt)
+	if err != nil {
+		return nil, err
+	}
+	oids := make([]system.ObjectID, len(typeNames))
+	for n, typeName := range typeNames {
+		oids[n] = postgresqlParameterObjectIDOf(db, typeName)
+	}
+	return &postgresqlCopyIn{
+		server:  server,
+		conn:    conn,
+		db:      db,
+		cp:      cp,
+		columns: columns,
+		oids:    oids,
+		stmt:    insertStmt,
+		buf:     []byte{},
+		header:  false,
+		line:    0,
+		nRows:   0,
+		inTx:    db.InTransaction(),
+		done:    false,
+		err:     nil,
+	}, nil
+}
+
+// CopyInResponses returns the CopyInResponse which starts the copy-in mode.
+func (copyIn *postgresqlCopyIn) CopyInResponses() (protocol.Responses, error) {
+	copyFormat, formatCode := protocol.TextCopy, int16(protocol.TextFormat)
+	if copyIn.cp.Format == dialect.CopyBinaryFormat {
+		copyFormat, formatCode = protocol.BinaryCopy, int16(protocol.BinaryFormat)
+	}
+	res := protocol.NewCopyInResponseWith(copyFormat)
+	for range copyIn.columns {
+		res.AppendFormatCode(formatCode)
+	}
+	return protocol.NewResponsesWith(res), nil
+}
+
+// CopyFrom inserts the rows of the CopyData messages of the specified reader until CopyDone or CopyFail, and returns the CommandComplete of COPY n.
+// The rows are inserted in a transaction outside of the transaction blocks, or after a savepoint in the transaction blocks,
+// so all rows of the COPY are discarded on errors.
+func (copyIn *postgresqlCopyIn) CopyFrom(reader *protocol.MessageReader) (protocol.Responses, error) {
+	if err := copyIn.begin(); err != nil {
+		return nil, err
+	}
+	failMsg, ok, err := readPostgreSQLCopyData(reader, copyIn.write)
+	if err != nil {
+		copyIn.rollback()
+		return nil, err
+	}
+	switch {
+	case !ok:
+		copyIn.err = &postgresqlCopyError{
+			Table:  copyIn.cp.Table,
+			Line:   0,
+			Column: "",
+			Code:   postgresqlQueryCanceled,
+			Err:    fmt.Errorf("COPY from stdin failed: %s", failMsg),
+		}
+	default:
+		copyIn.close()
+	}
+	if copyIn.err != nil {
+		copyIn.rollback()
+		return newPostgreSQLErrorResponsesFrom(copyIn.err)
+	}
+	if err := copyIn.commit(); err != nil {
+		copyIn.rollback()
+		return newPostgreSQLErrorResponsesFrom(err)
+	}
+	return protocol.NewCopyCompleteResponsesWith(copyIn.nRows)
+}
+
+// begin begins the transaction of the rows outside of the transaction blocks, or sets the savepoint in the transaction blocks.
+func (copyIn *postgresqlCopyIn) begin() error {
+	if copyIn.inTx {
+		_, err := copyIn.db.exec("SAVEPOINT " + postgresqlCopySavepoint)
+		return err
+	}
+	return copyIn.db.Begin()
+}
+
+// commit commits the rows outside of the transaction blocks, or releases the savepoint in the transaction blocks.
+func (copyIn *postgresqlCopyIn) commit() error {
+	if copyIn.inTx {
+		_, err := copyIn.db.exec("RELEASE " + postgresqlCopySavepoint)
+		return err
+	}
+	return copyIn.db.Commit()
+}
+
+// rollback discards all rows of the COPY.
+func (copyIn *postgresqlCopyIn) rollback() {
+	if !copyIn.inTx {
+		if err := copyIn.db.Rollback(); err != nil {
+			log.Error(err)
+		}
+		return
+	}
+	for _, q := range []string{"ROLLBACK TO " + postgresqlCopySavepoint, "RELEASE " + postgresqlCopySavepoint} {
+		if _, err := copyIn.db.exec(q); err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+// write appends the specified CopyData to the buffer, and inserts the complete records. The data after an error
+// or the end-of-data marker is discarded, and the binary format rejects the data after the trailer.
+func (copyIn *postgresqlCopyIn) write(data []byte) {
+	switch {
+	case copyIn.err != nil:
+		return
+	case copyIn.done:
+		if copyIn.cp.Format == dialect.CopyBinaryFormat && 0 < len(data) {
+			copyIn.err = copyIn.newError("", postgresqlBadCopyFileFormat, errPostgreSQLCopyAfterEOF)
+		}
+		return
+	}
+	copyIn.buf = append(copyIn.buf, data...)
+	var offset int
+	if copyIn.cp.Format == dialect.CopyBinaryFormat {
+		offset = copyIn.writeBinary()
+	} else {
+		offset = copyIn.writeText()
+	}
+	copyIn.buf = append([]byte{}, copyIn.buf[offset:]...)
+}
+
+// writeText inserts the complete records of the text or CSV format in the buffer, and returns the length of the read data.
+func (copyIn *postgresqlCopyIn) writeText() int {
+	offset := 0
+	for copyIn.err == nil && !copyIn.done {
+		end := copyIn.recordEnd(copyIn.buf[offset:])
+		if end < 0 {
+			break
+		}
+		copyIn.insertRecord(copyIn.buf[offset : offset+end])
+		offset += end + 1
+	}
+	return offset
+}
+
+// writeBinary inserts the complete tuples of the binary format in the buffer after the header, and returns the length of the read data.
+func (copyIn *postgresqlCopyIn) writeBinary() int {
+	offset := 0
+	if !copyIn.header {
+		n, err := readPostgreSQLCopyBinaryHeader(copyIn.buf)
+		if err != nil {
+			copyIn.err = copyIn.newError("", postgresqlBadCopyFileFormat, err)
+			return 0
+		}
+		if n == 0 {
+			return 0
+		}
+		copyIn.header = true
+		offset = n
+	}
+	for copyIn.err == nil && !copyIn.done {
+		fields, n, err := readPostgreSQLCopyBinaryTuple(copyIn.buf[offset:])
+		switch {
+		case err != nil:
+			copyIn.line++
+			copyIn.err = copyIn.newError("", postgresqlBadCopyFileFormat, err)
+		case n == 0:
+			return offset
+		case fields == nil:
+			copyIn.done = true
+			if offset+n < len(copyIn.buf) {
+				copyIn.err = copyIn.newError("", postgresqlBadCopyFileFormat, errPostgreSQLCopyAfterEOF)
+			}
+		default:
+			copyIn.line++
+			copyIn.insertFields(fields, protocol.BinaryFormat)
+		}
+		offset += n
+	}
+	return offset
+}
+
+// close reads the rest of the buffer at the end of the data. The last line of the text formats may have no newline,
+// and the binary format requires the header and the complete tuples.
+func (copyIn *postgresqlCopyIn) close() {
+	switch {
+	case copyIn.err != nil, copyIn.done:
+		return
+	case copyIn.cp.Format != dialect.CopyBinaryFormat:
+		if 0 < len(copyIn.buf) {
+			copyIn.insertRecord(copyIn.buf)
+		}
+	case !copyIn.header:
+		copyIn.err = copyIn.newError("", postgresqlBadCopyFileFormat, errPostgreSQLCopySignature)
+	case 0 < len(copyIn.buf):
+		copyIn.err = copyIn.newError("", postgresqlBadCopyFileFormat, errPostgreSQLCopyEOF)
+	}
+}
+
+// newError returns the COPY error of the current line.
+func (copyIn *postgresqlCopyIn) newError(column string, code string, err error) error {
+	return &postgresqlCopyError{
+		Table:  copyIn.cp.Table,
+		Line:   copyIn.line,
+		Column: column,
+		Code:   code,
+		Err:    err,
+	}
+}
+
+// recordEnd returns the index of the newline which terminates the first record of the specified data, or -1 if the record is incomplete.
+// The newlines in the quoted values of CSV are a part of the values.
+func (copyIn *postgresqlCopyIn) recordEnd(data []byte) int {
+	if copyIn.cp.Format != dialect.CopyCSVFormat {
+		return bytes.IndexByte(data, '\n')
+	}
+	quote, escape := copyIn.cp.Quote[0], copyIn.cp.Escape[0]
+	inQuote := false
+	for n := 0; n < len(data); n++ {
+		switch c := data[n]; {
+		case inQuote && c == escape && n+1 < len(data) && (data[n+1] == quote || data[n+1] == escape):
+			n++
+		case c == quote:
+			inQuote = !inQuote
+		case c == '\n' && !inQuote:
+			return n
+		}
+	}
+	return -1
+}
+
+// insertRecord inserts the row of the specified record of the text or CSV format into the table.
+// The header line is skipped, and the end-of-data marker \. ends the data.
+func (copyIn *postgresqlCopyIn) insertRecord(record []byte) {
+	copyIn.line++
+	record = bytes.TrimSuffix(record, []byte("\r"))
+	switch {
+	case string(record) == `\.`:
+		copyIn.done = true
+		return
+	case copyIn.cp.Header && copyIn.line == 1:
+		return
+	}
+	var fields [][]byte
+	var err error
+	if copyIn.cp.Format == dialect.CopyCSVFormat {
+		fields, err = decodePostgreSQLCopyCSVRecord(copyIn.cp, record)
+	} else {
+		fields, err = decodePostgreSQLCopyTextRecord(copyIn.cp, record)
+	}
+	copyIn.line += bytes.Count(record, []byte("\n"))
+	if err != nil {
+		copyIn.err = copyIn.newError("", postgresqlBadCopyFileFormat, err)
+		return
+	}
+	copyIn.insertFields(fields, protocol.TextFormat)
+}
+
+// insertFields inserts the row of the specified fields of the text or binary format into the table.
+func (copyIn *postgresqlCopyIn) insertFields(fields [][]byte, format int16) {
+	switch {
+	case format == protocol.BinaryFormat && len(fields) != len(copyIn.columns):
+		copyIn.err = copyIn.newError("", postgresqlBadCopyFileFormat, fmt.Errorf("row field count is %d, expected %d", len(fields), len(copyIn.columns)))
+		return
+	case len(fields) < len(copyIn.columns):
+		column := copyIn.columns[len(fields)]
+		copyIn.err = copyIn.newError(column, postgresqlBadCopyFileFormat, fmt.Errorf("missing data for column \"%s\"", column))
+		return
+	case len(copyIn.columns) < len(fields):
+		copyIn.err = copyIn.newError("", postgresqlBadCopyFileFormat, errors.New("extra data after last expected column"))
+		return
+	}
+	args := make([]any, len(fields))
+	for n, field := range fields {
+		var err error
+		if format == protocol.BinaryFormat {
+			args[n], err = newPostgreSQLParameterValueFrom(postgresqlCopyBinaryObjectIDOf(copyIn.oids[n], len(field)), format, field)
+			if err != nil {
+				copyIn.err = copyIn.newError(copyIn.columns[n], postgresqlInvalidBinaryRepresentation, errors.New("incorrect binary data format"))
+				return
+			}
+			continue
+		}
+		args[n], err = newPostgreSQLParameterValueFrom(copyIn.oids[n], format, field)
+		if err != nil {
+			copyIn.err = copyIn.newError(copyIn.columns[n], postgresqlInvalidTextRepresentation, err)
+			return
+		}
+	}
+	if _, err := copyIn.server.execStatement(copyIn.conn, copyIn.db, copyIn.stmt, args...); err != nil {
+		copyIn.err = copyIn.newError("", "", err)
+		return
+	}
+	copyIn.nRows++
+}
+
+// readPostgreSQLCopyData reads the CopyData messages until CopyDone or CopyFail, and passes the data to the specified function.
+// It returns true on CopyDone, or the message of CopyFail and false. Flush and Sync are ignored in the copy-in mode.
+func readPostgreSQLCopyData(reader *protocol.MessageReader, fn func([]byte)) (string, bool, error) {
+	for {
+		t, err := reader.PeekType()
+		if err != nil {
+			return "", false, err
+		}
+		switch t {
+		case protocol.CopyDataMessage:
+			msg, err := protocol.NewRequestMessageWithReader(reader)
+			if err != nil {
+				return "", false, err
+			}
+			data, err := msg.ReadMessageData()
+			if err != nil {
+				return "", false, err
+			}
+			fn(data)
+		case protocol.CopyDoneMessage:
+			_, err := protocol.NewCopyDoneWithReader(reader)
+			return "", true, err
+		case protocol.CopyFailMessage:
+			if _, err := reader.ReadType(); err != nil {
+				return "", false, err
+			}
+			msg, err := protocol.NewCopyFailWithReader(reader.Reader)
+			if err != nil {
+				return "", false, err
+			}
+			return msg.Message, false, nil
+		case protocol.FlushMessage, protocol.SyncMessage:
+			msg, err := protocol.NewRequestMessageWithReader(reader)
+			if err != nil {
+				return "", false, err
+			}
+			if _, err := msg.ReadMessageData(); err != nil {
+				return "", false, err
+			}
+		default:
+			return "", false, fmt.Errorf("unexpected message type 0x%02X during COPY from stdin", byte(t))
+		}
+	}
+}
+
+// decodePostgreSQLCopyTextRecord returns the fields of the specified record of the text format, and the NULL fields are nil.
+// The fields are separated by the unescaped delimiters, and the backslash escape sequences are decoded.
+func decodePostgreSQLCopyTextRecord(cp *dialect.Copy, record []byte) ([][]byte, error) {
+	delim := cp.Delimiter[0]
+	fields := [][]byte{}
+	start := 0
+	for n := 0; n <= len(record); n++ {
+		switch {
+		case n < len(record) && record[n] == '\\':
+			n++
+			continue
+		case n < len(record) && record[n] != delim:
+			continue
+		}
+		raw := record[start:min(n, len(record))]
+		start = n + 1
+		if string(raw) == cp.Null {
+			fields = append(fields, nil)
+			continue
+		}
+		field, err := unescapePostgreSQLCopyText(raw)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// unescapePostgreSQLCopyText returns the specified field of the text format which the backslash escape sequences are decoded.
+func unescapePostgreSQLCopyText(raw []byte) ([]byte, error) {
+	if bytes.IndexByte(raw, '\\') < 0 {
+		return append([]byte{}, raw...), nil
+	}
+	isOctal := func(c byte) bool {
+		return '0' <= c && c <= '7'
+	}
+	hexValueOf := func(c byte) (byte, bool) {
+		switch {
+		case '0' <= c && c <= '9':
+			return c - '0', true
+		case 'a' <= c && c <= 'f':
+			return c - 'a' + 10, true
+		case 'A' <= c && c <= 'F':
+			return c - 'A' + 10, true
+		}
+		return 0, false
+	}
+	escapes := map[byte]byte{
+		'b': '\b',
+		'f': '\f',
+		'n': '\n',
+		'r': '\r',
+		't': '\t',
+		'v': '\v',
+	}
+	field := make([]byte, 0, len(raw))
+	for n := 0; n < len(raw); n++ {
+		if raw[n] != '\\' {
+			field = append(field, raw[n])
+			continue
+		}
+		n++
+		if len(raw) <= n {
+			return nil, errors.New("end-of-copy marker corrupt")
+		}
+		c := raw[n]
+		if b, ok := escapes[c]; ok {
+			field = append(field, b)
+			continue
+		}
+		switch {
+		case isOctal(c):
+			b := c - '0'
+			for i := 0; i < 2 && n+1 < len(raw) && isOctal(raw[n+1]); i++ {
+				n++
+				b = b<<3 | (raw[n] - '0')
+			}
+			field = append(field, b)
+		case c == 'x' && n+1 < len(raw):
+			b, ok := hexValueOf(raw[n+1])
+			if !ok {
+				field = append(field, c)
+				continue
+			}
+			n++
+			if n+1 < len(raw) {
+				if l, ok := hexValueOf(raw[n+1]); ok {
+					b = b<<4 | l
+					n++
+				}
+			}
+			field = append(field, b)
+		default:
+			field = append(field, c)
+		}
+	}
+	return field, nil
+}
+
+// decodePostgreSQLCopyCSVRecord returns the fields of the specified record of the CSV format, and the NULL fields are nil.
+// The unquoted fields which match the NULL string are NULL, and the quoted fields are never NULL.
+func decodePostgreSQLCopyCSVRecord(cp *dialect.Copy, record []byte) ([][]byte, error) {
+	delim, quote, escape := cp.Delimiter[0], cp.Quote[0], cp.Escape[0]
+	fields := [][]byte{}
+	field := []byte{}
+	quoted := false
+	inQuote := false
+	appendField := func() {
+		if !quoted && string(field) == cp.Null {
+			fields = append(fields, nil)
+		} else {
+			fields = append(fields, field)
+		}
+		field = []byte{}
+		quoted = false
+	}
+	for n := 0; n < len(record); n++ {
+		switch c := record[n]; {
+		case inQuote && c == escape && n+1 < len(record) && (record[n+1] == quote || record[n+1] == escape):
+			field = append(field, record[n+1])
+			n++
+		case inQuote && c == quote:
+			inQuote = false
+		case inQuote:
+			field = append(field, c)
+		case c == quote:
+			inQuote, quoted = true, true
+		case c == delim:
+			appendField()
+		default:
+			field = append(field, c)
+		}
+	}
+	if inQuote {
+		return nil, errors.New("unterminated CSV quoted field")
+	}
+	appendField()
+	return fields, nil
+}
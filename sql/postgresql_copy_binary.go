@@ -0,0 +1,155 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// PostgreSQL: Documentation: 16: COPY - Binary Format
+// https://www.postgresql.org/docs/16/sql-copy.html#id-1.9.3.55.9.4
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/cybergarage/go-postgresql/postgresql/system"
+)
+
+// postgresqlCopySignature is the signature of the binary COPY header.
+var postgresqlCopySignature = []byte("PGCOPY\n\377\r\n\000")
+
+// postgresqlCopyOIDsFlag is the flag bit of the binary COPY header which shows that the tuples have OIDs.
+const postgresqlCopyOIDsFlag = 1 << 16
+
+// postgresqlCopyTrailer is the field count of the binary COPY trailer.
+const postgresqlCopyTrailer = -1
+
+// postgresqlCopyNullLength is the field length of NULL in the binary COPY tuples.
+const postgresqlCopyNullLength = -1
+
+// Errors of the binary COPY streams.
+var (
+	errPostgreSQLCopySignature = errors.New("COPY file signature not recognized")
+	errPostgreSQLCopyOIDs      = errors.New("unrecognized critical flags in COPY file header")
+	errPostgreSQLCopyEOF       = errors.New("unexpected EOF in COPY data")
+	errPostgreSQLCopyAfterEOF  = errors.New("received copy data after EOF marker")
+)
+
+// readPostgreSQLCopyBinaryHeader returns the length of the binary COPY header at the beginning of the specified data,
+// or 0 if the header is incomplete. The header extension area is skipped.
+func readPostgreSQLCopyBinaryHeader(data []byte) (int, error) {
+	n := len(postgresqlCopySignature)
+	if !bytes.HasPrefix(data, postgresqlCopySignature[:min(n, len(data))]) {
+		return 0, errPostgreSQLCopySignature
+	}
+	if len(data) < n+8 {
+		return 0, nil
+	}
+	flags := binary.BigEndian.Uint32(data[n:])
+	if flags&postgresqlCopyOIDsFlag != 0 || flags&0xFFFF0000 != 0 {
+		return 0, errPostgreSQLCopyOIDs
+	}
+	extLen := int(int32(binary.BigEndian.Uint32(data[n+4:])))
+	if extLen < 0 {
+		return 0, errors.New("invalid COPY file header (wrong length)")
+	}
+	if len(data) < n+8+extLen {
+		return 0, nil
+	}
+	return n + 8 + extLen, nil
+}
+
+// readPostgreSQLCopyBinaryTuple returns the fields and the length of the binary COPY tuple at the beginning of the specified data,
+// and the NULL fields are nil. The length is 0 if the tuple is incomplete, and the fields are nil for the trailer.
+func readPostgreSQLCopyBinaryTuple(data []byte) ([][]byte, int, error) {
+	if len(data) < 2 {
+		return nil, 0, nil
+	}
+	count := int(int16(binary.BigEndian.Uint16(data)))
+	if count == postgresqlCopyTrailer {
+		return nil, 2, nil
+	}
+	if count < 0 {
+		return nil, 0, fmt.Errorf("row field count is %d", count)
+	}
+	fields := make([][]byte, count)
+	offset := 2
+	for n := range count {
+		if len(data) < offset+4 {
+			return nil, 0, nil
+		}
+		fieldLen := int(int32(binary.BigEndian.Uint32(data[offset:])))
+		offset += 4
+		switch {
+		case fieldLen == postgresqlCopyNullLength:
+			continue
+		case fieldLen < 0:
+			return nil, 0, fmt.Errorf("invalid field size %d", fieldLen)
+		case len(data) < offset+fieldLen:
+			return nil, 0, nil
+		}
+		fields[n] = data[offset : offset+fieldLen : offset+fieldLen]
+		offset += fieldLen
+	}
+	return fields, offset, nil
+}
+
+// newPostgreSQLCopyBinaryHeader returns the binary COPY header without flags and extensions.
+func newPostgreSQLCopyBinaryHeader() []byte {
+	b := append([]byte{}, postgresqlCopySignature...)
+	b = binary.BigEndian.AppendUint32(b, 0)
+	return binary.BigEndian.AppendUint32(b, 0)
+}
+
+// newPostgreSQLCopyBinaryTuple returns the binary COPY tuple of the specified binary format fields, and the nil fields are NULL.
+func newPostgreSQLCopyBinaryTuple(fields []any) ([]byte, error) {
+	nullLength := int32(postgresqlCopyNullLength)
+	b := binary.BigEndian.AppendUint16(nil, uint16(len(fields)))
+	for _, field := range fields {
+		switch v := field.(type) {
+		case nil:
+			b = binary.BigEndian.AppendUint32(b, uint32(nullLength))
+		case []byte:
+			b = binary.BigEndian.AppendUint32(b, uint32(len(v)))
+			b = append(b, v...)
+		default:
+			return nil, newErrInvalidValue("binary", v)
+		}
+	}
+	return b, nil
+}
+
+// newPostgreSQLCopyBinaryTrailer returns the binary COPY trailer.
+func newPostgreSQLCopyBinaryTrailer() []byte {
+	trailer := int16(postgresqlCopyTrailer)
+	return binary.BigEndian.AppendUint16(nil, uint16(trailer))
+}
+
+// postgresqlCopyBinaryObjectIDOf returns the type OID to read the binary field of the specified length as. The integers and
+// the floating point numbers of the other widths are accepted because the loaders encode the fields with their source column types.
+func postgresqlCopyBinaryObjectIDOf(oid system.ObjectID, n int) system.ObjectID {
+	intObjectIDs := map[int]system.ObjectID{2: system.Int2, 4: system.Int4, 8: system.Int8}
+	floatObjectIDs := map[int]system.ObjectID{4: system.Float4, 8: system.Float8}
+	switch oid {
+	case system.Int2, system.Int4, system.Int8:
+		if intOID, ok := intObjectIDs[n]; ok {
+			return intOID
+		}
+	case system.Float4, system.Float8:
+		if floatOID, ok := floatObjectIDs[n]; ok {
+			return floatOID
+		}
+	}
+	return oid
+}
@@ -0,0 +1,255 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// PostgreSQL: Documentation: 16: COPY
+// https://www.postgresql.org/docs/16/sql-copy.html
+// PostgreSQL: Documentation: 16: 55.2.6. COPY Operations
+// https://www.postgresql.org/docs/16/protocol-flow.html#PROTOCOL-COPY
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cybergarage/go-postgresql/postgresql/protocol"
+	"github.com/cybergarage/go-postgresql/postgresql/system"
+	"github.com/cybergarage/go-sqlserver/sql/dialect"
+)
+
+// copyTo handles the specified COPY TO STDOUT statement. The rows of the table or the query are streamed from the result set,
+// and every row is sent as a CopyData message after the CopyOutResponse. The binary format has the header and the trailer
+// in the first and the last CopyData messages.
+func (handler *postgresqlMessageHandler) copyTo(conn protocol.Conn, cp *dialect.Copy) (protocol.Responses, error) {
+	stmt, err := handler.server.postgresqlCopyQueryOf(conn, cp)
+	if err != nil {
+		return newPostgreSQLErrorResponsesFrom(err)
+	}
+	portal, err := handler.openPortal(conn, stmt)
+	if err != nil {
+		return newPostgreSQLErrorResponsesFrom(err)
+	}
+	defer portal.Close()
+	columns := portal.rs.Schema().Columns()
+	res, err := newPostgreSQLCopyOutResponseWith(cp, len(columns))
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.ResponseMessage(res); err != nil {
+		return nil, err
+	}
+	isBinary := cp.Format == dialect.CopyBinaryFormat
+	if isBinary {
+		portal.setResultFormats([]int16{protocol.BinaryFormat})
+		if err := handler.sendCopyBytes(conn, newPostgreSQLCopyBinaryHeader()); err != nil {
+			return nil, err
+		}
+	}
+	if cp.Header {
+		fields := make([]any, len(columns))
+		for n, column := range columns {
+			fields[n] = column.Name()
+		}
+		if err := handler.sendCopyData(conn, cp, fields); err != nil {
+			return nil, err
+		}
+	}
+	nRows := 0
+	for portal.rs.Next() {
+		dataRow, err := newPostgreSQLDataRowFrom(portal.rowDesc, portal.rs)
+		if err != nil {
+			return newPostgreSQLErrorResponsesFrom(err)
+		}
+		for n, v := range dataRow.Data {
+			dataRow.Data[n] = postgresqlCopyValueOf(portal.rowDesc.Field(n), v)
+		}
+		if err := handler.sendCopyData(conn, cp, dataRow.Data); err != nil {
+			return nil, err
+		}
+		nRows++
+	}
+	if err := resultSetErrOf(portal.rs); err != nil {
+		return newPostgreSQLErrorResponsesFrom(err)
+	}
+	if isBinary {
+		if err := handler.sendCopyBytes(conn, newPostgreSQLCopyBinaryTrailer()); err != nil {
+			return nil, err
+		}
+	}
+	if err := conn.ResponseMessage(protocol.NewResponseMessageWith(protocol.CopyDoneMessage)); err != nil {
+		return nil, err
+	}
+	return protocol.NewCopyCompleteResponsesWith(nRows)
+}
+
+// sendCopyData sends the CopyData message of the specified fields to the connection.
+func (handler *postgresqlMessageHandler) sendCopyData(conn protocol.Conn, cp *dialect.Copy, fields []any) error {
+	b, err := newPostgreSQLCopyDataBytesWith(cp, fields)
+	if err != nil {
+		return err
+	}
+	return handler.sendCopyBytes(conn, b)
+}
+
+// sendCopyBytes sends the CopyData message of the specified bytes to the connection.
+func (handler *postgresqlMessageHandler) sendCopyBytes(conn protocol.Conn, b []byte) error {
+	res := protocol.NewResponseMessageWith(protocol.CopyDataMessage)
+	if err := res.AppendBytes(b); err != nil {
+		return err
+	}
+	return conn.ResponseMessage(res)
+}
+
+// postgresqlCopyQueryOf returns the SQLite query of the specified COPY TO STDOUT, which selects the columns of the table
+// or is rewritten from the PostgreSQL query.
+func (server *server) postgresqlCopyQueryOf(conn protocol.Conn, cp *dialect.Copy) (string, error) {
+	if cp.Query != "" {
+		stmts, err := dialect.NewPostgreSQLRewriter().RewriteStatements(cp.Query)
+		if err != nil {
+			return "", err
+		}
+		if len(stmts) != 1 || !dialect.IsQuery(stmts[0]) {
+			return "", newErrQueryNotSupported(cp.Query)
+		}
+		return stmts[0], nil
+	}
+	db, err := server.databaseOf(conn)
+	if err != nil {
+		return "", err
+	}
+	columns, err := db.copyColumnsOf(cp)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("SELECT %s FROM %s",
+		strings.Join(quotedCopyColumnsOf(columns), ", "),
+		dialect.QuoteIdentifier(cp.Table)), nil
+}
+
+// newPostgreSQLCopyOutResponseWith returns the CopyOutResponse of the specified number of the columns in the format of the COPY.
+func newPostgreSQLCopyOutResponseWith(cp *dialect.Copy, nColumns int) (protocol.Response, error) {
+	copyFormat, formatCode := protocol.TextCopy, int16(protocol.TextFormat)
+	if cp.Format == dialect.CopyBinaryFormat {
+		copyFormat, formatCode = protocol.BinaryCopy, int16(protocol.BinaryFormat)
+	}
+	res := protocol.NewResponseMessageWith(protocol.CopyOutResponseMessage)
+	if err := res.AppendInt8(copyFormat); err != nil {
+		return nil, err
+	}
+	if err := res.AppendInt16(int16(nColumns)); err != nil {
+		return nil, err
+	}
+	for range nColumns {
+		if err := res.AppendInt16(formatCode); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// postgresqlCopyValueOf returns the text of the specified data row value which COPY writes, and the booleans are written as t and f.
+func postgresqlCopyValueOf(field *protocol.RowField, v any) any {
+	s, ok := v.(string)
+	if !ok || field.ObjectID != system.Bool {
+		return v
+	}
+	b, err := strconv.ParseBool(s)
+	switch {
+	case err != nil:
+		return v
+	case b:
+		return "t"
+	}
+	return "f"
+}
+
+// newPostgreSQLCopyDataBytesWith returns the CopyData bytes of the specified fields in the format of the COPY,
+// and the nil fields are written as the NULL string or the NULL length of the binary format.
+func newPostgreSQLCopyDataBytesWith(cp *dialect.Copy, fields []any) ([]byte, error) {
+	if cp.Format == dialect.CopyBinaryFormat {
+		return newPostgreSQLCopyBinaryTuple(fields)
+	}
+	var b strings.Builder
+	for n, field := range fields {
+		if 0 < n {
+			b.WriteString(cp.Delimiter)
+		}
+		var s string
+		switch v := field.(type) {
+		case nil:
+			b.WriteString(cp.Null)
+			continue
+		case string:
+			s = v
+		case []byte:
+			s = string(v)
+		default:
+			s = fmt.Sprintf("%v", v)
+		}
+		if cp.Format == dialect.CopyCSVFormat {
+			writePostgreSQLCopyCSVField(&b, cp, s, len(fields) == 1)
+		} else {
+			writePostgreSQLCopyTextField(&b, cp, s)
+		}
+	}
+	b.WriteByte('\n')
+	return []byte(b.String()), nil
+}
+
+// writePostgreSQLCopyTextField writes the specified field of the text format, and the backslashes, the delimiters
+// and the control characters are escaped with backslashes.
+func writePostgreSQLCopyTextField(b *strings.Builder, cp *dialect.Copy, s string) {
+	escapes := map[byte]string{
+		'\\': `\\`,
+		'\b': `\b`,
+		'\f': `\f`,
+		'\n': `\n`,
+		'\r': `\r`,
+		'\t': `\t`,
+		'\v': `\v`,
+	}
+	delim := cp.Delimiter[0]
+	for n := 0; n < len(s); n++ {
+		c := s[n]
+		if escape, ok := escapes[c]; ok {
+			b.WriteString(escape)
+			continue
+		}
+		if c == delim {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+}
+
+// writePostgreSQLCopyCSVField writes the specified field of the CSV format. The fields which contain the delimiter, the quote,
+// the newlines or match the NULL string are quoted, and the quotes and the escapes in the quoted fields are escaped.
+// The single column field \. is also quoted not to be read as the end-of-data marker.
+func writePostgreSQLCopyCSVField(b *strings.Builder, cp *dialect.Copy, s string, single bool) {
+	quote, escape := cp.Quote[0], cp.Escape[0]
+	if s != cp.Null && !strings.ContainsAny(s, cp.Delimiter+cp.Quote+"\r\n") && (!single || s != `\.`) {
+		b.WriteString(s)
+		return
+	}
+	b.WriteByte(quote)
+	for n := 0; n < len(s); n++ {
+		c := s[n]
+		if c == quote || c == escape {
+			b.WriteByte(escape)
+		}
+		b.WriteByte(c)
+	}
+	b.WriteByte(quote)
+}
@@ -0,0 +1,213 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// PostgreSQL: Documentation: 16: 55.7. Message Formats (Bind)
+// https://www.postgresql.org/docs/16/protocol-message-formats.html#PROTOCOL-MESSAGE-FORMATS-BIND
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/cybergarage/go-postgresql/postgresql/protocol"
+)
+
+// postgresqlMessageHeaderSize is the size of the message type and the message length.
+const postgresqlMessageHeaderSize = 5
+
+// postgresqlBind represents a recorded Bind message. go-postgresql drops the result format codes, and reads
+// the NULL parameters of the text format as the empty texts.
+type postgresqlBind struct {
+	stmtName      string
+	paramFormats  []int16
+	params        [][]byte
+	resultFormats []int16
+}
+
+// postgresqlBindReader represents a reader of the connection which records the Bind messages by the portal names.
+type postgresqlBindReader struct {
+	io.Reader
+	header []byte
+	body   []byte
+	remain int
+	binds  map[string]*postgresqlBind
+}
+
+// postgresqlBindReaderOf returns the Bind reader of the specified connection, and the reader is installed into the message reader
+// of the connection if the connection has no Bind reader. The reader has to be installed between the messages, such as
+// in the message handlers, because it tracks the message boundaries.
+func postgresqlBindReaderOf(conn protocol.Conn) *postgresqlBindReader {
+	msgReader := conn.MessageReader()
+	if reader, ok := msgReader.Reader.Reader.(*postgresqlBindReader); ok {
+		return reader
+	}
+	reader := &postgresqlBindReader{
+		Reader: msgReader.Reader.Reader,
+		header: make([]byte, 0, postgresqlMessageHeaderSize),
+		body:   nil,
+		remain: 0,
+		binds:  map[string]*postgresqlBind{},
+	}
+	msgReader.Reader.Reader = reader
+	return reader
+}
+
+// postgresqlBindOf returns the recorded Bind message of the specified portal, or false if the portal was not bound with the Bind reader.
+func postgresqlBindOf(conn protocol.Conn, portal string) (*postgresqlBind, bool) {
+	reader, ok := conn.MessageReader().Reader.Reader.(*postgresqlBindReader)
+	if !ok {
+		return nil, false
+	}
+	bind, ok := reader.binds[portal]
+	return bind, ok
+}
+
+// postgresqlResultFormatsOf returns the result format codes of the specified portal, or nil if the portal was not bound with the Bind reader.
+func postgresqlResultFormatsOf(conn protocol.Conn, portal string) []int16 {
+	bind, ok := postgresqlBindOf(conn, portal)
+	if !ok {
+		return nil
+	}
+	return bind.resultFormats
+}
+
+// Read reads the bytes of the connection, and records the Bind messages in the bytes.
+func (reader *postgresqlBindReader) Read(p []byte) (int, error) {
+	n, err := reader.Reader.Read(p)
+	reader.record(p[:n])
+	return n, err
+}
+
+// record tracks the message boundaries of the specified read bytes, and parses the completed Bind messages.
+func (reader *postgresqlBindReader) record(b []byte) {
+	for 0 < len(b) {
+		if len(reader.header) < postgresqlMessageHeaderSize {
+			n := min(postgresqlMessageHeaderSize-len(reader.header), len(b))
+			reader.header = append(reader.header, b[:n]...)
+			b = b[n:]
+			if len(reader.header) < postgresqlMessageHeaderSize {
+				return
+			}
+			// The message length includes itself.
+			reader.remain = int(int32(binary.BigEndian.Uint32(reader.header[1:]))) - 4
+		}
+		n := min(max(reader.remain, 0), len(b))
+		isBind := protocol.Type(reader.header[0]) == protocol.BindMessage
+		if isBind {
+			reader.body = append(reader.body, b[:n]...)
+		}
+		b = b[n:]
+		reader.remain -= n
+		if 0 < reader.remain {
+			return
+		}
+		if isBind {
+			if portal, bind, ok := parsePostgreSQLBind(reader.body); ok {
+				reader.binds[portal] = bind
+			}
+		}
+		reader.header = reader.header[:0]
+		reader.body = nil
+	}
+}
+
+// parsePostgreSQLBind returns the portal name and the recorded Bind message of the specified Bind message body.
+// The result format codes are not nil even if the message has no format codes, which means all columns are sent in the text format.
+func parsePostgreSQLBind(body []byte) (string, *postgresqlBind, bool) {
+	portal, body, ok := bytes.Cut(body, []byte{0})
+	if !ok {
+		return "", nil, false
+	}
+	// The statement name follows the portal name.
+	stmtName, body, ok := bytes.Cut(body, []byte{0})
+	if !ok {
+		return "", nil, false
+	}
+	readInt16 := func() (int, bool) {
+		if len(body) < 2 {
+			return 0, false
+		}
+		v := int16(binary.BigEndian.Uint16(body))
+		body = body[2:]
+		return int(v), true
+	}
+	readFormats := func() ([]int16, bool) {
+		nFormats, ok := readInt16()
+		if !ok {
+			return nil, false
+		}
+		formats := make([]int16, 0, nFormats)
+		for n := 0; n < nFormats; n++ {
+			format, ok := readInt16()
+			if !ok {
+				return nil, false
+			}
+			formats = append(formats, int16(format))
+		}
+		return formats, true
+	}
+	paramFormats, ok := readFormats()
+	if !ok {
+		return "", nil, false
+	}
+	// The parameter values which have the lengths, and the length of NULL is -1.
+	nParams, ok := readInt16()
+	if !ok {
+		return "", nil, false
+	}
+	params := make([][]byte, 0, nParams)
+	for n := 0; n < nParams; n++ {
+		if len(body) < 4 {
+			return "", nil, false
+		}
+		length := int(int32(binary.BigEndian.Uint32(body)))
+		body = body[4:]
+		if length < 0 {
+			params = append(params, nil)
+			continue
+		}
+		if len(body) < length {
+			return "", nil, false
+		}
+		params = append(params, append([]byte{}, body[:length]...))
+		body = body[length:]
+	}
+	resultFormats, ok := readFormats()
+	if !ok {
+		return "", nil, false
+	}
+	bind := &postgresqlBind{
+		stmtName:      string(stmtName),
+		paramFormats:  paramFormats,
+		params:        params,
+		resultFormats: resultFormats,
+	}
+	return string(portal), bind, true
+}
+
+// postgresqlFormatCodeAt returns the format code of the specified column. No format codes mean the text format for all columns,
+// and one format code is applied to all columns.
+func postgresqlFormatCodeAt(formats []int16, n int) int16 {
+	switch {
+	case len(formats) == 0:
+		return protocol.TextFormat
+	case len(formats) == 1:
+		return formats[0]
+	case n < len(formats):
+		return formats[n]
+	}
+	return protocol.TextFormat
+}
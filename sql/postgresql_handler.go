@@ -0,0 +1,662 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// PostgreSQL: Documentation: 16: 55.2. Message Flow (Simple Query)
+// https://www.postgresql.org/docs/16/protocol-flow.html#PROTOCOL-FLOW-SIMPLE-QUERY
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cybergarage/go-logger/log"
+	"github.com/cybergarage/go-postgresql/postgresql/protocol"
+	"github.com/cybergarage/go-postgresql/postgresql/query"
+	"github.com/cybergarage/go-postgresql/postgresql/system"
+	sql "github.com/cybergarage/go-sqlparser/sql/query/response/resultset"
+	"github.com/cybergarage/go-sqlserver/sql/dialect"
+)
+
+// postgresqlMessageHandler represents a PostgreSQL message handler which executes DML queries
+// without the go-sqlparser AST, because the AST drops the PostgreSQL specific syntax such as
+// type casts, DISTINCT ON and ON CONFLICT.
+type postgresqlMessageHandler struct {
+	protocol.MessageHandler
+	server  *server
+	portals *postgresqlPortals
+}
+
+// postgresqlMessageHandlerSetter represents a PostgreSQL server which is able to replace the message handler.
+type postgresqlMessageHandlerSetter interface {
+	SetMessageHandler(protocol.MessageHandler)
+}
+
+// newPostgreSQLMessageHandlerWith returns a new PostgreSQL message handler which delegates the other messages to the specified handler.
+func newPostgreSQLMessageHandlerWith(server *server, handler protocol.MessageHandler) *postgresqlMessageHandler {
+	return &postgresqlMessageHandler{
+		MessageHandler: handler,
+		server:         server,
+		portals:        newPostgreSQLPortals(),
+	}
+}
+
+// setupPostgreSQLMessageHandler replaces the message handler of the PostgreSQL server.
+func (server *server) setupPostgreSQLMessageHandler() {
+	pgServer := server.PostgreSQLServer()
+	handler, ok := pgServer.(protocol.MessageHandler)
+	if !ok {
+		return
+	}
+	setter, ok := pgServer.(postgresqlMessageHandlerSetter)
+	if !ok {
+		return
+	}
+	setter.SetMessageHandler(newPostgreSQLMessageHandlerWith(server, handler))
+}
+
+// Query handles a simple query message. The multiple statements are executed in sequence, and every statement returns
+// the responses.
+func (handler *postgresqlMessageHandler) Query(conn protocol.Conn, msg *protocol.Query) (protocol.Responses, error) {
+	srcStmts, err := dialect.SplitStatements(dialect.PostgreSQL, msg.Query)
+	if err == nil && 1 < len(srcStmts) && len(msg.BindParams) == 0 {
+		return handler.queryStatements(conn, srcStmts)
+	}
+	return handler.queryStatement(conn, msg)
+}
+
+// queryStatements executes the specified statements of a simple query message in sequence, and sends the responses
+// of the statements to the connection. The execution stops at the first error which is returned as the last response.
+// The statements are executed in an implicit transaction unless they have the transaction control statements, so that
+// the preceding statements are rolled back if a statement fails as PostgreSQL does.
+func (handler *postgresqlMessageHandler) queryStatements(conn protocol.Conn, srcStmts []string) (protocol.Responses, error) {
+	var db *Database
+	if !hasPostgreSQLTransactionStatement(srcStmts) {
+		if d, err := handler.server.databaseOf(conn); err == nil && !d.InTransaction() {
+			if err := d.Begin(); err != nil {
+				return nil, err
+			}
+			db = d
+		}
+	}
+	rollback := func() {
+		if db == nil {
+			return
+		}
+		if err := db.Rollback(); err != nil {
+			log.Error(err)
+		}
+		handler.portals.Rollback(conn)
+	}
+	for _, srcStmt := range srcStmts {
+		msg := &protocol.Query{
+			RequestMessage: nil,
+			Query:          srcStmt,
+			BindParams:     protocol.BindParams{},
+		}
+		res, err := handler.queryStatement(conn, msg)
+		if err != nil || res.HasErrorResponse() {
+			rollback()
+			return res, err
+		}
+		if err := conn.ResponseMessages(res); err != nil {
+			rollback()
+			return nil, err
+		}
+	}
+	if db == nil {
+		return nil, nil
+	}
+	if err := db.Commit(); err != nil {
+		return nil, err
+	}
+	handler.portals.Commit(conn)
+	return nil, nil
+}
+
+// hasPostgreSQLTransactionStatement returns true if the specified statements have a transaction control statement.
+func hasPostgreSQLTransactionStatement(stmts []string) bool {
+	for _, stmt := range stmts {
+		switch dialect.LeadingKeyword(stmt) {
+		case "BEGIN", "START", "COMMIT", "END", "ROLLBACK", "ABORT":
+			return true
+		}
+	}
+	return false
+}
+
+// queryStatement handles a simple query message which has a statement.
+func (handler *postgresqlMessageHandler) queryStatement(conn protocol.Conn, msg *protocol.Query) (protocol.Responses, error) {
+	// The portals are closed at the end of the transaction except the cursors WITH HOLD.
+	switch dialect.LeadingKeyword(msg.Query) {
+	case "COMMIT", "END":
+		handler.portals.Commit(conn)
+	case "ROLLBACK", "ABORT":
+		handler.portals.Rollback(conn)
+	}
+	if 0 < len(msg.BindParams) {
+		return handler.MessageHandler.Query(conn, msg)
+	}
+	if name, value, ok := dialect.ParameterSetting(msg.Query); ok && name == postgresqlClientMinMessages {
+		return handler.setClientMinMessages(conn, value, dialect.LeadingKeyword(msg.Query))
+	}
+	if dialect.LeadingKeyword(msg.Query) == "COPY" {
+		return handler.copy(conn, msg.Query)
+	}
+	stmts, err := dialect.NewPostgreSQLRewriter().RewriteStatements(msg.Query)
+	if errors.Is(err, dialect.ErrNotSupported) {
+		// The unsupported statements are reported as they are instead of the parser errors.
+		return newPostgreSQLErrorResponsesFrom(err)
+	}
+	if err != nil || len(stmts) != 1 {
+		return handler.MessageHandler.Query(conn, msg)
+	}
+	var res protocol.Responses
+	switch stmt := stmts[0]; {
+	case isPostgreSQLDMLStatement(stmt), dialect.HasExtendedColumnType(stmt), handler.isEnumStatement(conn, stmt):
+		res, err = handler.executeStatement(conn, stmt)
+	case isPostgreSQLCursorStatement(stmt):
+		res, err = handler.executeCursorStatement(conn, stmt)
+	default:
+		return handler.MessageHandler.Query(conn, msg)
+	}
+	if err != nil {
+		return newPostgreSQLErrorResponsesFrom(err)
+	}
+	return res, nil
+}
+
+// executeStatement executes the specified SQLite statement with the bind arguments, and returns the PostgreSQL responses.
+func (handler *postgresqlMessageHandler) executeStatement(conn protocol.Conn, stmt string, args ...any) (protocol.Responses, error) {
+	if dialect.IsQuery(stmt) {
+		return handler.executeQuery(conn, stmt, args...)
+	}
+	rs, err := handler.server.executeStatement(conn, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	notices, err := handler.server.postgresqlNoticesOf(conn, rs)
+	if err != nil {
+		return nil, err
+	}
+	res, err := newPostgreSQLCompleteResponsesFrom(stmt, rs)
+	if err != nil {
+		return nil, err
+	}
+	return append(notices, res...), nil
+}
+
+// newPostgreSQLCompleteResponsesFrom returns the command complete responses of the specified executed SQLite statement.
+func newPostgreSQLCompleteResponsesFrom(stmt string, rs sql.ResultSet) (protocol.Responses, error) {
+	n := int(rs.RowsAffected())
+	switch dialect.LeadingKeyword(stmt) {
+	case "INSERT":
+		return protocol.NewInsertCompleteResponsesWith(n)
+	case "UPDATE":
+		return protocol.NewUpdateCompleteResponsesWith(n)
+	case "DELETE":
+		return protocol.NewDeleteCompleteResponsesWith(n)
+	case "CREATE":
+		if dialect.IsEnumTypeStatement(stmt) {
+			return protocol.NewCommandCompleteResponsesWith("CREATE TYPE")
+		}
+		return protocol.NewCommandCompleteResponsesWith("CREATE TABLE")
+	case "DROP":
+		if dialect.IsEnumTypeStatement(stmt) {
+			return protocol.NewCommandCompleteResponsesWith("DROP TYPE")
+		}
+	}
+	return protocol.NewCommandCompleteResponsesWith(dialect.LeadingKeyword(stmt))
+}
+
+// executeQuery executes the specified SQLite query with the bind arguments, and sends the row description and the data rows to the connection
+// row by row, so that the rows are not buffered in the server memory.
+func (handler *postgresqlMessageHandler) executeQuery(conn protocol.Conn, stmt string, args ...any) (protocol.Responses, error) {
+	portal, err := handler.openPortal(conn, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer portal.Close()
+	if err := conn.ResponseMessage(portal.rowDesc); err != nil {
+		return nil, err
+	}
+	nRows, _, err := portal.fetch(conn, postgresqlFetchAll)
+	if err != nil {
+		return nil, err
+	}
+	return portal.completeResponses(nRows)
+}
+
+// isEnumStatement returns true if the specified statement is CREATE TYPE, DROP TYPE or CREATE TABLE with the enum type columns,
+// which are handled by the side catalog.
+func (handler *postgresqlMessageHandler) isEnumStatement(conn protocol.Conn, stmt string) bool {
+	if dialect.IsEnumTypeStatement(stmt) {
+		return true
+	}
+	db, err := handler.server.databaseOf(conn)
+	if err != nil {
+		return false
+	}
+	for _, typeName := range dialect.ColumnTypes(stmt) {
+		if _, _, ok := db.EnumType(dialect.EnumType + ":" + strings.ToLower(typeName)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// newPostgreSQLErrorResponseFrom returns the error response of the specified error, the invalid enum values are reported
+// with the invalid_text_representation SQLSTATE, and the values rejected by the strict tables and the rows of COPY are reported as PostgreSQL does.
+func newPostgreSQLErrorResponseFrom(err error) (*protocol.ErrorResponse, error) {
+	var copyErr *postgresqlCopyError
+	if errors.As(err, &copyErr) {
+		return newPostgreSQLCopyErrorResponseFrom(copyErr)
+	}
+	var strictErr *StrictValueError
+	if errors.As(err, &strictErr) {
+		return newPostgreSQLStrictValueErrorResponseFrom(strictErr)
+	}
+	var decimalErr *DecimalValueError
+	if errors.As(err, &decimalErr) {
+		return newPostgreSQLDecimalValueErrorResponseFrom(decimalErr)
+	}
+	var arrayErr *ArrayValueError
+	if errors.As(err, &arrayErr) {
+		return newPostgreSQLArrayValueErrorResponseFrom(arrayErr)
+	}
+	var jsonErr *JSONValueError
+	if errors.As(err, &jsonErr) {
+		return newPostgreSQLJSONValueErrorResponseFrom(jsonErr)
+	}
+	var enumErr *EnumValueError
+	if !errors.As(err, &enumErr) {
+		return protocol.NewErrorResponseWith(err)
+	}
+	res := protocol.NewErrorResponse()
+	fields := []struct {
+		t protocol.ErrorType
+		v string
+	}{
+		{protocol.SeverityError, "ERROR"},
+		{protocol.CodeError, "22P02"},
+		{protocol.MessageError, "invalid input value for enum " + enumErr.Enum.Name},
+		{protocol.ColumnError, enumErr.Column},
+		{protocol.DataTypeNameError, enumErr.Enum.Name},
+	}
+	for _, field := range fields {
+		if err := res.AppendField(field.t, field.v); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// postgresqlStrictTypeNames maps the STRICT datatypes and the character types into the PostgreSQL type names of the error messages.
+var postgresqlStrictTypeNames = map[string]string{
+	dialect.StrictIntegerType: "integer",
+	dialect.StrictRealType:    "double precision",
+	dialect.StrictTextType:    "text",
+	dialect.StrictBlobType:    "bytea",
+	"CHAR":                    "character",
+	"CHARACTER":               "character",
+	"BPCHAR":                  "character",
+	"NCHAR":                   "character",
+}
+
+// newPostgreSQLStrictValueErrorResponseFrom returns the error response of the specified value rejected by a strict table.
+// The values longer than the declared lengths are reported with the string_data_right_truncation SQLSTATE,
+// and the values of the other types are reported with the invalid_text_representation SQLSTATE.
+func newPostgreSQLStrictValueErrorResponseFrom(err *StrictValueError) (*protocol.ErrorResponse, error) {
+	typeName, ok := postgresqlStrictTypeNames[err.Type]
+	if !ok {
+		typeName = "character varying"
+	}
+	code := "22P02"
+	msg := fmt.Sprintf("invalid input syntax for type %s: \"%s\"", typeName, err.Value)
+	if err.IsTooLong() {
+		code = "22001"
+		msg = fmt.Sprintf("value too long for type %s(%d)", typeName, err.Length)
+	}
+	res := protocol.NewErrorResponse()
+	fields := []struct {
+		t protocol.ErrorType
+		v string
+	}{
+		{protocol.SeverityError, "ERROR"},
+		{protocol.CodeError, code},
+		{protocol.MessageError, msg},
+		{protocol.ColumnError, err.Column},
+	}
+	for _, field := range fields {
+		if err := res.AppendField(field.t, field.v); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// newPostgreSQLDecimalValueErrorResponseFrom returns the error response of the specified decimal value which does not fit the precision
+// with the numeric_value_out_of_range SQLSTATE.
+func newPostgreSQLDecimalValueErrorResponseFrom(err *DecimalValueError) (*protocol.ErrorResponse, error) {
+	res := protocol.NewErrorResponse()
+	fields := []struct {
+		t protocol.ErrorType
+		v string
+	}{
+		{protocol.SeverityError, "ERROR"},
+		{protocol.CodeError, "22003"},
+		{protocol.MessageError, "numeric field overflow"},
+		{protocol.DetailError, fmt.Sprintf("A field with precision %d, scale %d must round to an absolute value less than 10^%d.", err.Precision, err.Scale, err.Precision-err.Scale)},
+		{protocol.ColumnError, err.Column},
+	}
+	for _, field := range fields {
+		if err := res.AppendField(field.t, field.v); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// newPostgreSQLArrayValueErrorResponseFrom returns the error response of the specified value which is not a valid array literal
+// with the invalid_text_representation SQLSTATE.
+func newPostgreSQLArrayValueErrorResponseFrom(err *ArrayValueError) (*protocol.ErrorResponse, error) {
+	msg := "malformed array literal"
+	if err.Value != "" {
+		msg = fmt.Sprintf("malformed array literal: \"%s\"", err.Value)
+	}
+	res := protocol.NewErrorResponse()
+	fields := []struct {
+		t protocol.ErrorType
+		v string
+	}{
+		{protocol.SeverityError, "ERROR"},
+		{protocol.CodeError, "22P02"},
+		{protocol.MessageError, msg},
+		{protocol.ColumnError, err.Column},
+	}
+	for _, field := range fields {
+		if err := res.AppendField(field.t, field.v); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// newPostgreSQLJSONValueErrorResponseFrom returns the error response of the specified value which is not a well-formed JSON text
+// with the invalid_text_representation SQLSTATE.
+func newPostgreSQLJSONValueErrorResponseFrom(err *JSONValueError) (*protocol.ErrorResponse, error) {
+	res := protocol.NewErrorResponse()
+	fields := []struct {
+		t protocol.ErrorType
+		v string
+	}{
+		{protocol.SeverityError, "ERROR"},
+		{protocol.CodeError, "22P02"},
+		{protocol.MessageError, "invalid input syntax for type json"},
+		{protocol.ColumnError, err.Column},
+	}
+	for _, field := range fields {
+		if err := res.AppendField(field.t, field.v); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// newPostgreSQLErrorResponsesFrom returns the responses which have the error response of the specified error.
+func newPostgreSQLErrorResponsesFrom(err error) (protocol.Responses, error) {
+	errRes, err := newPostgreSQLErrorResponseFrom(err)
+	if err != nil {
+		return nil, err
+	}
+	return protocol.NewResponsesWith(errRes), nil
+}
+
+// postgresqlArrayObjectIDs maps the internal array type names into the PostgreSQL array type OIDs.
+// The array types are sent as the text type with the array type OIDs because go-postgresql has no array types.
+var postgresqlArrayObjectIDs = map[string]system.ObjectID{
+	"_BOOL":        1000,
+	"_BYTEA":       1001,
+	"_INT2":        1005,
+	"_INT4":        1007,
+	"_TEXT":        1009,
+	"_BPCHAR":      1014,
+	"_VARCHAR":     1015,
+	"_INT8":        1016,
+	"_FLOAT4":      1021,
+	"_FLOAT8":      1022,
+	"_TIMESTAMP":   1115,
+	"_DATE":        1182,
+	"_TIME":        1183,
+	"_TIMESTAMPTZ": 1185,
+	"_NUMERIC":     1231,
+	"_UUID":        2951,
+	"_JSON":        199,
+	"_JSONB":       3807,
+}
+
+// postgresqlLogicalObjectIDs maps the logical column types into the PostgreSQL type OIDs.
+var postgresqlLogicalObjectIDs = map[string]system.ObjectID{
+	dialect.BooleanType:     system.Bool,
+	dialect.DateType:        system.Date,
+	dialect.TimeType:        system.Time,
+	dialect.TimestampTZType: system.Timestamptz,
+	dialect.UUIDType:        system.UUID,
+}
+
+// newPostgreSQLRowDescriptionFrom returns the row description of the specified result set,
+// and the enum type OIDs are looked up from the specified database.
+func newPostgreSQLRowDescriptionFrom(db *Database, rs sql.ResultSet) (*protocol.RowDescription, error) {
+	rowDesc := protocol.NewRowDescription()
+	for n, column := range rs.Schema().Columns() {
+		dt, err := newPostgreSQLDataTypeFrom(rs, n, column)
+		if err != nil {
+			return nil, err
+		}
+		opts := []protocol.RowFieldOption{
+			protocol.WithRowFieldDataType(dt),
+			protocol.WithRowFieldModifier(postgresqlTypeModifierOf(rs, n)),
+		}
+		if originer, ok := rs.(columnOriginer); ok {
+			// The table OID and the column number are zero if the column is not a base table column.
+			if origin := originer.ColumnOrigin(n); origin != nil {
+				opts = append(opts,
+					protocol.WithRowFieldTableID(origin.TableID),
+					protocol.WithRowFieldNumber(origin.Number),
+				)
+			}
+		}
+		if oid, ok := postgresqlArrayObjectIDOf(rs, n); ok {
+			opts = append(opts, protocol.WithRowFieldObjectID(oid))
+		}
+		if oid, ok := postgresqlEnumObjectIDOf(db, rs, n); ok {
+			opts = append(opts, protocol.WithRowFieldObjectID(oid))
+		}
+		field := protocol.NewRowFieldWith(column.Name(), opts...)
+		rowDesc.AppendField(field)
+	}
+	return rowDesc, nil
+}
+
+// newPostgreSQLDataRowFrom returns the data row of the current row of the specified result set.
+func newPostgreSQLDataRowFrom(rowDesc *protocol.RowDescription, rs sql.ResultSet) (*protocol.DataRow, error) {
+	row, err := rs.Row()
+	if err != nil {
+		return nil, err
+	}
+	dataRow := protocol.NewDataRow()
+	for n, v := range row.Values() {
+		if v == nil {
+			// NULL is sent as is because AppendData converts the values into the column types.
+			dataRow.Data = append(dataRow.Data, nil)
+			continue
+		}
+		field := rowDesc.Field(n)
+		if field.FormatCode == protocol.BinaryFormat {
+			b, err := newPostgreSQLBinaryValueFrom(field.ObjectID, v)
+			if err != nil {
+				return nil, err
+			}
+			dataRow.Data = append(dataRow.Data, b)
+			continue
+		}
+		if b, ok := v.([]byte); ok && field.ObjectID == system.Bytea {
+			v = postgresqlByteaTextOf(b)
+		}
+		if namer, ok := rs.(columnTypeNamer); ok {
+			v = postgresqlValueOf(namer.ColumnTypeName(n), v)
+		}
+		err := dataRow.AppendData(field, v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return dataRow, nil
+}
+
+// newPostgreSQLDataTypeFrom returns the PostgreSQL data type of the specified column,
+// and the varchar, JSON, decimal, integer and logical type columns are returned as the corresponding PostgreSQL types.
+func newPostgreSQLDataTypeFrom(rs sql.ResultSet, n int, column sql.Column) (*query.DataType, error) {
+	if namer, ok := rs.(columnTypeNamer); ok {
+		if oid, ok := postgresqlObjectIDOf(namer.ColumnTypeName(n)); ok {
+			return system.NewDataTypeFrom(oid)
+		}
+	}
+	return query.NewDataTypeFrom(column.DataType())
+}
+
+// postgresqlObjectIDOf returns the PostgreSQL type OID of the specified varchar, JSON, decimal, integer or logical type name.
+func postgresqlObjectIDOf(typeName string) (system.ObjectID, bool) {
+	if integerType, ok := dialect.IntegerTypeOf(typeName); ok {
+		return postgresqlIntegerObjectIDs[integerType], true
+	}
+	switch {
+	case typeName == "VARCHAR", typeName == "CHARACTER VARYING":
+		return system.Varchar, true
+	case typeName == "JSON":
+		return system.JSON, true
+	case typeName == "JSONB":
+		return system.JSONb, true
+	case isDecimalTypeName(typeName):
+		return system.Numeric, true
+	case dialect.IsLogicalTypeName(typeName):
+		return postgresqlLogicalObjectIDs[typeName], true
+	}
+	return 0, false
+}
+
+// postgresqlIntegerObjectIDs maps the integer types into the PostgreSQL integer types, and the MySQL specific TINYINT,
+// MEDIUMINT and unsigned integer types are mapped into the narrowest PostgreSQL types which hold the values.
+var postgresqlIntegerObjectIDs = map[string]system.ObjectID{
+	"TINYINT":                  system.Int2,
+	"TINYINT UNSIGNED":         system.Int2,
+	"SMALLINT":                 system.Int2,
+	"SMALLINT UNSIGNED":        system.Int4,
+	"MEDIUMINT":                system.Int4,
+	"MEDIUMINT UNSIGNED":       system.Int4,
+	"INT":                      system.Int4,
+	"INT UNSIGNED":             system.Int8,
+	"BIGINT":                   system.Int8,
+	dialect.UnsignedBigIntType: system.Numeric,
+}
+
+// postgresqlArrayObjectIDOf returns the array type OID of the specified column if the column is an array column.
+func postgresqlArrayObjectIDOf(rs sql.ResultSet, n int) (system.ObjectID, bool) {
+	namer, ok := rs.(columnTypeNamer)
+	if !ok {
+		return 0, false
+	}
+	oid, ok := postgresqlArrayObjectIDs[namer.ColumnTypeName(n)]
+	return oid, ok
+}
+
+// postgresqlEnumObjectIDOf returns the enum type OID of the specified column if the column is an enum column.
+// The enum types are sent as the text type with the enum type OIDs.
+func postgresqlEnumObjectIDOf(db *Database, rs sql.ResultSet, n int) (system.ObjectID, bool) {
+	namer, ok := rs.(columnTypeNamer)
+	if !ok {
+		return 0, false
+	}
+	_, oid, ok := db.EnumType(namer.ColumnTypeName(n))
+	return oid, ok
+}
+
+// postgresqlValueOf returns the text representation of the specified date and time value of the logical type.
+func postgresqlValueOf(typeName string, v any) any {
+	t, ok := v.(time.Time)
+	if !ok {
+		return v
+	}
+	switch typeName {
+	case dialect.DateType:
+		return t.Format(dateLayout)
+	case dialect.TimeType:
+		return t.Format(timeLayout)
+	case dialect.TimestampTZType:
+		return t.Format(timestampTZLayout)
+	}
+	return v
+}
+
+// postgresqlNoTypeModifier is the type modifier of the columns which have no type modifiers.
+const postgresqlNoTypeModifier = -1
+
+// postgresqlTypeModifierOf returns the type modifier of the specified column such as the precision and scale of the numeric columns
+// and the length of the varchar columns, or -1 if the column has no type modifier.
+func postgresqlTypeModifierOf(rs sql.ResultSet, n int) int32 {
+	namer, ok := rs.(columnTypeNamer)
+	if !ok {
+		return postgresqlNoTypeModifier
+	}
+	switch typeName := namer.ColumnTypeName(n); {
+	case isDecimalTypeName(typeName):
+		precision, scale := decimalTypeOf(typeName)
+		if precision < 0 {
+			return postgresqlNoTypeModifier
+		}
+		// The numeric type modifier packs the precision and scale with VARHDRSZ.
+		return int32((precision<<16)|scale) + 4
+	case typeName == "VARCHAR", typeName == "CHARACTER VARYING":
+		originer, ok := rs.(columnOriginer)
+		if !ok {
+			return postgresqlNoTypeModifier
+		}
+		origin := originer.ColumnOrigin(n)
+		if origin == nil {
+			return postgresqlNoTypeModifier
+		}
+		length, ok := characterLengthOf(origin.DeclType)
+		if !ok {
+			return postgresqlNoTypeModifier
+		}
+		// The varchar type modifier is the length with VARHDRSZ.
+		return int32(length) + 4
+	}
+	return postgresqlNoTypeModifier
+}
+
+// isPostgreSQLDMLStatement returns true if the specified statement is a DML statement which is executed without the AST.
+// Queries for the system catalogs are handled by the system query executor.
+func isPostgreSQLDMLStatement(stmt string) bool {
+	if dialect.HasSchemaName(stmt, system.SystemSchemaNames...) {
+		return false
+	}
+	switch dialect.LeadingKeyword(stmt) {
+	case "SELECT", "INSERT", "UPDATE", "DELETE", "WITH", "VALUES":
+		return true
+	}
+	return false
+}
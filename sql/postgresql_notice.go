@@ -0,0 +1,132 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// PostgreSQL: Documentation: 16: 20.8. Error Reporting and Logging (client_min_messages)
+// https://www.postgresql.org/docs/16/runtime-config-client.html#GUC-CLIENT-MIN-MESSAGES
+// PostgreSQL: Documentation: 16: 55.8. Error and Notice Message Fields
+// https://www.postgresql.org/docs/16/protocol-error-fields.html
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cybergarage/go-postgresql/postgresql/protocol"
+	"github.com/cybergarage/go-sqlparser/sql/net"
+	sql "github.com/cybergarage/go-sqlparser/sql/query/response/resultset"
+)
+
+const (
+	postgresqlClientMinMessages        = "client_min_messages"
+	postgresqlDefaultClientMinMessages = "NOTICE"
+	postgresqlWarningSeverity          = "WARNING"
+	postgresqlNoticeSeverity           = "NOTICE"
+	postgresqlWarningCode              = "01000"
+	postgresqlNoticeCode               = "00000"
+	postgresqlInvalidParameterCode     = "22023"
+)
+
+// postgresqlMessageLevels maps the message severity levels of client_min_messages into the ordered levels.
+var postgresqlMessageLevels = map[string]int{
+	"DEBUG5":  0,
+	"DEBUG4":  1,
+	"DEBUG3":  2,
+	"DEBUG2":  3,
+	"DEBUG1":  4,
+	"DEBUG":   3,
+	"LOG":     5,
+	"NOTICE":  6,
+	"WARNING": 7,
+	"ERROR":   8,
+}
+
+// newPostgreSQLNoticeResponsesFrom returns the notice responses of the specified warnings which are at least
+// the specified client_min_messages level. The MySQL warning level is sent as the WARNING severity,
+// and the MySQL note level is sent as the NOTICE severity.
+func newPostgreSQLNoticeResponsesFrom(warnings []*Warning, minMessages string) (protocol.Responses, error) {
+	res := protocol.NewResponses()
+	minLevel, ok := postgresqlMessageLevels[minMessages]
+	if !ok {
+		minLevel = postgresqlMessageLevels[postgresqlDefaultClientMinMessages]
+	}
+	for _, warning := range warnings {
+		severity, code := postgresqlWarningSeverity, postgresqlWarningCode
+		if warning.Level == WarningLevelNote {
+			severity, code = postgresqlNoticeSeverity, postgresqlNoticeCode
+		}
+		if postgresqlMessageLevels[severity] < minLevel {
+			continue
+		}
+		notice := protocol.NewErrorResponse()
+		notice.SetType(protocol.NoticeResponseMessage)
+		fields := []struct {
+			t protocol.ErrorType
+			v string
+		}{
+			{protocol.SeverityError, severity},
+			{protocol.CodeError, code},
+			{protocol.MessageError, warning.Message},
+		}
+		for _, field := range fields {
+			if err := notice.AppendField(field.t, field.v); err != nil {
+				return nil, err
+			}
+		}
+		res = res.Append(notice)
+	}
+	return res, nil
+}
+
+// postgresqlNoticesOf returns the notice responses of the warnings of the specified result set
+// by the client_min_messages level of the specified connection.
+func (server *server) postgresqlNoticesOf(conn net.Conn, rs sql.ResultSet) (protocol.Responses, error) {
+	warnings := warningsOf(rs)
+	if len(warnings) == 0 {
+		return protocol.NewResponses(), nil
+	}
+	minMessages := postgresqlDefaultClientMinMessages
+	if s, ok := server.sessions.LookupSession(conn); ok {
+		minMessages = s.clientMinMessages
+	}
+	return newPostgreSQLNoticeResponsesFrom(warnings, minMessages)
+}
+
+// setClientMinMessages sets the client_min_messages level of the specified connection, and the default level is
+// restored if the value is empty. The invalid levels are reported with the invalid_parameter_value SQLSTATE.
+func (handler *postgresqlMessageHandler) setClientMinMessages(conn protocol.Conn, value string, tag string) (protocol.Responses, error) {
+	level := strings.ToUpper(value)
+	if level == "" {
+		level = postgresqlDefaultClientMinMessages
+	}
+	if _, ok := postgresqlMessageLevels[level]; !ok {
+		errRes := protocol.NewErrorResponse()
+		fields := []struct {
+			t protocol.ErrorType
+			v string
+		}{
+			{protocol.SeverityError, "ERROR"},
+			{protocol.CodeError, postgresqlInvalidParameterCode},
+			{protocol.MessageError, fmt.Sprintf("invalid value for parameter \"%s\": \"%s\"", postgresqlClientMinMessages, value)},
+		}
+		for _, field := range fields {
+			if err := errRes.AppendField(field.t, field.v); err != nil {
+				return nil, err
+			}
+		}
+		return protocol.NewResponsesWith(errRes), nil
+	}
+	handler.server.sessionOf(conn).clientMinMessages = level
+	return protocol.NewCommandCompleteResponsesWith(tag)
+}
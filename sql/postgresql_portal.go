@@ -0,0 +1,444 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// PostgreSQL: Documentation: 16: 55.2.3. Extended Query
+// https://www.postgresql.org/docs/16/protocol-flow.html#PROTOCOL-FLOW-EXT-QUERY
+// PostgreSQL: Documentation: 16: DECLARE
+// https://www.postgresql.org/docs/16/sql-declare.html
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cybergarage/go-postgresql/postgresql/protocol"
+	"github.com/cybergarage/go-sqlparser/sql/net"
+	sql "github.com/cybergarage/go-sqlparser/sql/query/response/resultset"
+	"github.com/cybergarage/go-sqlserver/sql/dialect"
+)
+
+// postgresqlFetchAll is the row count which fetches all rows as the zero row limit of Execute does.
+const postgresqlFetchAll = 0
+
+// postgresqlPortal represents an executed portal or a declared cursor whose result set is fetched incrementally.
+// The cursors WITH HOLD are kept after the transaction is committed, and they are committed if they are declared
+// outside of the transactions.
+type postgresqlPortal struct {
+	rs        sql.ResultSet
+	rowDesc   *protocol.RowDescription
+	keyword   string
+	hold      bool
+	committed bool
+}
+
+// newPostgreSQLPortalWith returns a new portal of the specified result set.
+func newPostgreSQLPortalWith(db *Database, rs sql.ResultSet) (*postgresqlPortal, error) {
+	rowDesc, err := newPostgreSQLRowDescriptionFrom(db, rs)
+	if err != nil {
+		return nil, err
+	}
+	return &postgresqlPortal{
+		rs:        rs,
+		rowDesc:   rowDesc,
+		keyword:   "",
+		hold:      false,
+		committed: false,
+	}, nil
+}
+
+// fetch sends the data rows of the result set up to the specified row count, and returns the number of the sent rows
+// and true if the row count is reached before the end of the result set. All rows are sent if the count is postgresqlFetchAll.
+func (portal *postgresqlPortal) fetch(conn protocol.Conn, count int) (int, bool, error) {
+	nRows := 0
+	for count == postgresqlFetchAll || nRows < count {
+		if !portal.rs.Next() {
+			// The rows which fail while stepping are reported by ErrorResponse instead of CommandComplete.
+			return nRows, false, resultSetErrOf(portal.rs)
+		}
+		dataRow, err := newPostgreSQLDataRowFrom(portal.rowDesc, portal.rs)
+		if err != nil {
+			return nRows, false, err
+		}
+		if err := conn.ResponseMessage(dataRow); err != nil {
+			return nRows, false, err
+		}
+		nRows++
+	}
+	return nRows, true, nil
+}
+
+// move skips the rows of the result set up to the specified row count without sending them, and returns the number
+// of the skipped rows. All rows are skipped if the count is postgresqlFetchAll.
+func (portal *postgresqlPortal) move(count int) (int, error) {
+	nRows := 0
+	for (count == postgresqlFetchAll || nRows < count) && portal.rs.Next() {
+		nRows++
+	}
+	return nRows, resultSetErrOf(portal.rs)
+}
+
+// completeResponses returns the command complete responses of the specified number of the fetched rows,
+// and the statements with RETURNING return the command tags of the modifications such as INSERT 0 1.
+func (portal *postgresqlPortal) completeResponses(nRows int) (protocol.Responses, error) {
+	switch portal.keyword {
+	case "INSERT":
+		return protocol.NewInsertCompleteResponsesWith(nRows)
+	case "UPDATE":
+		return protocol.NewUpdateCompleteResponsesWith(nRows)
+	case "DELETE":
+		return protocol.NewDeleteCompleteResponsesWith(nRows)
+	}
+	return protocol.NewSelectCompleteResponsesWith(nRows)
+}
+
+// setResultFormats sets the result format codes of the columns which are requested by Bind.
+func (portal *postgresqlPortal) setResultFormats(formats []int16) {
+	for n := range portal.rs.Schema().Columns() {
+		portal.rowDesc.Field(n).FormatCode = postgresqlFormatCodeAt(formats, n)
+	}
+}
+
+// Close closes the result set of the portal.
+func (portal *postgresqlPortal) Close() error {
+	return portal.rs.Close()
+}
+
+// postgresqlConnPortals represents the portals of a connection which are keyed by the portal names.
+type postgresqlConnPortals map[string]*postgresqlPortal
+
+// postgresqlPortals represents the portals of the connections which are keyed by the connection UUIDs.
+type postgresqlPortals struct {
+	conns sync.Map
+}
+
+// newPostgreSQLPortals returns a new portal map.
+func newPostgreSQLPortals() *postgresqlPortals {
+	return &postgresqlPortals{
+		conns: sync.Map{},
+	}
+}
+
+// connPortals returns the portals of the specified connection.
+func (portals *postgresqlPortals) connPortals(conn protocol.Conn) postgresqlConnPortals {
+	connPortals, _ := portals.conns.LoadOrStore(conn.UUID(), postgresqlConnPortals{})
+	return connPortals.(postgresqlConnPortals) // nolint: forcetypeassert
+}
+
+// Portal returns the portal of the specified name.
+func (portals *postgresqlPortals) Portal(conn protocol.Conn, name string) (*postgresqlPortal, bool) {
+	portal, ok := portals.connPortals(conn)[name]
+	return portal, ok
+}
+
+// SetPortal sets the specified portal, and closes the previous portal of the same name.
+func (portals *postgresqlPortals) SetPortal(conn protocol.Conn, name string, portal *postgresqlPortal) {
+	portals.ClosePortal(conn, name)
+	portals.connPortals(conn)[name] = portal
+}
+
+// ClosePortal closes the portal of the specified name, and returns false if the portal does not exist.
+func (portals *postgresqlPortals) ClosePortal(conn protocol.Conn, name string) bool {
+	connPortals := portals.connPortals(conn)
+	portal, ok := connPortals[name]
+	if !ok {
+		return false
+	}
+	portal.Close()
+	delete(connPortals, name)
+	return true
+}
+
+// CloseAll closes all portals of the specified connection.
+func (portals *postgresqlPortals) CloseAll(conn protocol.Conn) {
+	connPortals, ok := portals.conns.LoadAndDelete(conn.UUID())
+	if !ok {
+		return
+	}
+	for _, portal := range connPortals.(postgresqlConnPortals) { // nolint: forcetypeassert
+		portal.Close()
+	}
+}
+
+// Commit closes the portals of the committed transaction, and keeps the cursors WITH HOLD.
+func (portals *postgresqlPortals) Commit(conn protocol.Conn) {
+	connPortals := portals.connPortals(conn)
+	for name, portal := range connPortals {
+		if portal.hold {
+			portal.committed = true
+			continue
+		}
+		portal.Close()
+		delete(connPortals, name)
+	}
+}
+
+// Rollback closes the portals of the rolled back transaction, and keeps the cursors WITH HOLD of the committed transactions.
+func (portals *postgresqlPortals) Rollback(conn protocol.Conn) {
+	connPortals := portals.connPortals(conn)
+	for name, portal := range connPortals {
+		if portal.hold && portal.committed {
+			continue
+		}
+		portal.Close()
+		delete(connPortals, name)
+	}
+}
+
+// CloseDisconnected closes the portals of the connections which are not in the specified active connections.
+func (portals *postgresqlPortals) CloseDisconnected(conns []net.Conn) {
+	active := map[any]bool{}
+	for _, conn := range conns {
+		active[conn.UUID()] = true
+	}
+	portals.conns.Range(func(key, value any) bool {
+		if active[key] {
+			return true
+		}
+		portals.conns.Delete(key)
+		for _, portal := range value.(postgresqlConnPortals) { // nolint: forcetypeassert
+			portal.Close()
+		}
+		return true
+	})
+}
+
+// postgresqlConnManager represents a PostgreSQL server which returns the active connections.
+type postgresqlConnManager interface {
+	Conns() []net.Conn
+}
+
+// postgresqlPreparedPortalHandler represents a PostgreSQL server which returns the bound queries of the portals.
+type postgresqlPreparedPortalHandler interface {
+	PreparedPortal(conn protocol.Conn, name string) (*protocol.Query, error)
+}
+
+// Parse handles a parse message, and installs the Bind reader of the connection before the statement is bound.
+// The unsupported statements are reported as they are instead of the parser errors.
+func (handler *postgresqlMessageHandler) Parse(conn protocol.Conn, msg *protocol.Parse) (protocol.Responses, error) {
+	postgresqlBindReaderOf(conn)
+	if _, err := dialect.NewPostgreSQLRewriter().RewriteStatements(msg.Query); errors.Is(err, dialect.ErrNotSupported) {
+		return newPostgreSQLErrorResponsesFrom(err)
+	}
+	return handler.MessageHandler.Parse(conn, msg)
+}
+
+// Bind handles a bind message, and closes the executed portal of the same name.
+func (handler *postgresqlMessageHandler) Bind(conn protocol.Conn, msg *protocol.Bind) (protocol.Responses, error) {
+	postgresqlBindReaderOf(conn)
+	handler.portals.ClosePortal(conn, msg.PortalName)
+	return handler.MessageHandler.Bind(conn, msg)
+}
+
+// Execute handles an execute message. The DML statements of the portals are executed with the rewritten statements
+// and the bound parameters, and the data rows are sent up to the row limit of the message. The portal is suspended if the limit is reached,
+// and the next Execute message of the portal resumes fetching the rest rows.
+func (handler *postgresqlMessageHandler) Execute(conn protocol.Conn, msg *protocol.Execute) (protocol.Responses, error) {
+	portal, ok := handler.portals.Portal(conn, msg.PortalName)
+	if !ok {
+		portalHandler, ok := handler.MessageHandler.(postgresqlPreparedPortalHandler)
+		if !ok {
+			return handler.MessageHandler.Execute(conn, msg)
+		}
+		q, err := portalHandler.PreparedPortal(conn, msg.PortalName)
+		if err != nil {
+			return nil, err
+		}
+		stmt, ok := postgresqlPortalQueryOf(q)
+		if !ok {
+			return handler.Query(conn, q)
+		}
+		args, err := handler.portalArguments(conn, msg.PortalName, q, stmt)
+		if err != nil {
+			return newPostgreSQLErrorResponsesFrom(err)
+		}
+		if !dialect.IsQuery(stmt) {
+			res, err := handler.executeStatement(conn, stmt, args...)
+			if err != nil {
+				return newPostgreSQLErrorResponsesFrom(err)
+			}
+			return res, nil
+		}
+		portal, err = handler.openPortal(conn, stmt, args...)
+		if err != nil {
+			return newPostgreSQLErrorResponsesFrom(err)
+		}
+		if formats := postgresqlResultFormatsOf(conn, msg.PortalName); formats != nil {
+			portal.setResultFormats(formats)
+		}
+		handler.portals.SetPortal(conn, msg.PortalName, portal)
+		// The row description is sent with the first Execute because Describe of the portals returns NoData.
+		if err := conn.ResponseMessage(portal.rowDesc); err != nil {
+			return nil, err
+		}
+	}
+	nRows, suspended, err := portal.fetch(conn, int(msg.MaxRows))
+	if err != nil {
+		handler.portals.ClosePortal(conn, msg.PortalName)
+		return newPostgreSQLErrorResponsesFrom(err)
+	}
+	if suspended {
+		return protocol.NewResponsesWith(protocol.NewResponseMessageWith(protocol.PortalSuspendedMessage)), nil
+	}
+	// The completed portal is kept until it is closed, and the next Execute message returns no rows.
+	portal.Close()
+	return portal.completeResponses(nRows)
+}
+
+// Close handles a close message, and closes the executed portal.
+func (handler *postgresqlMessageHandler) Close(conn protocol.Conn, msg *protocol.Close) (protocol.Responses, error) {
+	if msg.Type == protocol.PreparedPortal {
+		handler.portals.ClosePortal(conn, msg.Name)
+	}
+	return handler.MessageHandler.Close(conn, msg)
+}
+
+// Sync handles a sync message. The portals are closed at the end of the implicit transaction,
+// and they are kept until COMMIT or ROLLBACK in the transaction.
+func (handler *postgresqlMessageHandler) Sync(conn protocol.Conn, msg *protocol.Sync) (protocol.Responses, error) {
+	db, err := handler.server.databaseOf(conn)
+	if err != nil || !db.InTransaction() {
+		handler.portals.Commit(conn)
+	}
+	return handler.MessageHandler.Sync(conn, msg)
+}
+
+// openPortal executes the specified SQLite query with the bind arguments, and returns the portal of the result set.
+func (handler *postgresqlMessageHandler) openPortal(conn protocol.Conn, stmt string, args ...any) (*postgresqlPortal, error) {
+	return handler.openPortalWith(conn, stmt, false, args...)
+}
+
+// openPortalWith executes the specified SQLite query with the bind arguments, and returns the portal of the result set. The query of the holdable portal
+// is executed outside of the transaction to be fetched after the transaction is committed. The portals of the disconnected
+// connections are closed before the portal is opened because go-postgresql has no disconnection hook.
+func (handler *postgresqlMessageHandler) openPortalWith(conn protocol.Conn, stmt string, hold bool, args ...any) (*postgresqlPortal, error) {
+	if connManager, ok := handler.server.PostgreSQLServer().(postgresqlConnManager); ok {
+		handler.portals.CloseDisconnected(connManager.Conns())
+	}
+	db, err := handler.server.databaseOf(conn)
+	if err != nil {
+		return nil, err
+	}
+	var rs sql.ResultSet
+	if hold {
+		rs, err = handler.server.executeHoldableQuery(conn, stmt, args...)
+	} else {
+		rs, err = handler.server.executeStatement(conn, stmt, args...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	portal, err := newPostgreSQLPortalWith(db, rs)
+	if err != nil {
+		rs.Close()
+		return nil, err
+	}
+	portal.keyword = dialect.StatementKeyword(stmt)
+	portal.hold = hold
+	portal.committed = !db.InTransaction()
+	return portal, nil
+}
+
+// executeCursorStatement executes the specified DECLARE, FETCH, MOVE or CLOSE statement with the portals of the connection.
+// The cursors share the names with the portals as PostgreSQL does.
+func (handler *postgresqlMessageHandler) executeCursorStatement(conn protocol.Conn, stmt string) (protocol.Responses, error) {
+	switch dialect.LeadingKeyword(stmt) {
+	case "DECLARE":
+		cursor, err := dialect.DeclaredCursor(stmt)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := handler.portals.Portal(conn, cursor.Name); ok {
+			return nil, newErrCursorExist(cursor.Name)
+		}
+		db, err := handler.server.databaseOf(conn)
+		if err != nil {
+			return nil, err
+		}
+		if !cursor.Hold && !db.InTransaction() {
+			// The cursors without WITH HOLD are closed at the end of the implicit transaction.
+			return nil, newErrCursorNotInTransaction(cursor.Name)
+		}
+		portal, err := handler.openPortalWith(conn, cursor.Query, cursor.Hold)
+		if err != nil {
+			return nil, err
+		}
+		handler.portals.SetPortal(conn, cursor.Name, portal)
+		return protocol.NewCommandCompleteResponsesWith("DECLARE CURSOR")
+	case "FETCH":
+		name, count, err := dialect.FetchedCursor(stmt)
+		if err != nil {
+			return nil, err
+		}
+		portal, ok := handler.portals.Portal(conn, name)
+		if !ok {
+			return nil, newErrCursorNotExist(name)
+		}
+		// Every FETCH returns the row description as PostgreSQL does.
+		if err := conn.ResponseMessage(portal.rowDesc); err != nil {
+			return nil, err
+		}
+		nRows, _, err := portal.fetch(conn, count)
+		if err != nil {
+			return nil, err
+		}
+		return protocol.NewCommandCompleteResponsesWith(fmt.Sprintf("FETCH %d", nRows))
+	case "MOVE":
+		name, count, err := dialect.FetchedCursor(stmt)
+		if err != nil {
+			return nil, err
+		}
+		portal, ok := handler.portals.Portal(conn, name)
+		if !ok {
+			return nil, newErrCursorNotExist(name)
+		}
+		nRows, err := portal.move(count)
+		if err != nil {
+			return nil, err
+		}
+		return protocol.NewCommandCompleteResponsesWith(fmt.Sprintf("MOVE %d", nRows))
+	case "CLOSE":
+		name, err := dialect.ClosedCursor(stmt)
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			handler.portals.CloseAll(conn)
+		} else if !handler.portals.ClosePortal(conn, name) {
+			return nil, newErrCursorNotExist(name)
+		}
+		return protocol.NewCommandCompleteResponsesWith("CLOSE CURSOR")
+	}
+	return nil, newErrQueryNotSupported(stmt)
+}
+
+// postgresqlPortalQueryOf returns the rewritten SQLite statement of the specified portal if the statement is executed
+// with the bound parameters of the portal. The other statements are executed by the go-postgresql server.
+func postgresqlPortalQueryOf(q *protocol.Query) (string, bool) {
+	stmts, err := dialect.NewPostgreSQLRewriter().RewriteStatements(q.Query)
+	if err != nil || len(stmts) != 1 || !isPostgreSQLDMLStatement(stmts[0]) {
+		return "", false
+	}
+	return stmts[0], true
+}
+
+// isPostgreSQLCursorStatement returns true if the specified statement is DECLARE, FETCH, MOVE or CLOSE.
+func isPostgreSQLCursorStatement(stmt string) bool {
+	switch dialect.LeadingKeyword(stmt) {
+	case "DECLARE", "FETCH", "MOVE", "CLOSE":
+		return true
+	}
+	return false
+}
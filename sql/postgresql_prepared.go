@@ -0,0 +1,194 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// PostgreSQL: Documentation: 16: 55.2.3. Extended Query
+// https://www.postgresql.org/docs/16/protocol-flow.html#PROTOCOL-FLOW-EXT-QUERY
+// PostgreSQL: Documentation: 16: PREPARE
+// https://www.postgresql.org/docs/16/sql-prepare.html
+
+import (
+	"github.com/cybergarage/go-postgresql/postgresql/protocol"
+	"github.com/cybergarage/go-postgresql/postgresql/query"
+	pgstmt "github.com/cybergarage/go-postgresql/postgresql/stmt"
+	"github.com/cybergarage/go-postgresql/postgresql/system"
+	"github.com/cybergarage/go-sqlserver/sql/dialect"
+)
+
+// postgresqlPreparedStatementHandler represents a PostgreSQL server which returns the parsed prepared statements.
+type postgresqlPreparedStatementHandler interface {
+	PreparedStatement(conn protocol.Conn, name string) (*pgstmt.PreparedStatement, error)
+}
+
+// Describe handles a describe message. The prepared DML statements are described with the parameter types specified by Parse,
+// or the types inferred from the columns which the parameters are assigned to or compared with.
+func (handler *postgresqlMessageHandler) Describe(conn protocol.Conn, msg *protocol.Describe) (protocol.Responses, error) {
+	if msg.Type != protocol.PreparedStatement {
+		return handler.MessageHandler.Describe(conn, msg)
+	}
+	prepStmt, stmt, ok := handler.preparedStatement(conn, msg.Name)
+	if !ok {
+		return handler.MessageHandler.Describe(conn, msg)
+	}
+	oids, err := handler.parameterObjectIDs(conn, stmt, prepStmt.DataTypes)
+	if err != nil {
+		return newPostgreSQLErrorResponsesFrom(err)
+	}
+	paramDesc, err := protocol.NewParameterDescriptionWith(oids...)
+	if err != nil {
+		return nil, err
+	}
+	rowDesc, ok := handler.describeRows(conn, stmt, len(oids))
+	if !ok {
+		return protocol.NewResponsesWith(paramDesc, protocol.NewNoData()), nil
+	}
+	return protocol.NewResponsesWith(paramDesc, rowDesc), nil
+}
+
+// describeRows returns the row description of the specified SQLite query which is executed with the NULL parameters,
+// because clients such as pgx choose the result formats and the COPY types by the described columns. It returns false
+// if the statement may change the rows such as INSERT with RETURNING or the query fails, and the row description
+// is sent with the first Execute as the portals.
+func (handler *postgresqlMessageHandler) describeRows(conn protocol.Conn, stmt string, nParams int) (*protocol.RowDescription, bool) {
+	switch dialect.StatementKeyword(stmt) {
+	case "SELECT", "VALUES":
+	default:
+		return nil, false
+	}
+	db, err := handler.server.databaseOf(conn)
+	if err != nil {
+		return nil, false
+	}
+	rs, err := handler.server.executeStatement(conn, stmt, make([]any, nParams)...)
+	if err != nil {
+		return nil, false
+	}
+	defer rs.Close()
+	rowDesc, err := newPostgreSQLRowDescriptionFrom(db, rs)
+	if err != nil {
+		return nil, false
+	}
+	return rowDesc, true
+}
+
+// preparedStatement returns the prepared statement of the specified name and the rewritten SQLite statement,
+// or false if the prepared statement is not a DML statement which is executed without the AST.
+func (handler *postgresqlMessageHandler) preparedStatement(conn protocol.Conn, name string) (*pgstmt.PreparedStatement, string, bool) {
+	stmtHandler, ok := handler.MessageHandler.(postgresqlPreparedStatementHandler)
+	if !ok {
+		return nil, "", false
+	}
+	prepStmt, err := stmtHandler.PreparedStatement(conn, name)
+	if err != nil {
+		return nil, "", false
+	}
+	stmts, err := dialect.NewPostgreSQLRewriter().RewriteStatements(prepStmt.Query)
+	if err != nil || len(stmts) != 1 || !isPostgreSQLDMLStatement(stmts[0]) {
+		return nil, "", false
+	}
+	return prepStmt, stmts[0], true
+}
+
+// parameterObjectIDs returns the parameter type OIDs of the specified SQLite statement. The non-zero OIDs specified by Parse
+// are used as they are, and the other OIDs are inferred from the statement.
+func (handler *postgresqlMessageHandler) parameterObjectIDs(conn protocol.Conn, stmt string, dataTypes []int32) ([]system.ObjectID, error) {
+	db, err := handler.server.databaseOf(conn)
+	if err != nil {
+		return nil, err
+	}
+	typeNames, err := db.ParameterTypes(stmt)
+	if err != nil {
+		return nil, err
+	}
+	oids := make([]system.ObjectID, len(typeNames))
+	for n, typeName := range typeNames {
+		if n < len(dataTypes) && dataTypes[n] != 0 {
+			oids[n] = dataTypes[n]
+			continue
+		}
+		oids[n] = postgresqlParameterObjectIDOf(db, typeName)
+	}
+	return oids, nil
+}
+
+// postgresqlParameterObjectIDOf returns the PostgreSQL type OID of the specified parameter type name,
+// and the parameters of the unknown types are described as the text type.
+func postgresqlParameterObjectIDOf(db *Database, typeName string) system.ObjectID {
+	if typeName == "" {
+		return system.Text
+	}
+	if _, oid, ok := db.EnumType(typeName); ok {
+		return oid
+	}
+	if oid, ok := postgresqlArrayObjectIDs[typeName]; ok {
+		return oid
+	}
+	if oid, ok := postgresqlObjectIDOf(typeName); ok {
+		return oid
+	}
+	dt, err := newResultSetDataTypeFrom(typeName)
+	if err != nil {
+		return system.Text
+	}
+	oid, err := query.NewObjectIDFrom(dt)
+	if err != nil {
+		return system.Text
+	}
+	return oid
+}
+
+// portalArguments returns the bind arguments of the specified portal of the SQLite statement. The parameters recorded by
+// the Bind reader are preferred because go-postgresql reads the NULL parameters of the text format as the empty texts.
+func (handler *postgresqlMessageHandler) portalArguments(conn protocol.Conn, portalName string, q *protocol.Query, stmt string) ([]any, error) {
+	bind, ok := postgresqlBindOf(conn, portalName)
+	if !ok {
+		// The statement name is unknown, and the parameter types are inferred from the statement.
+		bind = &postgresqlBind{
+			stmtName:      "",
+			paramFormats:  []int16{},
+			params:        [][]byte{},
+			resultFormats: nil,
+		}
+		for _, param := range q.BindParams {
+			switch v := param.Value.(type) {
+			case []byte:
+				bind.params = append(bind.params, v)
+			default:
+				bind.params = append(bind.params, []byte(postgresqlTextValueOf(v)))
+			}
+			bind.paramFormats = append(bind.paramFormats, param.FormatCode)
+		}
+	}
+	var dataTypes []int32
+	if prepStmt, _, found := handler.preparedStatement(conn, bind.stmtName); ok && found {
+		dataTypes = prepStmt.DataTypes
+	}
+	oids, err := handler.parameterObjectIDs(conn, stmt, dataTypes)
+	if err != nil {
+		return nil, err
+	}
+	args := make([]any, len(bind.params))
+	for n, b := range bind.params {
+		oid := system.Text
+		if n < len(oids) {
+			oid = oids[n]
+		}
+		args[n], err = newPostgreSQLParameterValueFrom(oid, postgresqlFormatCodeAt(bind.paramFormats, n), b)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return args, nil
+}
@@ -21,6 +21,8 @@ import (
 	"time"
 
 	"github.com/cybergarage/go-logger/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
@@ -29,6 +31,28 @@ const (
 	DefaultPrometheusConnectionTimeout time.Duration = time.Second * 60
 )
 
+const (
+	prometheusNamespace = "sqlserver"
+)
+
+var (
+	statementCacheHits = promauto.NewCounter(prometheus.CounterOpts{ // nolint:exhaustruct
+		Namespace: prometheusNamespace,
+		Name:      "statement_cache_hits_total",
+		Help:      "The number of the statements which are executed with the cached prepared statements.",
+	})
+	statementCacheMisses = promauto.NewCounter(prometheus.CounterOpts{ // nolint:exhaustruct
+		Namespace: prometheusNamespace,
+		Name:      "statement_cache_misses_total",
+		Help:      "The number of the statements which are not found in the statement caches.",
+	})
+	statementCacheEvictions = promauto.NewCounter(prometheus.CounterOpts{ // nolint:exhaustruct
+		Namespace: prometheusNamespace,
+		Name:      "statement_cache_evictions_total",
+		Help:      "The number of the prepared statements which are evicted from the statement caches by the size limit or the schema changes.",
+	})
+)
+
 // PrometheusExporter is a prometheus exporter service.
 type PrometheusExporter struct {
 	httpServer *http.Server
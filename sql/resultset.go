@@ -17,30 +17,134 @@ package sql
 import (
 	dbsql "database/sql"
 	"errors"
+	"math"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	query "github.com/cybergarage/go-sqlparser/sql/query"
 	sql "github.com/cybergarage/go-sqlparser/sql/query/response/resultset"
+	"github.com/cybergarage/go-sqlserver/sql/dialect"
 	_ "github.com/ncruces/go-sqlite3/driver"
 	_ "github.com/ncruces/go-sqlite3/embed"
 )
 
+// columnTypeNamer represents a result set which returns the declared column type names.
+type columnTypeNamer interface {
+	// ColumnTypeName returns the upper case SQLite declared type name of the specified column.
+	ColumnTypeName(n int) string
+}
+
+// columnOriginer represents a result set which returns the base table columns of the result columns.
+type columnOriginer interface {
+	// ColumnOrigin returns the base table column of the specified column, or nil if the column is not a base table column.
+	ColumnOrigin(n int) *ColumnOrigin
+}
+
+// lastInsertIDer represents a result set which returns the last insert ID of INSERT.
+type lastInsertIDer interface {
+	// LastInsertID returns the row ID of the first inserted row of INSERT as MySQL returns, or zero if no rows are inserted.
+	LastInsertID() int64
+}
+
+// upsertedRowser represents a result set which returns the existing rows updated by the upsert clause of INSERT.
+type upsertedRowser interface {
+	// UpdatedRows returns the number of the existing rows which are updated by the upsert clause.
+	UpdatedRows() uint
+}
+
+// rowChangeReporter represents a statement result which has the row changes of the target table of INSERT.
+type rowChangeReporter interface {
+	// FirstInsertedRowID returns the row ID of the first inserted row, or zero if no rows are inserted.
+	FirstInsertedRowID() int64
+	// UpdatedRows returns the number of the existing rows which are updated by the upsert clause.
+	UpdatedRows() int64
+}
+
+// warningReporter represents a result set which returns the warnings of the executed statement.
+type warningReporter interface {
+	// Warnings returns the warnings of the executed statement.
+	Warnings() []*Warning
+}
+
+// errReporter represents a result set which returns the error of the rows which terminates Next.
+type errReporter interface {
+	// Err returns the error which is encountered during the iteration.
+	Err() error
+}
+
+// resultSetErrOf returns the error which terminates the iteration of the specified result set, or nil if the rows are read through.
+func resultSetErrOf(rs sql.ResultSet) error {
+	reporter, ok := rs.(errReporter)
+	if !ok {
+		return nil
+	}
+	return reporter.Err()
+}
+
+// isJSONTypeName returns true if the specified declared type name is JSON or JSONB.
+func isJSONTypeName(typeName string) bool {
+	return typeName == "JSON" || typeName == "JSONB"
+}
+
 // ResultSetOption is a result set option.
 type ResultSetOption func(*resultset) error
 
 type resultset struct {
-	rows         *dbsql.Rows
-	schema       sql.Schema
-	rowsAffected uint
+	rows            *dbsql.Rows
+	origins         []*ColumnOrigin
+	expressionTypes []string
+	schema          sql.Schema
+	columnIdxes     []int
+	columnTypeNames []string
+	dynamicColumns  []bool
+	nRowColumns     int
+	peekedRow       []any
+	currentRow      []any
+	rowsAffected    uint
+	lastInsertID    int64
+	updatedRows     uint
+	warnings        []*Warning
+	err             error
+	release         func()
 }
 
 // NewResultSetDataTypeFrom creates a new result set data type from a column type.
 func NewResultSetDataTypeFrom(ct *dbsql.ColumnType) (sql.DataType, error) {
+	return newResultSetDataTypeFrom(strings.ToUpper(ct.DatabaseTypeName()))
+}
+
+// newResultSetDataTypeFrom creates a new result set data type from an upper case declared or logical type name.
+func newResultSetDataTypeFrom(s string) (sql.DataType, error) {
 	// Datatypes In SQLite
 	// https://sqlite.org/datatype3.html
-	s := strings.ToUpper(ct.DatabaseTypeName())
+	switch s {
+	case dialect.BooleanType:
+		return query.BooleanType, nil
+	case dialect.DateType:
+		return query.DateType, nil
+	case dialect.TimeType:
+		return query.TimeType, nil
+	case dialect.TimestampTZType:
+		return query.TimeStampType, nil
+	case dialect.UUIDType:
+		// UUIDs are returned as the texts, and the PostgreSQL handler returns them as the uuid type.
+		return query.TextType, nil
+	}
+	if integerType, ok := dialect.IntegerTypeOf(s); ok {
+		return integerDataTypes[integerType], nil
+	}
 	switch {
+	case dialect.IsArrayTypeName(s):
+		// Arrays are stored as the array literals, and the PostgreSQL handler returns them as the array types.
+		return query.TextType, nil
+	case dialect.IsEnumTypeName(s):
+		// Enum values are stored as the label texts, and the protocol handlers return them as the enum types.
+		return query.TextType, nil
+	case isDecimalTypeName(s):
+		// Decimals are stored as the texts not to lose the precision.
+		return query.DecimalType, nil
 	case (0 <= strings.Index(s, "INT")):
 		return query.IntegerType, nil
 	case strings.HasPrefix(s, "REAL"):
@@ -51,25 +155,48 @@ func NewResultSetDataTypeFrom(ct *dbsql.ColumnType) (sql.DataType, error) {
 		return query.DoubleType, nil
 	case strings.HasPrefix(s, "TEXT"):
 		return query.TextType, nil
-	case (0 <= strings.Index(s, "VARCHAR")):
+	case (0 <= strings.Index(s, "CHAR")):
+		// The character types such as VARCHAR and CHARACTER VARYING have the TEXT affinity.
 		return query.TextType, nil
 	case strings.HasPrefix(s, "BLOB"):
 		return query.BlobType, nil
 	case strings.HasPrefix(s, "BINARY"):
 		return query.BlobType, nil
-	case strings.HasPrefix(s, "NUMERIC"):
-		return query.RealType, nil
+	case strings.HasPrefix(s, "BYTEA"):
+		return query.BlobType, nil
 	case strings.HasPrefix(s, "TIMESTAMP"):
 		return query.TimeStampType, nil
 	case strings.HasPrefix(s, "DATETIME"):
 		return query.DateTimeType, nil
+	case strings.HasPrefix(s, "JSON"):
+		// JSON values are stored as texts, and the protocol handlers return them as the JSON types.
+		return query.TextType, nil
 	}
 	return 0, errors.New("unsupported data type")
 }
 
+// integerDataTypes maps the integer types into the result set data types, and the unsigned integers have the same data types.
+var integerDataTypes = map[string]sql.DataType{
+	"TINYINT":                  query.TinyIntType,
+	"TINYINT UNSIGNED":         query.TinyIntType,
+	"SMALLINT":                 query.SmallIntType,
+	"SMALLINT UNSIGNED":        query.SmallIntType,
+	"MEDIUMINT":                query.MediumIntType,
+	"MEDIUMINT UNSIGNED":       query.MediumIntType,
+	"INT":                      query.IntegerType,
+	"INT UNSIGNED":             query.IntegerType,
+	"BIGINT":                   query.BigIntType,
+	dialect.UnsignedBigIntType: query.BigIntType,
+}
+
 // NewResultSetColumn creates a new result set column from a column name and type.
 func NewResultSetColumnFrom(name string, ct *dbsql.ColumnType) (sql.Column, error) {
-	dt, err := NewResultSetDataTypeFrom(ct)
+	return newResultSetColumnFrom(name, strings.ToUpper(ct.DatabaseTypeName()))
+}
+
+// newResultSetColumnFrom creates a new result set column from a column name and an upper case declared or logical type name.
+func newResultSetColumnFrom(name string, typeName string) (sql.Column, error) {
+	dt, err := newResultSetDataTypeFrom(typeName)
 	if err != nil {
 		return nil, err
 	}
@@ -98,12 +225,50 @@ func WithResultSetRows(rows *dbsql.Rows) ResultSetOption {
 			return errors.New("column name and type length mismatch")
 		}
 		rsColums := []sql.Column{}
+		rs.columnIdxes = []int{}
+		rs.columnTypeNames = []string{}
+		rs.dynamicColumns = []bool{}
+		rs.nRowColumns = len(rowColumnNames)
 		for i, name := range rowColumnNames {
-			rsColumn, err := NewResultSetColumnFrom(name, rowColumnTypes[i])
+			// Skip the helper columns which are added by the dialect rewriter.
+			if dialect.IsHiddenColumn(name) {
+				continue
+			}
+			typeName := strings.ToUpper(rowColumnTypes[i].DatabaseTypeName())
+			if typeName == "" {
+				// The expression columns are typed by the inferred types of the expressions at first.
+				typeName = rs.expressionTypeOf(i)
+			}
+			if origin := rs.originOf(i); origin != nil && origin.DeclType != "" {
+				// The base table columns are typed by the declared types because the strict table columns have the STRICT datatypes.
+				typeName = origin.DeclType
+			}
+			if origin := rs.originOf(i); origin != nil && origin.LogicalType != "" {
+				typeName = origin.LogicalType
+			} else if logicalType, ok := dialect.LogicalTypeOf(typeName); ok {
+				typeName = logicalType
+			} else if integerType, ok := dialect.IntegerTypeOf(typeName); ok && dialect.IsUnsignedTypeName(integerType) {
+				// The internal unsigned type names are returned as the declared type names such as BIGINT UNSIGNED.
+				typeName = integerType
+			}
+			dynamic := false
+			if _, typeErr := newResultSetDataTypeFrom(typeName); typeName == "" || typeErr != nil {
+				// The expression columns such as MAX(x) and the columns of the unknown declared types such as VARBINARY and YEAR
+				// are typed by the first row values.
+				typeName, err = rs.peekColumnTypeName(i)
+				if err != nil {
+					return err
+				}
+				dynamic = true
+			}
+			rsColumn, err := newResultSetColumnFrom(name, typeName)
 			if err != nil {
 				return err
 			}
 			rsColums = append(rsColums, rsColumn)
+			rs.columnIdxes = append(rs.columnIdxes, i)
+			rs.columnTypeNames = append(rs.columnTypeNames, typeName)
+			rs.dynamicColumns = append(rs.dynamicColumns, dynamic)
 		}
 		rs.schema = sql.NewSchema(
 			sql.WithSchemaColumns(rsColums),
@@ -112,6 +277,35 @@ func WithResultSetRows(rows *dbsql.Rows) ResultSetOption {
 	}
 }
 
+// WithResultSetColumnOrigins sets the base table columns of the result columns which are returned by Database.ColumnOrigins.
+// The logical types of the base table columns are used as the column types, and the option should precede WithResultSetRows.
+func WithResultSetColumnOrigins(origins []*ColumnOrigin) ResultSetOption {
+	return func(rs *resultset) error {
+		rs.origins = origins
+		return nil
+	}
+}
+
+// WithResultSetExpressionTypes sets the inferred types of the result columns which are returned by dialect.ExpressionTypes.
+// The types are used for the columns which have no declared types, and the option should precede WithResultSetRows.
+func WithResultSetExpressionTypes(types []string) ResultSetOption {
+	return func(rs *resultset) error {
+		rs.expressionTypes = types
+		return nil
+	}
+}
+
+// WithResultSetPeekedRow peeks the first row of the result set rows, so that the errors of the first step such as the constraint
+// errors of INSERT ... RETURNING are returned by NewResultSet. The option should follow WithResultSetRows.
+func WithResultSetPeekedRow() ResultSetOption {
+	return func(rs *resultset) error {
+		if rs.rows == nil {
+			return errors.New("rows is nil")
+		}
+		return rs.peekRow()
+	}
+}
+
 // WithResultSetResult sets the result set result.
 func WithResultSetResult(result dbsql.Result) ResultSetOption {
 	return func(rs *resultset) error {
@@ -120,6 +314,32 @@ func WithResultSetResult(result dbsql.Result) ResultSetOption {
 			return err
 		}
 		rs.rowsAffected = uint(rowsAffected)
+		// The results of the side catalog statements such as CREATE TYPE have no last insert IDs.
+		if lastInsertID, err := result.LastInsertId(); err == nil {
+			rs.lastInsertID = lastInsertID
+		}
+		// The results of INSERT have the first inserted row IDs instead of the last ones.
+		if reporter, ok := result.(rowChangeReporter); ok {
+			rs.lastInsertID = reporter.FirstInsertedRowID()
+			rs.updatedRows = uint(reporter.UpdatedRows())
+		}
+		return nil
+	}
+}
+
+// WithResultSetWarnings returns a result set option to set the warnings of the executed statement.
+func WithResultSetWarnings(warnings []*Warning) ResultSetOption {
+	return func(rs *resultset) error {
+		rs.warnings = warnings
+		return nil
+	}
+}
+
+// WithResultSetReleaser returns a result set option to set the function which is called once when the rows are closed or read through,
+// such as to release the cached statement of the rows.
+func WithResultSetReleaser(release func()) ResultSetOption {
+	return func(rs *resultset) error {
+		rs.release = release
 		return nil
 	}
 }
@@ -127,9 +347,22 @@ func WithResultSetResult(result dbsql.Result) ResultSetOption {
 // NewResultSet creates a new result set.
 func NewResultSet(opts ...ResultSetOption) (sql.ResultSet, error) {
 	rs := &resultset{
-		rows:         nil,
-		schema:       nil,
-		rowsAffected: 0,
+		rows:            nil,
+		origins:         nil,
+		expressionTypes: nil,
+		schema:          nil,
+		columnIdxes:     nil,
+		columnTypeNames: nil,
+		dynamicColumns:  nil,
+		nRowColumns:     0,
+		peekedRow:       nil,
+		currentRow:      nil,
+		rowsAffected:    0,
+		lastInsertID:    0,
+		updatedRows:     0,
+		warnings:        nil,
+		err:             nil,
+		release:         nil,
 	}
 	for _, opt := range opts {
 		err := opt(rs)
@@ -145,15 +378,125 @@ func (rs *resultset) Schema() sql.Schema {
 	return rs.schema
 }
 
+// ColumnTypeName returns the upper case SQLite declared type name of the specified column, or an empty string if not declared.
+func (rs *resultset) ColumnTypeName(n int) string {
+	if n < 0 || len(rs.columnTypeNames) <= n {
+		return ""
+	}
+	return rs.columnTypeNames[n]
+}
+
+// ColumnOrigin returns the base table column of the specified column, or nil if the column is not a base table column.
+func (rs *resultset) ColumnOrigin(n int) *ColumnOrigin {
+	if n < 0 || len(rs.columnIdxes) <= n {
+		return nil
+	}
+	return rs.originOf(rs.columnIdxes[n])
+}
+
+// originOf returns the base table column of the specified row column index.
+func (rs *resultset) originOf(idx int) *ColumnOrigin {
+	if idx < 0 || len(rs.origins) <= idx {
+		return nil
+	}
+	return rs.origins[idx]
+}
+
+// expressionTypeOf returns the inferred type of the specified column expression, or an empty string if the type depends on the values.
+// The columns before the first wildcard and after the last wildcard of the select list are aligned with the expressions
+// because the wildcards are expanded into the unknown numbers of the columns.
+func (rs *resultset) expressionTypeOf(idx int) string {
+	types := rs.expressionTypes
+	first := slices.Index(types, dialect.WildcardExprType)
+	if first < 0 {
+		if len(types) != rs.nRowColumns {
+			return ""
+		}
+		return types[idx]
+	}
+	if idx < first {
+		return types[idx]
+	}
+	last := len(types) - 1
+	for types[last] != dialect.WildcardExprType {
+		last--
+	}
+	if n := len(types) - (rs.nRowColumns - idx); last < n {
+		return types[n]
+	}
+	return ""
+}
+
+// peekColumnTypeName returns the type name of the specified column by the dynamic type of the first row value,
+// and the first row is kept to be returned by Next. The type name is TEXT if the value is NULL or no rows.
+func (rs *resultset) peekColumnTypeName(idx int) (string, error) {
+	if err := rs.peekRow(); err != nil {
+		return "", err
+	}
+	if len(rs.peekedRow) <= idx {
+		return "TEXT", nil
+	}
+	switch rs.peekedRow[idx].(type) {
+	case int64:
+		return "BIGINT", nil
+	case float64:
+		return "DOUBLE", nil
+	case []byte:
+		return "BLOB", nil
+	case time.Time:
+		return "DATETIME", nil
+	}
+	return "TEXT", nil
+}
+
+// peekRow reads the first row which is kept to be returned by Next, and returns the error of the first step.
+func (rs *resultset) peekRow() error {
+	if rs.peekedRow != nil {
+		return nil
+	}
+	row := make([]any, rs.nRowColumns)
+	if rs.rows.Next() {
+		dest := make([]any, rs.nRowColumns)
+		for n := range dest {
+			dest[n] = &row[n]
+		}
+		if err := rs.rows.Scan(dest...); err != nil {
+			return err
+		}
+	} else {
+		if err := rs.rows.Err(); err != nil {
+			return err
+		}
+		row = []any{}
+	}
+	rs.peekedRow = row
+	return nil
+}
+
 // Next returns the next row.
 func (rs *resultset) Next() bool {
+	rs.currentRow = nil
+	if rs.peekedRow != nil {
+		// The peeked first row is returned at first, and the empty row means no rows.
+		row := rs.peekedRow
+		rs.peekedRow = nil
+		if len(row) == 0 {
+			rs.releaseRows()
+			return false
+		}
+		rs.currentRow = row
+		return true
+	}
 	if rs.rows == nil {
 		return false
 	}
-	if rs.rows.Err() != nil {
+	// The rows are closed if they are read through or failed, and the error is returned by Err.
+	if rs.rows.Err() != nil || !rs.rows.Next() {
+		rs.err = rs.rows.Err()
+		rs.releaseRows()
 		return false
 	}
-	return rs.rows.Next()
+	return true
 }
 
 // Row returns the current row.
@@ -164,50 +507,48 @@ func (rs *resultset) Row() (sql.Row, error) {
 	if rs.rows == nil {
 		return nil, errors.New("rows is nil")
 	}
-	dest := make([]any, len(rs.schema.Columns()))
+	dest := make([]any, rs.nRowColumns)
+	for n := range dest {
+		var v any
+		dest[n] = &v
+	}
 	for n, column := range rs.schema.Columns() {
-		dt := column.DataType()
-		switch dt {
-		case query.IntegerType:
-			var v int
-			dest[n] = &v
+		idx := rs.columnIdxes[n]
+		if dialect.IsLogicalTypeName(rs.ColumnTypeName(n)) || rs.dynamicColumns[n] {
+			// The logical and dynamic values are converted from the stored values.
+			continue
+		}
+		if dialect.IsUnsignedTypeName(rs.ColumnTypeName(n)) {
+			// The unsigned integers are scanned as the texts because BIGINT UNSIGNED values are stored as the texts.
+			dest[idx] = &dbsql.NullString{}
+			continue
+		}
+		// The nullable scan targets are used because any column may be NULL.
+		switch column.DataType() {
+		case query.TinyIntType, query.SmallIntType, query.MediumIntType, query.IntegerType, query.BigIntType:
+			dest[idx] = &dbsql.NullInt64{}
 		case query.RealType, query.FloatType, query.DoubleType:
-			var v float64
-			dest[n] = &v
-		case query.TextType:
-			var v string
-			dest[n] = &v
+			dest[idx] = &dbsql.NullFloat64{}
+		case query.TextType, query.DecimalType:
+			dest[idx] = &dbsql.NullString{}
 		case query.BlobType:
+			// NULL is scanned as the nil slice.
 			var v []byte
-			dest[n] = &v
+			dest[idx] = &v
 		case query.TimeStampType, query.DateTimeType:
-			var v time.Time
-			dest[n] = &v
-		default:
-			var v any
-			dest[n] = &v
+			dest[idx] = &dbsql.NullTime{}
 		}
 	}
-	err := rs.rows.Scan(dest...)
-	if err != nil {
+	if err := rs.scan(dest...); err != nil {
 		return nil, err
 	}
 	obj := map[string]any{}
 	for n, column := range rs.schema.Columns() {
-		switch v := dest[n].(type) {
-		case *int:
-			obj[column.Name()] = *v
-		case *float64:
-			obj[column.Name()] = *v
-		case *string:
-			obj[column.Name()] = *v
-		case *[]byte:
-			obj[column.Name()] = *v
-		case *time.Time:
-			obj[column.Name()] = *v
-		default:
-			obj[column.Name()] = v
+		v, err := rs.columnValueOf(n, dest[rs.columnIdxes[n]])
+		if err != nil {
+			return nil, err
 		}
+		obj[column.Name()] = v
 	}
 	return sql.NewRow(
 		sql.WithRowSchema(rs.schema),
@@ -215,15 +556,166 @@ func (rs *resultset) Row() (sql.Row, error) {
 	), nil
 }
 
+// columnValueOf returns the value of the specified column from the scanned destination, and NULL is returned as nil.
+func (rs *resultset) columnValueOf(n int, dest any) (any, error) {
+	switch v := dest.(type) {
+	case *dbsql.NullInt64:
+		if !v.Valid {
+			return nil, nil
+		}
+		if rs.schema.Columns()[n].DataType() == query.BigIntType {
+			return v.Int64, nil
+		}
+		return int(v.Int64), nil
+	case *dbsql.NullFloat64:
+		if !v.Valid {
+			return nil, nil
+		}
+		return v.Float64, nil
+	case *dbsql.NullString:
+		if !v.Valid {
+			return nil, nil
+		}
+		typeName := rs.ColumnTypeName(n)
+		switch {
+		case isDecimalTypeName(typeName):
+			_, scale := decimalTypeOf(typeName)
+			return formatDecimal(v.String, scale), nil
+		case dialect.IsUnsignedTypeName(typeName):
+			return unsignedValueOf(v.String)
+		}
+		return v.String, nil
+	case *[]byte:
+		if *v == nil {
+			return nil, nil
+		}
+		return *v, nil
+	case *dbsql.NullTime:
+		if !v.Valid {
+			return nil, nil
+		}
+		return v.Time, nil
+	case *any:
+		if typeName := rs.ColumnTypeName(n); dialect.IsLogicalTypeName(typeName) {
+			return newLogicalValueFrom(typeName, *v)
+		}
+		if rs.dynamicColumns[n] {
+			return dynamicValueOf(rs.schema.Columns()[n].DataType(), *v), nil
+		}
+		return *v, nil
+	}
+	return dest, nil
+}
+
+// unsignedValueOf returns the unsigned integer of the specified stored text, and the real texts such as 1.5e3
+// which SQLite stores for the other numeric values are converted into the unsigned integers.
+func unsignedValueOf(s string) (uint64, error) {
+	if v, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return v, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f < 0 || math.MaxUint64 <= f {
+		return 0, newErrInvalid(s)
+	}
+	return uint64(f), nil
+}
+
+// scan copies the current row values into the specified destinations, and the peeked row is copied without the rows.
+func (rs *resultset) scan(dest ...any) error {
+	if rs.currentRow == nil {
+		return rs.rows.Scan(dest...)
+	}
+	for n, v := range rs.currentRow {
+		switch d := dest[n].(type) {
+		case dbsql.Scanner:
+			if err := d.Scan(v); err != nil {
+				return err
+			}
+		case *[]byte:
+			switch v := v.(type) {
+			case []byte:
+				*d = v
+			case string:
+				*d = []byte(v)
+			case nil:
+				*d = nil
+			default:
+				return newErrNotSupported(v)
+			}
+		case *any:
+			*d = v
+		}
+	}
+	return nil
+}
+
+// dynamicValueOf returns the specified value of the column which is typed by the first row value,
+// and the values of the other dynamic types are converted into the column type if possible.
+func dynamicValueOf(dt sql.DataType, v any) any {
+	switch v := v.(type) {
+	case int64:
+		switch dt {
+		case query.TextType:
+			return strconv.FormatInt(v, 10)
+		case query.RealType, query.DoubleType:
+			return float64(v)
+		case query.BigIntType:
+			return v
+		}
+		return int(v)
+	case float64:
+		if dt == query.TextType {
+			return strconv.FormatFloat(v, 'g', -1, 64)
+		}
+	case []byte:
+		if dt == query.TextType {
+			return string(v)
+		}
+	}
+	return v
+}
+
 // RowsAffected returns the number of rows affected.
 func (rs *resultset) RowsAffected() uint {
 	return rs.rowsAffected
 }
 
+// LastInsertID returns the row ID of the first inserted row of INSERT as MySQL returns, or zero if no rows are inserted.
+func (rs *resultset) LastInsertID() int64 {
+	return rs.lastInsertID
+}
+
+// UpdatedRows returns the number of the existing rows which are updated by the upsert clause of INSERT.
+func (rs *resultset) UpdatedRows() uint {
+	return rs.updatedRows
+}
+
+// Warnings returns the warnings of the executed statement.
+func (rs *resultset) Warnings() []*Warning {
+	return rs.warnings
+}
+
+// Err returns the error which is encountered during the iteration, or nil if the rows are read through.
+func (rs *resultset) Err() error {
+	return rs.err
+}
+
 // Close closes the resultset.
 func (rs *resultset) Close() error {
 	if rs.rows == nil {
 		return nil
 	}
-	return rs.rows.Close()
+	err := rs.rows.Close()
+	rs.releaseRows()
+	return err
+}
+
+// releaseRows calls the release function of the closed rows once.
+func (rs *resultset) releaseRows() {
+	if rs.release == nil {
+		return
+	}
+	release := rs.release
+	rs.release = nil
+	release()
 }
@@ -0,0 +1,189 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"database/sql"
+	"strings"
+	"sync"
+
+	"github.com/cybergarage/go-sqlserver/sql/dialect"
+	"github.com/cybergarage/go-sqlserver/sql/function"
+	"github.com/ncruces/go-sqlite3"
+	"github.com/ncruces/go-sqlite3/driver"
+)
+
+// lastInsertIDFunctionName is the SQLite function name of LAST_INSERT_ID() of MySQL.
+const lastInsertIDFunctionName = function.MySQLPrefix + "last_insert_id"
+
+// rowChanges represents the rows of the target table which are changed by an INSERT statement.
+type rowChanges struct {
+	// firstInsertedRowID is the row ID of the first inserted row, or zero if no rows are inserted.
+	firstInsertedRowID int64
+	// insertedRowIDs is the row IDs of the inserted rows.
+	insertedRowIDs map[int64]bool
+	// updatedRowIDs is the row IDs of the existing rows which are updated by the upsert clause.
+	updatedRowIDs map[int64]bool
+}
+
+// rowChangeRecorder records the row changes of the target table of the executing INSERT statement with the update hook.
+type rowChangeRecorder struct {
+	// stmtMutex serializes the recorded statements.
+	stmtMutex sync.Mutex
+	mutex     sync.Mutex
+	table     string
+	changes   *rowChanges
+	// lastInsertID is the first inserted row ID of the last INSERT statement which inserts rows.
+	lastInsertID int64
+}
+
+// newRowChangeRecorder returns a new row change recorder.
+func newRowChangeRecorder() *rowChangeRecorder {
+	return &rowChangeRecorder{
+		stmtMutex:    sync.Mutex{},
+		mutex:        sync.Mutex{},
+		table:        "",
+		changes:      nil,
+		lastInsertID: 0,
+	}
+}
+
+// start starts recording the row changes of the specified table.
+func (recorder *rowChangeRecorder) start(table string) {
+	recorder.stmtMutex.Lock()
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.table = strings.ToLower(table)
+	recorder.changes = &rowChanges{
+		firstInsertedRowID: 0,
+		insertedRowIDs:     map[int64]bool{},
+		updatedRowIDs:      map[int64]bool{},
+	}
+}
+
+// stop stops recording, and returns the recorded row changes.
+func (recorder *rowChangeRecorder) stop() *rowChanges {
+	defer recorder.stmtMutex.Unlock()
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	changes := recorder.changes
+	recorder.table = ""
+	recorder.changes = nil
+	return changes
+}
+
+// setLastInsertID sets the last insert ID to the first inserted row ID of the specified row changes,
+// and the last insert ID is kept if no rows are inserted as MySQL does.
+func (recorder *rowChangeRecorder) setLastInsertID(changes *rowChanges) {
+	if changes.firstInsertedRowID == 0 {
+		return
+	}
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.lastInsertID = changes.firstInsertedRowID
+}
+
+// LastInsertID returns the first inserted row ID of the last INSERT statement which inserts rows.
+func (recorder *rowChangeRecorder) LastInsertID() int64 {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return recorder.lastInsertID
+}
+
+// record records the specified row change of the update hook. The changes of the other tables such as by the triggers are ignored,
+// and the updates of the inserted rows such as by the decimal triggers are not the updates of the upsert clause.
+func (recorder *rowChangeRecorder) record(action sqlite3.AuthorizerActionCode, schema string, table string, rowid int64) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	if recorder.changes == nil || !strings.EqualFold(table, recorder.table) {
+		return
+	}
+	changes := recorder.changes
+	switch action {
+	case sqlite3.AUTH_INSERT:
+		if len(changes.insertedRowIDs) == 0 {
+			changes.firstInsertedRowID = rowid
+		}
+		changes.insertedRowIDs[rowid] = true
+	case sqlite3.AUTH_UPDATE:
+		if !changes.insertedRowIDs[rowid] {
+			changes.updatedRowIDs[rowid] = true
+		}
+	}
+}
+
+// rowChangeResult represents the result of an INSERT statement with the row changes of the target table.
+type rowChangeResult struct {
+	sql.Result
+	changes *rowChanges
+}
+
+// FirstInsertedRowID returns the row ID of the first inserted row, or zero if no rows are inserted.
+func (result *rowChangeResult) FirstInsertedRowID() int64 {
+	return result.changes.firstInsertedRowID
+}
+
+// UpdatedRows returns the number of the existing rows which are updated by the upsert clause.
+func (result *rowChangeResult) UpdatedRows() int64 {
+	return int64(len(result.changes.updatedRowIDs))
+}
+
+// setUpdateHook sets the update hook of the connection to record the row changes of the INSERT statements.
+func (db *Database) setUpdateHook() error {
+	return db.conn.Raw(func(driverConn any) error {
+		conn, ok := driverConn.(driver.Conn)
+		if !ok {
+			return newErrNotSupported(driverConn)
+		}
+		conn.Raw().UpdateHook(db.rowChanges.record)
+		return nil
+	})
+}
+
+// createLastInsertIDFunction creates the function of the connection which returns the last insert ID of the session
+// for LAST_INSERT_ID() of MySQL, because SQLite last_insert_rowid() returns the last inserted row ID instead of the first one
+// which is returned in the OK packets.
+func (db *Database) createLastInsertIDFunction() error {
+	return db.conn.Raw(func(driverConn any) error {
+		conn, ok := driverConn.(driver.Conn)
+		if !ok {
+			return newErrNotSupported(driverConn)
+		}
+		return conn.Raw().CreateFunction(lastInsertIDFunctionName, 0, sqlite3.INNOCUOUS, func(ctx sqlite3.Context, arg ...sqlite3.Value) {
+			ctx.ResultInt64(db.rowChanges.LastInsertID())
+		})
+	})
+}
+
+// execRecordingRowChanges executes the specified SQLite statement with the specified function, and returns the result which has
+// the row changes of the target table if the statement is INSERT, because the last insert row ID of SQLite is the ID of the last
+// inserted row and the affected rows of SQLite count the rows updated by the upsert clause as the inserted rows.
+func (db *Database) execRecordingRowChanges(query string, exec func() (sql.Result, error)) (sql.Result, error) {
+	if dialect.StatementKeyword(query) != "INSERT" {
+		return exec()
+	}
+	tblNames := dialect.ReferencedTableNames(query)
+	if len(tblNames) == 0 {
+		return exec()
+	}
+	db.rowChanges.start(tblNames[0])
+	result, err := exec()
+	changes := db.rowChanges.stop()
+	if err != nil {
+		return nil, err
+	}
+	db.rowChanges.setLastInsertID(changes)
+	return &rowChangeResult{Result: result, changes: changes}, nil
+}
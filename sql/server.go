@@ -36,6 +36,8 @@ type Server interface {
 	MySQLServer() mysql.Server
 	// PostgreSQLServer returns a PostgreSQL server.
 	PostgreSQLServer() postgresql.Server
+	// StatementCacheStats returns the counts of the statement caches of the sessions.
+	StatementCacheStats() StatementCacheStats
 	// Start starts the server.
 	Start() error
 	// Stop stops the server.
@@ -32,6 +32,8 @@ type server struct {
 	myServer   mysql.Server
 	pgServer   postgresql.Server
 	ptExporter *PrometheusExporter
+	sessions   *sessions
+	stmtCache  *statementCacheCounters
 }
 
 // NewServer creates a new SQL server.
@@ -48,6 +50,8 @@ func NewServer() Server {
 		myServer:   mysql.NewServer(),
 		pgServer:   postgresql.NewServer(),
 		ptExporter: NewPrometheusExporter(),
+		sessions:   newSessions(),
+		stmtCache:  &statementCacheCounters{},
 	}
 
 	// Set common SQL executor for MySQL and PostgreSQL
@@ -56,6 +60,12 @@ func NewServer() Server {
 	// PostgreSQL server settings
 	server.PostgreSQLServer().SetBulkQueryExecutor(server)
 	server.PostgreSQLServer().SetErrorHandler(server)
+	server.setupPostgreSQLQueryExecutor()
+	server.setupPostgreSQLMessageHandler()
+
+	// MySQL server settings
+	server.setupMySQLQueryExecutor()
+	server.setupMySQLCommandHandler()
 
 	return server
 }
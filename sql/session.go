@@ -0,0 +1,168 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"sync"
+
+	"github.com/cybergarage/go-mysql/mysql"
+	"github.com/cybergarage/go-sqlparser/sql/net"
+	"github.com/cybergarage/go-sqlserver/sql/dialect"
+)
+
+// session represents the state of a client connection which is kept across the statements.
+type session struct {
+	// warnings is the warnings of the last statement which are returned by MySQL SHOW WARNINGS.
+	warnings []*Warning
+	// clientMinMessages is the lowest severity of the PostgreSQL notices which are sent to the client.
+	clientMinMessages string
+	// stmts is the cache of the prepared SQLite statements, or nil if the statement cache is disabled.
+	stmts *statementCache
+	// copyIn is the PostgreSQL COPY FROM STDIN which is started by Copy and read by CopyData.
+	copyIn *postgresqlCopyIn
+	// dbs is the databases of the session which have the connections and the transactions of the session.
+	dbs     map[*database]*Database
+	dbMutex sync.Mutex
+}
+
+// Database returns the database of the session which has the connection and the transaction of the session,
+// and opens a new connection in the specified dialect to the specified database if the session has no connection to it.
+func (s *session) Database(db *Database, d dialect.Dialect) (*Database, error) {
+	s.dbMutex.Lock()
+	defer s.dbMutex.Unlock()
+	if sessionDB, ok := s.dbs[db.database]; ok {
+		return sessionDB, nil
+	}
+	sessionDB, err := db.Open(d)
+	if err != nil {
+		return nil, err
+	}
+	s.dbs[db.database] = sessionDB
+	return sessionDB, nil
+}
+
+// Close closes the prepared statements of the session, and rolls back the transactions and closes the connections of the session.
+func (s *session) Close() {
+	if s.stmts != nil {
+		s.stmts.Close()
+	}
+	s.dbMutex.Lock()
+	defer s.dbMutex.Unlock()
+	for _, db := range s.dbs {
+		db.Close()
+	}
+	s.dbs = map[*database]*Database{}
+}
+
+// sessions represents the sessions of the client connections.
+type sessions struct {
+	conns sync.Map
+}
+
+// mysqlConnManager represents a MySQL server which returns the active connections.
+type mysqlConnManager interface {
+	Conns() []mysql.Conn
+}
+
+// newSessions returns a new session map.
+func newSessions() *sessions {
+	return &sessions{
+		conns: sync.Map{},
+	}
+}
+
+// LookupSession returns the session of the specified connection, or false if the connection has no session.
+func (sessions *sessions) LookupSession(conn net.Conn) (*session, bool) {
+	s, ok := sessions.conns.Load(conn.UUID())
+	if !ok {
+		return nil, false
+	}
+	return s.(*session), true // nolint: forcetypeassert
+}
+
+// Session returns the session of the specified connection, and creates a new session if the connection has no session.
+func (sessions *sessions) Session(conn net.Conn, stmts *statementCache) *session {
+	s, _ := sessions.conns.LoadOrStore(conn.UUID(), &session{
+		warnings:          nil,
+		clientMinMessages: postgresqlDefaultClientMinMessages,
+		stmts:             stmts,
+		dbs:               map[*database]*Database{},
+		dbMutex:           sync.Mutex{},
+	})
+	return s.(*session) // nolint: forcetypeassert
+}
+
+// DeleteDisconnected deletes and closes the sessions of the connections which are not in the specified active connections.
+func (sessions *sessions) DeleteDisconnected(conns []net.Conn) {
+	active := map[any]bool{}
+	for _, conn := range conns {
+		active[conn.UUID()] = true
+	}
+	sessions.conns.Range(func(key, value any) bool {
+		if !active[key] {
+			sessions.conns.Delete(key)
+			value.(*session).Close() // nolint: forcetypeassert
+		}
+		return true
+	})
+}
+
+// sessionOf returns the session of the specified connection. The sessions of the disconnected connections are deleted
+// before a new session is created because the protocol servers have no disconnection hooks.
+func (server *server) sessionOf(conn net.Conn) *session {
+	if s, ok := server.sessions.LookupSession(conn); ok {
+		return s
+	}
+	conns := []net.Conn{}
+	if connManager, ok := server.MySQLServer().(mysqlConnManager); ok {
+		for _, conn := range connManager.Conns() {
+			conns = append(conns, conn)
+		}
+	}
+	if connManager, ok := server.PostgreSQLServer().(postgresqlConnManager); ok {
+		conns = append(conns, connManager.Conns()...)
+	}
+	server.sessions.DeleteDisconnected(conns)
+	return server.sessions.Session(conn, server.newSessionStatementCache())
+}
+
+// databaseOf returns the current database of the specified connection which has the connection and the transaction of the session.
+func (server *server) databaseOf(conn net.Conn) (*Database, error) {
+	db, err := server.LookupDatabase(conn.Database())
+	if err != nil {
+		return nil, err
+	}
+	return server.sessionOf(conn).Database(db, dialectOf(conn))
+}
+
+// setWarnings keeps the warnings of the last statement of the specified connection.
+func (server *server) setWarnings(conn net.Conn, warnings []*Warning) {
+	if len(warnings) == 0 {
+		if s, ok := server.sessions.LookupSession(conn); ok {
+			s.warnings = nil
+		}
+		return
+	}
+	server.sessionOf(conn).warnings = warnings
+}
+
+// lastWarnings returns the warnings of the last statement of the specified connection.
+func (server *server) lastWarnings(conn net.Conn) []*Warning {
+	s, ok := server.sessions.LookupSession(conn)
+	if !ok {
+		return nil
+	}
+	return s.warnings
+}
@@ -0,0 +1,371 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// SQLite: Prepared Statement Object
+// https://www.sqlite.org/c3ref/stmt.html
+// PostgreSQL: Documentation: 16: PREPARE
+// https://www.postgresql.org/docs/16/sql-prepare.html
+
+import (
+	"container/list"
+	"context"
+	dbsql "database/sql"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cybergarage/go-sqlparser/sql/net"
+	sql "github.com/cybergarage/go-sqlparser/sql/query/response/resultset"
+	"github.com/cybergarage/go-sqlserver/sql/dialect"
+)
+
+const (
+	DefaultStatementCacheSize = 64
+)
+
+// databaseSchemaName is the schema version key of the changes whose tables are unknown such as DROP INDEX.
+const databaseSchemaName = ""
+
+// StatementCacheStats represents the counts of the statement caches of the sessions of a server.
+type StatementCacheStats struct {
+	// Hits is the number of the statements which are executed with the cached prepared statements.
+	Hits uint64
+	// Misses is the number of the statements which are not found in the statement caches.
+	Misses uint64
+	// Evictions is the number of the prepared statements which are evicted by the size limit or the schema changes.
+	Evictions uint64
+}
+
+// statementCacheCounters represents the counters of the statement caches of a server. The counts are also added to
+// the Prometheus counters which count the statement caches of all servers in the process.
+type statementCacheCounters struct {
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+}
+
+// hit counts a statement which is executed with the cached prepared statement.
+func (counters *statementCacheCounters) hit() {
+	counters.hits.Add(1)
+	statementCacheHits.Inc()
+}
+
+// miss counts a statement which is not found in the statement cache.
+func (counters *statementCacheCounters) miss() {
+	counters.misses.Add(1)
+	statementCacheMisses.Inc()
+}
+
+// evict counts a prepared statement which is evicted from the statement cache.
+func (counters *statementCacheCounters) evict() {
+	counters.evictions.Add(1)
+	statementCacheEvictions.Inc()
+}
+
+// Stats returns the current counts of the counters.
+func (counters *statementCacheCounters) Stats() StatementCacheStats {
+	return StatementCacheStats{
+		Hits:      counters.hits.Load(),
+		Misses:    counters.misses.Load(),
+		Evictions: counters.evictions.Load(),
+	}
+}
+
+// statementCacheKey represents a key of the cached statements.
+type statementCacheKey struct {
+	db    *Database
+	query string
+}
+
+// cachedStatement represents a prepared SQLite statement in the statement cache.
+type cachedStatement struct {
+	key      statementCacheKey
+	stmt     *dbsql.Stmt
+	versions map[string]uint64
+	// busy is true while the statement is executed or the rows of the statement are read.
+	busy bool
+	// evicted is true if the statement is removed from the cache, and the statement is closed when it is released.
+	evicted bool
+}
+
+// statementCache represents a LRU cache of the prepared SQLite statements of a session, which are keyed by the normalized statements.
+type statementCache struct {
+	size     int
+	entries  map[statementCacheKey]*list.Element
+	lru      *list.List
+	counters *statementCacheCounters
+	mutex    sync.Mutex
+}
+
+// newStatementCache returns a new statement cache which keeps the specified number of the statements,
+// and the cache counts the hits, the misses and the evictions with the specified counters of the server.
+func newStatementCache(size int, counters *statementCacheCounters) *statementCache {
+	return &statementCache{
+		size:     size,
+		entries:  map[statementCacheKey]*list.Element{},
+		lru:      list.New(),
+		counters: counters,
+		mutex:    sync.Mutex{},
+	}
+}
+
+// isCacheableStatement returns true if the specified SQLite statement is a DML statement which is cached.
+func isCacheableStatement(stmt string) bool {
+	switch dialect.StatementKeyword(stmt) {
+	case "SELECT", "VALUES", "INSERT", "UPDATE", "DELETE", "REPLACE":
+		return true
+	}
+	return false
+}
+
+// checkout returns the cached statement of the specified query, and the statement is prepared if the statement is not cached
+// or the schemas of the referenced tables are changed. The statement is used exclusively until it is released,
+// and nil is returned if the statement is used by the other result set or the statement is not prepared.
+func (cache *statementCache) checkout(db *Database, query string) *cachedStatement {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	key := statementCacheKey{db: db, query: dialect.NormalizedStatement(query)}
+	if elem, ok := cache.entries[key]; ok {
+		entry := elem.Value.(*cachedStatement) // nolint: forcetypeassert
+		switch {
+		case entry.busy:
+			cache.counters.miss()
+			return nil
+		case db.isSchemaChanged(entry.versions):
+			cache.evict(elem)
+		default:
+			cache.lru.MoveToFront(elem)
+			entry.busy = true
+			cache.counters.hit()
+			return entry
+		}
+	}
+	cache.counters.miss()
+	// The schema versions are read before the statement is prepared not to miss the schema changes in the meantime.
+	versions := db.schemaVersionsOf(dialect.ReferencedTableNames(query))
+	stmt, err := db.Prepare(query)
+	if err != nil {
+		// The statement is executed without the cache, and the execution returns the error.
+		return nil
+	}
+	entry := &cachedStatement{
+		key:      key,
+		stmt:     stmt,
+		versions: versions,
+		busy:     true,
+		evicted:  false,
+	}
+	cache.entries[key] = cache.lru.PushFront(entry)
+	for cache.size < cache.lru.Len() {
+		cache.evict(cache.lru.Back())
+	}
+	return entry
+}
+
+// evict removes the specified element from the cache, and the statement is closed unless it is used.
+func (cache *statementCache) evict(elem *list.Element) {
+	entry := cache.lru.Remove(elem).(*cachedStatement) // nolint: forcetypeassert
+	delete(cache.entries, entry.key)
+	entry.evicted = true
+	if !entry.busy {
+		entry.stmt.Close()
+	}
+	cache.counters.evict()
+}
+
+// release releases the specified statement which is returned by checkout.
+func (cache *statementCache) release(entry *cachedStatement) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	entry.busy = false
+	if entry.evicted {
+		entry.stmt.Close()
+	}
+}
+
+// Close closes all cached statements, and the used statements are closed when they are released.
+func (cache *statementCache) Close() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	for elem := cache.lru.Front(); elem != nil; elem = elem.Next() {
+		entry := elem.Value.(*cachedStatement) // nolint: forcetypeassert
+		entry.evicted = true
+		if !entry.busy {
+			entry.stmt.Close()
+		}
+	}
+	cache.entries = map[statementCacheKey]*list.Element{}
+	cache.lru.Init()
+}
+
+// Prepare prepares the specified SQLite statement on the database connection. The statement is executed in the transaction
+// if the transaction is started because the transaction shares the connection.
+func (db *Database) Prepare(query string) (*dbsql.Stmt, error) {
+	return db.conn.PrepareContext(context.Background(), query)
+}
+
+// QueryStatement executes the specified prepared query.
+func (db *Database) QueryStatement(stmt *dbsql.Stmt, query string, args ...any) (*dbsql.Rows, error) {
+	rows, err := stmt.QueryContext(context.Background(), args...)
+	return rows, db.valueErrorOf(query, err)
+}
+
+// ExecStatement executes the specified prepared statement which returns no rows.
+func (db *Database) ExecStatement(stmt *dbsql.Stmt, query string, args ...any) (dbsql.Result, error) {
+	result, err := db.execRecordingRowChanges(query, func() (dbsql.Result, error) {
+		return stmt.ExecContext(context.Background(), args...)
+	})
+	if err != nil {
+		return nil, db.valueErrorOf(query, err)
+	}
+	return result, nil
+}
+
+// schemaVersionsOf returns the current schema versions of the specified tables and the database.
+func (db *Database) schemaVersionsOf(tblNames []string) map[string]uint64 {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	versions := map[string]uint64{
+		databaseSchemaName: db.schemaVersions[databaseSchemaName],
+	}
+	for _, tblName := range tblNames {
+		versions[tblName] = db.schemaVersions[tblName]
+	}
+	return versions
+}
+
+// isSchemaChanged returns true if any of the specified schema versions is changed.
+func (db *Database) isSchemaChanged(versions map[string]uint64) bool {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	for name, version := range versions {
+		if db.schemaVersions[name] != version {
+			return true
+		}
+	}
+	return false
+}
+
+// changeSchema increments the schema versions of the specified tables, or the database version if the tables are unknown,
+// so that the cached statements which refer to the tables are prepared again.
+func (db *Database) changeSchema(tblNames []string) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	if len(tblNames) == 0 {
+		tblNames = []string{databaseSchemaName}
+	}
+	for _, tblName := range tblNames {
+		db.schemaVersions[tblName]++
+	}
+}
+
+// StatementCacheStats returns the counts of the statement caches of the sessions of the server.
+func (server *server) StatementCacheStats() StatementCacheStats {
+	return server.stmtCache.Stats()
+}
+
+// statementCacheOf returns the statement cache of the specified connection, or nil if the statement cache is disabled.
+func (server *server) statementCacheOf(conn net.Conn) *statementCache {
+	return server.sessionOf(conn).stmts
+}
+
+// newSessionStatementCache returns a new statement cache of the configured size, or nil if the statement cache is disabled.
+func (server *server) newSessionStatementCache() *statementCache {
+	size, err := server.StatementCacheSize()
+	if err != nil || size <= 0 {
+		return nil
+	}
+	return newStatementCache(size, server.stmtCache)
+}
+
+// checkoutStatement returns the cached statement of the specified SQLite statement from the statement cache of the connection,
+// or false if the statement is not cached.
+func (server *server) checkoutStatement(conn net.Conn, db *Database, stmt string) (*statementCache, *cachedStatement, bool) {
+	if !isCacheableStatement(stmt) {
+		return nil, nil, false
+	}
+	cache := server.statementCacheOf(conn)
+	if cache == nil {
+		return nil, nil, false
+	}
+	entry := cache.checkout(db, stmt)
+	if entry == nil {
+		return nil, nil, false
+	}
+	return cache, entry, true
+}
+
+// query executes the specified SQLite query with the cached statement of the connection, and returns the result set.
+func (server *server) query(conn net.Conn, db *Database, stmt string, args ...any) (sql.ResultSet, error) {
+	return server.queryWith(conn, db, db.Query, stmt, args...)
+}
+
+// queryWithHold executes the specified SQLite query with the cached statement of the connection outside of the transaction,
+// and returns the result set which can be read after the transaction is committed.
+func (server *server) queryWithHold(conn net.Conn, db *Database, stmt string, args ...any) (sql.ResultSet, error) {
+	return server.queryWith(conn, db, db.QueryWithHold, stmt, args...)
+}
+
+// queryWith executes the specified SQLite query with the cached statement of the connection, or with the specified query function
+// if the statement is not cached. The cached statement is released when the rows are closed or read through.
+// The arithmetic operators of the decimal columns and the concatenation operators of the arrays and JSON values are rewritten into the functions
+// before the statement is looked up.
+func (server *server) queryWith(conn net.Conn, db *Database, queryFn func(string, ...any) (*dbsql.Rows, error), stmt string, args ...any) (sql.ResultSet, error) {
+	stmt = db.rewriteDecimalExpressions(dialectOf(conn), stmt)
+	stmt = db.rewriteConcatExpressions(dialectOf(conn), stmt)
+	cache, entry, ok := server.checkoutStatement(conn, db, stmt)
+	if !ok {
+		rows, err := queryFn(stmt, args...)
+		if err != nil {
+			return nil, err
+		}
+		return newQueryResultSetWith(db, stmt, rows)
+	}
+	rows, err := db.QueryStatement(entry.stmt, stmt, args...)
+	if err != nil {
+		cache.release(entry)
+		return nil, err
+	}
+	rs, err := newQueryResultSetWith(db, stmt, rows, WithResultSetReleaser(func() { cache.release(entry) }))
+	if err != nil {
+		cache.release(entry)
+		return nil, err
+	}
+	return rs, nil
+}
+
+// exec executes the specified SQLite statement which returns no rows with the cached statement of the connection,
+// and returns the result set. The strings which are assigned to the numeric columns are coerced into the numbers with the warnings.
+func (server *server) exec(conn net.Conn, db *Database, stmt string, args ...any) (sql.ResultSet, error) {
+	stmt, args, warnings := db.coerceAssignments(stmt, args)
+	result, err := server.execStatement(conn, db, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	return newExecResultSetWith(result, warnings)
+}
+
+// execStatement executes the specified SQLite statement which returns no rows with the cached statement of the connection,
+// and returns the result without the warnings.
+func (server *server) execStatement(conn net.Conn, db *Database, stmt string, args ...any) (dbsql.Result, error) {
+	stmt = db.rewriteDecimalExpressions(dialectOf(conn), stmt)
+	stmt = db.rewriteConcatExpressions(dialectOf(conn), stmt)
+	cache, entry, ok := server.checkoutStatement(conn, db, stmt)
+	if !ok {
+		return db.Exec(stmt, args...)
+	}
+	defer cache.release(entry)
+	return db.ExecStatement(entry.stmt, stmt, args...)
+}
@@ -0,0 +1,223 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// SQLite: STRICT Tables
+// https://www.sqlite.org/stricttables.html
+// MySQL :: MySQL 8.0 Reference Manual :: 7.1.11 Server SQL Modes (Strict SQL Mode)
+// https://dev.mysql.com/doc/refman/8.0/en/sql-mode.html#sql-mode-strict
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cybergarage/go-sqlserver/sql/dialect"
+)
+
+// declTypeTableName is the hidden table which keeps the declared column types of the strict tables,
+// because the columns of the STRICT tables are declared with the STRICT datatypes.
+var declTypeTableName = dialect.QuoteIdentifier(dialect.HiddenColumnPrefix + "decltypes")
+
+// StrictValueError represents an error of a value which is rejected by a strict table.
+type StrictValueError struct {
+	// Column is the column name.
+	Column string
+	// Type is the STRICT datatype of the column such as INTEGER, or the upper case character type such as VARCHAR.
+	Type string
+	// Length is the declared length of the character column if the value is too long, otherwise zero.
+	Length int
+	// Value is the rejected string literal, or an empty string if the value is not a string literal.
+	Value string
+	// Row is the row number of the rejected value which starts from 1.
+	Row int
+}
+
+// Error returns the error message.
+func (err *StrictValueError) Error() string {
+	if 0 < err.Length {
+		return fmt.Sprintf("value too long for %s(%d) column (%s)", err.Type, err.Length, err.Column)
+	}
+	return fmt.Sprintf("invalid input value for %s column (%s)", err.Type, err.Column)
+}
+
+// IsTooLong returns true if the value is longer than the declared length of the character column.
+func (err *StrictValueError) IsTooLong() bool {
+	return 0 < err.Length
+}
+
+// WithDatabaseStrictMode returns a database option that enables the strict mode. The tables are created as the STRICT tables
+// which reject the values of the other types, and the character columns reject the values longer than the declared lengths.
+func WithDatabaseStrictMode(enabled bool) DatabaseOption {
+	return func(db *Database) error {
+		db.strict = enabled
+		return nil
+	}
+}
+
+// IsStrictModeEnabled returns true if the strict mode is enabled.
+func (db *Database) IsStrictModeEnabled() bool {
+	return db.strict
+}
+
+// loadDeclTypes creates the declared type table if not exists, and loads the declared types of the strict tables.
+func (db *Database) loadDeclTypes() error {
+	_, err := db.exec("CREATE TABLE IF NOT EXISTS " + declTypeTableName + " (table_name TEXT, column_name TEXT, type_name TEXT, PRIMARY KEY (table_name, column_name))")
+	if err != nil {
+		return err
+	}
+	rows, err := db.Query("SELECT table_name, column_name, type_name FROM " + declTypeTableName)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	for rows.Next() {
+		var tblName, columnName, typeName string
+		if err := rows.Scan(&tblName, &columnName, &typeName); err != nil {
+			return err
+		}
+		db.setDeclType(tblName, columnName, typeName)
+	}
+	return rows.Err()
+}
+
+// rewriteStrictColumns rewrites the specified CREATE TABLE statement into the STRICT table if the strict mode is enabled,
+// and returns the rewritten statement and the declared types of the rewritten columns.
+func (db *Database) rewriteStrictColumns(query string) (string, map[string]string, error) {
+	if !db.strict {
+		return query, nil, nil
+	}
+	return dialect.StrictColumns(query)
+}
+
+// updateDeclTypes updates the declared types with the specified executed CREATE TABLE or DROP TABLE statement
+// and the declared types of the rewritten columns.
+func (db *Database) updateDeclTypes(query string, declTypes map[string]string) error {
+	switch dialect.LeadingKeyword(query) {
+	case "CREATE":
+		tblName, _, ok := dialect.LogicalColumnTypes(query)
+		if !ok || tblName == "" {
+			return nil
+		}
+		if err := db.deleteDeclTypes(tblName); err != nil {
+			return err
+		}
+		for columnName, typeName := range declTypes {
+			_, err := db.exec("INSERT INTO "+declTypeTableName+" (table_name, column_name, type_name) VALUES (?, ?, ?)", strings.ToLower(tblName), strings.ToLower(columnName), typeName)
+			if err != nil {
+				return err
+			}
+			db.mutex.Lock()
+			db.setDeclType(tblName, columnName, typeName)
+			db.mutex.Unlock()
+		}
+	case "DROP":
+		for _, tblName := range dialect.DroppedTableNames(query) {
+			if err := db.deleteDeclTypes(tblName); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deleteDeclTypes deletes the declared types of the specified table.
+func (db *Database) deleteDeclTypes(tblName string) error {
+	tblName = strings.ToLower(tblName)
+	if _, err := db.exec("DELETE FROM "+declTypeTableName+" WHERE table_name = ?", tblName); err != nil {
+		return err
+	}
+	db.mutex.Lock()
+	delete(db.declTypes, tblName)
+	db.mutex.Unlock()
+	return nil
+}
+
+func (db *Database) setDeclType(tblName string, columnName string, typeName string) {
+	tblName = strings.ToLower(tblName)
+	columns, ok := db.declTypes[tblName]
+	if !ok {
+		columns = map[string]string{}
+		db.declTypes[tblName] = columns
+	}
+	columns[strings.ToLower(columnName)] = typeName
+}
+
+// declTypeOf returns the declared type of the specified column of a strict table, or false if the column type is not rewritten.
+func (db *Database) declTypeOf(tblName string, columnName string) (string, bool) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	typeName, ok := db.declTypes[strings.ToLower(tblName)][strings.ToLower(columnName)]
+	return typeName, ok
+}
+
+// valueErrorOf returns the enum value error, the decimal value error, the array value error, the JSON value error or the strict value error
+// if the specified error of the specified query is a constraint failure of an enum column, a decimal column, an array column, a JSON column
+// or a strict table column, otherwise returns the specified error as it is.
+func (db *Database) valueErrorOf(query string, err error) error {
+	err = db.enumValueErrorOf(err)
+	if err == nil {
+		return nil
+	}
+	if column, precision, scale, ok := dialect.DecimalConstraintOf(err.Error()); ok {
+		return &DecimalValueError{Column: column, Precision: precision, Scale: scale}
+	}
+	if column, typeName, ok := dialect.ArrayConstraintOf(err.Error()); ok {
+		return db.arrayValueErrorOf(query, column, typeName)
+	}
+	if column, ok := dialect.JSONConstraintOf(err.Error()); ok {
+		return db.jsonValueErrorOf(query, column)
+	}
+	valueErr := &StrictValueError{Column: "", Type: "", Length: 0, Value: "", Row: 1}
+	var isRejected func(string) bool
+	if column, typeName, length, ok := dialect.LengthConstraintOf(err.Error()); ok {
+		valueErr.Column, valueErr.Type, valueErr.Length = column, typeName, length
+		isRejected = func(v string) bool { return length < utf8.RuneCountInString(v) }
+	} else if _, column, datatype, ok := dialect.StrictDatatypeErrorOf(err.Error()); ok {
+		valueErr.Column, valueErr.Type = column, datatype
+		isRejected = func(v string) bool { return !sqliteNumericTextRegexp.MatchString(v) }
+	} else {
+		return err
+	}
+	if assignment, ok := db.assignmentOf(query, valueErr.Column, isRejected); ok {
+		valueErr.Value, valueErr.Row = assignment.Value, assignment.Row
+	}
+	return valueErr
+}
+
+// assignmentOf returns the first string literal assignment of the specified column in the specified INSERT or UPDATE statement
+// whose value is rejected by the specified function.
+func (db *Database) assignmentOf(query string, column string, isRejected func(string) bool) (*dialect.Assignment, bool) {
+	tblName, assignments, ok := dialect.StringAssignments(query)
+	if !ok || len(assignments) == 0 {
+		return nil, false
+	}
+	columnNames, _, err := db.tableColumns(tblName)
+	if err != nil {
+		return nil, false
+	}
+	for _, assignment := range assignments {
+		columnName := assignment.Column
+		if columnName == "" && assignment.Index < len(columnNames) {
+			columnName = columnNames[assignment.Index]
+		}
+		if strings.EqualFold(columnName, column) && isRejected(assignment.Value) {
+			return assignment, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,228 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// MySQL :: MySQL 8.0 Reference Manual :: 15.7.7.42 SHOW WARNINGS Statement
+// https://dev.mysql.com/doc/refman/8.0/en/show-warnings.html
+// SQLite: Type Affinity
+// https://www.sqlite.org/datatype3.html#type_affinity
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	query "github.com/cybergarage/go-sqlparser/sql/query"
+	sql "github.com/cybergarage/go-sqlparser/sql/query/response/resultset"
+	"github.com/cybergarage/go-sqlserver/sql/dialect"
+)
+
+// The warning levels of MySQL SHOW WARNINGS.
+const (
+	WarningLevelNote    = "Note"
+	WarningLevelWarning = "Warning"
+)
+
+// The MySQL error codes of the warnings.
+const (
+	// mysqlDataTruncatedCode is the MySQL error code of WARN_DATA_TRUNCATED.
+	mysqlDataTruncatedCode = 1265
+	// mysqlIncorrectValueCode is the MySQL error code of ER_TRUNCATED_WRONG_VALUE_FOR_FIELD.
+	mysqlIncorrectValueCode = 1366
+)
+
+// Warning represents a warning of an executed statement such as a silent data coercion.
+type Warning struct {
+	// Level is the warning level such as Warning and Note.
+	Level string
+	// Code is the MySQL error code.
+	Code uint16
+	// Message is the warning message.
+	Message string
+}
+
+// sqliteNumericTextRegexp matches the texts which SQLite converts into the numbers in the numeric columns.
+var sqliteNumericTextRegexp = regexp.MustCompile(`^\s*[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][+-]?[0-9]+)?\s*$`)
+
+// sqliteNumericPrefixRegexp matches the leading numbers of the texts which are coerced into the numbers.
+var sqliteNumericPrefixRegexp = regexp.MustCompile(`^\s*[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][+-]?[0-9]+)?`)
+
+// warningsOf returns the warnings of the specified result set, or nil if the result set has no warnings.
+func warningsOf(rs sql.ResultSet) []*Warning {
+	reporter, ok := rs.(warningReporter)
+	if !ok {
+		return nil
+	}
+	return reporter.Warnings()
+}
+
+// coerceAssignments returns the specified SQLite statement and bind arguments whose strings assigned to the numeric columns
+// are coerced into the numbers, and the warnings of the coercions. SQLite stores the strings which are not the well-formed numbers
+// in the numeric columns as they are, so the strings are coerced into the leading numbers or zero as MySQL does in the non-strict mode.
+// The statement and the arguments are returned as they are in the strict mode because the STRICT tables reject the strings.
+func (db *Database) coerceAssignments(stmt string, args []any) (string, []any, []*Warning) {
+	if db.strict {
+		return stmt, args, nil
+	}
+	tblName, assignments, ok := dialect.StringAssignments(stmt)
+	if !ok {
+		return stmt, args, nil
+	}
+	values := map[int]string{}
+	for idx, assignment := range assignments {
+		value := assignment.Value
+		if 0 < assignment.Param {
+			if len(args) < assignment.Param {
+				continue
+			}
+			var ok bool
+			value, ok = stringArgumentOf(args[assignment.Param-1])
+			if !ok {
+				continue
+			}
+		}
+		if !sqliteNumericTextRegexp.MatchString(value) {
+			values[idx] = value
+		}
+	}
+	if len(values) == 0 {
+		return stmt, args, nil
+	}
+	columnNames, declTypes, err := db.tableColumns(tblName)
+	if err != nil {
+		return stmt, args, nil
+	}
+	numbers := map[int]string{}
+	coercedArgs := slices.Clone(args)
+	warnings := []*Warning{}
+	for idx, assignment := range assignments {
+		value, ok := values[idx]
+		if !ok {
+			continue
+		}
+		n := assignment.Index
+		if assignment.Column != "" {
+			n = -1
+			for columnIdx, columnName := range columnNames {
+				if strings.EqualFold(columnName, assignment.Column) {
+					n = columnIdx
+					break
+				}
+			}
+		}
+		if n < 0 || len(columnNames) <= n {
+			continue
+		}
+		valueType, ok := numericValueTypeOf(declTypes[n])
+		if !ok {
+			continue
+		}
+		number, warning := coercedNumberOf(valueType, value, columnNames[n], assignment.Row)
+		if 0 < assignment.Param {
+			coercedArgs[assignment.Param-1] = number
+		} else {
+			numbers[idx] = number
+		}
+		warnings = append(warnings, warning)
+	}
+	if len(warnings) == 0 {
+		return stmt, args, nil
+	}
+	if 0 < len(numbers) {
+		coercedStmt, err := dialect.ReplaceStringAssignments(stmt, numbers)
+		if err != nil {
+			return stmt, args, nil
+		}
+		stmt = coercedStmt
+	}
+	return stmt, coercedArgs, warnings
+}
+
+// stringArgumentOf returns the string of the specified bind argument, or false if the argument is not a string.
+func stringArgumentOf(arg any) (string, bool) {
+	switch v := arg.(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	}
+	return "", false
+}
+
+// coercedNumberOf returns the leading number of the specified string which is assigned to the numeric column, or zero if the string
+// has no leading number, and the warning of the coercion. The leading numbers of the integer columns are rounded as MySQL does.
+func coercedNumberOf(valueType string, value string, columnName string, row int) (string, *Warning) {
+	number := strings.TrimSpace(sqliteNumericPrefixRegexp.FindString(value))
+	if number == "" {
+		return "0", &Warning{
+			Level:   WarningLevelWarning,
+			Code:    mysqlIncorrectValueCode,
+			Message: fmt.Sprintf("Incorrect %s value: '%s' for column '%s' at row %d", valueType, value, columnName, row),
+		}
+	}
+	if f, err := strconv.ParseFloat(number, 64); err == nil && valueType == "integer" {
+		number = strconv.FormatFloat(math.Round(f), 'f', -1, 64)
+	}
+	return number, &Warning{
+		Level:   WarningLevelWarning,
+		Code:    mysqlDataTruncatedCode,
+		Message: fmt.Sprintf("Data truncated for column '%s' at row %d", columnName, row),
+	}
+}
+
+// tableColumns returns the column names and the upper case declared types of the specified table,
+// and the columns of the strict tables have the declared types instead of the STRICT datatypes.
+func (db *Database) tableColumns(tblName string) ([]string, []string, error) {
+	rows, err := db.Query("SELECT name, upper(type) FROM pragma_table_info(?)", tblName)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	columnNames := []string{}
+	declTypes := []string{}
+	for rows.Next() {
+		var columnName, declType string
+		if err := rows.Scan(&columnName, &declType); err != nil {
+			return nil, nil, err
+		}
+		if strictDeclType, ok := db.declTypeOf(tblName, columnName); ok {
+			declType = strictDeclType
+		}
+		columnNames = append(columnNames, columnName)
+		declTypes = append(declTypes, declType)
+	}
+	return columnNames, declTypes, rows.Err()
+}
+
+// numericValueTypeOf returns the value type name of the warning messages such as integer for the specified declared type,
+// or false if the declared type is not a numeric type.
+func numericValueTypeOf(declType string) (string, bool) {
+	dt, err := newResultSetDataTypeFrom(declType)
+	if err != nil {
+		return "", false
+	}
+	switch dt {
+	case query.TinyIntType, query.SmallIntType, query.MediumIntType, query.IntegerType, query.BigIntType:
+		return "integer", true
+	case query.RealType, query.FloatType, query.DoubleType:
+		return "double", true
+	case query.DecimalType:
+		return "decimal", true
+	}
+	return "", false
+}
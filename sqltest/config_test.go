@@ -35,6 +35,12 @@ func TestDefaultConfig(t *testing.T) {
 		func() (any, error) {
 			return cfg.PostgresqlPort()
 		},
+		func() (any, error) {
+			return cfg.IsStrictModeEnabled("")
+		},
+		func() (any, error) {
+			return cfg.StatementCacheSize()
+		},
 	}
 
 	for _, f := range fns {
@@ -0,0 +1,84 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"fmt"
+	"testing"
+
+	sqlserver "github.com/cybergarage/go-sqlserver/sql"
+)
+
+// statementCacheStatsSince returns the differences of the statement cache counts of the test server from the specified counts.
+func statementCacheStatsSince(from sqlserver.StatementCacheStats) sqlserver.StatementCacheStats {
+	stats := testServer.StatementCacheStats()
+	return sqlserver.StatementCacheStats{
+		Hits:      stats.Hits - from.Hits,
+		Misses:    stats.Misses - from.Misses,
+		Evictions: stats.Evictions - from.Evictions,
+	}
+}
+
+// TestStatementCache tests the cached statements are counted as the hits, and the statements are prepared again
+// after the referenced tables are altered or they are evicted by the cache size.
+func TestStatementCache(t *testing.T) {
+	db := openTestDB(t, "statementcache", nil)
+
+	for _, stmt := range []string{
+		"CREATE TABLE nums (id INT PRIMARY KEY, v INT)",
+		"INSERT INTO nums VALUES (1, 10)",
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %s", stmt, err)
+		}
+	}
+
+	query := "SELECT v FROM nums WHERE id = 1"
+	steps := []struct {
+		ddl      string
+		expected sqlserver.StatementCacheStats
+	}{
+		// The first query is prepared, and the second query uses the cached statement.
+		{"", sqlserver.StatementCacheStats{Hits: 0, Misses: 1, Evictions: 0}},
+		{"", sqlserver.StatementCacheStats{Hits: 1, Misses: 0, Evictions: 0}},
+		// The statements are kept if the other tables are changed.
+		{"CREATE TABLE others (id INT PRIMARY KEY)", sqlserver.StatementCacheStats{Hits: 1, Misses: 0, Evictions: 0}},
+		// The statements are prepared again if the referenced tables are altered.
+		{"ALTER TABLE nums ADD COLUMN w INT", sqlserver.StatementCacheStats{Hits: 0, Misses: 1, Evictions: 1}},
+	}
+	for n, step := range steps {
+		if step.ddl != "" {
+			if _, err := db.Exec(step.ddl); err != nil {
+				t.Fatalf("%s: %s", step.ddl, err)
+			}
+		}
+		from := testServer.StatementCacheStats()
+		if v := countRows(t, db, query); v != 10 {
+			t.Errorf("%d != 10", v)
+		}
+		if stats := statementCacheStatsSince(from); stats != step.expected {
+			t.Errorf("step %d: %+v != %+v", n, stats, step.expected)
+		}
+	}
+
+	// The least recently used statements are evicted if the cache is full.
+	from := testServer.StatementCacheStats()
+	for n := 0; n <= sqlserver.DefaultStatementCacheSize; n++ {
+		countRows(t, db, fmt.Sprintf("SELECT COUNT(*) FROM nums WHERE id = %d", n))
+	}
+	if stats := statementCacheStatsSince(from); stats.Evictions < 1 {
+		t.Errorf("%+v", stats)
+	}
+}
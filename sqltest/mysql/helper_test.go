@@ -0,0 +1,92 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/cybergarage/go-logger/log"
+	"github.com/cybergarage/go-sqlserver/sqltest/server"
+	"github.com/cybergarage/go-sqltest/sqltest"
+	mysqldriver "github.com/go-sql-driver/mysql"
+)
+
+// testServer is the server of the tests which is started by TestMain.
+var testServer *server.Server
+
+// TestMain starts the test server before running the tests, and stops it after the tests.
+func TestMain(m *testing.M) {
+	log.EnableStdoutDebug(true)
+
+	testServer = server.NewServer()
+	if err := testServer.Start(); err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+
+	if err := testServer.Stop(); err != nil {
+		log.Error(err)
+	}
+
+	os.Exit(code)
+}
+
+// openTestDB creates the specified database, and returns the database handle of the driver with the specified DSN parameters,
+// because the test client returns no OK packet fields such as the last insert IDs and the affected rows.
+func openTestDB(t *testing.T, name string, params map[string]string) *sql.DB {
+	t.Helper()
+	admin := sqltest.NewMySQLClient()
+	if err := admin.CreateDatabase(name); err != nil {
+		t.Fatal(err)
+	}
+	config := mysqldriver.NewConfig()
+	config.User = sqltest.NewDefaultConfig().User
+	config.Net = "tcp"
+	config.Addr = "localhost:3306"
+	config.DBName = name
+	config.Params = params
+	db, err := sql.Open("mysql", config.FormatDSN())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The statements are executed on the same connection to keep the session state.
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Error(err)
+		}
+		if err := admin.DropDatabase(name); err != nil {
+			t.Error(err)
+		}
+		if err := admin.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	return db
+}
+
+// countRows returns the number of the rows of the specified query.
+func countRows(t *testing.T, db *sql.DB, query string) int {
+	t.Helper()
+	var n int
+	if err := db.QueryRow(query).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
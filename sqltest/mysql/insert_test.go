@@ -0,0 +1,68 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"testing"
+)
+
+// TestInsertResults tests the last insert IDs and the affected rows of the OK packets of INSERT,
+// and LAST_INSERT_ID() returns the last insert ID of the OK packets.
+func TestInsertResults(t *testing.T) {
+	db := openTestDB(t, "insertresults", nil)
+
+	if _, err := db.Exec("CREATE TABLE items (id INT AUTO_INCREMENT PRIMARY KEY, code INT UNIQUE, name TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		stmt         string
+		lastInsertID int64
+		affectedRows int64
+	}{
+		// The last insert ID of the multiple row INSERT is the ID of the first inserted row.
+		{"INSERT INTO items (code, name) VALUES (1, 'a'), (2, 'b'), (3, 'c')", 1, 3},
+		// The explicit IDs are returned as they are even if they are not ascending.
+		{"INSERT INTO items VALUES (20, 4, 'd'), (10, 5, 'e')", 20, 2},
+		// The ignored rows are neither inserted nor counted.
+		{"INSERT IGNORE INTO items (code, name) VALUES (1, 'x'), (6, 'f')", 21, 1},
+		{"INSERT IGNORE INTO items (code, name) VALUES (1, 'x')", 0, 0},
+		{"REPLACE INTO items VALUES (30, 7, 'g')", 30, 1},
+		// The updated rows of ON DUPLICATE KEY UPDATE are counted as two affected rows.
+		{"INSERT INTO items (code, name) VALUES (2, 'h'), (8, 'i') ON DUPLICATE KEY UPDATE name = VALUES(name)", 31, 3},
+		{"INSERT INTO items (code, name) VALUES (3, 'j') ON DUPLICATE KEY UPDATE name = VALUES(name)", 0, 2},
+	}
+	lastInsertID := int64(0)
+	for _, test := range tests {
+		result, err := db.Exec(test.stmt)
+		if err != nil {
+			t.Errorf("%s: %s", test.stmt, err)
+			continue
+		}
+		if id, err := result.LastInsertId(); err != nil || id != test.lastInsertID {
+			t.Errorf("%s: last insert ID %d != %d (%v)", test.stmt, id, test.lastInsertID, err)
+		}
+		if n, err := result.RowsAffected(); err != nil || n != test.affectedRows {
+			t.Errorf("%s: affected rows %d != %d (%v)", test.stmt, n, test.affectedRows, err)
+		}
+		// LAST_INSERT_ID() is kept by the statements which insert no rows.
+		if test.lastInsertID != 0 {
+			lastInsertID = test.lastInsertID
+		}
+		if id := countRows(t, db, "SELECT LAST_INSERT_ID()"); int64(id) != lastInsertID {
+			t.Errorf("%s: LAST_INSERT_ID() %d != %d", test.stmt, id, lastInsertID)
+		}
+	}
+}
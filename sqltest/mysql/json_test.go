@@ -0,0 +1,49 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestJSONErrors tests the JSON columns reject the values which are not well-formed JSON texts.
+func TestJSONErrors(t *testing.T) {
+	db := openTestDB(t, "jsonerrors", nil)
+
+	stmts := []string{
+		"CREATE TABLE docs (id INT PRIMARY KEY, doc JSON)",
+		"INSERT INTO docs VALUES (1, '{\"a\":1}'), (2, NULL)",
+	}
+	for _, stmt := range stmts {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %s", stmt, err)
+		}
+	}
+
+	for _, stmt := range []string{
+		"INSERT INTO docs VALUES (3, '{\"a\":')",
+		"UPDATE docs SET doc = 'abc' WHERE id = 1",
+	} {
+		_, err := db.Exec(stmt)
+		if err == nil {
+			t.Errorf("%s: error is not returned", stmt)
+			continue
+		}
+		if !strings.Contains(err.Error(), "3140") || !strings.Contains(err.Error(), "column 'doc'") {
+			t.Errorf("%s: %s", stmt, err)
+		}
+	}
+}
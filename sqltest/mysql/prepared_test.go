@@ -0,0 +1,64 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"testing"
+)
+
+// TestPreparedStatements tests the statements with arguments are executed by COM_STMT_PREPARE and COM_STMT_EXECUTE,
+// and the parameters are bound without being inlined into the statements.
+func TestPreparedStatements(t *testing.T) {
+	db := openTestDB(t, "preparedstatements", nil)
+
+	if _, err := db.Exec("CREATE TABLE items (id INT PRIMARY KEY, name TEXT, price DOUBLE)"); err != nil {
+		t.Fatal(err)
+	}
+
+	stmt, err := db.Prepare("INSERT INTO items VALUES (?, ?, ?)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+
+	// The quotes, the backslashes and the question marks of the parameters are stored as they are, and nil is bound as NULL.
+	name := "O'Brien\\'); DROP TABLE items; -- ?"
+	args := [][]any{
+		{1, name, 12.5},
+		{2, nil, 3.0},
+	}
+	for _, arg := range args {
+		if _, err := stmt.Exec(arg...); err != nil {
+			t.Fatalf("%v: %s", arg, err)
+		}
+	}
+
+	// The parameter count of COM_STMT_PREPARE is checked by the driver.
+	if _, err := stmt.Exec(3); err == nil {
+		t.Error("the statement is executed with the fewer arguments")
+	}
+
+	var storedName string
+	var price float64
+	if err := db.QueryRow("SELECT name, price FROM items WHERE id = ? OR name = ?", 1, "").Scan(&storedName, &price); err != nil {
+		t.Fatal(err)
+	}
+	if storedName != name || price != 12.5 {
+		t.Errorf("%s %f != %s %f", storedName, price, name, 12.5)
+	}
+	if n := countRows(t, db, "SELECT COUNT(*) FROM items WHERE name IS NULL"); n != 1 {
+		t.Errorf("%d != 1", n)
+	}
+}
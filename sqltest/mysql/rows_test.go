@@ -0,0 +1,48 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"testing"
+)
+
+// TestRowsError tests the error of a row after the preceding rows are sent is returned by the ERR packet instead of the EOF packet.
+func TestRowsError(t *testing.T) {
+	db := openTestDB(t, "rowserror", nil)
+
+	stmts := []string{
+		"CREATE TABLE nums (id INT PRIMARY KEY, v BIGINT)",
+		"INSERT INTO nums VALUES (1, 1), (2, 0)",
+	}
+	for _, stmt := range stmts {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %s", stmt, err)
+		}
+	}
+
+	// ABS of the minimum integer of the second row fails while the first row has been sent.
+	rows, err := db.Query("SELECT id, ABS(v - 9223372036854775807 - 1) FROM nums ORDER BY id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	nRows := 0
+	for rows.Next() {
+		nRows++
+	}
+	if rows.Err() == nil {
+		t.Errorf("the row error is not returned (%d rows)", nRows)
+	}
+}
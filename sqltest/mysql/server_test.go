@@ -17,7 +17,6 @@ package mysql
 import (
 	"testing"
 
-	"github.com/cybergarage/go-logger/log"
 	"github.com/cybergarage/go-mysql/mysql"
 	"github.com/cybergarage/go-sqlserver/sqltest/server"
 )
@@ -29,7 +28,17 @@ const (
 )
 
 func TestServer(t *testing.T) {
-	log.EnableStdoutDebug(true)
+	// The shared test server is stopped while the servers of the settings are started,
+	// and it is started again after the test.
+	if err := testServer.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		testServer = server.NewServer()
+		if err := testServer.Start(); err != nil {
+			t.Error(err)
+		}
+	})
 
 	defer func() {
 		t.Setenv("GO_SQLSERVER_AUTH_ENABLED", "false")
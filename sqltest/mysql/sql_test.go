@@ -17,23 +17,11 @@ package mysql
 import (
 	"testing"
 
-	"github.com/cybergarage/go-logger/log"
-	"github.com/cybergarage/go-sqlserver/sqltest/server"
 	"github.com/cybergarage/go-sqltest/sqltest"
 )
 
 // TestSQLTestSuite runs already passed scenario test files.
 func TestSQLTestSuite(t *testing.T) {
-	log.EnableStdoutDebug(true)
-
-	server := server.NewServer()
-	err := server.Start()
-	if err != nil {
-		t.Error(err)
-		return
-	}
-	defer server.Stop()
-
 	testNames := []string{
 		"SmplCrud.*",
 		"SmplIndex.*",
@@ -42,3 +30,17 @@ func TestSQLTestSuite(t *testing.T) {
 
 	sqltest.RunEmbedSuites(t, sqltest.NewMySQLClient(), testNames...)
 }
+
+// TestDialectSuite runs the MySQL dialect scenario test files.
+func TestDialectSuite(t *testing.T) {
+	suite, err := sqltest.NewSuiteWith(
+		sqltest.WithSuiteDirectories("scenarios"),
+		sqltest.WithSuiteClient(sqltest.NewMySQLClient()),
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	suite.Test(t)
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestMultiStatements tests the multiple statements are executed in sequence and return the result sets with
+// SERVER_MORE_RESULTS_EXISTS, and the execution stops at the first error without rolling back the preceding statements.
+func TestMultiStatements(t *testing.T) {
+	db := openTestDB(t, "multistatements", map[string]string{"multiStatements": "true"})
+
+	if _, err := db.Exec("CREATE TABLE nums (id INT PRIMARY KEY, v INT)"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The driver skips the result of INSERT which has no rows, and reads the following result sets.
+	query := "INSERT INTO nums VALUES (1, 10), (2, 20); SELECT id FROM nums ORDER BY id; SELECT SUM(v) AS total FROM nums"
+	rows, err := db.Query(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := []string{}
+	for {
+		values := []string{}
+		for rows.Next() {
+			var v any
+			if err := rows.Scan(&v); err != nil {
+				t.Fatal(err)
+			}
+			if b, ok := v.([]byte); ok {
+				v = string(b)
+			}
+			values = append(values, fmt.Sprint(v))
+		}
+		results = append(results, strings.Join(values, " "))
+		if !rows.NextResultSet() {
+			break
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"1 2", "30"}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("%v != %v", results, expected)
+	}
+
+	// The statement of the duplicate key fails, the preceding statement is kept and the following statement is not executed.
+	if _, err := db.Exec("INSERT INTO nums VALUES (3, 30); INSERT INTO nums VALUES (3, 31); INSERT INTO nums VALUES (4, 40)"); err == nil {
+		t.Error("the error of the second statement is not returned")
+	}
+	if n := countRows(t, db, "SELECT COUNT(*) FROM nums WHERE id = 3"); n != 1 {
+		t.Errorf("%d != 1", n)
+	}
+	if n := countRows(t, db, "SELECT COUNT(*) FROM nums WHERE id = 4"); n != 0 {
+		t.Errorf("%d != 0", n)
+	}
+
+	// The multiple statements are not allowed without CLIENT_MULTI_STATEMENTS.
+	single := openTestDB(t, "singlestatement", nil)
+	if _, err := single.Exec("SELECT 1; SELECT 2"); err == nil {
+		t.Error("the multiple statements are executed without CLIENT_MULTI_STATEMENTS")
+	}
+}
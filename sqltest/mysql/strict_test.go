@@ -0,0 +1,76 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"errors"
+	"testing"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+)
+
+// TestStrictMode tests the strict mode database of the test configuration rejects the invalid values with the MySQL errors
+// of the strict SQL mode, and the other databases store the values as they are.
+func TestStrictMode(t *testing.T) {
+	db := openTestDB(t, "strictmode", nil)
+
+	if _, err := db.Exec("CREATE TABLE items (id INT PRIMARY KEY, v INT, name VARCHAR(5))"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		stmt    string
+		number  uint16
+		message string
+	}{
+		{"INSERT INTO items VALUES (1, 'abc', 'a')", 1366, "Incorrect integer value: 'abc' for column 'v' at row 1"},
+		{"INSERT INTO items VALUES (1, 1, 'a'), (2, 2, 'abcdef')", 1406, "Data too long for column 'name' at row 2"},
+		{"INSERT INTO items VALUES (3, 3, 'abcde')", 0, ""},
+		{"UPDATE items SET name = 'abcdef' WHERE id = 3", 1406, "Data too long for column 'name' at row 1"},
+	}
+	for _, test := range tests {
+		_, err := db.Exec(test.stmt)
+		if test.number == 0 {
+			if err != nil {
+				t.Errorf("%s: %s", test.stmt, err)
+			}
+			continue
+		}
+		var mysqlErr *mysqldriver.MySQLError
+		if !errors.As(err, &mysqlErr) {
+			t.Errorf("%s: the error is not returned (%v)", test.stmt, err)
+			continue
+		}
+		if mysqlErr.Number != test.number || mysqlErr.Message != test.message {
+			t.Errorf("%s: %d %s != %d %s", test.stmt, mysqlErr.Number, mysqlErr.Message, test.number, test.message)
+		}
+	}
+
+	// The rows of the rejected statements are not inserted.
+	if n := countRows(t, db, "SELECT COUNT(*) FROM items"); n != 1 {
+		t.Errorf("%d != 1", n)
+	}
+
+	// The strict mode is disabled for the other databases.
+	nonStrict := openTestDB(t, "nonstrictmode", nil)
+	for _, stmt := range []string{
+		"CREATE TABLE items (id INT PRIMARY KEY, v INT, name VARCHAR(5))",
+		"INSERT INTO items VALUES (1, 'abc', 'abcdef')",
+	} {
+		if _, err := nonStrict.Exec(stmt); err != nil {
+			t.Errorf("%s: %s", stmt, err)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestWarnings tests the silent data coercions are returned by SHOW WARNINGS and SHOW COUNT(*) WARNINGS,
+// the coerced values are stored as MySQL does in the non-strict mode, and the warnings are kept until the next statement.
+func TestWarnings(t *testing.T) {
+	db := openTestDB(t, "warnings", nil)
+
+	type warning struct {
+		level   string
+		code    int
+		message string
+	}
+	showWarnings := func() []warning {
+		t.Helper()
+		rows, err := db.Query("SHOW WARNINGS")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rows.Close()
+		warnings := []warning{}
+		for rows.Next() {
+			var w warning
+			if err := rows.Scan(&w.level, &w.code, &w.message); err != nil {
+				t.Fatal(err)
+			}
+			warnings = append(warnings, w)
+		}
+		if err := rows.Err(); err != nil {
+			t.Fatal(err)
+		}
+		return warnings
+	}
+
+	if _, err := db.Exec("CREATE TABLE nums (id INT PRIMARY KEY, v INT)"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The string literals and the bound strings are coerced into the leading numbers or zero.
+	tests := []struct {
+		stmt     string
+		args     []any
+		id       int
+		value    int
+		expected warning
+	}{
+		{"INSERT INTO nums VALUES (1, 'abc')", nil, 1, 0, warning{"Warning", 1366, "Incorrect integer value: 'abc' for column 'v' at row 1"}},
+		{"INSERT INTO nums VALUES (?, ?)", []any{2, "xyz"}, 2, 0, warning{"Warning", 1366, "Incorrect integer value: 'xyz' for column 'v' at row 1"}},
+		{"INSERT INTO nums VALUES (?, ?)", []any{3, "12abc"}, 3, 12, warning{"Warning", 1265, "Data truncated for column 'v' at row 1"}},
+		{"UPDATE nums SET v = ? WHERE id = ?", []any{"7.6x", 3}, 3, 8, warning{"Warning", 1265, "Data truncated for column 'v' at row 1"}},
+	}
+	for _, test := range tests {
+		if _, err := db.Exec(test.stmt, test.args...); err != nil {
+			t.Fatalf("%s: %s", test.stmt, err)
+		}
+		warnings := showWarnings()
+		if len(warnings) != 1 || warnings[0] != test.expected {
+			t.Errorf("%s: %v != %v", test.stmt, warnings, test.expected)
+		}
+		var v any
+		if err := db.QueryRow("SELECT v FROM nums WHERE id = ?", test.id).Scan(&v); err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(v) != fmt.Sprint(test.value) {
+			t.Errorf("%s: %v != %d", test.stmt, v, test.value)
+		}
+	}
+
+	// SHOW WARNINGS keeps the warnings, and the next statement without coercions clears them.
+	if _, err := db.Exec("INSERT INTO nums VALUES (4, 'abc')"); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(showWarnings()); n != 1 {
+		t.Errorf("%d != 1", n)
+	}
+	if n := countRows(t, db, "SHOW COUNT(*) WARNINGS"); n != 1 {
+		t.Errorf("%d != 1", n)
+	}
+	if _, err := db.Exec("INSERT INTO nums VALUES (5, 5)"); err != nil {
+		t.Fatal(err)
+	}
+	if n := countRows(t, db, "SHOW COUNT(*) WARNINGS"); n != 0 {
+		t.Errorf("%d != 0", n)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// TestArrayErrors tests the errors of the invalid array literals and the unsupported array expressions.
+func TestArrayErrors(t *testing.T) {
+	conn := openTestConn(t, "arrayerrors")
+	ctx := context.Background()
+
+	if _, err := conn.Exec(ctx, "CREATE TABLE arrays (k INT PRIMARY KEY, nums INT[])"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The array columns reject the malformed array literals and the invalid elements.
+	for _, value := range []string{"{1,2", "{1,x}", "3"} {
+		_, err := conn.Exec(ctx, "INSERT INTO arrays VALUES (1, '"+value+"')")
+		var pgErr *pgconn.PgError
+		if !errors.As(err, &pgErr) {
+			t.Errorf("%s: error is not returned: %v", value, err)
+			continue
+		}
+		if pgErr.Code != "22P02" || pgErr.ColumnName != "nums" || !strings.Contains(pgErr.Message, value) {
+			t.Errorf("%s: %s %s (%s)", value, pgErr.Code, pgErr.Message, pgErr.ColumnName)
+		}
+	}
+	if n := countRows(t, conn, "SELECT COUNT(*) FROM arrays"); n != 0 {
+		t.Errorf("%d != 0", n)
+	}
+
+	// The unsupported array expressions are reported as they are in the simple and extended protocols.
+	queries := []string{
+		"SELECT ARRAY[[1, 2], [3, 4]]",
+		"SELECT unnest(nums), unnest(nums) FROM arrays",
+	}
+	for _, query := range queries {
+		for _, mode := range []pgx.QueryExecMode{pgx.QueryExecModeSimpleProtocol, pgx.QueryExecModeExec} {
+			_, err := conn.Exec(ctx, query, mode)
+			if err == nil || !strings.Contains(err.Error(), "not supported") {
+				t.Errorf("%s (%s): %v", query, mode, err)
+			}
+		}
+	}
+}
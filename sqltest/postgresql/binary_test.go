@@ -0,0 +1,116 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresql
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// binaryTestRow represents a row of the columns of the binary result formats.
+type binaryTestRow struct {
+	S    int16
+	I    int32
+	B    int64
+	R    float32
+	D    float64
+	F    bool
+	N    float64
+	Day  time.Time
+	At   time.Time
+	ID   string
+	Data []byte
+	Doc  map[string]any
+	Nums []int32
+	Tags []string
+}
+
+// TestBinaryResults tests the columns requested in the binary formats by the extended query protocol are decoded
+// into the same values as the columns of the text formats of the simple query protocol.
+func TestBinaryResults(t *testing.T) {
+	conn := openTestConn(t, "binaryresults")
+	ctx := context.Background()
+
+	if _, err := conn.Exec(ctx, "CREATE TABLE vals (k INT PRIMARY KEY, s SMALLINT, i INTEGER, b BIGINT, r REAL, d DOUBLE PRECISION, f BOOLEAN, n NUMERIC(10, 2), day DATE, at TIMESTAMP, id UUID, data BYTEA, doc JSONB, nums INT[], tags TEXT[])"); err != nil {
+		t.Fatal(err)
+	}
+	insert := "INSERT INTO vals VALUES (1, -2, 3, 9000000000, 1.5, -2.25, TRUE, 12.34, '2024-01-02', '2024-01-02 03:04:05', 'a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11', $1, '{\"a\": [1, 2]}', '{1,2,3}', '{a,b}')"
+	if _, err := conn.Exec(ctx, insert, []byte{0x01, 0x02}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := binaryTestRow{
+		S:    -2,
+		I:    3,
+		B:    9000000000,
+		R:    1.5,
+		D:    -2.25,
+		F:    true,
+		N:    12.34,
+		Day:  time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		At:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		ID:   "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11",
+		Data: []byte{0x01, 0x02},
+		Doc:  map[string]any{"a": []any{float64(1), float64(2)}},
+		Nums: []int32{1, 2, 3},
+		Tags: []string{"a", "b"},
+	}
+
+	query := "SELECT s, i, b, r, d, f, n, day, at, id, data, doc, nums, tags FROM vals WHERE k = 1"
+	// The binary formats are requested for all columns because pgx prefers the text formats of jsonb.
+	modes := []struct {
+		mode   pgx.QueryExecMode
+		format int16
+	}{
+		{pgx.QueryExecModeSimpleProtocol, pgx.TextFormatCode},
+		{pgx.QueryExecModeCacheStatement, pgx.BinaryFormatCode},
+	}
+	for _, mode := range modes {
+		args := []any{mode.mode}
+		if mode.format == pgx.BinaryFormatCode {
+			args = append(args, pgx.QueryResultFormats{pgx.BinaryFormatCode})
+		}
+		rows, err := conn.Query(ctx, query, args...)
+		if err != nil {
+			t.Fatalf("%s: %s", mode.mode, err)
+		}
+		var row binaryTestRow
+		if rows.Next() {
+			for _, field := range rows.FieldDescriptions() {
+				if field.Format != mode.format {
+					t.Errorf("%s: %s format %d != %d", mode.mode, field.Name, field.Format, mode.format)
+				}
+			}
+			err = rows.Scan(&row.S, &row.I, &row.B, &row.R, &row.D, &row.F, &row.N, &row.Day, &row.At, &row.ID, &row.Data, &row.Doc, &row.Nums, &row.Tags)
+		}
+		rows.Close()
+		if err == nil {
+			err = rows.Err()
+		}
+		if err != nil {
+			t.Errorf("%s: %s", mode.mode, err)
+			continue
+		}
+		row.Day = row.Day.UTC()
+		row.At = row.At.UTC()
+		if !reflect.DeepEqual(row, expected) {
+			t.Errorf("%s: %v != %v", mode.mode, row, expected)
+		}
+	}
+}
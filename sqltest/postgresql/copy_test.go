@@ -0,0 +1,317 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresql
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// TestCopyFrom tests COPY FROM STDIN of the text and CSV formats, and the failed COPY keeps no rows.
+func TestCopyFrom(t *testing.T) {
+	conn := openTestConn(t, "copyfrom")
+	ctx := context.Background()
+
+	if _, err := conn.Exec(ctx, "CREATE TABLE items (id INT PRIMARY KEY, name TEXT, price NUMERIC(12, 2))"); err != nil {
+		t.Fatal(err)
+	}
+
+	copies := []struct {
+		query string
+		data  string
+		tag   string
+	}{
+		{
+			query: "COPY items FROM STDIN",
+			data:  "1\tapple\t1.50\n2\t\\N\t2\n",
+			tag:   "COPY 2",
+		},
+		{
+			query: "COPY items (id, name, price) FROM STDIN WITH (FORMAT csv, HEADER true)",
+			data:  "id,name,price\n3,\"b,c\",3\n",
+			tag:   "COPY 1",
+		},
+	}
+	for _, cp := range copies {
+		tag, err := conn.PgConn().CopyFrom(ctx, strings.NewReader(cp.data), cp.query)
+		if err != nil {
+			t.Fatalf("%s: %s", cp.query, err)
+		}
+		if tag.String() != cp.tag {
+			t.Errorf("%s: %s != %s", cp.query, tag.String(), cp.tag)
+		}
+	}
+
+	// The rows of the failed COPY are discarded outside of the transaction blocks.
+	_, err := conn.PgConn().CopyFrom(ctx, strings.NewReader("4\tx\t1\n5\tbad\n"), "COPY items FROM STDIN")
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		t.Fatalf("COPY error is not returned: %v", err)
+	}
+	if pgErr.Code != "22P04" || pgErr.Where != "COPY items, line 2, column price" {
+		t.Errorf("%s (%s)", pgErr.Code, pgErr.Where)
+	}
+	if n := countRows(t, conn, "SELECT COUNT(*) FROM items"); n != 3 {
+		t.Errorf("%d != 3", n)
+	}
+
+	// The rows are not committed in batches, so no rows of a large COPY are kept when the last row is rejected.
+	var data strings.Builder
+	for id := 100; id < 10100; id++ {
+		fmt.Fprintf(&data, "%d\tbulk\t1\n", id)
+	}
+	data.WriteString("10100\tbad\n")
+	if _, err := conn.PgConn().CopyFrom(ctx, strings.NewReader(data.String()), "COPY items FROM STDIN"); err == nil {
+		t.Error("COPY error is not returned")
+	}
+	if n := countRows(t, conn, "SELECT COUNT(*) FROM items"); n != 3 {
+		t.Errorf("%d != 3", n)
+	}
+
+	// The rows of the failed COPY are discarded in the transaction blocks.
+	if _, err := conn.Exec(ctx, "BEGIN"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := conn.PgConn().CopyFrom(ctx, strings.NewReader("6\ty\t1\n7\n"), "COPY items FROM STDIN"); err == nil {
+		t.Error("COPY error is not returned")
+	}
+	if _, err := conn.Exec(ctx, "COMMIT"); err != nil {
+		t.Fatal(err)
+	}
+	if n := countRows(t, conn, "SELECT COUNT(*) FROM items WHERE id = 6"); n != 0 {
+		t.Errorf("%d != 0", n)
+	}
+}
+
+// TestCopyFromErrors tests the invalid data of COPY FROM STDIN are reported with the SQLSTATEs and the lines,
+// and the lines of CSV count the newlines in the quoted values.
+func TestCopyFromErrors(t *testing.T) {
+	conn := openTestConn(t, "copyfromerrors")
+	ctx := context.Background()
+
+	if _, err := conn.Exec(ctx, "CREATE TABLE items (id INT PRIMARY KEY, name TEXT, price NUMERIC(12, 2))"); err != nil {
+		t.Fatal(err)
+	}
+
+	copies := []struct {
+		query string
+		data  string
+		code  string
+		where string
+	}{
+		{
+			query: "COPY items FROM STDIN",
+			data:  "1\tx\t1\nx\ty\t1\n",
+			code:  "22P02",
+			where: "COPY items, line 2, column id",
+		},
+		{
+			query: "COPY items FROM STDIN",
+			data:  "1\ty\t1\textra\n",
+			code:  "22P04",
+			where: "COPY items, line 1",
+		},
+		{
+			query: "COPY items FROM STDIN WITH (FORMAT csv, HEADER true)",
+			data:  "id,name,price\n1,\"a\nb\",1\nx,q,2\n",
+			code:  "22P02",
+			where: "COPY items, line 4, column id",
+		},
+	}
+	for _, cp := range copies {
+		_, err := conn.PgConn().CopyFrom(ctx, strings.NewReader(cp.data), cp.query)
+		var pgErr *pgconn.PgError
+		if !errors.As(err, &pgErr) {
+			t.Errorf("%s: COPY error is not returned: %v", cp.query, err)
+			continue
+		}
+		if pgErr.Code != cp.code || pgErr.Where != cp.where {
+			t.Errorf("%s: %s (%s) != %s (%s)", cp.query, pgErr.Code, pgErr.Where, cp.code, cp.where)
+		}
+	}
+
+	if n := countRows(t, conn, "SELECT COUNT(*) FROM items"); n != 0 {
+		t.Errorf("%d != 0", n)
+	}
+}
+
+// TestCopyTo tests COPY TO STDOUT of the tables and the queries in the text and CSV formats, and the special characters
+// are escaped or quoted by the formats.
+func TestCopyTo(t *testing.T) {
+	conn := openTestConn(t, "copyto")
+	ctx := context.Background()
+
+	if _, err := conn.Exec(ctx, "CREATE TABLE items (id INT PRIMARY KEY, name TEXT, qty INT)"); err != nil {
+		t.Fatal(err)
+	}
+	rows := [][]any{
+		{1, "a\tb", 10},
+		{2, nil, 20},
+		{3, "x,\"y\"", 30},
+	}
+	for _, row := range rows {
+		if _, err := conn.Exec(ctx, "INSERT INTO items VALUES ($1, $2, $3)", row...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	copies := []struct {
+		query string
+		data  string
+		tag   string
+	}{
+		{
+			query: "COPY items TO STDOUT",
+			data:  "1\ta\\tb\t10\n2\t\\N\t20\n3\tx,\"y\"\t30\n",
+			tag:   "COPY 3",
+		},
+		{
+			query: "COPY items (id, name) TO STDOUT WITH (FORMAT csv, HEADER true)",
+			data:  "id,name\n1,a\tb\n2,\n3,\"x,\"\"y\"\"\"\n",
+			tag:   "COPY 3",
+		},
+		{
+			query: "COPY (SELECT id, qty * 2 AS double_qty FROM items WHERE id > 1 ORDER BY id) TO STDOUT WITH (FORMAT csv, HEADER true)",
+			data:  "id,double_qty\n2,40\n3,60\n",
+			tag:   "COPY 2",
+		},
+	}
+	for _, cp := range copies {
+		var b strings.Builder
+		tag, err := conn.PgConn().CopyTo(ctx, &b, cp.query)
+		if err != nil {
+			t.Errorf("%s: %s", cp.query, err)
+			continue
+		}
+		if tag.String() != cp.tag {
+			t.Errorf("%s: %s != %s", cp.query, tag.String(), cp.tag)
+		}
+		if b.String() != cp.data {
+			t.Errorf("%s: %q != %q", cp.query, b.String(), cp.data)
+		}
+	}
+}
+
+// newCopyBinaryData returns the COPY binary format data of the specified tuples with the header and the trailer.
+func newCopyBinaryData(tuples ...[][]byte) []byte {
+	b := []byte("PGCOPY\n\377\r\n\000")
+	b = binary.BigEndian.AppendUint32(b, 0)
+	b = binary.BigEndian.AppendUint32(b, 0)
+	for _, tuple := range tuples {
+		b = binary.BigEndian.AppendUint16(b, uint16(len(tuple)))
+		for _, field := range tuple {
+			b = binary.BigEndian.AppendUint32(b, uint32(len(field)))
+			b = append(b, field...)
+		}
+	}
+	return binary.BigEndian.AppendUint16(b, 0xFFFF)
+}
+
+// TestCopyBinary tests COPY of the binary format with pgx CopyFrom, and the binary data of COPY TO STDOUT is copied
+// into another table. The malformed data are reported with the SQLSTATEs and the lines.
+func TestCopyBinary(t *testing.T) {
+	conn := openTestConn(t, "copybinary")
+	ctx := context.Background()
+
+	for _, stmt := range []string{
+		"CREATE TABLE items (id INT PRIMARY KEY, name TEXT, qty BIGINT)",
+		"CREATE TABLE copies (id INT PRIMARY KEY, name TEXT, qty BIGINT)",
+	} {
+		if _, err := conn.Exec(ctx, stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rows := [][]any{
+		{1, "a\tb", int64(10)},
+		{2, nil, int64(9000000000)},
+	}
+	n, err := conn.CopyFrom(ctx, pgx.Identifier{"items"}, []string{"id", "name", "qty"}, pgx.CopyFromRows(rows))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(rows)) {
+		t.Errorf("%d != %d", n, len(rows))
+	}
+
+	var data bytes.Buffer
+	tag, err := conn.PgConn().CopyTo(ctx, &data, "COPY items TO STDOUT WITH (FORMAT binary)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag.String() != "COPY 2" {
+		t.Errorf("%s != COPY 2", tag.String())
+	}
+	if !bytes.HasPrefix(data.Bytes(), []byte("PGCOPY\n\377\r\n\000")) || !bytes.HasSuffix(data.Bytes(), []byte{0xFF, 0xFF}) {
+		t.Errorf("%q", data.Bytes())
+	}
+	tag, err = conn.PgConn().CopyFrom(ctx, bytes.NewReader(data.Bytes()), "COPY copies FROM STDIN WITH (FORMAT binary)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag.String() != "COPY 2" {
+		t.Errorf("%s != COPY 2", tag.String())
+	}
+	query := "SELECT COUNT(*) FROM copies c JOIN items i ON c.id = i.id AND c.qty = i.qty AND (c.name = i.name OR (c.name IS NULL AND i.name IS NULL))"
+	if n := countRows(t, conn, query); n != len(rows) {
+		t.Errorf("%d != %d", n, len(rows))
+	}
+
+	id := binary.BigEndian.AppendUint32(nil, 3)
+	qty := binary.BigEndian.AppendUint64(nil, 30)
+	copies := []struct {
+		data  []byte
+		code  string
+		where string
+	}{
+		{
+			data:  []byte("PGCOPY\n\377\r\n\001"),
+			code:  "22P04",
+			where: "COPY copies",
+		},
+		{
+			data:  newCopyBinaryData([][]byte{id, []byte("c")}),
+			code:  "22P04",
+			where: "COPY copies, line 1",
+		},
+		{
+			data:  newCopyBinaryData([][]byte{id, []byte("c"), qty}, [][]byte{id[1:], []byte("d"), qty}),
+			code:  "22P03",
+			where: "COPY copies, line 2, column id",
+		},
+	}
+	for _, cp := range copies {
+		_, err := conn.PgConn().CopyFrom(ctx, bytes.NewReader(cp.data), "COPY copies FROM STDIN WITH (FORMAT binary)")
+		var pgErr *pgconn.PgError
+		if !errors.As(err, &pgErr) {
+			t.Errorf("%q: COPY error is not returned: %v", cp.data, err)
+			continue
+		}
+		if pgErr.Code != cp.code || pgErr.Where != cp.where {
+			t.Errorf("%q: %s (%s) != %s (%s)", cp.data, pgErr.Code, pgErr.Where, cp.code, cp.where)
+		}
+	}
+	if n := countRows(t, conn, "SELECT COUNT(*) FROM copies"); n != len(rows) {
+		t.Errorf("%d != %d", n, len(rows))
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// TestExpressionTypes tests the expression columns are typed by the inferred types regardless of the first row values
+// and the wildcards of the select lists.
+func TestExpressionTypes(t *testing.T) {
+	conn := openTestConn(t, "expressiontypes")
+	ctx := context.Background()
+
+	for _, stmt := range []string{
+		"CREATE TABLE measures (k INT PRIMARY KEY, i INTEGER)",
+		"INSERT INTO measures VALUES (1, 1), (2, 2), (3, NULL)",
+	} {
+		if _, err := conn.Exec(ctx, stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		query string
+		oids  []uint32
+	}{
+		// The AVG columns are DOUBLE even if the first values are NULL or there are no rows.
+		{"SELECT AVG(i) AS avg_i FROM measures", []uint32{pgtype.Float8OID}},
+		{"SELECT AVG(i) AS avg_i FROM measures WHERE k = 3", []uint32{pgtype.Float8OID}},
+		{"SELECT AVG(i) AS avg_i, COUNT(*) AS cnt FROM measures WHERE k > 100", []uint32{pgtype.Float8OID, pgtype.Int8OID}},
+		// The expressions before and after the wildcards are typed by the inferred types, and the table columns are not checked.
+		{"SELECT COUNT(*) AS cnt, *, AVG(i) AS avg_i FROM measures WHERE k = 3 GROUP BY k", []uint32{pgtype.Int8OID, 0, 0, pgtype.Float8OID}},
+	}
+	for _, test := range tests {
+		rows, err := conn.Query(ctx, test.query)
+		if err != nil {
+			t.Errorf("%s: %s", test.query, err)
+			continue
+		}
+		fields := rows.FieldDescriptions()
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			t.Errorf("%s: %s", test.query, err)
+			continue
+		}
+		if len(fields) != len(test.oids) {
+			t.Errorf("%s: %d != %d", test.query, len(fields), len(test.oids))
+			continue
+		}
+		for n, field := range fields {
+			if test.oids[n] != 0 && field.DataTypeOID != test.oids[n] {
+				t.Errorf("%s: %s %d != %d", test.query, field.Name, field.DataTypeOID, test.oids[n])
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresql
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/cybergarage/go-logger/log"
+	"github.com/cybergarage/go-sqlserver/sqltest/server"
+	"github.com/cybergarage/go-sqltest/sqltest"
+	"github.com/jackc/pgx/v5"
+)
+
+// testServer is the server of the tests which is started by TestMain.
+var testServer *server.Server
+
+// TestMain starts the test server before running the tests, and stops it after the tests.
+func TestMain(m *testing.M) {
+	log.EnableStdoutDebug(true)
+
+	testServer = server.NewServer()
+	if err := testServer.Start(); err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+
+	if err := testServer.Stop(); err != nil {
+		log.Error(err)
+	}
+
+	os.Exit(code)
+}
+
+// openTestConn returns a pgx connection to a new database of the specified name.
+func openTestConn(t *testing.T, name string) *pgx.Conn {
+	t.Helper()
+	client := sqltest.NewPgxClient()
+	client.SetDatabase(name)
+	if err := client.Open(); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.CreateDatabase(name); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := client.DropDatabase(name); err != nil {
+			t.Error(err)
+		}
+		if err := client.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	return client.Conn()
+}
+
+// countRows returns the number of the rows of the specified query.
+func countRows(t *testing.T, conn *pgx.Conn, query string) int {
+	t.Helper()
+	var n int
+	if err := conn.QueryRow(context.Background(), query).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// TestJSONErrors tests the JSON columns reject the values which are not well-formed JSON texts.
+func TestJSONErrors(t *testing.T) {
+	conn := openTestConn(t, "jsonerrors")
+	ctx := context.Background()
+
+	stmts := []string{
+		"CREATE TABLE docs (k INT PRIMARY KEY, doc JSONB, j JSON)",
+		"INSERT INTO docs VALUES (1, '{\"a\":1}', '[1]')",
+	}
+	for _, stmt := range stmts {
+		if _, err := conn.Exec(ctx, stmt); err != nil {
+			t.Fatalf("%s: %s", stmt, err)
+		}
+	}
+
+	for _, stmt := range []string{
+		"INSERT INTO docs VALUES (2, '{\"a\":', NULL)",
+		"UPDATE docs SET j = 'abc' WHERE k = 1",
+	} {
+		_, err := conn.Exec(ctx, stmt)
+		var pgErr *pgconn.PgError
+		if !errors.As(err, &pgErr) {
+			t.Errorf("%s: error is not returned: %v", stmt, err)
+			continue
+		}
+		if pgErr.Code != "22P02" || pgErr.Message != "invalid input syntax for type json" {
+			t.Errorf("%s: %s %s", stmt, pgErr.Code, pgErr.Message)
+		}
+	}
+	if n := countRows(t, conn, "SELECT COUNT(*) FROM docs WHERE j = '[1]'"); n != 1 {
+		t.Errorf("%d != 1", n)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// TestNotices tests the silent data coercions are sent as NoticeResponse messages of the WARNING severity,
+// and the messages below the client_min_messages level are not sent.
+func TestNotices(t *testing.T) {
+	ctx := context.Background()
+
+	// The notices are received by another connection because the notice handler is set before connecting.
+	notices := []*pgconn.Notice{}
+	config := openTestConn(t, "notices").Config()
+	config.OnNotice = func(_ *pgconn.PgConn, notice *pgconn.Notice) {
+		notices = append(notices, notice)
+	}
+	conn, err := pgx.ConnectConfig(ctx, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close(ctx)
+
+	tests := []struct {
+		stmt    string
+		message string
+	}{
+		{"CREATE TABLE nums (id INT PRIMARY KEY, v INT)", ""},
+		{"INSERT INTO nums VALUES (1, 'abc')", "Incorrect integer value: 'abc' for column 'v' at row 1"},
+		{"SET client_min_messages = ERROR", ""},
+		{"INSERT INTO nums VALUES (2, 'def')", ""},
+		{"RESET client_min_messages", ""},
+		{"UPDATE nums SET v = 'xyz' WHERE id = 2", "Incorrect integer value: 'xyz' for column 'v' at row 1"},
+	}
+	for _, test := range tests {
+		notices = notices[:0]
+		if _, err := conn.Exec(ctx, test.stmt); err != nil {
+			t.Fatalf("%s: %s", test.stmt, err)
+		}
+		if test.message == "" {
+			if len(notices) != 0 {
+				t.Errorf("%s: %d notices are sent", test.stmt, len(notices))
+			}
+			continue
+		}
+		if len(notices) != 1 {
+			t.Errorf("%s: %d notices are sent", test.stmt, len(notices))
+			continue
+		}
+		if notices[0].Severity != "WARNING" || notices[0].Code != "01000" || notices[0].Message != test.message {
+			t.Errorf("%s: %s %s %s", test.stmt, notices[0].Severity, notices[0].Code, notices[0].Message)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresql
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// TestPreparedStatements tests the parameter types of the prepared statements are described with the types of the columns
+// which the parameters are assigned to or compared with, the result columns of the queries are described,
+// and the parameters are bound without being inlined into the statements.
+func TestPreparedStatements(t *testing.T) {
+	conn := openTestConn(t, "preparedstatements")
+	ctx := context.Background()
+
+	if _, err := conn.Exec(ctx, "CREATE TABLE items (id INT PRIMARY KEY, name TEXT, price NUMERIC(10, 2))"); err != nil {
+		t.Fatal(err)
+	}
+
+	stmts := []struct {
+		name    string
+		query   string
+		oids    []uint32
+		columns []string
+	}{
+		{"insert", "INSERT INTO items (id, name, price) VALUES ($1, $2, $3)", []uint32{pgtype.Int4OID, pgtype.TextOID, pgtype.NumericOID}, []string{}},
+		{"select", "SELECT name, price FROM items WHERE id = $1 OR name = $2", []uint32{pgtype.Int4OID, pgtype.TextOID}, []string{"name", "price"}},
+	}
+	for _, stmt := range stmts {
+		sd, err := conn.Prepare(ctx, stmt.name, stmt.query)
+		if err != nil {
+			t.Fatalf("%s: %s", stmt.query, err)
+		}
+		if !reflect.DeepEqual(sd.ParamOIDs, stmt.oids) {
+			t.Errorf("%s: %v != %v", stmt.query, sd.ParamOIDs, stmt.oids)
+		}
+		columns := []string{}
+		for _, field := range sd.Fields {
+			columns = append(columns, field.Name)
+		}
+		if !reflect.DeepEqual(columns, stmt.columns) {
+			t.Errorf("%s: %v != %v", stmt.query, columns, stmt.columns)
+		}
+	}
+
+	// The quotes and the semicolons of the parameters are stored as they are, and nil is bound as NULL.
+	name := "O'Brien'); DROP TABLE items; --"
+	args := [][]any{
+		{1, name, 12.5},
+		{2, nil, 3.0},
+	}
+	for _, arg := range args {
+		if _, err := conn.Exec(ctx, "insert", arg...); err != nil {
+			t.Fatalf("%v: %s", arg, err)
+		}
+	}
+
+	var storedName string
+	var price float64
+	if err := conn.QueryRow(ctx, "select", 1, "").Scan(&storedName, &price); err != nil {
+		t.Fatal(err)
+	}
+	if storedName != name || price != 12.5 {
+		t.Errorf("%s %f != %s %f", storedName, price, name, 12.5)
+	}
+	if n := countRows(t, conn, "SELECT COUNT(*) FROM items WHERE name IS NULL"); n != 1 {
+		t.Errorf("%d != 1", n)
+	}
+}
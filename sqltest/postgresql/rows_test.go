@@ -0,0 +1,117 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresql
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgproto3"
+)
+
+// TestRowsError tests the error of a row after the preceding rows are sent is returned by ErrorResponse instead of CommandComplete
+// with the simple and extended query protocols.
+func TestRowsError(t *testing.T) {
+	conn := openTestConn(t, "rowserror")
+	ctx := context.Background()
+
+	stmts := []string{
+		"CREATE TABLE nums (id INT PRIMARY KEY, v BIGINT)",
+		"INSERT INTO nums VALUES (1, 1), (2, 0)",
+	}
+	for _, stmt := range stmts {
+		if _, err := conn.Exec(ctx, stmt); err != nil {
+			t.Fatalf("%s: %s", stmt, err)
+		}
+	}
+
+	// ABS of the minimum integer of the second row fails while the first row has been sent.
+	query := "SELECT id, ABS(v - 9223372036854775807 - 1) FROM nums ORDER BY id"
+	modes := []pgx.QueryExecMode{
+		pgx.QueryExecModeSimpleProtocol,
+		pgx.QueryExecModeExec,
+	}
+	for _, mode := range modes {
+		rows, err := conn.Query(ctx, query, mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nRows := 0
+		for rows.Next() {
+			nRows++
+		}
+		rows.Close()
+		if rows.Err() == nil {
+			t.Errorf("%s: the row error is not returned (%d rows)", mode, nRows)
+		}
+	}
+}
+
+// TestPortalSuspension tests the portal of the extended query protocol is suspended at the row limit of Execute,
+// and the next Execute messages resume fetching the rest rows.
+func TestPortalSuspension(t *testing.T) {
+	conn := openTestConn(t, "portalsuspension")
+	ctx := context.Background()
+
+	stmts := []string{
+		"CREATE TABLE nums (id INT PRIMARY KEY)",
+		"INSERT INTO nums VALUES (1), (2), (3), (4), (5)",
+	}
+	for _, stmt := range stmts {
+		if _, err := conn.Exec(ctx, stmt); err != nil {
+			t.Fatalf("%s: %s", stmt, err)
+		}
+	}
+
+	// The messages are sent directly because pgx fetches all rows of the portals.
+	frontend := conn.PgConn().Frontend()
+	frontend.Send(&pgproto3.Parse{Name: "", Query: "SELECT id FROM nums ORDER BY id", ParameterOIDs: nil})
+	frontend.Send(&pgproto3.Bind{DestinationPortal: "", PreparedStatement: "", ParameterFormatCodes: nil, Parameters: nil, ResultFormatCodes: nil})
+	frontend.Send(&pgproto3.Execute{Portal: "", MaxRows: 2})
+	frontend.Send(&pgproto3.Execute{Portal: "", MaxRows: 2})
+	frontend.Send(&pgproto3.Execute{Portal: "", MaxRows: 0})
+	frontend.Send(&pgproto3.Sync{})
+	if err := frontend.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	responses := []string{}
+	for {
+		msg, err := frontend.Receive()
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch msg := msg.(type) {
+		case *pgproto3.DataRow:
+			responses = append(responses, string(msg.Values[0]))
+		case *pgproto3.PortalSuspended:
+			responses = append(responses, "suspended")
+		case *pgproto3.CommandComplete:
+			responses = append(responses, string(msg.CommandTag))
+		case *pgproto3.ErrorResponse:
+			t.Fatal(msg.Message)
+		}
+		if _, ok := msg.(*pgproto3.ReadyForQuery); ok {
+			break
+		}
+	}
+
+	expected := "1, 2, suspended, 3, 4, suspended, 5, SELECT 1"
+	if response := strings.Join(responses, ", "); response != expected {
+		t.Errorf("%s != %s", response, expected)
+	}
+}
@@ -17,23 +17,11 @@ package postgresql
 import (
 	"testing"
 
-	"github.com/cybergarage/go-logger/log"
-	"github.com/cybergarage/go-sqlserver/sqltest/server"
 	"github.com/cybergarage/go-sqltest/sqltest"
 )
 
 // TestSQLTestSuite runs already passed scenario test files.
 func TestSQLTestSuite(t *testing.T) {
-	log.EnableStdoutDebug(true)
-
-	server := server.NewServer()
-	err := server.Start()
-	if err != nil {
-		t.Error(err)
-		return
-	}
-	defer server.Stop()
-
 	client := sqltest.NewPostgresClient()
 
 	testNames := []string{
@@ -50,3 +38,17 @@ func TestSQLTestSuite(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+// TestDialectSuite runs the PostgreSQL dialect scenario test files.
+func TestDialectSuite(t *testing.T) {
+	suite, err := sqltest.NewSuiteWith(
+		sqltest.WithSuiteDirectories("scenarios"),
+		sqltest.WithSuiteClient(sqltest.NewPostgresClient()),
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	suite.Test(t)
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresql
+
+import (
+	"context"
+	"testing"
+)
+
+// TestMultiStatements tests the multiple statements of a simple query message are executed in sequence and return
+// the results of the statements, and the preceding statements are rolled back if a statement fails.
+func TestMultiStatements(t *testing.T) {
+	conn := openTestConn(t, "multistatements")
+	ctx := context.Background()
+
+	if _, err := conn.Exec(ctx, "CREATE TABLE nums (id INT PRIMARY KEY, v INT)"); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := conn.PgConn().Exec(ctx, "INSERT INTO nums VALUES (1, 10); SELECT id FROM nums ORDER BY id; UPDATE nums SET v = v + 1").ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tags := []string{"INSERT 0 1", "SELECT 1", "UPDATE 1"}
+	if len(results) != len(tags) {
+		t.Fatalf("%d != %d", len(results), len(tags))
+	}
+	for n, result := range results {
+		if result.CommandTag.String() != tags[n] {
+			t.Errorf("%s != %s", result.CommandTag.String(), tags[n])
+		}
+	}
+	if rows := results[1].Rows; len(rows) != 1 || string(rows[0][0]) != "1" {
+		t.Errorf("%v", rows)
+	}
+
+	// The statement of the duplicate key fails, and the preceding statement is rolled back in the implicit transaction.
+	if _, err := conn.PgConn().Exec(ctx, "INSERT INTO nums VALUES (2, 20); INSERT INTO nums VALUES (1, 11); INSERT INTO nums VALUES (3, 30)").ReadAll(); err == nil {
+		t.Error("the error of the second statement is not returned")
+	}
+	if n := countRows(t, conn, "SELECT COUNT(*) FROM nums"); n != 1 {
+		t.Errorf("%d != 1", n)
+	}
+	if n := countRows(t, conn, "SELECT v FROM nums WHERE id = 1"); n != 11 {
+		t.Errorf("%d != 11", n)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// TestStrictMode tests the strict mode database of the test configuration rejects the invalid values with the SQLSTATEs
+// of PostgreSQL, and the other databases store the values as they are.
+func TestStrictMode(t *testing.T) {
+	conn := openTestConn(t, "strictmode")
+	ctx := context.Background()
+
+	if _, err := conn.Exec(ctx, "CREATE TABLE items (id INT PRIMARY KEY, v INT, name VARCHAR(5))"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		stmt string
+		code string
+	}{
+		{"INSERT INTO items VALUES (1, 'abc', 'a')", "22P02"},
+		{"INSERT INTO items VALUES (1, 1, 'a'), (2, 2, 'abcdef')", "22001"},
+		{"INSERT INTO items VALUES (3, 3, 'abcde')", ""},
+		{"UPDATE items SET name = 'abcdef' WHERE id = 3", "22001"},
+	}
+	for _, test := range tests {
+		_, err := conn.Exec(ctx, test.stmt)
+		if test.code == "" {
+			if err != nil {
+				t.Errorf("%s: %s", test.stmt, err)
+			}
+			continue
+		}
+		var pgErr *pgconn.PgError
+		if !errors.As(err, &pgErr) {
+			t.Errorf("%s: the error is not returned (%v)", test.stmt, err)
+			continue
+		}
+		if pgErr.Code != test.code {
+			t.Errorf("%s: %s != %s (%s)", test.stmt, pgErr.Code, test.code, pgErr.Message)
+		}
+	}
+
+	// The rows of the rejected statements are not inserted.
+	if n := countRows(t, conn, "SELECT COUNT(*) FROM items"); n != 1 {
+		t.Errorf("%d != 1", n)
+	}
+
+	// The strict mode is disabled for the other databases.
+	nonStrict := openTestConn(t, "nonstrictmode")
+	for _, stmt := range []string{
+		"CREATE TABLE items (id INT PRIMARY KEY, v INT, name VARCHAR(5))",
+		"INSERT INTO items VALUES (1, 'abc', 'abcdef')",
+	} {
+		if _, err := nonStrict.Exec(ctx, stmt); err != nil {
+			t.Errorf("%s: %s", stmt, err)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2025 The go-sqlserver Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cybergarage/go-sqltest/sqltest"
+)
+
+// TestSessionTransactions tests the transactions are local to the sessions, so the uncommitted rows of a session are not visible
+// to the other sessions, and the cursors of the other sessions are declared outside of the transaction blocks.
+func TestSessionTransactions(t *testing.T) {
+	conn := openTestConn(t, "sessiontransactions")
+	ctx := context.Background()
+
+	client := sqltest.NewPgxClient()
+	client.SetDatabase("sessiontransactions")
+	if err := client.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	other := client.Conn()
+
+	for _, stmt := range []string{
+		"CREATE TABLE nums (id INT PRIMARY KEY)",
+		"BEGIN",
+		"INSERT INTO nums VALUES (1)",
+		"DECLARE c CURSOR FOR SELECT id FROM nums",
+	} {
+		if _, err := conn.Exec(ctx, stmt); err != nil {
+			t.Fatalf("%s: %s", stmt, err)
+		}
+	}
+
+	if n := countRows(t, other, "SELECT COUNT(*) FROM nums"); n != 0 {
+		t.Errorf("%d != 0", n)
+	}
+	if _, err := other.Exec(ctx, "DECLARE c CURSOR FOR SELECT id FROM nums"); err == nil {
+		t.Error("the cursor is declared outside of the transaction blocks")
+	}
+
+	if _, err := conn.Exec(ctx, "COMMIT"); err != nil {
+		t.Fatal(err)
+	}
+	if n := countRows(t, other, "SELECT COUNT(*) FROM nums"); n != 1 {
+		t.Errorf("%d != 1", n)
+	}
+
+	// The rows of the rolled back transaction of the other session are not visible.
+	for _, stmt := range []string{
+		"BEGIN",
+		"INSERT INTO nums VALUES (2)",
+		"ROLLBACK",
+	} {
+		if _, err := other.Exec(ctx, stmt); err != nil {
+			t.Fatalf("%s: %s", stmt, err)
+		}
+	}
+	if n := countRows(t, conn, "SELECT COUNT(*) FROM nums"); n != 1 {
+		t.Errorf("%d != 1", n)
+	}
+}